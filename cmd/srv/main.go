@@ -4,18 +4,24 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"os/signal"
+	"slices"
 	"strings"
 	"syscall"
-	"time"
 
 	"github.com/honeycombio/otel-config-go/otelconfig"
+	"github.com/webframp/quoteqt/db"
 	"github.com/webframp/quoteqt/srv"
 )
 
-var flagListenAddr = flag.String("listen", ":8000", "address to listen on")
+var (
+	flagListenAddr = flag.String("listen", ":8000", "address to listen on")
+	flagAdmin      = flag.String("admin", "", "comma-separated admin email addresses, merged with ADMIN_EMAILS")
+	flagStatus     = flag.Bool("status", false, "print pending database migrations and exit without applying them")
+)
 
 func main() {
 	if err := run(); err != nil {
@@ -24,6 +30,52 @@ func main() {
 	}
 }
 
+// mergeAdminEmails combines admin emails from the environment with those
+// given on the command line, dropping case-insensitive duplicates.
+func mergeAdminEmails(envEmails []string, flagValue string) []string {
+	seen := make(map[string]bool)
+	var merged []string
+	for _, email := range slices.Concat(envEmails, srv.ParseEmailList(flagValue)) {
+		key := strings.ToLower(email)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, email)
+	}
+	return merged
+}
+
+// newLogger returns a logger writing to w in cfg's log format, dropping
+// records below cfg's log level.
+func newLogger(cfg srv.Config, w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: cfg.LogLevel}
+	if cfg.LogFormat == srv.LogFormatJSON {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// printPendingMigrations lists the migrations a normal start would apply,
+// one filename per line, without touching the schema.
+func printPendingMigrations(dbPath string) error {
+	conn, err := db.Open(dbPath)
+	if err != nil {
+		return fmt.Errorf("open db: %w", err)
+	}
+	defer conn.Close()
+
+	pending, err := db.RunMigrationsDryRun(conn)
+	if err != nil {
+		return fmt.Errorf("check migrations: %w", err)
+	}
+	for _, name := range pending {
+		fmt.Println(name)
+	}
+	slog.Info("migration status", "pending", len(pending))
+	return nil
+}
+
 func run() error {
 	flag.Parse()
 	hostname, err := os.Hostname()
@@ -31,18 +83,30 @@ func run() error {
 		hostname = "unknown"
 	}
 
+	// Load config from environment with defaults
+	cfg := srv.ConfigFromEnv()
+	// Request and security event logs go through the default logger
+	slog.SetDefault(newLogger(cfg, os.Stderr))
+	// Only use os.Hostname() if HOSTNAME env var not set
+	if cfg.Hostname == "localhost" {
+		cfg.Hostname = hostname
+	}
+
+	if *flagStatus {
+		return printPendingMigrations(cfg.DBPath)
+	}
+
 	// Initialize OpenTelemetry with Honeycomb
 	// Requires HONEYCOMB_API_KEY environment variable
-	honeycombKey := os.Getenv("HONEYCOMB_API_KEY")
 	var shutdownOtel func()
-	if honeycombKey != "" {
+	if cfg.HoneycombAPIKey != "" {
 		shutdownOtel, err = otelconfig.ConfigureOpenTelemetry(
-			otelconfig.WithServiceName("quoteqt"),
+			otelconfig.WithServiceName(cfg.ServiceName),
 			otelconfig.WithServiceVersion(srv.Version),
 			otelconfig.WithMetricsEnabled(false),
 			otelconfig.WithExporterEndpoint("api.honeycomb.io:443"),
 			otelconfig.WithHeaders(map[string]string{
-				"x-honeycomb-team": honeycombKey,
+				"x-honeycomb-team": cfg.HoneycombAPIKey,
 			}),
 		)
 	} else {
@@ -53,26 +117,15 @@ func run() error {
 		// Continue without tracing - don't fail startup
 	} else if shutdownOtel != nil {
 		defer shutdownOtel()
-		slog.Info("OpenTelemetry configured", "endpoint", "api.honeycomb.io:443")
+		slog.Info("OpenTelemetry configured", "endpoint", "api.honeycomb.io:443", "service", cfg.ServiceName)
 	}
 
-	// Load config from environment with defaults
-	cfg := srv.ConfigFromEnv()
-	// Only use os.Hostname() if HOSTNAME env var not set
-	if cfg.Hostname == "localhost" {
-		cfg.Hostname = hostname
-	}
-
-	// Parse admin emails from environment variable (comma-separated)
-	if adminEnv := os.Getenv("ADMIN_EMAILS"); adminEnv != "" {
-		for _, email := range strings.Split(adminEnv, ",") {
-			if e := strings.TrimSpace(email); e != "" {
-				cfg.AdminEmails = append(cfg.AdminEmails, e)
-			}
-		}
+	// Admin emails come from ADMIN_EMAILS and the -admin flag
+	cfg.AdminEmails = mergeAdminEmails(cfg.AdminEmails, *flagAdmin)
+	if len(cfg.AdminEmails) > 0 {
 		slog.Info("admin emails configured", "count", len(cfg.AdminEmails))
 	} else {
-		slog.Warn("ADMIN_EMAILS not set, no admin access configured")
+		slog.Warn("no admin emails configured (set ADMIN_EMAILS or -admin)")
 	}
 
 	slog.Info("server config loaded",
@@ -108,7 +161,7 @@ func run() error {
 	}
 
 	// Graceful shutdown with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GracefulShutdownTimeout)
 	defer cancel()
 
 	if err := server.Shutdown(ctx); err != nil {
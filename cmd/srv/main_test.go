@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/webframp/quoteqt/db"
+	"github.com/webframp/quoteqt/srv"
+)
+
+func TestMergeAdminEmails(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		flag string
+		want []string
+	}{
+		{
+			name: "neither set",
+		},
+		{
+			name: "env only",
+			env:  "alice@example.com, bob@example.com",
+			want: []string{"alice@example.com", "bob@example.com"},
+		},
+		{
+			name: "flag only",
+			flag: "carol@example.com",
+			want: []string{"carol@example.com"},
+		},
+		{
+			name: "merged and deduplicated",
+			env:  "alice@example.com,bob@example.com",
+			flag: "Bob@Example.com, carol@example.com,,",
+			want: []string{"alice@example.com", "bob@example.com", "carol@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ADMIN_EMAILS", tt.env)
+
+			fs := flag.NewFlagSet("srv", flag.ContinueOnError)
+			admin := fs.String("admin", "", "")
+			if err := fs.Parse([]string{"-admin", tt.flag}); err != nil {
+				t.Fatalf("parse flags: %v", err)
+			}
+
+			got := mergeAdminEmails(srv.ConfigFromEnv().AdminEmails, *admin)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAdminFlagRegistered(t *testing.T) {
+	if flag.Lookup("admin") == nil {
+		t.Fatal("expected -admin flag to be registered")
+	}
+}
+
+func TestPrintPendingMigrations(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "status.sqlite3")
+	if err := printPendingMigrations(dbPath); err != nil {
+		t.Fatalf("printPendingMigrations: %v", err)
+	}
+
+	conn, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer conn.Close()
+	pending, err := db.RunMigrationsDryRun(conn)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(pending) == 0 {
+		t.Error("expected -status to leave migrations pending")
+	}
+}
+
+func TestNewLogger(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		cfg := srv.DefaultConfig()
+		cfg.LogFormat = srv.LogFormatJSON
+		var buf bytes.Buffer
+		newLogger(cfg, &buf).Info("request", "status", 200)
+
+		var record map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+			t.Fatalf("expected a JSON log line, got %q: %v", buf.String(), err)
+		}
+		if record["msg"] != "request" || record["status"] != float64(200) {
+			t.Errorf("unexpected record %v", record)
+		}
+	})
+
+	t.Run("text drops records below the level", func(t *testing.T) {
+		cfg := srv.DefaultConfig()
+		cfg.LogLevel = slog.LevelWarn
+		var buf bytes.Buffer
+		logger := newLogger(cfg, &buf)
+		logger.Info("quote served")
+		logger.Warn("security event")
+
+		out := buf.String()
+		if strings.Contains(out, "quote served") {
+			t.Errorf("expected info record to be dropped, got %q", out)
+		}
+		if !strings.Contains(out, `level=WARN msg="security event"`) {
+			t.Errorf("expected a text warn record, got %q", out)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"embed"
 	"errors"
@@ -20,34 +21,83 @@ import (
 var migrationFS embed.FS
 
 // Open opens an sqlite database and prepares pragmas suitable for a small web app.
+// The pool is limited to a single connection so that there is only ever one
+// writer, which avoids SQLITE_BUSY errors under concurrent writes.
 func Open(path string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite", path)
+	return OpenWithPool(path, 1, 1, 0)
+}
+
+// OpenWithPool is like Open but with explicit connection pool limits.
+// A maxLifetime of zero means connections are reused forever.
+func OpenWithPool(path string, maxOpen, maxIdle int, maxLifetime time.Duration) (*sql.DB, error) {
+	// Pragmas go in the DSN so that every pooled connection gets them, not
+	// just the first one.
+	dsn := path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(1000)"
+	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		return nil, err
 	}
-	// Light pragmas similar
-	if _, err := db.Exec("PRAGMA foreign_keys=ON;"); err != nil {
-		_ = db.Close()
-		return nil, fmt.Errorf("enable foreign keys: %w", err)
-	}
+	db.SetMaxOpenConns(maxOpen)
+	db.SetMaxIdleConns(maxIdle)
+	db.SetConnMaxLifetime(maxLifetime)
+
 	if _, err := db.Exec("PRAGMA journal_mode=wal;"); err != nil {
 		_ = db.Close()
 		return nil, fmt.Errorf("set WAL: %w", err)
 	}
-	if _, err := db.Exec("PRAGMA busy_timeout=1000;"); err != nil {
-		_ = db.Close()
-		return nil, fmt.Errorf("set busy_timeout: %w", err)
-	}
 	return db, nil
 }
 
+// readReplicaMaxConns is the pool size for a read-only replica. Readers
+// don't contend for SQLite's write lock, so a few can run at once.
+const readReplicaMaxConns = 4
+
+// OpenPrimary opens a writer on writePath and a read-only pool on readPath,
+// for deployments that keep a copy of the database on faster storage for
+// reads. If readPath is empty the writer is returned for both.
+func OpenPrimary(writePath, readPath string) (writer, reader *sql.DB, err error) {
+	return OpenPrimaryWithPool(writePath, readPath, 1)
+}
+
+// OpenPrimaryWithPool is like OpenPrimary but lets the writer pool hold up
+// to maxOpen connections, as OpenWithPool does.
+func OpenPrimaryWithPool(writePath, readPath string, maxOpen int) (writer, reader *sql.DB, err error) {
+	writer, err = OpenWithPool(writePath, maxOpen, maxOpen, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	if readPath == "" {
+		return writer, writer, nil
+	}
+
+	dsn := "file:" + readPath + "?mode=ro&_pragma=busy_timeout(1000)"
+	reader, err = sql.Open("sqlite", dsn)
+	if err != nil {
+		_ = writer.Close()
+		return nil, nil, err
+	}
+	reader.SetMaxOpenConns(readReplicaMaxConns)
+	reader.SetMaxIdleConns(readReplicaMaxConns)
+	if err := reader.Ping(); err != nil {
+		_ = reader.Close()
+		_ = writer.Close()
+		return nil, nil, fmt.Errorf("open read replica: %w", err)
+	}
+	return writer, reader, nil
+}
+
 // MigrationResult contains information about an applied migration
 type MigrationResult struct {
-	Filename  string
-	StartTime time.Time
-	EndTime   time.Time
+	Number     int
+	Filename   string
+	StartTime  time.Time
+	EndTime    time.Time
+	DurationMS int64
 }
 
+// slowMigrationThreshold is the duration above which a migration is logged as slow.
+const slowMigrationThreshold = time.Second
+
 // RunMigrations executes database migrations in numeric order (NNN-*.sql),
 // similar in spirit to exed's exedb.RunMigrations.
 // Returns a list of migrations that were applied.
@@ -113,16 +163,112 @@ func RunMigrations(db *sql.DB) ([]MigrationResult, error) {
 		}
 		endTime := time.Now()
 
+		duration := endTime.Sub(startTime)
 		results = append(results, MigrationResult{
-			Filename:  m,
-			StartTime: startTime,
-			EndTime:   endTime,
+			Number:     n,
+			Filename:   m,
+			StartTime:  startTime,
+			EndTime:    endTime,
+			DurationMS: duration.Milliseconds(),
 		})
+		if duration > slowMigrationThreshold {
+			slog.Warn("db: slow migration", "file", m, "number", n, "duration_ms", duration.Milliseconds())
+		}
 		slog.Info("db: applied migration", "file", m, "number", n)
 	}
 	return results, nil
 }
 
+// MigrationInfo describes a single embedded migration and whether it has been
+// recorded in the migrations table.
+type MigrationInfo struct {
+	Number    int
+	Filename  string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// MigrationStatus reports every embedded migration along with its applied
+// state. A database without a migrations table reports all migrations as
+// pending.
+func MigrationStatus(db *sql.DB) ([]MigrationInfo, error) {
+	entries, err := migrationFS.ReadDir("migrations")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations dir: %w", err)
+	}
+	pat := regexp.MustCompile(`^(\d{3})-.*\.sql$`)
+	var infos []MigrationInfo
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		match := pat.FindStringSubmatch(e.Name())
+		if len(match) != 2 {
+			continue
+		}
+		n, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("parse migration number %s: %w", e.Name(), err)
+		}
+		infos = append(infos, MigrationInfo{Number: n, Filename: e.Name()})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Filename < infos[j].Filename })
+
+	applied := make(map[int]time.Time)
+	var tableName string
+	err = db.QueryRow("SELECT name FROM sqlite_master WHERE type='table' AND name='migrations'").Scan(&tableName)
+	switch {
+	case err == nil:
+		rows, err := db.Query("SELECT migration_number, executed_at FROM migrations")
+		if err != nil {
+			return nil, fmt.Errorf("query executed migrations: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var n int
+			var at time.Time
+			if err := rows.Scan(&n, &at); err != nil {
+				return nil, fmt.Errorf("scan migration row: %w", err)
+			}
+			applied[n] = at
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("iterate executed migrations: %w", err)
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		// Nothing applied yet
+	default:
+		return nil, fmt.Errorf("check migrations table: %w", err)
+	}
+
+	for i := range infos {
+		if at, ok := applied[infos[i].Number]; ok {
+			infos[i].Applied = true
+			infos[i].AppliedAt = &at
+		}
+	}
+	return infos, nil
+}
+
+// RunMigrationsDryRun reports the embedded migrations that RunMigrations
+// would apply, in order, without executing any SQL. Each pending migration
+// is logged so CI output shows what a deploy will change.
+func RunMigrationsDryRun(db *sql.DB) ([]string, error) {
+	infos, err := MigrationStatus(db)
+	if err != nil {
+		return nil, err
+	}
+	var pending []string
+	for _, m := range infos {
+		if m.Applied {
+			continue
+		}
+		slog.Info("db: pending migration", "file", m.Filename, "number", m.Number)
+		pending = append(pending, m.Filename)
+	}
+	return pending, nil
+}
+
 func executeMigration(db *sql.DB, filename string) error {
 	content, err := migrationFS.ReadFile("migrations/" + filename)
 	if err != nil {
@@ -133,3 +279,26 @@ func executeMigration(db *sql.DB, filename string) error {
 	}
 	return nil
 }
+
+// WALStatus is the result of a passive WAL checkpoint.
+type WALStatus struct {
+	Busy               bool // readers or writers kept the checkpoint from finishing
+	LogFrames          int  // frames in the WAL file
+	CheckpointedFrames int  // frames copied back into the database file
+}
+
+// WALCheckpoint runs a passive checkpoint, which copies what it can from the
+// WAL into the database without waiting on other connections, and reports
+// how far it got. A WAL that keeps growing while CheckpointedFrames lags
+// behind LogFrames means something is holding a long read transaction.
+func WALCheckpoint(ctx context.Context, db *sql.DB) (WALStatus, error) {
+	var busy int
+	var status WALStatus
+	err := db.QueryRowContext(ctx, "PRAGMA wal_checkpoint(PASSIVE)").
+		Scan(&busy, &status.LogFrames, &status.CheckpointedFrames)
+	if err != nil {
+		return WALStatus{}, fmt.Errorf("wal checkpoint: %w", err)
+	}
+	status.Busy = busy != 0
+	return status, nil
+}
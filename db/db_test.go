@@ -0,0 +1,401 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMigrationStatus(t *testing.T) {
+	conn, err := Open(filepath.Join(t.TempDir(), "status.sqlite3"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer conn.Close()
+
+	fresh, err := MigrationStatus(conn)
+	if err != nil {
+		t.Fatalf("status on fresh db: %v", err)
+	}
+	if len(fresh) == 0 {
+		t.Fatal("expected embedded migrations to be listed")
+	}
+	for _, m := range fresh {
+		if m.Applied || m.AppliedAt != nil {
+			t.Errorf("fresh db: %s reported as applied", m.Filename)
+		}
+	}
+	if fresh[0].Number != 1 || fresh[0].Filename != "001-base.sql" {
+		t.Errorf("expected first migration 001-base.sql, got %d %s", fresh[0].Number, fresh[0].Filename)
+	}
+
+	if _, err := RunMigrations(conn); err != nil {
+		t.Fatalf("run migrations: %v", err)
+	}
+
+	after, err := MigrationStatus(conn)
+	if err != nil {
+		t.Fatalf("status after migrations: %v", err)
+	}
+	if len(after) != len(fresh) {
+		t.Fatalf("expected %d entries, got %d", len(fresh), len(after))
+	}
+	byFile := make(map[string]MigrationInfo)
+	for _, m := range after {
+		byFile[m.Filename] = m
+	}
+	for _, name := range []string{"001-base.sql", "009-created-by-email.sql", "022-fix-moderator-constraint.sql"} {
+		m, ok := byFile[name]
+		if !ok {
+			t.Fatalf("missing %s", name)
+		}
+		if !m.Applied || m.AppliedAt == nil {
+			t.Errorf("%s should be applied after RunMigrations", name)
+		}
+	}
+}
+
+func TestRunMigrationsDryRun(t *testing.T) {
+	conn, err := Open(filepath.Join(t.TempDir(), "dryrun.sqlite3"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer conn.Close()
+
+	all, err := MigrationStatus(conn)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+
+	pending, err := RunMigrationsDryRun(conn)
+	if err != nil {
+		t.Fatalf("dry run on fresh db: %v", err)
+	}
+	if len(pending) != len(all) {
+		t.Fatalf("expected %d pending on fresh db, got %d", len(all), len(pending))
+	}
+	if pending[0] != "001-base.sql" {
+		t.Errorf("expected first pending 001-base.sql, got %s", pending[0])
+	}
+	var tableName string
+	err = conn.QueryRow("SELECT name FROM sqlite_master WHERE type='table' AND name='migrations'").Scan(&tableName)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("dry run must not create the migrations table, got err=%v", err)
+	}
+
+	// Apply only the base migration; the rest should stay pending.
+	if err := executeMigration(conn, "001-base.sql"); err != nil {
+		t.Fatalf("apply base: %v", err)
+	}
+	var before int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM migrations").Scan(&before); err != nil {
+		t.Fatalf("count migrations: %v", err)
+	}
+
+	pending, err = RunMigrationsDryRun(conn)
+	if err != nil {
+		t.Fatalf("dry run after base: %v", err)
+	}
+	if len(pending) != len(all)-1 {
+		t.Errorf("expected %d pending after base, got %d", len(all)-1, len(pending))
+	}
+	if slices.Contains(pending, "001-base.sql") {
+		t.Error("001-base.sql should not be pending once applied")
+	}
+
+	var after int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM migrations").Scan(&after); err != nil {
+		t.Fatalf("count migrations: %v", err)
+	}
+	if after != before {
+		t.Errorf("dry run changed migrations table: %d rows before, %d after", before, after)
+	}
+}
+
+func TestRunMigrations_Number(t *testing.T) {
+	conn, err := Open(filepath.Join(t.TempDir(), "numbers.sqlite3"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer conn.Close()
+
+	results, err := RunMigrations(conn)
+	if err != nil {
+		t.Fatalf("run migrations: %v", err)
+	}
+	if len(results) == 0 {
+		t.Fatal("expected migrations to be applied")
+	}
+	for _, r := range results {
+		prefix, err := strconv.Atoi(r.Filename[:3])
+		if err != nil {
+			t.Fatalf("bad filename %s: %v", r.Filename, err)
+		}
+		if r.Number != prefix {
+			t.Errorf("%s: expected Number %d, got %d", r.Filename, prefix, r.Number)
+		}
+		if r.DurationMS != r.EndTime.Sub(r.StartTime).Milliseconds() {
+			t.Errorf("%s: DurationMS %d does not match start/end", r.Filename, r.DurationMS)
+		}
+	}
+}
+
+func TestOpen_ConcurrentWrites(t *testing.T) {
+	conn, err := Open(filepath.Join(t.TempDir(), "concurrent.sqlite3"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer conn.Close()
+
+	if got := conn.Stats().MaxOpenConnections; got != 1 {
+		t.Fatalf("expected MaxOpenConnections 1, got %d", got)
+	}
+	if _, err := conn.Exec("CREATE TABLE counter (n INTEGER)"); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	const writers, writesEach = 10, 20
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*writesEach)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < writesEach; j++ {
+				if _, err := conn.Exec("INSERT INTO counter (n) VALUES (?)", i*writesEach+j); err != nil {
+					errs <- err
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("concurrent write: %v", err)
+	}
+
+	var count int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM counter").Scan(&count); err != nil {
+		t.Fatalf("count rows: %v", err)
+	}
+	if count != writers*writesEach {
+		t.Errorf("expected %d rows, got %d", writers*writesEach, count)
+	}
+}
+
+func TestOpenWithPool(t *testing.T) {
+	conn, err := OpenWithPool(filepath.Join(t.TempDir(), "pool.sqlite3"), 4, 2, time.Minute)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer conn.Close()
+
+	if got := conn.Stats().MaxOpenConnections; got != 4 {
+		t.Errorf("expected MaxOpenConnections 4, got %d", got)
+	}
+
+	// Pragmas must apply to every pooled connection, not just the first
+	ctx := context.Background()
+	var conns []*sql.Conn
+	for i := 0; i < 3; i++ {
+		c, err := conn.Conn(ctx)
+		if err != nil {
+			t.Fatalf("get conn: %v", err)
+		}
+		conns = append(conns, c)
+	}
+	for i, c := range conns {
+		var fk int
+		if err := c.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&fk); err != nil {
+			t.Fatalf("conn %d: read foreign_keys: %v", i, err)
+		}
+		if fk != 1 {
+			t.Errorf("conn %d: expected foreign_keys on, got %d", i, fk)
+		}
+		c.Close()
+	}
+}
+
+// copyDB checkpoints src and copies its main file to a new path, the way a
+// deployment would refresh a read replica.
+func copyDB(t testing.TB, src *sql.DB, srcPath string) string {
+	t.Helper()
+	if _, err := src.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		t.Fatalf("checkpoint: %v", err)
+	}
+	data, err := os.ReadFile(srcPath)
+	if err != nil {
+		t.Fatalf("read db: %v", err)
+	}
+	dst := filepath.Join(t.TempDir(), "replica.sqlite3")
+	if err := os.WriteFile(dst, data, 0o600); err != nil {
+		t.Fatalf("write replica: %v", err)
+	}
+	return dst
+}
+
+func TestOpenPrimary(t *testing.T) {
+	t.Run("single database without read path", func(t *testing.T) {
+		writer, reader, err := OpenPrimary(filepath.Join(t.TempDir(), "single.sqlite3"), "")
+		if err != nil {
+			t.Fatalf("open: %v", err)
+		}
+		defer writer.Close()
+		if writer != reader {
+			t.Error("expected writer to be used for reads when no read path is set")
+		}
+	})
+
+	t.Run("read replica is read-only", func(t *testing.T) {
+		writePath := filepath.Join(t.TempDir(), "primary.sqlite3")
+		seed, err := Open(writePath)
+		if err != nil {
+			t.Fatalf("open seed: %v", err)
+		}
+		if _, err := seed.Exec("CREATE TABLE items (n INTEGER); INSERT INTO items VALUES (1), (2)"); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+		readPath := copyDB(t, seed, writePath)
+		seed.Close()
+
+		writer, reader, err := OpenPrimary(writePath, readPath)
+		if err != nil {
+			t.Fatalf("open: %v", err)
+		}
+		defer writer.Close()
+		defer reader.Close()
+
+		var count int
+		if err := reader.QueryRow("SELECT COUNT(*) FROM items").Scan(&count); err != nil {
+			t.Fatalf("read from replica: %v", err)
+		}
+		if count != 2 {
+			t.Errorf("expected 2 rows in replica, got %d", count)
+		}
+		if _, err := reader.Exec("INSERT INTO items VALUES (3)"); err == nil {
+			t.Error("expected write to read replica to fail")
+		}
+		if _, err := writer.Exec("INSERT INTO items VALUES (3)"); err != nil {
+			t.Errorf("write to primary: %v", err)
+		}
+	})
+
+	t.Run("writer pool size applies with a replica", func(t *testing.T) {
+		writePath := filepath.Join(t.TempDir(), "primary.sqlite3")
+		seed, err := Open(writePath)
+		if err != nil {
+			t.Fatalf("open seed: %v", err)
+		}
+		readPath := copyDB(t, seed, writePath)
+		seed.Close()
+
+		writer, reader, err := OpenPrimaryWithPool(writePath, readPath, 3)
+		if err != nil {
+			t.Fatalf("open: %v", err)
+		}
+		defer writer.Close()
+		defer reader.Close()
+		if got := writer.Stats().MaxOpenConnections; got != 3 {
+			t.Errorf("expected writer MaxOpenConnections 3, got %d", got)
+		}
+		if got := reader.Stats().MaxOpenConnections; got != readReplicaMaxConns {
+			t.Errorf("expected reader MaxOpenConnections %d, got %d", readReplicaMaxConns, got)
+		}
+	})
+
+	t.Run("missing replica fails", func(t *testing.T) {
+		_, _, err := OpenPrimary(filepath.Join(t.TempDir(), "primary.sqlite3"), filepath.Join(t.TempDir(), "missing.sqlite3"))
+		if err == nil {
+			t.Error("expected error for missing read replica")
+		}
+	})
+}
+
+// BenchmarkReads compares concurrent reads through the single-connection
+// primary with reads through a read replica pool.
+func BenchmarkReads(b *testing.B) {
+	writePath := filepath.Join(b.TempDir(), "primary.sqlite3")
+	seed, err := Open(writePath)
+	if err != nil {
+		b.Fatalf("open seed: %v", err)
+	}
+	if _, err := RunMigrations(seed); err != nil {
+		b.Fatalf("migrate: %v", err)
+	}
+	for i := 0; i < 500; i++ {
+		if _, err := seed.Exec("INSERT INTO quotes (user_id, text) VALUES ('bench', ?)", "Benchmark quote "+strconv.Itoa(i)); err != nil {
+			b.Fatalf("seed quote: %v", err)
+		}
+	}
+	readPath := copyDB(b, seed, writePath)
+	seed.Close()
+
+	bench := func(b *testing.B, conn *sql.DB) {
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				var text string
+				if err := conn.QueryRow("SELECT text FROM quotes ORDER BY RANDOM() LIMIT 1").Scan(&text); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		})
+	}
+
+	b.Run("primary", func(b *testing.B) {
+		writer, _, err := OpenPrimary(writePath, "")
+		if err != nil {
+			b.Fatalf("open: %v", err)
+		}
+		defer writer.Close()
+		bench(b, writer)
+	})
+
+	b.Run("replica", func(b *testing.B) {
+		writer, reader, err := OpenPrimary(writePath, readPath)
+		if err != nil {
+			b.Fatalf("open: %v", err)
+		}
+		defer writer.Close()
+		defer reader.Close()
+		bench(b, reader)
+	})
+}
+
+func TestWALCheckpoint(t *testing.T) {
+	conn, err := Open(filepath.Join(t.TempDir(), "wal.sqlite3"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Exec("CREATE TABLE t (v TEXT)"); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	if _, err := conn.Exec("INSERT INTO t (v) VALUES ('x')"); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	status, err := WALCheckpoint(context.Background(), conn)
+	if err != nil {
+		t.Fatalf("checkpoint: %v", err)
+	}
+	if status.Busy {
+		t.Error("expected an idle database to checkpoint fully")
+	}
+	if status.LogFrames == 0 || status.CheckpointedFrames != status.LogFrames {
+		t.Errorf("expected every WAL frame checkpointed, got %+v", status)
+	}
+
+	conn.Close()
+	if _, err := WALCheckpoint(context.Background(), conn); err == nil {
+		t.Error("expected an error on a closed database")
+	}
+}
@@ -35,6 +35,24 @@ func (q *Queries) CountChannelOwners(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countSearchChannelOwners = `-- name: CountSearchChannelOwners :one
+SELECT COUNT(*) as count FROM channel_owners
+WHERE (?1 IS NULL OR channel LIKE '%' || ?1 || '%' ESCAPE '\')
+  AND (?2 IS NULL OR user_email LIKE '%' || ?2 || '%' ESCAPE '\')
+`
+
+type CountSearchChannelOwnersParams struct {
+	Channel interface{} `json:"channel"`
+	Email   interface{} `json:"email"`
+}
+
+func (q *Queries) CountSearchChannelOwners(ctx context.Context, arg CountSearchChannelOwnersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSearchChannelOwners, arg.Channel, arg.Email)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const getChannelsByOwner = `-- name: GetChannelsByOwner :many
 SELECT channel FROM channel_owners WHERE user_email = ?
 `
@@ -138,6 +156,39 @@ func (q *Queries) ListAllChannelOwners(ctx context.Context) ([]ChannelOwner, err
 	return items, nil
 }
 
+const listChannelOwnersByChannel = `-- name: ListChannelOwnersByChannel :many
+SELECT id, channel, user_email, invited_at, invited_by FROM channel_owners WHERE channel = ? ORDER BY user_email
+`
+
+func (q *Queries) ListChannelOwnersByChannel(ctx context.Context, channel string) ([]ChannelOwner, error) {
+	rows, err := q.db.QueryContext(ctx, listChannelOwnersByChannel, channel)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ChannelOwner{}
+	for rows.Next() {
+		var i ChannelOwner
+		if err := rows.Scan(
+			&i.ID,
+			&i.Channel,
+			&i.UserEmail,
+			&i.InvitedAt,
+			&i.InvitedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const removeChannelOwner = `-- name: RemoveChannelOwner :exec
 DELETE FROM channel_owners WHERE channel = ? AND user_email = ?
 `
@@ -151,3 +202,52 @@ func (q *Queries) RemoveChannelOwner(ctx context.Context, arg RemoveChannelOwner
 	_, err := q.db.ExecContext(ctx, removeChannelOwner, arg.Channel, arg.UserEmail)
 	return err
 }
+
+const searchChannelOwners = `-- name: SearchChannelOwners :many
+SELECT id, channel, user_email, invited_at, invited_by FROM channel_owners
+WHERE (?1 IS NULL OR channel LIKE '%' || ?1 || '%' ESCAPE '\')
+  AND (?2 IS NULL OR user_email LIKE '%' || ?2 || '%' ESCAPE '\')
+ORDER BY channel, user_email
+LIMIT ?4 OFFSET ?3
+`
+
+type SearchChannelOwnersParams struct {
+	Channel interface{} `json:"channel"`
+	Email   interface{} `json:"email"`
+	Offset  int64       `json:"offset"`
+	Limit   int64       `json:"limit"`
+}
+
+func (q *Queries) SearchChannelOwners(ctx context.Context, arg SearchChannelOwnersParams) ([]ChannelOwner, error) {
+	rows, err := q.db.QueryContext(ctx, searchChannelOwners,
+		arg.Channel,
+		arg.Email,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ChannelOwner{}
+	for rows.Next() {
+		var i ChannelOwner
+		if err := rows.Scan(
+			&i.ID,
+			&i.Channel,
+			&i.UserEmail,
+			&i.InvitedAt,
+			&i.InvitedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
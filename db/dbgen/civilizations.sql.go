@@ -10,8 +10,19 @@ import (
 	"time"
 )
 
+const countCivs = `-- name: CountCivs :one
+SELECT COUNT(*) as count FROM civilizations
+`
+
+func (q *Queries) CountCivs(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCivs)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countQuotesByCiv = `-- name: CountQuotesByCiv :one
-SELECT COUNT(*) as count FROM quotes WHERE civilization = ?
+SELECT COUNT(*) as count FROM quotes WHERE civilization = ? AND deleted_at IS NULL
 `
 
 func (q *Queries) CountQuotesByCiv(ctx context.Context, civilization *string) (int64, error) {
@@ -105,6 +116,52 @@ func (q *Queries) GetCivByShortname(ctx context.Context, shortname *string) (Civ
 	return i, err
 }
 
+const getRandomCiv = `-- name: GetRandomCiv :one
+SELECT id, name, variant_of, dlc, created_at, shortname FROM civilizations
+WHERE ',' || ?1 || ',' NOT LIKE '%,' || LOWER(name) || ',%'
+  AND (shortname IS NULL OR ',' || ?1 || ',' NOT LIKE '%,' || LOWER(shortname) || ',%')
+ORDER BY RANDOM()
+LIMIT 1
+`
+
+// exclude is a comma-separated, lowercased list of names/shortnames to skip.
+func (q *Queries) GetRandomCiv(ctx context.Context, exclude *string) (Civilization, error) {
+	row := q.db.QueryRowContext(ctx, getRandomCiv, exclude)
+	var i Civilization
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.VariantOf,
+		&i.Dlc,
+		&i.CreatedAt,
+		&i.Shortname,
+	)
+	return i, err
+}
+
+const getRandomCivWithQuotes = `-- name: GetRandomCivWithQuotes :one
+SELECT id, name, variant_of, dlc, created_at, shortname FROM civilizations
+WHERE EXISTS (SELECT 1 FROM quotes WHERE quotes.civilization = civilizations.name AND quotes.deleted_at IS NULL)
+  AND ',' || ?1 || ',' NOT LIKE '%,' || LOWER(name) || ',%'
+  AND (shortname IS NULL OR ',' || ?1 || ',' NOT LIKE '%,' || LOWER(shortname) || ',%')
+ORDER BY RANDOM()
+LIMIT 1
+`
+
+func (q *Queries) GetRandomCivWithQuotes(ctx context.Context, exclude *string) (Civilization, error) {
+	row := q.db.QueryRowContext(ctx, getRandomCivWithQuotes, exclude)
+	var i Civilization
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.VariantOf,
+		&i.Dlc,
+		&i.CreatedAt,
+		&i.Shortname,
+	)
+	return i, err
+}
+
 const listCivs = `-- name: ListCivs :many
 SELECT id, name, variant_of, dlc, created_at, shortname FROM civilizations ORDER BY name
 `
@@ -144,7 +201,7 @@ SELECT
     c.id, c.name, c.variant_of, c.dlc, c.created_at, c.shortname,
     COUNT(q.id) as quote_count
 FROM civilizations c
-LEFT JOIN quotes q ON q.civilization = c.name
+LEFT JOIN quotes q ON q.civilization = c.name AND q.deleted_at IS NULL
 GROUP BY c.id
 ORDER BY c.name
 `
@@ -8,6 +8,15 @@ import (
 	"time"
 )
 
+type AuditEvent struct {
+	ID        int64     `json:"id"`
+	Event     string    `json:"event"`
+	UserEmail *string   `json:"user_email"`
+	Path      *string   `json:"path"`
+	Reason    *string   `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type ChannelOwner struct {
 	ID        int64     `json:"id"`
 	Channel   string    `json:"channel"`
@@ -16,6 +25,13 @@ type ChannelOwner struct {
 	InvitedBy string    `json:"invited_by"`
 }
 
+type ChannelSetting struct {
+	Channel                  string    `json:"channel"`
+	RequireModForSuggestions bool      `json:"require_mod_for_suggestions"`
+	UpdatedAt                time.Time `json:"updated_at"`
+	UpdatedBy                *string   `json:"updated_by"`
+}
+
 type Civilization struct {
 	ID        int64     `json:"id"`
 	Name      string    `json:"name"`
@@ -85,16 +101,19 @@ type NightbotToken struct {
 }
 
 type Quote struct {
-	ID             int64     `json:"id"`
-	UserID         string    `json:"user_id"`
-	Text           string    `json:"text"`
-	Author         *string   `json:"author"`
-	CreatedAt      time.Time `json:"created_at"`
-	Civilization   *string   `json:"civilization"`
-	OpponentCiv    *string   `json:"opponent_civ"`
-	Channel        *string   `json:"channel"`
-	CreatedByEmail *string   `json:"created_by_email"`
-	RequestedBy    *string   `json:"requested_by"`
+	ID             int64      `json:"id"`
+	UserID         string     `json:"user_id"`
+	Text           string     `json:"text"`
+	Author         *string    `json:"author"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Civilization   *string    `json:"civilization"`
+	OpponentCiv    *string    `json:"opponent_civ"`
+	Channel        *string    `json:"channel"`
+	CreatedByEmail *string    `json:"created_by_email"`
+	RequestedBy    *string    `json:"requested_by"`
+	Upvotes        int64      `json:"upvotes"`
+	Downvotes      int64      `json:"downvotes"`
+	DeletedAt      *time.Time `json:"deleted_at"`
 }
 
 type QuoteSuggestion struct {
@@ -110,6 +129,24 @@ type QuoteSuggestion struct {
 	ReviewedBy      *string    `json:"reviewed_by"`
 	ReviewedAt      *time.Time `json:"reviewed_at"`
 	SubmittedByUser *string    `json:"submitted_by_user"`
+	ViewedAt        *time.Time `json:"viewed_at"`
+	ViewedBy        *string    `json:"viewed_by"`
+	RejectReason    *string    `json:"reject_reason"`
+}
+
+type QuoteTag struct {
+	QuoteID int64 `json:"quote_id"`
+	TagID   int64 `json:"tag_id"`
+}
+
+type QuotesFt struct {
+	Text string `json:"text"`
+}
+
+type Tag struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type TwitchSession struct {
@@ -12,7 +12,7 @@ import (
 )
 
 const bulkDeleteQuotes = `-- name: BulkDeleteQuotes :exec
-DELETE FROM quotes WHERE id IN (/*SLICE:ids*/?)
+UPDATE quotes SET deleted_at = CURRENT_TIMESTAMP WHERE id IN (/*SLICE:ids*/?) AND deleted_at IS NULL
 `
 
 func (q *Queries) BulkDeleteQuotes(ctx context.Context, ids []int64) error {
@@ -30,8 +30,33 @@ func (q *Queries) BulkDeleteQuotes(ctx context.Context, ids []int64) error {
 	return err
 }
 
+const bulkUpdateAuthor = `-- name: BulkUpdateAuthor :exec
+UPDATE quotes SET author = ? WHERE id IN (/*SLICE:ids*/?) AND deleted_at IS NULL
+`
+
+type BulkUpdateAuthorParams struct {
+	Author *string `json:"author"`
+	Ids    []int64 `json:"ids"`
+}
+
+func (q *Queries) BulkUpdateAuthor(ctx context.Context, arg BulkUpdateAuthorParams) error {
+	query := bulkUpdateAuthor
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.Author)
+	if len(arg.Ids) > 0 {
+		for _, v := range arg.Ids {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:ids*/?", strings.Repeat(",?", len(arg.Ids))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:ids*/?", "NULL", 1)
+	}
+	_, err := q.db.ExecContext(ctx, query, queryParams...)
+	return err
+}
+
 const bulkUpdateChannel = `-- name: BulkUpdateChannel :exec
-UPDATE quotes SET channel = ? WHERE id IN (/*SLICE:ids*/?)
+UPDATE quotes SET channel = ? WHERE id IN (/*SLICE:ids*/?) AND deleted_at IS NULL
 `
 
 type BulkUpdateChannelParams struct {
@@ -56,7 +81,7 @@ func (q *Queries) BulkUpdateChannel(ctx context.Context, arg BulkUpdateChannelPa
 }
 
 const bulkUpdateCivilization = `-- name: BulkUpdateCivilization :exec
-UPDATE quotes SET civilization = ? WHERE id IN (/*SLICE:ids*/?)
+UPDATE quotes SET civilization = ? WHERE id IN (/*SLICE:ids*/?) AND deleted_at IS NULL
 `
 
 type BulkUpdateCivilizationParams struct {
@@ -80,8 +105,46 @@ func (q *Queries) BulkUpdateCivilization(ctx context.Context, arg BulkUpdateCivi
 	return err
 }
 
-const countQuotes = `-- name: CountQuotes :one
+const clearQuoteCivilization = `-- name: ClearQuoteCivilization :exec
+UPDATE quotes SET civilization = NULL WHERE civilization = ?
+`
+
+func (q *Queries) ClearQuoteCivilization(ctx context.Context, civilization *string) error {
+	_, err := q.db.ExecContext(ctx, clearQuoteCivilization, civilization)
+	return err
+}
+
+const clearQuoteOpponentCiv = `-- name: ClearQuoteOpponentCiv :exec
+UPDATE quotes SET opponent_civ = NULL WHERE opponent_civ = ?
+`
+
+func (q *Queries) ClearQuoteOpponentCiv(ctx context.Context, opponentCiv *string) error {
+	_, err := q.db.ExecContext(ctx, clearQuoteOpponentCiv, opponentCiv)
+	return err
+}
+
+const countMatchupQuotes = `-- name: CountMatchupQuotes :one
 SELECT COUNT(*) as count FROM quotes
+WHERE opponent_civ IS NOT NULL
+  AND (civilization = ?1 OR ?1 IS NULL)
+  AND (channel = ?2 OR ?2 IS NULL)
+  AND deleted_at IS NULL
+`
+
+type CountMatchupQuotesParams struct {
+	Civ     *string `json:"civ"`
+	Channel *string `json:"channel"`
+}
+
+func (q *Queries) CountMatchupQuotes(ctx context.Context, arg CountMatchupQuotesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countMatchupQuotes, arg.Civ, arg.Channel)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countQuotes = `-- name: CountQuotes :one
+SELECT COUNT(*) as count FROM quotes WHERE deleted_at IS NULL
 `
 
 func (q *Queries) CountQuotes(ctx context.Context) (int64, error) {
@@ -91,8 +154,21 @@ func (q *Queries) CountQuotes(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countQuotesByAuthor = `-- name: CountQuotesByAuthor :one
+SELECT COUNT(*) as count FROM quotes
+WHERE author LIKE '%' || ?1 || '%' ESCAPE '\'
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) CountQuotesByAuthor(ctx context.Context, author *string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countQuotesByAuthor, author)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countQuotesByChannel = `-- name: CountQuotesByChannel :one
-SELECT COUNT(*) as count FROM quotes WHERE channel = ?
+SELECT COUNT(*) as count FROM quotes WHERE channel = ? AND deleted_at IS NULL
 `
 
 func (q *Queries) CountQuotesByChannel(ctx context.Context, channel *string) (int64, error) {
@@ -102,9 +178,78 @@ func (q *Queries) CountQuotesByChannel(ctx context.Context, channel *string) (in
 	return count, err
 }
 
-const createQuote = `-- name: CreateQuote :exec
+const countQuotesByChannelAndAuthor = `-- name: CountQuotesByChannelAndAuthor :one
+SELECT COUNT(*) as count FROM quotes
+WHERE channel = ?1 AND author LIKE '%' || ?2 || '%' ESCAPE '\'
+  AND deleted_at IS NULL
+`
+
+type CountQuotesByChannelAndAuthorParams struct {
+	Channel *string `json:"channel"`
+	Author  *string `json:"author"`
+}
+
+func (q *Queries) CountQuotesByChannelAndAuthor(ctx context.Context, arg CountQuotesByChannelAndAuthorParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countQuotesByChannelAndAuthor, arg.Channel, arg.Author)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countQuotesByCivAndChannel = `-- name: CountQuotesByCivAndChannel :one
+SELECT COUNT(*) as count FROM quotes
+WHERE civilization = ?1
+  AND (channel = ?2 OR ?2 IS NULL)
+  AND deleted_at IS NULL
+`
+
+type CountQuotesByCivAndChannelParams struct {
+	Civ     *string `json:"civ"`
+	Channel *string `json:"channel"`
+}
+
+func (q *Queries) CountQuotesByCivAndChannel(ctx context.Context, arg CountQuotesByCivAndChannelParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countQuotesByCivAndChannel, arg.Civ, arg.Channel)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countQuotesIncludingDeleted = `-- name: CountQuotesIncludingDeleted :one
+SELECT COUNT(*) as count FROM quotes
+`
+
+func (q *Queries) CountQuotesIncludingDeleted(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countQuotesIncludingDeleted)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSearchQuotes = `-- name: CountSearchQuotes :one
+SELECT COUNT(*) FROM quotes
+JOIN quotes_fts ON quotes_fts.rowid = quotes.id
+WHERE quotes_fts.text MATCH ?1
+  AND (quotes.channel = ?2 OR ?2 IS NULL)
+  AND quotes.deleted_at IS NULL
+`
+
+type CountSearchQuotesParams struct {
+	Query   string  `json:"query"`
+	Channel *string `json:"channel"`
+}
+
+func (q *Queries) CountSearchQuotes(ctx context.Context, arg CountSearchQuotesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSearchQuotes, arg.Query, arg.Channel)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createQuote = `-- name: CreateQuote :one
 INSERT INTO quotes (user_id, created_by_email, text, author, civilization, opponent_civ, channel, requested_by, created_at)
 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id
 `
 
 type CreateQuoteParams struct {
@@ -119,8 +264,8 @@ type CreateQuoteParams struct {
 	CreatedAt      time.Time `json:"created_at"`
 }
 
-func (q *Queries) CreateQuote(ctx context.Context, arg CreateQuoteParams) error {
-	_, err := q.db.ExecContext(ctx, createQuote,
+func (q *Queries) CreateQuote(ctx context.Context, arg CreateQuoteParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createQuote,
 		arg.UserID,
 		arg.CreatedByEmail,
 		arg.Text,
@@ -131,11 +276,13 @@ func (q *Queries) CreateQuote(ctx context.Context, arg CreateQuoteParams) error
 		arg.RequestedBy,
 		arg.CreatedAt,
 	)
-	return err
+	var id int64
+	err := row.Scan(&id)
+	return id, err
 }
 
 const deleteQuote = `-- name: DeleteQuote :exec
-DELETE FROM quotes WHERE id = ? AND user_id = ?
+UPDATE quotes SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND user_id = ? AND deleted_at IS NULL
 `
 
 type DeleteQuoteParams struct {
@@ -149,16 +296,17 @@ func (q *Queries) DeleteQuote(ctx context.Context, arg DeleteQuoteParams) error
 }
 
 const deleteQuoteByID = `-- name: DeleteQuoteByID :exec
-DELETE FROM quotes WHERE id = ?
+UPDATE quotes SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL
 `
 
+// Moves the quote to the trash; PurgeQuoteByID deletes it for good.
 func (q *Queries) DeleteQuoteByID(ctx context.Context, id int64) error {
 	_, err := q.db.ExecContext(ctx, deleteQuoteByID, id)
 	return err
 }
 
 const deleteQuoteByText = `-- name: DeleteQuoteByText :exec
-DELETE FROM quotes WHERE text = ?
+UPDATE quotes SET deleted_at = CURRENT_TIMESTAMP WHERE text = ? AND deleted_at IS NULL
 `
 
 func (q *Queries) DeleteQuoteByText(ctx context.Context, text string) error {
@@ -166,8 +314,46 @@ func (q *Queries) DeleteQuoteByText(ctx context.Context, text string) error {
 	return err
 }
 
+const findSimilarQuotes = `-- name: FindSimilarQuotes :many
+SELECT id FROM quotes
+WHERE LOWER(TRIM(text)) = LOWER(TRIM(?1))
+  AND LOWER(channel) IS LOWER(?2)
+  AND deleted_at IS NULL
+ORDER BY id
+`
+
+type FindSimilarQuotesParams struct {
+	Text    string  `json:"text"`
+	Channel *string `json:"channel"`
+}
+
+// Quotes whose text matches, ignoring surrounding whitespace and case, in the
+// same channel. A NULL channel matches only global quotes. Oldest first.
+func (q *Queries) FindSimilarQuotes(ctx context.Context, arg FindSimilarQuotesParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, findSimilarQuotes, arg.Text, arg.Channel)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLastUpdated = `-- name: GetLastUpdated :one
-SELECT created_at FROM quotes ORDER BY created_at DESC LIMIT 1
+SELECT created_at FROM quotes WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT 1
 `
 
 func (q *Queries) GetLastUpdated(ctx context.Context) (time.Time, error) {
@@ -177,8 +363,28 @@ func (q *Queries) GetLastUpdated(ctx context.Context) (time.Time, error) {
 	return created_at, err
 }
 
+const getNextQuoteID = `-- name: GetNextQuoteID :one
+SELECT id FROM quotes WHERE id > ? AND deleted_at IS NULL ORDER BY id ASC LIMIT 1
+`
+
+func (q *Queries) GetNextQuoteID(ctx context.Context, id int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getNextQuoteID, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getPrevQuoteID = `-- name: GetPrevQuoteID :one
+SELECT id FROM quotes WHERE id < ? AND deleted_at IS NULL ORDER BY id DESC LIMIT 1
+`
+
+func (q *Queries) GetPrevQuoteID(ctx context.Context, id int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getPrevQuoteID, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getQuoteByID = `-- name: GetQuoteByID :one
-SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by FROM quotes WHERE id = ?
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes WHERE id = ? AND deleted_at IS NULL
 `
 
 func (q *Queries) GetQuoteByID(ctx context.Context, id int64) (Quote, error) {
@@ -195,13 +401,41 @@ func (q *Queries) GetQuoteByID(ctx context.Context, id int64) (Quote, error) {
 		&i.Channel,
 		&i.CreatedByEmail,
 		&i.RequestedBy,
+		&i.Upvotes,
+		&i.Downvotes,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getQuoteByIDIncludeDeleted = `-- name: GetQuoteByIDIncludeDeleted :one
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes WHERE id = ?
+`
+
+func (q *Queries) GetQuoteByIDIncludeDeleted(ctx context.Context, id int64) (Quote, error) {
+	row := q.db.QueryRowContext(ctx, getQuoteByIDIncludeDeleted, id)
+	var i Quote
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Text,
+		&i.Author,
+		&i.CreatedAt,
+		&i.Civilization,
+		&i.OpponentCiv,
+		&i.Channel,
+		&i.CreatedByEmail,
+		&i.RequestedBy,
+		&i.Upvotes,
+		&i.Downvotes,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getRandomMatchupQuote = `-- name: GetRandomMatchupQuote :one
-SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by FROM quotes
-WHERE civilization = ? AND opponent_civ = ? AND (channel IS NULL OR channel = ?)
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE civilization = ? AND opponent_civ = ? AND (channel IS NULL OR channel = ?) AND deleted_at IS NULL
 ORDER BY RANDOM()
 LIMIT 1
 `
@@ -226,13 +460,90 @@ func (q *Queries) GetRandomMatchupQuote(ctx context.Context, arg GetRandomMatchu
 		&i.Channel,
 		&i.CreatedByEmail,
 		&i.RequestedBy,
+		&i.Upvotes,
+		&i.Downvotes,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getRandomMatchupQuoteBidirectional = `-- name: GetRandomMatchupQuoteBidirectional :one
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE ((civilization = ?1 AND opponent_civ = ?2)
+    OR (civilization = ?2 AND opponent_civ = ?1))
+  AND (channel IS NULL OR channel = ?3)
+  AND deleted_at IS NULL
+ORDER BY civilization = ?1 DESC, RANDOM()
+LIMIT 1
+`
+
+type GetRandomMatchupQuoteBidirectionalParams struct {
+	Civ     *string `json:"civ"`
+	Vs      *string `json:"vs"`
+	Channel *string `json:"channel"`
+}
+
+// Also matches tips stored the other way round; those written from the
+// requested civ's side come first.
+func (q *Queries) GetRandomMatchupQuoteBidirectional(ctx context.Context, arg GetRandomMatchupQuoteBidirectionalParams) (Quote, error) {
+	row := q.db.QueryRowContext(ctx, getRandomMatchupQuoteBidirectional, arg.Civ, arg.Vs, arg.Channel)
+	var i Quote
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Text,
+		&i.Author,
+		&i.CreatedAt,
+		&i.Civilization,
+		&i.OpponentCiv,
+		&i.Channel,
+		&i.CreatedByEmail,
+		&i.RequestedBy,
+		&i.Upvotes,
+		&i.Downvotes,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getRandomMatchupQuoteBidirectionalGlobal = `-- name: GetRandomMatchupQuoteBidirectionalGlobal :one
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE ((civilization = ?1 AND opponent_civ = ?2)
+    OR (civilization = ?2 AND opponent_civ = ?1))
+  AND deleted_at IS NULL
+ORDER BY civilization = ?1 DESC, RANDOM()
+LIMIT 1
+`
+
+type GetRandomMatchupQuoteBidirectionalGlobalParams struct {
+	Civ *string `json:"civ"`
+	Vs  *string `json:"vs"`
+}
+
+func (q *Queries) GetRandomMatchupQuoteBidirectionalGlobal(ctx context.Context, arg GetRandomMatchupQuoteBidirectionalGlobalParams) (Quote, error) {
+	row := q.db.QueryRowContext(ctx, getRandomMatchupQuoteBidirectionalGlobal, arg.Civ, arg.Vs)
+	var i Quote
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Text,
+		&i.Author,
+		&i.CreatedAt,
+		&i.Civilization,
+		&i.OpponentCiv,
+		&i.Channel,
+		&i.CreatedByEmail,
+		&i.RequestedBy,
+		&i.Upvotes,
+		&i.Downvotes,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getRandomMatchupQuoteGlobal = `-- name: GetRandomMatchupQuoteGlobal :one
-SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by FROM quotes
-WHERE civilization = ? AND opponent_civ = ?
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE civilization = ? AND opponent_civ = ? AND deleted_at IS NULL
 ORDER BY RANDOM()
 LIMIT 1
 `
@@ -256,13 +567,16 @@ func (q *Queries) GetRandomMatchupQuoteGlobal(ctx context.Context, arg GetRandom
 		&i.Channel,
 		&i.CreatedByEmail,
 		&i.RequestedBy,
+		&i.Upvotes,
+		&i.Downvotes,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getRandomQuote = `-- name: GetRandomQuote :one
-SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by FROM quotes
-WHERE channel IS NULL OR channel = ?
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE (channel IS NULL OR channel = ?) AND deleted_at IS NULL
 ORDER BY RANDOM()
 LIMIT 1
 `
@@ -281,13 +595,16 @@ func (q *Queries) GetRandomQuote(ctx context.Context, channel *string) (Quote, e
 		&i.Channel,
 		&i.CreatedByEmail,
 		&i.RequestedBy,
+		&i.Upvotes,
+		&i.Downvotes,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getRandomQuoteByCiv = `-- name: GetRandomQuoteByCiv :one
-SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by FROM quotes
-WHERE civilization = ? AND (channel IS NULL OR channel = ?)
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE civilization = ? AND (channel IS NULL OR channel = ?) AND deleted_at IS NULL
 ORDER BY RANDOM()
 LIMIT 1
 `
@@ -311,13 +628,16 @@ func (q *Queries) GetRandomQuoteByCiv(ctx context.Context, arg GetRandomQuoteByC
 		&i.Channel,
 		&i.CreatedByEmail,
 		&i.RequestedBy,
+		&i.Upvotes,
+		&i.Downvotes,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getRandomQuoteByCivGlobal = `-- name: GetRandomQuoteByCivGlobal :one
-SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by FROM quotes
-WHERE civilization = ?
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE civilization = ? AND deleted_at IS NULL
 ORDER BY RANDOM()
 LIMIT 1
 `
@@ -336,12 +656,57 @@ func (q *Queries) GetRandomQuoteByCivGlobal(ctx context.Context, civilization *s
 		&i.Channel,
 		&i.CreatedByEmail,
 		&i.RequestedBy,
+		&i.Upvotes,
+		&i.Downvotes,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getRandomQuoteByTag = `-- name: GetRandomQuoteByTag :one
+SELECT quotes.id, quotes.user_id, quotes.text, quotes.author, quotes.created_at, quotes.civilization, quotes.opponent_civ, quotes.channel, quotes.created_by_email, quotes.requested_by, quotes.upvotes, quotes.downvotes, quotes.deleted_at FROM quotes
+JOIN quote_tags ON quote_tags.quote_id = quotes.id
+JOIN tags ON tags.id = quote_tags.tag_id
+WHERE tags.name = ?1
+  AND (quotes.civilization = ?2 OR ?2 IS NULL)
+  AND (?3 IS NULL OR quotes.channel IS NULL OR quotes.channel = ?3)
+  AND quotes.deleted_at IS NULL
+ORDER BY RANDOM()
+LIMIT 1
+`
+
+type GetRandomQuoteByTagParams struct {
+	Tag          string  `json:"tag"`
+	Civilization *string `json:"civilization"`
+	Channel      *string `json:"channel"`
+}
+
+// Random quote with the tag, also filtered by civilization and channel when
+// given. A NULL channel matches every quote, like the global variants.
+func (q *Queries) GetRandomQuoteByTag(ctx context.Context, arg GetRandomQuoteByTagParams) (Quote, error) {
+	row := q.db.QueryRowContext(ctx, getRandomQuoteByTag, arg.Tag, arg.Civilization, arg.Channel)
+	var i Quote
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Text,
+		&i.Author,
+		&i.CreatedAt,
+		&i.Civilization,
+		&i.OpponentCiv,
+		&i.Channel,
+		&i.CreatedByEmail,
+		&i.RequestedBy,
+		&i.Upvotes,
+		&i.Downvotes,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getRandomQuoteGlobal = `-- name: GetRandomQuoteGlobal :one
-SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by FROM quotes
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE deleted_at IS NULL
 ORDER BY RANDOM()
 LIMIT 1
 `
@@ -360,16 +725,28 @@ func (q *Queries) GetRandomQuoteGlobal(ctx context.Context) (Quote, error) {
 		&i.Channel,
 		&i.CreatedByEmail,
 		&i.RequestedBy,
+		&i.Upvotes,
+		&i.Downvotes,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
-const listAllQuotes = `-- name: ListAllQuotes :many
-SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by FROM quotes ORDER BY created_at DESC
+const getTopQuotes = `-- name: GetTopQuotes :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE (channel = ?1 OR ?1 IS NULL)
+  AND deleted_at IS NULL
+ORDER BY upvotes - downvotes DESC, upvotes DESC, id DESC
+LIMIT ?2
 `
 
-func (q *Queries) ListAllQuotes(ctx context.Context) ([]Quote, error) {
-	rows, err := q.db.QueryContext(ctx, listAllQuotes)
+type GetTopQuotesParams struct {
+	Channel *string `json:"channel"`
+	Limit   int64   `json:"limit"`
+}
+
+func (q *Queries) GetTopQuotes(ctx context.Context, arg GetTopQuotesParams) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, getTopQuotes, arg.Channel, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -388,6 +765,9 @@ func (q *Queries) ListAllQuotes(ctx context.Context) ([]Quote, error) {
 			&i.Channel,
 			&i.CreatedByEmail,
 			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -402,23 +782,61 @@ func (q *Queries) ListAllQuotes(ctx context.Context) ([]Quote, error) {
 	return items, nil
 }
 
-const listChannels = `-- name: ListChannels :many
-SELECT DISTINCT channel FROM quotes WHERE channel IS NOT NULL ORDER BY channel
+const incrementDownvote = `-- name: IncrementDownvote :execrows
+UPDATE quotes SET downvotes = downvotes + 1 WHERE id = ? AND deleted_at IS NULL
 `
 
-func (q *Queries) ListChannels(ctx context.Context) ([]*string, error) {
-	rows, err := q.db.QueryContext(ctx, listChannels)
+func (q *Queries) IncrementDownvote(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, incrementDownvote, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const incrementUpvote = `-- name: IncrementUpvote :execrows
+UPDATE quotes SET upvotes = upvotes + 1 WHERE id = ? AND deleted_at IS NULL
+`
+
+func (q *Queries) IncrementUpvote(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, incrementUpvote, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const listAllQuotes = `-- name: ListAllQuotes :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes WHERE deleted_at IS NULL ORDER BY created_at DESC
+`
+
+func (q *Queries) ListAllQuotes(ctx context.Context) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, listAllQuotes)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*string{}
+	items := []Quote{}
 	for rows.Next() {
-		var channel *string
-		if err := rows.Scan(&channel); err != nil {
+		var i Quote
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Text,
+			&i.Author,
+			&i.CreatedAt,
+			&i.Civilization,
+			&i.OpponentCiv,
+			&i.Channel,
+			&i.CreatedByEmail,
+			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, channel)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -429,12 +847,85 @@ func (q *Queries) ListChannels(ctx context.Context) ([]*string, error) {
 	return items, nil
 }
 
-const listCivilizations = `-- name: ListCivilizations :many
-SELECT DISTINCT civilization FROM quotes WHERE civilization IS NOT NULL ORDER BY civilization
+const listAllQuotesIncludingDeleted = `-- name: ListAllQuotesIncludingDeleted :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?
 `
 
-func (q *Queries) ListCivilizations(ctx context.Context) ([]*string, error) {
-	rows, err := q.db.QueryContext(ctx, listCivilizations)
+type ListAllQuotesIncludingDeletedParams struct {
+	Limit  int64 `json:"limit"`
+	Offset int64 `json:"offset"`
+}
+
+func (q *Queries) ListAllQuotesIncludingDeleted(ctx context.Context, arg ListAllQuotesIncludingDeletedParams) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, listAllQuotesIncludingDeleted, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Quote{}
+	for rows.Next() {
+		var i Quote
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Text,
+			&i.Author,
+			&i.CreatedAt,
+			&i.Civilization,
+			&i.OpponentCiv,
+			&i.Channel,
+			&i.CreatedByEmail,
+			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChannels = `-- name: ListChannels :many
+SELECT DISTINCT channel FROM quotes WHERE channel IS NOT NULL AND deleted_at IS NULL ORDER BY channel
+`
+
+func (q *Queries) ListChannels(ctx context.Context) ([]*string, error) {
+	rows, err := q.db.QueryContext(ctx, listChannels)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*string{}
+	for rows.Next() {
+		var channel *string
+		if err := rows.Scan(&channel); err != nil {
+			return nil, err
+		}
+		items = append(items, channel)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCivilizations = `-- name: ListCivilizations :many
+SELECT DISTINCT civilization FROM quotes WHERE civilization IS NOT NULL AND deleted_at IS NULL ORDER BY civilization
+`
+
+func (q *Queries) ListCivilizations(ctx context.Context) ([]*string, error) {
+	rows, err := q.db.QueryContext(ctx, listCivilizations)
 	if err != nil {
 		return nil, err
 	}
@@ -456,9 +947,54 @@ func (q *Queries) ListCivilizations(ctx context.Context) ([]*string, error) {
 	return items, nil
 }
 
+const listDeletedQuotes = `-- name: ListDeletedQuotes :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id DESC
+`
+
+// The trash: soft-deleted quotes, most recently deleted first.
+func (q *Queries) ListDeletedQuotes(ctx context.Context) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, listDeletedQuotes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Quote{}
+	for rows.Next() {
+		var i Quote
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Text,
+			&i.Author,
+			&i.CreatedAt,
+			&i.Civilization,
+			&i.OpponentCiv,
+			&i.Channel,
+			&i.CreatedByEmail,
+			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listMatchupQuotes = `-- name: ListMatchupQuotes :many
-SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by FROM quotes
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
 WHERE civilization = ? AND opponent_civ = ?
+  AND deleted_at IS NULL
 ORDER BY created_at DESC
 `
 
@@ -487,6 +1023,9 @@ func (q *Queries) ListMatchupQuotes(ctx context.Context, arg ListMatchupQuotesPa
 			&i.Channel,
 			&i.CreatedByEmail,
 			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -501,14 +1040,30 @@ func (q *Queries) ListMatchupQuotes(ctx context.Context, arg ListMatchupQuotesPa
 	return items, nil
 }
 
-const listQuotesByChannel = `-- name: ListQuotesByChannel :many
-SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by FROM quotes
-WHERE channel = ? OR channel IS NULL
+const listMatchupQuotesPaginated = `-- name: ListMatchupQuotesPaginated :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE opponent_civ IS NOT NULL
+  AND (civilization = ?1 OR ?1 IS NULL)
+  AND (channel = ?2 OR ?2 IS NULL)
+  AND deleted_at IS NULL
 ORDER BY created_at DESC
+LIMIT ?4 OFFSET ?3
 `
 
-func (q *Queries) ListQuotesByChannel(ctx context.Context, channel *string) ([]Quote, error) {
-	rows, err := q.db.QueryContext(ctx, listQuotesByChannel, channel)
+type ListMatchupQuotesPaginatedParams struct {
+	Civ     *string `json:"civ"`
+	Channel *string `json:"channel"`
+	Offset  int64   `json:"offset"`
+	Limit   int64   `json:"limit"`
+}
+
+func (q *Queries) ListMatchupQuotesPaginated(ctx context.Context, arg ListMatchupQuotesPaginatedParams) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, listMatchupQuotesPaginated,
+		arg.Civ,
+		arg.Channel,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -527,6 +1082,9 @@ func (q *Queries) ListQuotesByChannel(ctx context.Context, channel *string) ([]Q
 			&i.Channel,
 			&i.CreatedByEmail,
 			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -541,14 +1099,59 @@ func (q *Queries) ListQuotesByChannel(ctx context.Context, channel *string) ([]Q
 	return items, nil
 }
 
-const listQuotesByChannelOnly = `-- name: ListQuotesByChannelOnly :many
-SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by FROM quotes
-WHERE channel = ?
+const listQuoteChannelsByIDs = `-- name: ListQuoteChannelsByIDs :many
+SELECT DISTINCT channel FROM quotes WHERE id IN (/*SLICE:ids*/?) AND deleted_at IS NULL
+`
+
+func (q *Queries) ListQuoteChannelsByIDs(ctx context.Context, ids []int64) ([]*string, error) {
+	query := listQuoteChannelsByIDs
+	var queryParams []interface{}
+	if len(ids) > 0 {
+		for _, v := range ids {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:ids*/?", strings.Repeat(",?", len(ids))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*string{}
+	for rows.Next() {
+		var channel *string
+		if err := rows.Scan(&channel); err != nil {
+			return nil, err
+		}
+		items = append(items, channel)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuotesByAuthorPaginated = `-- name: ListQuotesByAuthorPaginated :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE author LIKE '%' || ?1 || '%' ESCAPE '\'
+  AND deleted_at IS NULL
 ORDER BY created_at DESC
+LIMIT ?3 OFFSET ?2
 `
 
-func (q *Queries) ListQuotesByChannelOnly(ctx context.Context, channel *string) ([]Quote, error) {
-	rows, err := q.db.QueryContext(ctx, listQuotesByChannelOnly, channel)
+type ListQuotesByAuthorPaginatedParams struct {
+	Author *string `json:"author"`
+	Offset int64   `json:"offset"`
+	Limit  int64   `json:"limit"`
+}
+
+func (q *Queries) ListQuotesByAuthorPaginated(ctx context.Context, arg ListQuotesByAuthorPaginatedParams) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, listQuotesByAuthorPaginated, arg.Author, arg.Offset, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -567,6 +1170,9 @@ func (q *Queries) ListQuotesByChannelOnly(ctx context.Context, channel *string)
 			&i.Channel,
 			&i.CreatedByEmail,
 			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -581,21 +1187,71 @@ func (q *Queries) ListQuotesByChannelOnly(ctx context.Context, channel *string)
 	return items, nil
 }
 
-const listQuotesByChannelPaginated = `-- name: ListQuotesByChannelPaginated :many
-SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by FROM quotes
-WHERE channel = ?
+const listQuotesByChannel = `-- name: ListQuotesByChannel :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE (channel = ? OR channel IS NULL) AND deleted_at IS NULL
 ORDER BY created_at DESC
-LIMIT ? OFFSET ?
 `
 
-type ListQuotesByChannelPaginatedParams struct {
+func (q *Queries) ListQuotesByChannel(ctx context.Context, channel *string) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, listQuotesByChannel, channel)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Quote{}
+	for rows.Next() {
+		var i Quote
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Text,
+			&i.Author,
+			&i.CreatedAt,
+			&i.Civilization,
+			&i.OpponentCiv,
+			&i.Channel,
+			&i.CreatedByEmail,
+			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuotesByChannelAndAuthorPaginated = `-- name: ListQuotesByChannelAndAuthorPaginated :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE channel = ?1 AND author LIKE '%' || ?2 || '%' ESCAPE '\'
+  AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT ?4 OFFSET ?3
+`
+
+type ListQuotesByChannelAndAuthorPaginatedParams struct {
 	Channel *string `json:"channel"`
-	Limit   int64   `json:"limit"`
+	Author  *string `json:"author"`
 	Offset  int64   `json:"offset"`
+	Limit   int64   `json:"limit"`
 }
 
-func (q *Queries) ListQuotesByChannelPaginated(ctx context.Context, arg ListQuotesByChannelPaginatedParams) ([]Quote, error) {
-	rows, err := q.db.QueryContext(ctx, listQuotesByChannelPaginated, arg.Channel, arg.Limit, arg.Offset)
+func (q *Queries) ListQuotesByChannelAndAuthorPaginated(ctx context.Context, arg ListQuotesByChannelAndAuthorPaginatedParams) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, listQuotesByChannelAndAuthorPaginated,
+		arg.Channel,
+		arg.Author,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -614,6 +1270,9 @@ func (q *Queries) ListQuotesByChannelPaginated(ctx context.Context, arg ListQuot
 			&i.Channel,
 			&i.CreatedByEmail,
 			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -628,14 +1287,14 @@ func (q *Queries) ListQuotesByChannelPaginated(ctx context.Context, arg ListQuot
 	return items, nil
 }
 
-const listQuotesByUser = `-- name: ListQuotesByUser :many
-SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by FROM quotes
-WHERE user_id = ?
+const listQuotesByChannelOnly = `-- name: ListQuotesByChannelOnly :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE channel = ? AND deleted_at IS NULL
 ORDER BY created_at DESC
 `
 
-func (q *Queries) ListQuotesByUser(ctx context.Context, userID string) ([]Quote, error) {
-	rows, err := q.db.QueryContext(ctx, listQuotesByUser, userID)
+func (q *Queries) ListQuotesByChannelOnly(ctx context.Context, channel *string) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, listQuotesByChannelOnly, channel)
 	if err != nil {
 		return nil, err
 	}
@@ -654,6 +1313,9 @@ func (q *Queries) ListQuotesByUser(ctx context.Context, userID string) ([]Quote,
 			&i.Channel,
 			&i.CreatedByEmail,
 			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -668,17 +1330,532 @@ func (q *Queries) ListQuotesByUser(ctx context.Context, userID string) ([]Quote,
 	return items, nil
 }
 
-const listQuotesPaginated = `-- name: ListQuotesPaginated :many
-SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by FROM quotes ORDER BY created_at DESC LIMIT ? OFFSET ?
+const listQuotesByChannelPaginated = `-- name: ListQuotesByChannelPaginated :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE channel = ? AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?
 `
 
-type ListQuotesPaginatedParams struct {
-	Limit  int64 `json:"limit"`
-	Offset int64 `json:"offset"`
+type ListQuotesByChannelPaginatedParams struct {
+	Channel *string `json:"channel"`
+	Limit   int64   `json:"limit"`
+	Offset  int64   `json:"offset"`
 }
 
-func (q *Queries) ListQuotesPaginated(ctx context.Context, arg ListQuotesPaginatedParams) ([]Quote, error) {
-	rows, err := q.db.QueryContext(ctx, listQuotesPaginated, arg.Limit, arg.Offset)
+func (q *Queries) ListQuotesByChannelPaginated(ctx context.Context, arg ListQuotesByChannelPaginatedParams) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, listQuotesByChannelPaginated, arg.Channel, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Quote{}
+	for rows.Next() {
+		var i Quote
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Text,
+			&i.Author,
+			&i.CreatedAt,
+			&i.Civilization,
+			&i.OpponentCiv,
+			&i.Channel,
+			&i.CreatedByEmail,
+			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuotesByChannels = `-- name: ListQuotesByChannels :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE channel IN (/*SLICE:channels*/?)
+  AND deleted_at IS NULL
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListQuotesByChannels(ctx context.Context, channels []*string) ([]Quote, error) {
+	query := listQuotesByChannels
+	var queryParams []interface{}
+	if len(channels) > 0 {
+		for _, v := range channels {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:channels*/?", strings.Repeat(",?", len(channels))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:channels*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Quote{}
+	for rows.Next() {
+		var i Quote
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Text,
+			&i.Author,
+			&i.CreatedAt,
+			&i.Civilization,
+			&i.OpponentCiv,
+			&i.Channel,
+			&i.CreatedByEmail,
+			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuotesByCivPaginated = `-- name: ListQuotesByCivPaginated :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE civilization = ?1
+  AND (channel = ?2 OR ?2 IS NULL)
+  AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT ?4 OFFSET ?3
+`
+
+type ListQuotesByCivPaginatedParams struct {
+	Civ     *string `json:"civ"`
+	Channel *string `json:"channel"`
+	Offset  int64   `json:"offset"`
+	Limit   int64   `json:"limit"`
+}
+
+func (q *Queries) ListQuotesByCivPaginated(ctx context.Context, arg ListQuotesByCivPaginatedParams) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, listQuotesByCivPaginated,
+		arg.Civ,
+		arg.Channel,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Quote{}
+	for rows.Next() {
+		var i Quote
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Text,
+			&i.Author,
+			&i.CreatedAt,
+			&i.Civilization,
+			&i.OpponentCiv,
+			&i.Channel,
+			&i.CreatedByEmail,
+			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuotesByUser = `-- name: ListQuotesByUser :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE user_id = ? AND deleted_at IS NULL
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListQuotesByUser(ctx context.Context, userID string) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, listQuotesByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Quote{}
+	for rows.Next() {
+		var i Quote
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Text,
+			&i.Author,
+			&i.CreatedAt,
+			&i.Civilization,
+			&i.OpponentCiv,
+			&i.Channel,
+			&i.CreatedByEmail,
+			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuotesPaginated = `-- name: ListQuotesPaginated :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?
+`
+
+type ListQuotesPaginatedParams struct {
+	Limit  int64 `json:"limit"`
+	Offset int64 `json:"offset"`
+}
+
+func (q *Queries) ListQuotesPaginated(ctx context.Context, arg ListQuotesPaginatedParams) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, listQuotesPaginated, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Quote{}
+	for rows.Next() {
+		var i Quote
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Text,
+			&i.Author,
+			&i.CreatedAt,
+			&i.Civilization,
+			&i.OpponentCiv,
+			&i.Channel,
+			&i.CreatedByEmail,
+			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRandomQuoteCandidateIDs = `-- name: ListRandomQuoteCandidateIDs :many
+SELECT id FROM quotes
+WHERE (civilization = ?1 OR ?1 IS NULL)
+  AND (?2 IS NULL OR channel IS NULL OR channel = ?2)
+  AND (?3 IS NULL OR id IN (
+    SELECT quote_tags.quote_id FROM quote_tags
+    JOIN tags ON tags.id = quote_tags.tag_id
+    WHERE tags.name = ?3
+  ))
+  AND deleted_at IS NULL
+`
+
+type ListRandomQuoteCandidateIDsParams struct {
+	Civilization *string `json:"civilization"`
+	Channel      *string `json:"channel"`
+	Tag          *string `json:"tag"`
+}
+
+// Mirrors the filters of the GetRandomQuote* queries so /api/quote can pick
+// from the same pool while skipping recently served IDs. A NULL channel
+// or tag matches every quote, like the global variants.
+func (q *Queries) ListRandomQuoteCandidateIDs(ctx context.Context, arg ListRandomQuoteCandidateIDsParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listRandomQuoteCandidateIDs, arg.Civilization, arg.Channel, arg.Tag)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecentQuotes = `-- name: ListRecentQuotes :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE (channel = ?1 OR ?1 IS NULL)
+  AND deleted_at IS NULL
+ORDER BY created_at DESC, id DESC
+LIMIT ?2
+`
+
+type ListRecentQuotesParams struct {
+	Channel *string `json:"channel"`
+	Limit   int64   `json:"limit"`
+}
+
+// Newest quotes first, for the RSS feed. A NULL channel lists every quote.
+func (q *Queries) ListRecentQuotes(ctx context.Context, arg ListRecentQuotesParams) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, listRecentQuotes, arg.Channel, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Quote{}
+	for rows.Next() {
+		var i Quote
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Text,
+			&i.Author,
+			&i.CreatedAt,
+			&i.Civilization,
+			&i.OpponentCiv,
+			&i.Channel,
+			&i.CreatedByEmail,
+			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeQuoteByID = `-- name: PurgeQuoteByID :execrows
+DELETE FROM quotes WHERE id = ?
+`
+
+func (q *Queries) PurgeQuoteByID(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeQuoteByID, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const rebuildQuotesFTS = `-- name: RebuildQuotesFTS :exec
+INSERT INTO quotes_fts (quotes_fts) VALUES ('rebuild')
+`
+
+// Repopulates the full-text index from the quotes table.
+func (q *Queries) RebuildQuotesFTS(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, rebuildQuotesFTS)
+	return err
+}
+
+const restoreQuote = `-- name: RestoreQuote :execrows
+UPDATE quotes SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) RestoreQuote(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreQuote, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const searchQuotes = `-- name: SearchQuotes :many
+SELECT id, user_id, text, author, created_at, civilization, opponent_civ, channel, created_by_email, requested_by, upvotes, downvotes, deleted_at FROM quotes
+WHERE (text LIKE '%' || ?1 || '%' ESCAPE '\'
+       OR author LIKE '%' || ?1 || '%' ESCAPE '\')
+  AND (channel = ?2 OR ?2 IS NULL)
+  AND (civilization = ?3 OR ?3 IS NULL)
+  AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT ?4
+`
+
+type SearchQuotesParams struct {
+	Term    string  `json:"term"`
+	Channel *string `json:"channel"`
+	Civ     *string `json:"civ"`
+	Limit   int64   `json:"limit"`
+}
+
+func (q *Queries) SearchQuotes(ctx context.Context, arg SearchQuotesParams) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, searchQuotes,
+		arg.Term,
+		arg.Channel,
+		arg.Civ,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Quote{}
+	for rows.Next() {
+		var i Quote
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Text,
+			&i.Author,
+			&i.CreatedAt,
+			&i.Civilization,
+			&i.OpponentCiv,
+			&i.Channel,
+			&i.CreatedByEmail,
+			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchQuotesFTS = `-- name: SearchQuotesFTS :many
+SELECT quotes.id, quotes.user_id, quotes.text, quotes.author, quotes.created_at, quotes.civilization, quotes.opponent_civ, quotes.channel, quotes.created_by_email, quotes.requested_by, quotes.upvotes, quotes.downvotes, quotes.deleted_at FROM quotes
+JOIN quotes_fts ON quotes_fts.rowid = quotes.id
+WHERE quotes_fts MATCH ?1
+  AND (quotes.channel = ?2 OR ?2 IS NULL)
+  AND (quotes.civilization = ?3 OR ?3 IS NULL)
+  AND quotes.deleted_at IS NULL
+ORDER BY bm25(quotes_fts), quotes.id DESC
+LIMIT ?4
+`
+
+type SearchQuotesFTSParams struct {
+	Query   string  `json:"query"`
+	Channel *string `json:"channel"`
+	Civ     *string `json:"civ"`
+	Limit   int64   `json:"limit"`
+}
+
+// Searches quote text and author through the full-text index, best bm25
+// match first.
+func (q *Queries) SearchQuotesFTS(ctx context.Context, arg SearchQuotesFTSParams) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, searchQuotesFTS,
+		arg.Query,
+		arg.Channel,
+		arg.Civ,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Quote{}
+	for rows.Next() {
+		var i Quote
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Text,
+			&i.Author,
+			&i.CreatedAt,
+			&i.Civilization,
+			&i.OpponentCiv,
+			&i.Channel,
+			&i.CreatedByEmail,
+			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchQuotesPaginated = `-- name: SearchQuotesPaginated :many
+SELECT quotes.id, quotes.user_id, quotes.text, quotes.author, quotes.created_at, quotes.civilization, quotes.opponent_civ, quotes.channel, quotes.created_by_email, quotes.requested_by, quotes.upvotes, quotes.downvotes, quotes.deleted_at FROM quotes
+JOIN quotes_fts ON quotes_fts.rowid = quotes.id
+WHERE quotes_fts.text MATCH ?1
+  AND (quotes.channel = ?2 OR ?2 IS NULL)
+  AND quotes.deleted_at IS NULL
+ORDER BY bm25(quotes_fts), quotes.id DESC
+LIMIT ?4 OFFSET ?3
+`
+
+type SearchQuotesPaginatedParams struct {
+	Query   string  `json:"query"`
+	Channel *string `json:"channel"`
+	Offset  int64   `json:"offset"`
+	Limit   int64   `json:"limit"`
+}
+
+func (q *Queries) SearchQuotesPaginated(ctx context.Context, arg SearchQuotesPaginatedParams) ([]Quote, error) {
+	rows, err := q.db.QueryContext(ctx, searchQuotesPaginated,
+		arg.Query,
+		arg.Channel,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -697,6 +1874,9 @@ func (q *Queries) ListQuotesPaginated(ctx context.Context, arg ListQuotesPaginat
 			&i.Channel,
 			&i.CreatedByEmail,
 			&i.RequestedBy,
+			&i.Upvotes,
+			&i.Downvotes,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -712,7 +1892,7 @@ func (q *Queries) ListQuotesPaginated(ctx context.Context, arg ListQuotesPaginat
 }
 
 const updateQuote = `-- name: UpdateQuote :exec
-UPDATE quotes SET text = ?, author = ?, civilization = ?, opponent_civ = ?, channel = ? WHERE id = ?
+UPDATE quotes SET text = ?, author = ?, civilization = ?, opponent_civ = ?, channel = ? WHERE id = ? AND deleted_at IS NULL
 `
 
 type UpdateQuoteParams struct {
@@ -7,6 +7,7 @@ package dbgen
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
@@ -49,6 +50,19 @@ func (q *Queries) CountPendingSuggestionsByChannel(ctx context.Context, channel
 	return count, err
 }
 
+const countPendingSuggestionsForOwner = `-- name: CountPendingSuggestionsForOwner :one
+SELECT COUNT(*) as count FROM quote_suggestions
+WHERE status = 'pending'
+  AND channel IN (SELECT channel FROM channel_owners WHERE user_email = ?)
+`
+
+func (q *Queries) CountPendingSuggestionsForOwner(ctx context.Context, userEmail string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPendingSuggestionsForOwner, userEmail)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countRecentSuggestionsByChannel = `-- name: CountRecentSuggestionsByChannel :one
 SELECT COUNT(*) as count FROM quote_suggestions
 WHERE channel = ? AND submitted_at > ?
@@ -123,7 +137,7 @@ func (q *Queries) DeleteSuggestion(ctx context.Context, id int64) error {
 }
 
 const getSuggestionByID = `-- name: GetSuggestionByID :one
-SELECT id, text, author, civilization, opponent_civ, channel, submitted_by_ip, submitted_at, status, reviewed_by, reviewed_at, submitted_by_user FROM quote_suggestions WHERE id = ?
+SELECT id, text, author, civilization, opponent_civ, channel, submitted_by_ip, submitted_at, status, reviewed_by, reviewed_at, submitted_by_user, viewed_at, viewed_by, reject_reason FROM quote_suggestions WHERE id = ?
 `
 
 func (q *Queries) GetSuggestionByID(ctx context.Context, id int64) (QuoteSuggestion, error) {
@@ -142,12 +156,15 @@ func (q *Queries) GetSuggestionByID(ctx context.Context, id int64) (QuoteSuggest
 		&i.ReviewedBy,
 		&i.ReviewedAt,
 		&i.SubmittedByUser,
+		&i.ViewedAt,
+		&i.ViewedBy,
+		&i.RejectReason,
 	)
 	return i, err
 }
 
 const listPendingSuggestions = `-- name: ListPendingSuggestions :many
-SELECT id, text, author, civilization, opponent_civ, channel, submitted_by_ip, submitted_at, status, reviewed_by, reviewed_at, submitted_by_user FROM quote_suggestions
+SELECT id, text, author, civilization, opponent_civ, channel, submitted_by_ip, submitted_at, status, reviewed_by, reviewed_at, submitted_by_user, viewed_at, viewed_by, reject_reason FROM quote_suggestions
 WHERE status = 'pending'
 ORDER BY submitted_at DESC
 `
@@ -174,6 +191,9 @@ func (q *Queries) ListPendingSuggestions(ctx context.Context) ([]QuoteSuggestion
 			&i.ReviewedBy,
 			&i.ReviewedAt,
 			&i.SubmittedByUser,
+			&i.ViewedAt,
+			&i.ViewedBy,
+			&i.RejectReason,
 		); err != nil {
 			return nil, err
 		}
@@ -189,7 +209,7 @@ func (q *Queries) ListPendingSuggestions(ctx context.Context) ([]QuoteSuggestion
 }
 
 const listPendingSuggestionsByChannel = `-- name: ListPendingSuggestionsByChannel :many
-SELECT id, text, author, civilization, opponent_civ, channel, submitted_by_ip, submitted_at, status, reviewed_by, reviewed_at, submitted_by_user FROM quote_suggestions
+SELECT id, text, author, civilization, opponent_civ, channel, submitted_by_ip, submitted_at, status, reviewed_by, reviewed_at, submitted_by_user, viewed_at, viewed_by, reject_reason FROM quote_suggestions
 WHERE channel = ? AND status = 'pending'
 ORDER BY submitted_at DESC
 `
@@ -216,6 +236,99 @@ func (q *Queries) ListPendingSuggestionsByChannel(ctx context.Context, channel s
 			&i.ReviewedBy,
 			&i.ReviewedAt,
 			&i.SubmittedByUser,
+			&i.ViewedAt,
+			&i.ViewedBy,
+			&i.RejectReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnviewedPendingSuggestions = `-- name: ListUnviewedPendingSuggestions :many
+SELECT id, text, author, civilization, opponent_civ, channel, submitted_by_ip, submitted_at, status, reviewed_by, reviewed_at, submitted_by_user, viewed_at, viewed_by, reject_reason FROM quote_suggestions
+WHERE status = 'pending' AND viewed_at IS NULL
+ORDER BY submitted_at DESC
+`
+
+func (q *Queries) ListUnviewedPendingSuggestions(ctx context.Context) ([]QuoteSuggestion, error) {
+	rows, err := q.db.QueryContext(ctx, listUnviewedPendingSuggestions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []QuoteSuggestion{}
+	for rows.Next() {
+		var i QuoteSuggestion
+		if err := rows.Scan(
+			&i.ID,
+			&i.Text,
+			&i.Author,
+			&i.Civilization,
+			&i.OpponentCiv,
+			&i.Channel,
+			&i.SubmittedByIp,
+			&i.SubmittedAt,
+			&i.Status,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.SubmittedByUser,
+			&i.ViewedAt,
+			&i.ViewedBy,
+			&i.RejectReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnviewedPendingSuggestionsByChannel = `-- name: ListUnviewedPendingSuggestionsByChannel :many
+SELECT id, text, author, civilization, opponent_civ, channel, submitted_by_ip, submitted_at, status, reviewed_by, reviewed_at, submitted_by_user, viewed_at, viewed_by, reject_reason FROM quote_suggestions
+WHERE channel = ? AND status = 'pending' AND viewed_at IS NULL
+ORDER BY submitted_at DESC
+`
+
+func (q *Queries) ListUnviewedPendingSuggestionsByChannel(ctx context.Context, channel string) ([]QuoteSuggestion, error) {
+	rows, err := q.db.QueryContext(ctx, listUnviewedPendingSuggestionsByChannel, channel)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []QuoteSuggestion{}
+	for rows.Next() {
+		var i QuoteSuggestion
+		if err := rows.Scan(
+			&i.ID,
+			&i.Text,
+			&i.Author,
+			&i.Civilization,
+			&i.OpponentCiv,
+			&i.Channel,
+			&i.SubmittedByIp,
+			&i.SubmittedAt,
+			&i.Status,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.SubmittedByUser,
+			&i.ViewedAt,
+			&i.ViewedBy,
+			&i.RejectReason,
 		); err != nil {
 			return nil, err
 		}
@@ -230,6 +343,52 @@ func (q *Queries) ListPendingSuggestionsByChannel(ctx context.Context, channel s
 	return items, nil
 }
 
+const markSuggestionViewed = `-- name: MarkSuggestionViewed :exec
+UPDATE quote_suggestions
+SET viewed_by = ?1, viewed_at = ?2
+WHERE id = ?3
+`
+
+type MarkSuggestionViewedParams struct {
+	ViewerEmail *string    `json:"viewer_email"`
+	ViewedAt    *time.Time `json:"viewed_at"`
+	ID          int64      `json:"id"`
+}
+
+func (q *Queries) MarkSuggestionViewed(ctx context.Context, arg MarkSuggestionViewedParams) error {
+	_, err := q.db.ExecContext(ctx, markSuggestionViewed, arg.ViewerEmail, arg.ViewedAt, arg.ID)
+	return err
+}
+
+const purgeSuggestions = `-- name: PurgeSuggestions :execrows
+DELETE FROM quote_suggestions
+WHERE reviewed_at < ? AND status IN (/*SLICE:statuses*/?)
+`
+
+type PurgeSuggestionsParams struct {
+	ReviewedAt *time.Time `json:"reviewed_at"`
+	Statuses   []string   `json:"statuses"`
+}
+
+func (q *Queries) PurgeSuggestions(ctx context.Context, arg PurgeSuggestionsParams) (int64, error) {
+	query := purgeSuggestions
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.ReviewedAt)
+	if len(arg.Statuses) > 0 {
+		for _, v := range arg.Statuses {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:statuses*/?", strings.Repeat(",?", len(arg.Statuses))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:statuses*/?", "NULL", 1)
+	}
+	result, err := q.db.ExecContext(ctx, query, queryParams...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const rejectSuggestion = `-- name: RejectSuggestion :exec
 UPDATE quote_suggestions
 SET status = 'rejected', reviewed_by = ?, reviewed_at = ?
@@ -246,3 +405,26 @@ func (q *Queries) RejectSuggestion(ctx context.Context, arg RejectSuggestionPara
 	_, err := q.db.ExecContext(ctx, rejectSuggestion, arg.ReviewedBy, arg.ReviewedAt, arg.ID)
 	return err
 }
+
+const rejectSuggestionWithReason = `-- name: RejectSuggestionWithReason :exec
+UPDATE quote_suggestions
+SET status = 'rejected', reviewed_by = ?, reviewed_at = ?, reject_reason = ?
+WHERE id = ?
+`
+
+type RejectSuggestionWithReasonParams struct {
+	ReviewedBy   *string    `json:"reviewed_by"`
+	ReviewedAt   *time.Time `json:"reviewed_at"`
+	RejectReason *string    `json:"reject_reason"`
+	ID           int64      `json:"id"`
+}
+
+func (q *Queries) RejectSuggestionWithReason(ctx context.Context, arg RejectSuggestionWithReasonParams) error {
+	_, err := q.db.ExecContext(ctx, rejectSuggestionWithReason,
+		arg.ReviewedBy,
+		arg.ReviewedAt,
+		arg.RejectReason,
+		arg.ID,
+	)
+	return err
+}
@@ -0,0 +1,7 @@
+-- Let reviewers acknowledge a suggestion without approving or rejecting it
+ALTER TABLE quote_suggestions ADD COLUMN viewed_at DATETIME;
+ALTER TABLE quote_suggestions ADD COLUMN viewed_by TEXT;
+
+-- Record execution of this migration
+INSERT OR IGNORE INTO migrations (migration_number, migration_name)
+VALUES (23, '023-suggestion-viewed');
@@ -0,0 +1,6 @@
+-- Optional reason recorded when suggestions are rejected in bulk
+ALTER TABLE quote_suggestions ADD COLUMN reject_reason TEXT;
+
+-- Record execution of this migration
+INSERT OR IGNORE INTO migrations (migration_number, migration_name)
+VALUES (25, '025-suggestion-reject-reason');
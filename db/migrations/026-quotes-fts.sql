@@ -0,0 +1,28 @@
+-- Full-text index over quote text for public search.
+-- External content table: the index reads rows from quotes, and the
+-- triggers below keep it in sync.
+CREATE VIRTUAL TABLE IF NOT EXISTS quotes_fts USING fts5(
+    text,
+    content='quotes',
+    content_rowid='id'
+);
+
+CREATE TRIGGER IF NOT EXISTS quotes_fts_insert AFTER INSERT ON quotes BEGIN
+    INSERT INTO quotes_fts (rowid, text) VALUES (new.id, new.text);
+END;
+
+CREATE TRIGGER IF NOT EXISTS quotes_fts_delete AFTER DELETE ON quotes BEGIN
+    INSERT INTO quotes_fts (quotes_fts, rowid, text) VALUES ('delete', old.id, old.text);
+END;
+
+CREATE TRIGGER IF NOT EXISTS quotes_fts_update AFTER UPDATE OF text ON quotes BEGIN
+    INSERT INTO quotes_fts (quotes_fts, rowid, text) VALUES ('delete', old.id, old.text);
+    INSERT INTO quotes_fts (rowid, text) VALUES (new.id, new.text);
+END;
+
+-- Index quotes that existed before this migration
+INSERT INTO quotes_fts (quotes_fts) VALUES ('rebuild');
+
+-- Record execution of this migration
+INSERT OR IGNORE INTO migrations (migration_number, migration_name)
+VALUES (26, '026-quotes-fts');
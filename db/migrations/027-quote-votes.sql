@@ -0,0 +1,7 @@
+-- Viewer up/down votes for quote popularity
+ALTER TABLE quotes ADD COLUMN upvotes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE quotes ADD COLUMN downvotes INTEGER NOT NULL DEFAULT 0;
+
+-- Record execution of this migration
+INSERT OR IGNORE INTO migrations (migration_number, migration_name)
+VALUES (27, '027-quote-votes');
@@ -0,0 +1,33 @@
+-- Add quote authors to the full-text index so API search can rank matches
+-- in text and author together. FTS5 tables can't be altered, so the index
+-- and its triggers are recreated and rebuilt from quotes.
+DROP TRIGGER IF EXISTS quotes_fts_insert;
+DROP TRIGGER IF EXISTS quotes_fts_delete;
+DROP TRIGGER IF EXISTS quotes_fts_update;
+DROP TABLE IF EXISTS quotes_fts;
+
+CREATE VIRTUAL TABLE quotes_fts USING fts5(
+    text,
+    author,
+    content='quotes',
+    content_rowid='id'
+);
+
+CREATE TRIGGER quotes_fts_insert AFTER INSERT ON quotes BEGIN
+    INSERT INTO quotes_fts (rowid, text, author) VALUES (new.id, new.text, new.author);
+END;
+
+CREATE TRIGGER quotes_fts_delete AFTER DELETE ON quotes BEGIN
+    INSERT INTO quotes_fts (quotes_fts, rowid, text, author) VALUES ('delete', old.id, old.text, old.author);
+END;
+
+CREATE TRIGGER quotes_fts_update AFTER UPDATE OF text, author ON quotes BEGIN
+    INSERT INTO quotes_fts (quotes_fts, rowid, text, author) VALUES ('delete', old.id, old.text, old.author);
+    INSERT INTO quotes_fts (rowid, text, author) VALUES (new.id, new.text, new.author);
+END;
+
+INSERT INTO quotes_fts (quotes_fts) VALUES ('rebuild');
+
+-- Record execution of this migration
+INSERT OR IGNORE INTO migrations (migration_number, migration_name)
+VALUES (28, '028-quotes-fts-author');
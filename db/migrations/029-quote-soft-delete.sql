@@ -0,0 +1,10 @@
+-- Soft delete for quotes: deleting moves a quote to the trash, where an
+-- admin can restore it or purge it for good
+
+ALTER TABLE quotes ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX idx_quotes_deleted ON quotes(deleted_at);
+
+-- Record execution of this migration
+INSERT OR IGNORE INTO migrations (migration_number, migration_name)
+VALUES (29, '029-quote-soft-delete');
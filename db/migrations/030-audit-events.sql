@@ -0,0 +1,17 @@
+-- In-app audit trail of security events (permission denials, rate limits,
+-- impersonation and so on), for admins without Honeycomb access
+CREATE TABLE IF NOT EXISTS audit_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event TEXT NOT NULL,  -- security event name without the "security." prefix
+    user_email TEXT,
+    path TEXT,
+    reason TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events(created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_event ON audit_events(event, created_at);
+
+-- Record execution of this migration
+INSERT OR IGNORE INTO migrations (migration_number, migration_name)
+VALUES (30, '030-audit-events');
@@ -0,0 +1,20 @@
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (event, user_email, path, reason, created_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: ListAuditEvents :many
+-- Newest events first. A NULL event lists every event type.
+SELECT * FROM audit_events
+WHERE (event = sqlc.narg(event) OR sqlc.narg(event) IS NULL)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CountAuditEvents :one
+SELECT COUNT(*) as count FROM audit_events
+WHERE (event = sqlc.narg(event) OR sqlc.narg(event) IS NULL);
+
+-- name: ListAuditEventTypes :many
+SELECT DISTINCT event FROM audit_events ORDER BY event;
+
+-- name: PurgeAuditEvents :execrows
+DELETE FROM audit_events WHERE created_at < ?;
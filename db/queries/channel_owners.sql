@@ -16,5 +16,20 @@ DELETE FROM channel_owners WHERE channel = ? AND user_email = ?;
 -- name: ListAllChannelOwners :many
 SELECT * FROM channel_owners ORDER BY channel, user_email;
 
+-- name: ListChannelOwnersByChannel :many
+SELECT * FROM channel_owners WHERE channel = ? ORDER BY user_email;
+
 -- name: CountChannelOwners :one
 SELECT COUNT(*) as count FROM channel_owners;
+
+-- name: SearchChannelOwners :many
+SELECT * FROM channel_owners
+WHERE (sqlc.narg(channel) IS NULL OR channel LIKE '%' || sqlc.narg(channel) || '%' ESCAPE '\')
+  AND (sqlc.narg(email) IS NULL OR user_email LIKE '%' || sqlc.narg(email) || '%' ESCAPE '\')
+ORDER BY channel, user_email
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CountSearchChannelOwners :one
+SELECT COUNT(*) as count FROM channel_owners
+WHERE (sqlc.narg(channel) IS NULL OR channel LIKE '%' || sqlc.narg(channel) || '%' ESCAPE '\')
+  AND (sqlc.narg(email) IS NULL OR user_email LIKE '%' || sqlc.narg(email) || '%' ESCAPE '\');
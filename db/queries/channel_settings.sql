@@ -0,0 +1,10 @@
+-- name: GetChannelSettings :one
+SELECT * FROM channel_settings WHERE channel = ?;
+
+-- name: UpsertChannelSettings :exec
+INSERT INTO channel_settings (channel, require_mod_for_suggestions, updated_at, updated_by)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(channel) DO UPDATE SET
+    require_mod_for_suggestions = excluded.require_mod_for_suggestions,
+    updated_at = excluded.updated_at,
+    updated_by = excluded.updated_by;
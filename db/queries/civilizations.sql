@@ -6,7 +6,7 @@ SELECT
     c.*,
     COUNT(q.id) as quote_count
 FROM civilizations c
-LEFT JOIN quotes q ON q.civilization = c.name
+LEFT JOIN quotes q ON q.civilization = c.name AND q.deleted_at IS NULL
 GROUP BY c.id
 ORDER BY c.name;
 
@@ -22,8 +22,11 @@ SELECT * FROM civilizations WHERE shortname = ?;
 -- name: ResolveCivName :one
 SELECT name FROM civilizations WHERE shortname = ? OR LOWER(name) = LOWER(?);
 
+-- name: CountCivs :one
+SELECT COUNT(*) as count FROM civilizations;
+
 -- name: CountQuotesByCiv :one
-SELECT COUNT(*) as count FROM quotes WHERE civilization = ?;
+SELECT COUNT(*) as count FROM quotes WHERE civilization = ? AND deleted_at IS NULL;
 
 -- name: CreateCiv :exec
 INSERT INTO civilizations (name, variant_of, dlc, shortname) VALUES (?, ?, ?, ?);
@@ -33,3 +36,19 @@ UPDATE civilizations SET name = ?, variant_of = ?, dlc = ?, shortname = ? WHERE
 
 -- name: DeleteCiv :exec
 DELETE FROM civilizations WHERE id = ?;
+
+-- name: GetRandomCiv :one
+-- exclude is a comma-separated, lowercased list of names/shortnames to skip.
+SELECT * FROM civilizations
+WHERE ',' || sqlc.arg(exclude) || ',' NOT LIKE '%,' || LOWER(name) || ',%'
+  AND (shortname IS NULL OR ',' || sqlc.arg(exclude) || ',' NOT LIKE '%,' || LOWER(shortname) || ',%')
+ORDER BY RANDOM()
+LIMIT 1;
+
+-- name: GetRandomCivWithQuotes :one
+SELECT * FROM civilizations
+WHERE EXISTS (SELECT 1 FROM quotes WHERE quotes.civilization = civilizations.name AND quotes.deleted_at IS NULL)
+  AND ',' || sqlc.arg(exclude) || ',' NOT LIKE '%,' || LOWER(name) || ',%'
+  AND (shortname IS NULL OR ',' || sqlc.arg(exclude) || ',' NOT LIKE '%,' || LOWER(shortname) || ',%')
+ORDER BY RANDOM()
+LIMIT 1;
@@ -1,109 +1,332 @@
--- name: CreateQuote :exec
+-- name: CreateQuote :one
 INSERT INTO quotes (user_id, created_by_email, text, author, civilization, opponent_civ, channel, requested_by, created_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id;
 
 -- name: ListQuotesByUser :many
 SELECT * FROM quotes
-WHERE user_id = ?
+WHERE user_id = ? AND deleted_at IS NULL
 ORDER BY created_at DESC;
 
 -- name: GetRandomQuote :one
 SELECT * FROM quotes
-WHERE channel IS NULL OR channel = ?
+WHERE (channel IS NULL OR channel = ?) AND deleted_at IS NULL
 ORDER BY RANDOM()
 LIMIT 1;
 
 -- name: GetRandomQuoteGlobal :one
 SELECT * FROM quotes
+WHERE deleted_at IS NULL
 ORDER BY RANDOM()
 LIMIT 1;
 
 -- name: GetRandomQuoteByCiv :one
 SELECT * FROM quotes
-WHERE civilization = ? AND (channel IS NULL OR channel = ?)
+WHERE civilization = ? AND (channel IS NULL OR channel = ?) AND deleted_at IS NULL
 ORDER BY RANDOM()
 LIMIT 1;
 
 -- name: GetRandomQuoteByCivGlobal :one
 SELECT * FROM quotes
-WHERE civilization = ?
+WHERE civilization = ? AND deleted_at IS NULL
+ORDER BY RANDOM()
+LIMIT 1;
+
+-- name: GetRandomQuoteByTag :one
+-- Random quote with the tag, also filtered by civilization and channel when
+-- given. A NULL channel matches every quote, like the global variants.
+SELECT quotes.* FROM quotes
+JOIN quote_tags ON quote_tags.quote_id = quotes.id
+JOIN tags ON tags.id = quote_tags.tag_id
+WHERE tags.name = sqlc.arg(tag)
+  AND (quotes.civilization = sqlc.narg(civilization) OR sqlc.narg(civilization) IS NULL)
+  AND (sqlc.narg(channel) IS NULL OR quotes.channel IS NULL OR quotes.channel = sqlc.narg(channel))
+  AND quotes.deleted_at IS NULL
 ORDER BY RANDOM()
 LIMIT 1;
 
 -- name: DeleteQuote :exec
-DELETE FROM quotes WHERE id = ? AND user_id = ?;
+UPDATE quotes SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND user_id = ? AND deleted_at IS NULL;
 
 -- name: DeleteQuoteByID :exec
-DELETE FROM quotes WHERE id = ?;
+-- Moves the quote to the trash; PurgeQuoteByID deletes it for good.
+UPDATE quotes SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL;
 
 -- name: GetQuoteByID :one
+SELECT * FROM quotes WHERE id = ? AND deleted_at IS NULL;
+
+-- name: GetQuoteByIDIncludeDeleted :one
+-- Also finds quotes in the trash, so callers can tell deleted from missing.
 SELECT * FROM quotes WHERE id = ?;
 
 -- name: UpdateQuote :exec
-UPDATE quotes SET text = ?, author = ?, civilization = ?, opponent_civ = ?, channel = ? WHERE id = ?;
+UPDATE quotes SET text = ?, author = ?, civilization = ?, opponent_civ = ?, channel = ? WHERE id = ? AND deleted_at IS NULL;
 
 -- name: CountQuotes :one
-SELECT COUNT(*) as count FROM quotes;
+SELECT COUNT(*) as count FROM quotes WHERE deleted_at IS NULL;
 
 -- name: ListAllQuotes :many
-SELECT * FROM quotes ORDER BY created_at DESC;
+SELECT * FROM quotes WHERE deleted_at IS NULL ORDER BY created_at DESC;
 
 -- name: ListQuotesPaginated :many
-SELECT * FROM quotes ORDER BY created_at DESC LIMIT ? OFFSET ?;
+SELECT * FROM quotes WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?;
+
+-- name: CountQuotesIncludingDeleted :one
+SELECT COUNT(*) as count FROM quotes;
+
+-- name: ListAllQuotesIncludingDeleted :many
+-- Like ListQuotesPaginated but also lists quotes in the trash, for admins.
+SELECT * FROM quotes ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?;
 
 -- name: GetRandomMatchupQuote :one
 SELECT * FROM quotes
-WHERE civilization = ? AND opponent_civ = ? AND (channel IS NULL OR channel = ?)
+WHERE civilization = ? AND opponent_civ = ? AND (channel IS NULL OR channel = ?) AND deleted_at IS NULL
 ORDER BY RANDOM()
 LIMIT 1;
 
 -- name: GetRandomMatchupQuoteGlobal :one
 SELECT * FROM quotes
-WHERE civilization = ? AND opponent_civ = ?
+WHERE civilization = ? AND opponent_civ = ? AND deleted_at IS NULL
 ORDER BY RANDOM()
 LIMIT 1;
 
+-- name: GetRandomMatchupQuoteBidirectional :one
+-- Also matches tips stored the other way round; those written from the
+-- requested civ's side come first.
+SELECT * FROM quotes
+WHERE ((civilization = sqlc.arg(civ) AND opponent_civ = sqlc.arg(vs))
+    OR (civilization = sqlc.arg(vs) AND opponent_civ = sqlc.arg(civ)))
+  AND (channel IS NULL OR channel = sqlc.arg(channel))
+  AND deleted_at IS NULL
+ORDER BY civilization = sqlc.arg(civ) DESC, RANDOM()
+LIMIT 1;
+
+-- name: GetRandomMatchupQuoteBidirectionalGlobal :one
+SELECT * FROM quotes
+WHERE ((civilization = sqlc.arg(civ) AND opponent_civ = sqlc.arg(vs))
+    OR (civilization = sqlc.arg(vs) AND opponent_civ = sqlc.arg(civ)))
+  AND deleted_at IS NULL
+ORDER BY civilization = sqlc.arg(civ) DESC, RANDOM()
+LIMIT 1;
+
 -- name: ListMatchupQuotes :many
 SELECT * FROM quotes
 WHERE civilization = ? AND opponent_civ = ?
+  AND deleted_at IS NULL
 ORDER BY created_at DESC;
 
 -- name: ListCivilizations :many
-SELECT DISTINCT civilization FROM quotes WHERE civilization IS NOT NULL ORDER BY civilization;
+SELECT DISTINCT civilization FROM quotes WHERE civilization IS NOT NULL AND deleted_at IS NULL ORDER BY civilization;
 
 -- name: DeleteQuoteByText :exec
-DELETE FROM quotes WHERE text = ?;
+UPDATE quotes SET deleted_at = CURRENT_TIMESTAMP WHERE text = ? AND deleted_at IS NULL;
 
 -- name: ListQuotesByChannel :many
 SELECT * FROM quotes
-WHERE channel = ? OR channel IS NULL
+WHERE (channel = ? OR channel IS NULL) AND deleted_at IS NULL
 ORDER BY created_at DESC;
 
 -- name: ListChannels :many
-SELECT DISTINCT channel FROM quotes WHERE channel IS NOT NULL ORDER BY channel;
+SELECT DISTINCT channel FROM quotes WHERE channel IS NOT NULL AND deleted_at IS NULL ORDER BY channel;
 
 -- name: BulkUpdateChannel :exec
-UPDATE quotes SET channel = ? WHERE id IN (sqlc.slice('ids'));
+UPDATE quotes SET channel = ? WHERE id IN (sqlc.slice('ids')) AND deleted_at IS NULL;
 
 -- name: BulkUpdateCivilization :exec
-UPDATE quotes SET civilization = ? WHERE id IN (sqlc.slice('ids'));
+UPDATE quotes SET civilization = ? WHERE id IN (sqlc.slice('ids')) AND deleted_at IS NULL;
+
+-- name: BulkUpdateAuthor :exec
+UPDATE quotes SET author = ? WHERE id IN (sqlc.slice('ids')) AND deleted_at IS NULL;
+
+-- name: ClearQuoteCivilization :exec
+UPDATE quotes SET civilization = NULL WHERE civilization = ?;
+
+-- name: ClearQuoteOpponentCiv :exec
+UPDATE quotes SET opponent_civ = NULL WHERE opponent_civ = ?;
 
 -- name: BulkDeleteQuotes :exec
-DELETE FROM quotes WHERE id IN (sqlc.slice('ids'));
+UPDATE quotes SET deleted_at = CURRENT_TIMESTAMP WHERE id IN (sqlc.slice('ids')) AND deleted_at IS NULL;
 
 -- name: GetLastUpdated :one
-SELECT created_at FROM quotes ORDER BY created_at DESC LIMIT 1;
+SELECT created_at FROM quotes WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT 1;
 
 -- name: ListQuotesByChannelOnly :many
 SELECT * FROM quotes
-WHERE channel = ?
+WHERE channel = ? AND deleted_at IS NULL
 ORDER BY created_at DESC;
 
 -- name: ListQuotesByChannelPaginated :many
 SELECT * FROM quotes
-WHERE channel = ?
+WHERE channel = ? AND deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT ? OFFSET ?;
 
 -- name: CountQuotesByChannel :one
-SELECT COUNT(*) as count FROM quotes WHERE channel = ?;
+SELECT COUNT(*) as count FROM quotes WHERE channel = ? AND deleted_at IS NULL;
+
+-- name: ListQuotesByAuthorPaginated :many
+SELECT * FROM quotes
+WHERE author LIKE '%' || sqlc.arg(author) || '%' ESCAPE '\'
+  AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CountQuotesByAuthor :one
+SELECT COUNT(*) as count FROM quotes
+WHERE author LIKE '%' || sqlc.arg(author) || '%' ESCAPE '\'
+  AND deleted_at IS NULL;
+
+-- name: ListQuotesByChannelAndAuthorPaginated :many
+SELECT * FROM quotes
+WHERE channel = sqlc.arg(channel) AND author LIKE '%' || sqlc.arg(author) || '%' ESCAPE '\'
+  AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CountQuotesByChannelAndAuthor :one
+SELECT COUNT(*) as count FROM quotes
+WHERE channel = sqlc.arg(channel) AND author LIKE '%' || sqlc.arg(author) || '%' ESCAPE '\'
+  AND deleted_at IS NULL;
+
+-- name: ListQuotesByCivPaginated :many
+SELECT * FROM quotes
+WHERE civilization = sqlc.arg(civ)
+  AND (channel = sqlc.narg(channel) OR sqlc.narg(channel) IS NULL)
+  AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CountQuotesByCivAndChannel :one
+SELECT COUNT(*) as count FROM quotes
+WHERE civilization = sqlc.arg(civ)
+  AND (channel = sqlc.narg(channel) OR sqlc.narg(channel) IS NULL)
+  AND deleted_at IS NULL;
+
+-- name: ListMatchupQuotesPaginated :many
+SELECT * FROM quotes
+WHERE opponent_civ IS NOT NULL
+  AND (civilization = sqlc.narg(civ) OR sqlc.narg(civ) IS NULL)
+  AND (channel = sqlc.narg(channel) OR sqlc.narg(channel) IS NULL)
+  AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CountMatchupQuotes :one
+SELECT COUNT(*) as count FROM quotes
+WHERE opponent_civ IS NOT NULL
+  AND (civilization = sqlc.narg(civ) OR sqlc.narg(civ) IS NULL)
+  AND (channel = sqlc.narg(channel) OR sqlc.narg(channel) IS NULL)
+  AND deleted_at IS NULL;
+
+-- name: ListQuotesByChannels :many
+SELECT * FROM quotes
+WHERE channel IN (sqlc.slice('channels'))
+  AND deleted_at IS NULL
+ORDER BY created_at DESC;
+
+-- name: ListQuoteChannelsByIDs :many
+SELECT DISTINCT channel FROM quotes WHERE id IN (sqlc.slice('ids')) AND deleted_at IS NULL;
+
+-- name: GetNextQuoteID :one
+SELECT id FROM quotes WHERE id > ? AND deleted_at IS NULL ORDER BY id ASC LIMIT 1;
+
+-- name: GetPrevQuoteID :one
+SELECT id FROM quotes WHERE id < ? AND deleted_at IS NULL ORDER BY id DESC LIMIT 1;
+
+-- name: SearchQuotesPaginated :many
+SELECT quotes.* FROM quotes
+JOIN quotes_fts ON quotes_fts.rowid = quotes.id
+WHERE quotes_fts.text MATCH sqlc.arg(query)
+  AND (quotes.channel = sqlc.narg(channel) OR sqlc.narg(channel) IS NULL)
+  AND quotes.deleted_at IS NULL
+ORDER BY bm25(quotes_fts), quotes.id DESC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CountSearchQuotes :one
+SELECT COUNT(*) FROM quotes
+JOIN quotes_fts ON quotes_fts.rowid = quotes.id
+WHERE quotes_fts.text MATCH sqlc.arg(query)
+  AND (quotes.channel = sqlc.narg(channel) OR sqlc.narg(channel) IS NULL)
+  AND quotes.deleted_at IS NULL;
+
+-- name: SearchQuotes :many
+SELECT * FROM quotes
+WHERE (text LIKE '%' || sqlc.arg(term) || '%' ESCAPE '\'
+       OR author LIKE '%' || sqlc.arg(term) || '%' ESCAPE '\')
+  AND (channel = sqlc.narg(channel) OR sqlc.narg(channel) IS NULL)
+  AND (civilization = sqlc.narg(civ) OR sqlc.narg(civ) IS NULL)
+  AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit);
+
+-- name: SearchQuotesFTS :many
+-- Searches quote text and author through the full-text index, best bm25
+-- match first.
+SELECT quotes.* FROM quotes
+JOIN quotes_fts ON quotes_fts.rowid = quotes.id
+WHERE quotes_fts MATCH sqlc.arg(query)
+  AND (quotes.channel = sqlc.narg(channel) OR sqlc.narg(channel) IS NULL)
+  AND (quotes.civilization = sqlc.narg(civ) OR sqlc.narg(civ) IS NULL)
+  AND quotes.deleted_at IS NULL
+ORDER BY bm25(quotes_fts), quotes.id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: RebuildQuotesFTS :exec
+-- Repopulates the full-text index from the quotes table.
+INSERT INTO quotes_fts (quotes_fts) VALUES ('rebuild');
+
+-- name: IncrementUpvote :execrows
+UPDATE quotes SET upvotes = upvotes + 1 WHERE id = ? AND deleted_at IS NULL;
+
+-- name: IncrementDownvote :execrows
+UPDATE quotes SET downvotes = downvotes + 1 WHERE id = ? AND deleted_at IS NULL;
+
+-- name: GetTopQuotes :many
+SELECT * FROM quotes
+WHERE (channel = sqlc.narg(channel) OR sqlc.narg(channel) IS NULL)
+  AND deleted_at IS NULL
+ORDER BY upvotes - downvotes DESC, upvotes DESC, id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: ListRandomQuoteCandidateIDs :many
+-- Mirrors the filters of the GetRandomQuote* queries so /api/quote can pick
+-- from the same pool while skipping recently served IDs. A NULL channel
+-- or tag matches every quote, like the global variants.
+SELECT id FROM quotes
+WHERE (civilization = sqlc.narg(civilization) OR sqlc.narg(civilization) IS NULL)
+  AND (sqlc.narg(channel) IS NULL OR channel IS NULL OR channel = sqlc.narg(channel))
+  AND (sqlc.narg(tag) IS NULL OR id IN (
+    SELECT quote_tags.quote_id FROM quote_tags
+    JOIN tags ON tags.id = quote_tags.tag_id
+    WHERE tags.name = sqlc.narg(tag)
+  ))
+  AND deleted_at IS NULL;
+
+-- name: FindSimilarQuotes :many
+-- Quotes whose text matches, ignoring surrounding whitespace and case, in the
+-- same channel. A NULL channel matches only global quotes. Oldest first.
+SELECT id FROM quotes
+WHERE LOWER(TRIM(text)) = LOWER(TRIM(sqlc.arg(text)))
+  AND LOWER(channel) IS LOWER(sqlc.narg(channel))
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: ListRecentQuotes :many
+-- Newest quotes first, for the RSS feed. A NULL channel lists every quote.
+SELECT * FROM quotes
+WHERE (channel = sqlc.narg(channel) OR sqlc.narg(channel) IS NULL)
+  AND deleted_at IS NULL
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: ListDeletedQuotes :many
+-- The trash: soft-deleted quotes, most recently deleted first.
+SELECT * FROM quotes
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id DESC;
+
+-- name: RestoreQuote :execrows
+UPDATE quotes SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL;
+
+-- name: PurgeQuoteByID :execrows
+DELETE FROM quotes WHERE id = ?;
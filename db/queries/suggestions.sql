@@ -12,6 +12,16 @@ SELECT * FROM quote_suggestions
 WHERE channel = ? AND status = 'pending'
 ORDER BY submitted_at DESC;
 
+-- name: ListUnviewedPendingSuggestions :many
+SELECT * FROM quote_suggestions
+WHERE status = 'pending' AND viewed_at IS NULL
+ORDER BY submitted_at DESC;
+
+-- name: ListUnviewedPendingSuggestionsByChannel :many
+SELECT * FROM quote_suggestions
+WHERE channel = ? AND status = 'pending' AND viewed_at IS NULL
+ORDER BY submitted_at DESC;
+
 -- name: GetSuggestionByID :one
 SELECT * FROM quote_suggestions WHERE id = ?;
 
@@ -25,12 +35,27 @@ UPDATE quote_suggestions
 SET status = 'rejected', reviewed_by = ?, reviewed_at = ?
 WHERE id = ?;
 
+-- name: RejectSuggestionWithReason :exec
+UPDATE quote_suggestions
+SET status = 'rejected', reviewed_by = ?, reviewed_at = ?, reject_reason = ?
+WHERE id = ?;
+
+-- name: MarkSuggestionViewed :exec
+UPDATE quote_suggestions
+SET viewed_by = sqlc.arg(viewer_email), viewed_at = sqlc.arg(viewed_at)
+WHERE id = sqlc.arg(id);
+
 -- name: CountPendingSuggestions :one
 SELECT COUNT(*) as count FROM quote_suggestions WHERE status = 'pending';
 
 -- name: CountPendingSuggestionsByChannel :one
 SELECT COUNT(*) as count FROM quote_suggestions WHERE channel = ? AND status = 'pending';
 
+-- name: CountPendingSuggestionsForOwner :one
+SELECT COUNT(*) as count FROM quote_suggestions
+WHERE status = 'pending'
+  AND channel IN (SELECT channel FROM channel_owners WHERE user_email = ?);
+
 -- name: CountRecentSuggestionsByIP :one
 SELECT COUNT(*) as count FROM quote_suggestions
 WHERE submitted_by_ip = ? AND submitted_at > ?;
@@ -41,3 +66,7 @@ WHERE channel = ? AND submitted_at > ?;
 
 -- name: DeleteSuggestion :exec
 DELETE FROM quote_suggestions WHERE id = ?;
+
+-- name: PurgeSuggestions :execrows
+DELETE FROM quote_suggestions
+WHERE reviewed_at < ? AND status IN (sqlc.slice('statuses'));
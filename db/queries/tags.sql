@@ -0,0 +1,24 @@
+-- name: CreateTag :exec
+INSERT OR IGNORE INTO tags (name) VALUES (?);
+
+-- name: AddQuoteTag :exec
+-- Tags a quote with an existing tag. Adding a tag twice is a no-op.
+INSERT OR IGNORE INTO quote_tags (quote_id, tag_id)
+VALUES (?, (SELECT id FROM tags WHERE name = ?));
+
+-- name: RemoveQuoteTag :exec
+DELETE FROM quote_tags
+WHERE quote_id = ? AND tag_id = (SELECT id FROM tags WHERE name = ?);
+
+-- name: ListQuoteTags :many
+SELECT tags.name FROM tags
+JOIN quote_tags ON quote_tags.tag_id = tags.id
+WHERE quote_tags.quote_id = ?
+ORDER BY tags.name;
+
+-- name: ListQuotesByTag :many
+SELECT quotes.* FROM quotes
+JOIN quote_tags ON quote_tags.quote_id = quotes.id
+JOIN tags ON tags.id = quote_tags.tag_id
+WHERE tags.name = ? AND quotes.deleted_at IS NULL
+ORDER BY quotes.created_at DESC;
@@ -23,40 +23,266 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/matchup": {
+        "/admin/owners": {
             "get": {
-                "description": "Returns a random tip for a specific civilization matchup (your civ vs opponent civ).\nSupports two query formats: standard (?civ=X\u0026vs=Y) or Nightbot querystring (?X Y).",
+                "description": "Returns channel owners ordered by channel and email. Requires admin authentication.",
                 "produces": [
-                    "text/plain",
                     "application/json"
                 ],
                 "tags": [
-                    "matchups"
+                    "admin"
                 ],
-                "summary": "Get a matchup tip",
+                "summary": "List channel owners",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Your civilization shortname (e.g., hre)",
-                        "name": "civ",
+                        "description": "Only owners of this channel",
+                        "name": "channel",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Channel owners",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/srv.ChannelOwnerResponse"
+                            }
+                        }
                     },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Grants a user ownership of a channel. Requires admin authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Add a channel owner",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "Opponent civilization shortname (e.g., french)",
-                        "name": "vs",
+                        "description": "Channel and owner email",
+                        "name": "owner",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/srv.ChannelOwnerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Owner added",
+                        "schema": {
+                            "$ref": "#/definitions/srv.ChannelOwnerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON or missing channel/email",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "User already owns this channel",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Revokes a user's ownership of a channel. Requires admin authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Remove a channel owner",
+                "parameters": [
+                    {
+                        "description": "Channel and owner email",
+                        "name": "owner",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/srv.ChannelOwnerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Owner removed"
+                    },
+                    "400": {
+                        "description": "Invalid JSON or missing channel/email",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "User does not own this channel",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/changelog": {
+            "get": {
+                "description": "Returns user-facing changes, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "meta"
+                ],
+                "summary": "Get the changelog",
+                "responses": {
+                    "200": {
+                        "description": "Changelog entries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/srv.ChangelogEntry"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/civs": {
+            "get": {
+                "description": "Returns every civilization with its number of quotes, sorted by name, e.g. to fill an overlay's dropdown.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "civilizations"
+                ],
+                "summary": "List civilizations",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only list civilizations that have at least one quote",
+                        "name": "withQuotes",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Matchup tip text (plain text default)",
+                        "description": "Civilizations",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/srv.CivSummaryResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "string"
                         }
+                    }
+                }
+            }
+        },
+        "/civs/random": {
+            "get": {
+                "description": "Returns a random civilization, e.g. for a !randomciv bot command.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "civilizations"
+                ],
+                "summary": "Get a random civilization",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only pick civilizations that have at least one quote",
+                        "name": "has_quotes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated civilization names or shortnames to skip",
+                        "name": "exclude",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Random civilization, or {\"error\": \"no civilizations\"} when none match",
+                        "schema": {
+                            "$ref": "#/definitions/srv.CivResponse"
+                        }
                     },
-                    "400": {
-                        "description": "Usage: /api/matchup?civ=X\u0026vs=Y",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "string"
                         }
@@ -64,82 +290,820 @@ const docTemplate = `{
                 }
             }
         },
-        "/quote": {
+        "/civs/{civ}/quotes": {
             "get": {
-                "description": "Returns a random quote from the database. Supports filtering by civilization and channel.",
+                "description": "Returns a page of quotes for a civilization, newest first. The civ may be a full name or a shortname. The total number of matching quotes is sent in the X-Total-Count header.",
                 "produces": [
-                    "text/plain",
                     "application/json"
                 ],
                 "tags": [
-                    "quotes"
+                    "civilizations"
                 ],
-                "summary": "Get a random quote",
+                "summary": "List quotes for a civilization",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Civilization shortname (e.g., hre, french, mongols)",
+                        "description": "Civilization name or shortname (e.g. hre)",
                         "name": "civ",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Quotes per page (max 100)",
+                        "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Channel name for channel-specific quotes",
+                        "description": "Only include quotes from this channel",
                         "name": "channel",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Quote text (plain text default)",
+                        "description": "Quotes for the civilization",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/srv.QuoteResponse"
+                            }
                         },
                         "headers": {
-                            "Content-Type": {
-                                "type": "string",
-                                "description": "text/plain or application/json based on Accept header"
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total number of matching quotes"
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Unknown civilization or invalid channel",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "No quotes for the civilization yet",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
         },
-        "/quote/{id}": {
+        "/matchup": {
             "get": {
-                "description": "Returns a single quote by its database ID",
+                "description": "Returns a random tip for a specific civilization matchup (your civ vs opponent civ).\nSupports two query formats: standard (?civ=X\u0026vs=Y) or Nightbot querystring (?X Y).\nTips stored for the reverse pairing (Y vs X) also match; they come back with reversed set,\nor prefixed with the civ they were written for in plain text.",
                 "produces": [
                     "text/plain",
-                    "application/json"
+                    "application/json",
+                    "text/html"
                 ],
                 "tags": [
-                    "quotes"
+                    "matchups"
                 ],
-                "summary": "Get a specific quote by ID",
+                "summary": "Get a matchup tip",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Quote ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Your civilization shortname (e.g., hre)",
+                        "name": "civ",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opponent civilization shortname (e.g., french)",
+                        "name": "vs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to obs for a self-refreshing HTML overlay for OBS browser sources",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Quote found",
+                        "description": "Matchup tip text (plain text default)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Usage: /api/matchup?civ=X\u0026vs=Y",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/quote": {
+            "get": {
+                "description": "Returns a random quote from the database. Supports filtering by civilization, channel and tag.\nThe civ can also be given Nightbot querystring style as a single word (?hre).",
+                "produces": [
+                    "text/plain",
+                    "application/json",
+                    "text/html"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "Get a random quote",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Civilization shortname (e.g., hre, french, mongols)",
+                        "name": "civ",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel name for channel-specific quotes",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only pick quotes with this tag (e.g., funny, build-order)",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to obs for a self-refreshing HTML overlay for OBS browser sources",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Quote text (plain text default)",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "Content-Type": {
+                                "type": "string",
+                                "description": "text/plain or application/json based on Accept header"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/quote/today": {
+            "get": {
+                "description": "Returns the same quote to everyone for the whole UTC day, changing at midnight UTC.\nWith a channel, the pick comes from that channel's quotes plus global ones, like /quote.\nAdding or deleting quotes can change the day's pick.",
+                "produces": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "Get the quote of the day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel name for channel-specific quotes",
+                        "name": "channel",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Quote text (plain text default)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/quote/{id}": {
+            "get": {
+                "description": "Returns a single quote by its database ID, with the IDs of the adjacent quotes for navigation.\n/quotes/{id} is the canonical path; /quote/{id} is an alias kept for existing bot commands.",
+                "produces": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "Get a specific quote by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Quote found",
+                        "schema": {
+                            "$ref": "#/definitions/srv.QuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid quote ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Quote not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "410": {
+                        "description": "Quote has been deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/quote/{id}/vote": {
+            "post": {
+                "description": "Adds an up or down vote to a quote and returns the quote with its updated counts.\nVotes are rate limited per IP, separately from the read API limit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "Vote on a quote",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Vote direction",
+                        "name": "vote",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/srv.VoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Quote with updated vote counts",
+                        "schema": {
+                            "$ref": "#/definitions/srv.QuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid quote ID or direction",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Quote not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many votes",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotes": {
+            "get": {
+                "description": "Returns a page of quotes as JSON, newest first. The total is sent in the X-Total-Count header,\nand page-based requests get GitHub-style Link headers for the next and previous pages.\nWith meta=1 the response is an object: {\"quotes\": [...], \"total\": N, \"page\": P, \"limit\": L, \"offset\": O}.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "List quotes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Quotes per page (max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quotes to skip; overrides page",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Wrap the quotes in an object with pagination metadata",
+                        "name": "meta",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list quotes in the trash, marked deleted (admins only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of quotes",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/srv.QuoteResponse"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total number of quotes"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotes/search": {
+            "get": {
+                "description": "Full-text search of quote text and author, best match first, at most 25 results. Every word\nmust match the start of a word in the quote or its author, case-insensitively.\nA single match is returned like /quote (plain text by default). Several matches are a JSON array,\nexcept for plain-text clients such as chat bots, which get the best match.",
+                "produces": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "Search quotes by keyword",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search words, matched literally (FTS operators, % and _ have no special meaning)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only quotes for this channel",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only quotes for this civilization (name or shortname)",
+                        "name": "civ",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching quote text, or a no-results message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Usage: /api/quotes/search?q=term",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotes/top": {
+            "get": {
+                "description": "Returns quotes ordered by net score (upvotes minus downvotes), highest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "List the highest voted quotes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of quotes (default 10, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only quotes for this channel",
+                        "name": "channel",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Top quotes",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/srv.QuoteResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotes/{id}": {
+            "get": {
+                "description": "Returns a single quote by its database ID, with the IDs of the adjacent quotes for navigation.\n/quotes/{id} is the canonical path; /quote/{id} is an alias kept for existing bot commands.",
+                "produces": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "Get a specific quote by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Quote found",
+                        "schema": {
+                            "$ref": "#/definitions/srv.QuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid quote ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Quote not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "410": {
+                        "description": "Quote has been deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Moves a quote to the trash, where an admin can restore it. Requires authentication as an admin or as an owner/moderator of the quote's channel.\nWith purge=1, admins delete the quote permanently, whether or not it is in the trash.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "Delete a quote",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to 1 to delete permanently (admins only)",
+                        "name": "purge",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Quote deleted",
+                        "schema": {
+                            "$ref": "#/definitions/srv.DeleteQuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid quote ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to delete this quote",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Quote not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotes/{id}/vote": {
+            "post": {
+                "description": "Adds an up or down vote to a quote and returns the quote with its updated counts.\nVotes are rate limited per IP, separately from the read API limit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "Vote on a quote",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Vote direction",
+                        "name": "vote",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/srv.VoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Quote with updated vote counts",
+                        "schema": {
+                            "$ref": "#/definitions/srv.QuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid quote ID or direction",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Quote not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many votes",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggest": {
+            "get": {
+                "description": "Submit a quote suggestion using GET request. Designed for Nightbot/Moobot $(urlfetch) commands.\nChannel is determined from bot headers (Nightbot-Channel, Moobot-Channel) or query param.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "suggestions"
+                ],
+                "summary": "Submit a quote suggestion via GET (for chat bots)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quote text to suggest",
+                        "name": "text",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel name (optional if bot headers present)",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quote author",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Civilization shortname",
+                        "name": "civ",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success or error message, always 200 so bots relay it to chat",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggestions": {
+            "post": {
+                "description": "Submit a new quote for review. Rate limited per IP (default: 5 per hour, configurable via SUGGESTION_RATE_LIMIT and SUGGESTION_RATE_INTERVAL).\nIf channel is omitted it is taken from the Nightbot-Channel or Moobot-channel-name header.",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suggestions"
+                ],
+                "summary": "Submit a quote suggestion",
+                "parameters": [
+                    {
+                        "description": "Quote suggestion",
+                        "name": "suggestion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/srv.SuggestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Suggestion submitted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request (missing fields or text too long)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many suggestions",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "get": {
+                "description": "Returns suggestions for a channel, newest first. Only pending suggestions can be listed. Requires authentication as an admin or as an owner/moderator of the channel.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suggestions"
+                ],
+                "summary": "List suggestions for a channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel name",
+                        "name": "channel",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "pending",
+                        "description": "Suggestion status (only 'pending' is supported)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Suggestions for the channel",
                         "schema": {
-                            "$ref": "#/definitions/srv.QuoteResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/srv.SuggestionResponse"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid quote ID",
+                        "description": "Missing channel or unsupported status",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Quote not found",
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to review this channel's suggestions",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "string"
                         }
@@ -147,87 +1111,78 @@ const docTemplate = `{
                 }
             }
         },
-        "/quotes": {
+        "/version": {
             "get": {
-                "description": "Returns all quotes in the database as JSON",
+                "description": "Returns build information for the running server. Not rate limited.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "quotes"
+                    "meta"
                 ],
-                "summary": "List all quotes",
+                "summary": "Get server version",
                 "responses": {
                     "200": {
-                        "description": "List of all quotes",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/srv.QuoteResponse"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                        "description": "Build information",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/srv.VersionResponse"
                         }
                     }
                 }
             }
         },
-        "/suggest": {
+        "/suggestions/{id}": {
             "get": {
-                "description": "Submit a quote suggestion using GET request. Designed for Nightbot/Moobot $(urlfetch) commands.\nChannel is determined from bot headers (Nightbot-Channel, Moobot-Channel) or query param.",
+                "description": "Returns a single quote suggestion, including its review status. Requires authentication as an admin or as an owner/moderator of the suggestion's channel.",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
                     "suggestions"
                 ],
-                "summary": "Submit a quote suggestion via GET (for chat bots)",
+                "summary": "Get a suggestion by ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Quote text to suggest",
-                        "name": "text",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Suggestion ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Channel name (optional if bot headers present)",
-                        "name": "channel",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Quote author",
-                        "name": "author",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Civilization shortname",
-                        "name": "civ",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success message",
+                        "description": "Suggestion found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/srv.SuggestionResponse"
                         }
                     },
                     "400": {
-                        "description": "Missing text or channel",
+                        "description": "Invalid suggestion ID",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "429": {
-                        "description": "Too many suggestions",
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to review this channel's suggestions",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Suggestion not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "string"
                         }
@@ -235,54 +1190,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/suggestions": {
-            "post": {
-                "description": "Submit a new quote for review. Rate limited to 5 suggestions per IP per hour.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/suggestions/{id}/status": {
+            "get": {
+                "description": "Returns whether a suggestion is pending, approved or rejected, for chat bots to relay to the viewer who submitted it.\nNo login is needed, but the caller must identify the suggestion's channel with bot headers (Nightbot-Channel, Moobot-Channel) or the channel query param.\nPlain text by default; JSON with Accept: application/json. Plain-text errors use status 200 so bots relay them to chat.",
                 "produces": [
+                    "text/plain",
                     "application/json"
                 ],
                 "tags": [
                     "suggestions"
                 ],
-                "summary": "Submit a quote suggestion",
+                "summary": "Get the review status of a suggestion",
                 "parameters": [
                     {
-                        "description": "Quote suggestion",
-                        "name": "suggestion",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/srv.SuggestionRequest"
-                        }
+                        "type": "integer",
+                        "description": "Suggestion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel name (optional if bot headers present)",
+                        "name": "channel",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Suggestion submitted successfully",
+                    "200": {
+                        "description": "Suggestion status",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/srv.SuggestionStatusResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request (missing fields or text too long)",
+                        "description": "Invalid suggestion ID or unknown channel (JSON only)",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "429": {
-                        "description": "Too many suggestions",
+                    "404": {
+                        "description": "Suggestion not found in this channel (JSON only)",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal server error (JSON only)",
                         "schema": {
                             "type": "string"
                         }
@@ -292,6 +1246,104 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "srv.ChangelogEntry": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "date": {
+                    "description": "YYYY-MM-DD format",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "optional version tag",
+                    "type": "string"
+                }
+            }
+        },
+        "srv.ChannelOwnerRequest": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "srv.ChannelOwnerResponse": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "type": "string"
+                },
+                "channel": {
+                    "type": "string"
+                },
+                "invited_by": {
+                    "type": "string"
+                },
+                "user_email": {
+                    "type": "string"
+                }
+            }
+        },
+        "srv.CivResponse": {
+            "type": "object",
+            "properties": {
+                "dlc": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "shortname": {
+                    "type": "string"
+                }
+            }
+        },
+        "srv.CivSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "dlc": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quote_count": {
+                    "type": "integer"
+                },
+                "shortname": {
+                    "type": "string"
+                },
+                "variant_of": {
+                    "type": "string"
+                }
+            }
+        },
+        "srv.DeleteQuoteResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "purged": {
+                    "type": "boolean"
+                }
+            }
+        },
         "srv.QuoteResponse": {
             "type": "object",
             "properties": {
@@ -304,14 +1356,51 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "deleted": {
+                    "description": "Deleted marks a quote in the trash, only listed for admins with\n?include_deleted=true",
+                    "type": "boolean"
+                },
+                "downvotes": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "next_id": {
+                    "description": "Adjacent quote IDs, only set when fetching a single quote by ID.\nOmitted at either end of the sequence.",
+                    "type": "integer"
+                },
                 "opponent_civ": {
                     "type": "string"
                 },
+                "prev_id": {
+                    "type": "integer"
+                },
+                "reversed": {
+                    "description": "Reversed marks a matchup tip stored for the opposite pairing, so it is\nwritten from the opponent's point of view.",
+                    "type": "boolean"
+                },
+                "tags": {
+                    "description": "Tags are only set on single-quote responses (/api/quote and\n/api/quotes/{id})",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "text": {
                     "type": "string"
+                },
+                "upvotes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "srv.VoteRequest": {
+            "type": "object",
+            "properties": {
+                "direction": {
+                    "description": "\"up\" or \"down\"",
+                    "type": "string"
                 }
             }
         },
@@ -334,6 +1423,69 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "srv.VersionResponse": {
+            "type": "object",
+            "properties": {
+                "built_at": {
+                    "type": "string"
+                },
+                "commit": {
+                    "type": "string"
+                },
+                "go_version": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "srv.SuggestionResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "channel": {
+                    "type": "string"
+                },
+                "civilization": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "opponent_civ": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "srv.SuggestionStatusResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
         }
     },
     "tags": [
@@ -348,6 +1500,18 @@ const docTemplate = `{
         {
             "description": "Submit quote suggestions for review",
             "name": "suggestions"
+        },
+        {
+            "description": "Civilization lookups for bot commands",
+            "name": "civilizations"
+        },
+        {
+            "description": "Server and build information",
+            "name": "meta"
+        },
+        {
+            "description": "Admin-only management endpoints",
+            "name": "admin"
         }
     ]
 }`
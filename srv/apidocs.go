@@ -1,33 +1,102 @@
 package srv
 
 import (
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
+	"encoding/json"
 	"net/http"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 //go:embed swagger.json
 var swaggerJSON []byte
 
-// HandleAPIDocs serves the API documentation page using Scalar
+// ETags for the embedded docs, computed once since the content never changes at runtime
+var (
+	specETag  = computeETag(swaggerJSON)
+	docsETag  = computeETag([]byte(scalarHTML))
+	redocETag = computeETag([]byte(redocHTML))
+)
+
+// The YAML form of the spec is converted once at startup. If conversion
+// fails, HandleAPISpec falls back to serving JSON.
+var (
+	specYAML, specYAMLErr = specToYAML(swaggerJSON)
+	specYAMLETag          = computeETag(specYAML)
+)
+
+// specToYAML converts a JSON OpenAPI spec to YAML
+func specToYAML(spec []byte) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
+
+// wantsYAML checks if the client asked for YAML in the Accept header
+func wantsYAML(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, "application/yaml") ||
+		strings.Contains(accept, "application/x-yaml") ||
+		strings.Contains(accept, "text/yaml")
+}
+
+// computeETag returns a weak ETag derived from the first 8 bytes of the content's SHA-256
+func computeETag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return `W/"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// writeCacheable writes body with ETag and Cache-Control headers,
+// responding 304 Not Modified when the client's If-None-Match matches.
+func writeCacheable(w http.ResponseWriter, r *http.Request, etag, contentType string, body []byte) {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Write(body)
+}
+
+// HandleAPIDocs serves the API documentation page using Scalar, or Redoc
+// when requested with ?renderer=redoc
 func (s *Server) HandleAPIDocs(w http.ResponseWriter, r *http.Request) {
+	// Response depends on Accept, so caches must key on it
+	w.Header().Set("Vary", "Accept")
+
 	// Check Accept header - if client wants JSON, serve the spec
 	accept := r.Header.Get("Accept")
 	if strings.Contains(accept, "application/json") && !strings.Contains(accept, "text/html") {
-		w.Header().Set("Content-Type", "application/json")
-		w.Write(swaggerJSON)
+		writeCacheable(w, r, specETag, "application/json", swaggerJSON)
+		return
+	}
+
+	if r.URL.Query().Get("renderer") == "redoc" {
+		writeCacheable(w, r, redocETag, "text/html; charset=utf-8", []byte(redocHTML))
 		return
 	}
 
 	// Serve Scalar UI
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	w.Write([]byte(scalarHTML))
+	writeCacheable(w, r, docsETag, "text/html; charset=utf-8", []byte(scalarHTML))
 }
 
-// HandleAPISpec serves the raw OpenAPI spec as JSON
+// HandleAPISpec serves the raw OpenAPI spec as JSON, or as YAML when the
+// client sends Accept: application/yaml
 func (s *Server) HandleAPISpec(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(swaggerJSON)
+	// Response depends on Accept, so caches must key on it
+	w.Header().Set("Vary", "Accept")
+
+	if wantsYAML(r) && specYAMLErr == nil {
+		writeCacheable(w, r, specYAMLETag, "application/yaml", specYAML)
+		return
+	}
+	writeCacheable(w, r, specETag, "application/json", swaggerJSON)
 }
 
 const scalarHTML = `<!DOCTYPE html>
@@ -58,3 +127,21 @@ const scalarHTML = `<!DOCTYPE html>
 </body>
 </html>
 `
+
+const redocHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>AoE4 Quote Database API</title>
+    <link rel="icon" type="image/x-icon" href="/static/favicon.ico">
+    <style>
+        body { margin: 0; padding: 0; }
+    </style>
+</head>
+<body>
+    <redoc spec-url="/api/openapi.json"></redoc>
+    <script src="https://cdn.jsdelivr.net/npm/redoc@2/bundles/redoc.standalone.js"></script>
+</body>
+</html>
+`
@@ -0,0 +1,182 @@
+package srv
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestHandleAPISpec_ETag(t *testing.T) {
+	server := &Server{}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil)
+	w := httptest.NewRecorder()
+	server.HandleAPISpec(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	etag := w.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("expected weak ETag, got %q", etag)
+	}
+	if cc := w.Header().Get("Cache-Control"); cc != "public, max-age=3600" {
+		t.Errorf("unexpected Cache-Control: %q", cc)
+	}
+	if w.Body.Len() == 0 {
+		t.Error("expected spec body")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	server.HandleAPISpec(w, req)
+
+	if w.Code != http.StatusNotModified {
+		t.Errorf("expected 304, got %d", w.Code)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("expected empty body on 304, got %d bytes", w.Body.Len())
+	}
+}
+
+func TestHandleAPIDocs_ETag(t *testing.T) {
+	server := &Server{}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/", nil)
+	w := httptest.NewRecorder()
+	server.HandleAPIDocs(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected ETag header")
+	}
+	if etag == specETag {
+		t.Error("expected HTML page ETag to differ from spec ETag")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	server.HandleAPIDocs(w, req)
+
+	if w.Code != http.StatusNotModified {
+		t.Errorf("expected 304, got %d", w.Code)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("expected empty body on 304, got %d bytes", w.Body.Len())
+	}
+}
+
+func TestHandleAPIDocs_Renderer(t *testing.T) {
+	server := &Server{}
+
+	tests := []struct {
+		name      string
+		target    string
+		wantRedoc bool
+	}{
+		{"default", "/api/", false},
+		{"scalar for unknown renderer", "/api/?renderer=swagger", false},
+		{"redoc", "/api/?renderer=redoc", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			w := httptest.NewRecorder()
+			server.HandleAPIDocs(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", w.Code)
+			}
+			body := w.Body.String()
+			if !strings.Contains(body, "/api/openapi.json") {
+				t.Error("expected page to load /api/openapi.json")
+			}
+			if got := strings.Contains(body, "redoc"); got != tt.wantRedoc {
+				t.Errorf("redoc in body = %v, want %v", got, tt.wantRedoc)
+			}
+			if got := strings.Contains(body, "Scalar.createApiReference"); got == tt.wantRedoc {
+				t.Errorf("Scalar in body = %v, want %v", got, !tt.wantRedoc)
+			}
+		})
+	}
+
+	t.Run("renderers have distinct ETags", func(t *testing.T) {
+		if redocETag == docsETag {
+			t.Error("expected Redoc and Scalar pages to have different ETags")
+		}
+	})
+}
+
+func TestHandleAPISpec_YAML(t *testing.T) {
+	server := &Server{}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil)
+	req.Header.Set("Accept", "application/yaml")
+	w := httptest.NewRecorder()
+	server.HandleAPISpec(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/yaml" {
+		t.Errorf("expected application/yaml, got %q", ct)
+	}
+	if w.Header().Get("ETag") == specETag {
+		t.Error("YAML response should not share the JSON ETag")
+	}
+
+	var fromYAML map[string]any
+	if err := yaml.Unmarshal(w.Body.Bytes(), &fromYAML); err != nil {
+		t.Fatalf("YAML did not parse: %v", err)
+	}
+	var fromJSON map[string]any
+	if err := json.Unmarshal(swaggerJSON, &fromJSON); err != nil {
+		t.Fatalf("JSON did not parse: %v", err)
+	}
+
+	info, _ := fromYAML["info"].(map[string]any)
+	wantInfo, _ := fromJSON["info"].(map[string]any)
+	if info["title"] == nil || info["title"] != wantInfo["title"] {
+		t.Errorf("expected info.title %v, got %v", wantInfo["title"], info["title"])
+	}
+
+	// YAML and JSON decode numbers differently, so compare via a JSON roundtrip
+	roundtrip, err := json.Marshal(fromYAML)
+	if err != nil {
+		t.Fatalf("marshal YAML document: %v", err)
+	}
+	var normalized map[string]any
+	if err := json.Unmarshal(roundtrip, &normalized); err != nil {
+		t.Fatalf("unmarshal roundtrip: %v", err)
+	}
+	if !reflect.DeepEqual(normalized, fromJSON) {
+		t.Error("YAML spec does not match the JSON spec")
+	}
+}
+
+func TestHandleAPISpec_DefaultsToJSON(t *testing.T) {
+	server := &Server{}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil)
+	req.Header.Set("Accept", "*/*")
+	w := httptest.NewRecorder()
+	server.HandleAPISpec(w, req)
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected application/json, got %q", ct)
+	}
+	if w.Header().Get("Vary") != "Accept" {
+		t.Errorf("expected Vary: Accept, got %q", w.Header().Get("Vary"))
+	}
+}
@@ -0,0 +1,316 @@
+package srv
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Audit trail settings
+const (
+	auditBufferSize = 256
+	auditPageSize   = 50
+	auditRetention  = 90 * 24 * time.Hour
+)
+
+// unauditedEvents are security events that are logged and traced but not
+// persisted. They fire on every rejected request, so writing them would let
+// a flood of traffic tie up the database; the rate_limited_total metric
+// counts them instead. Events without a user, such as auth_required from a
+// crawler, are skipped in record for the same reason.
+var unauditedEvents = map[string]bool{
+	"rate_limited":            true,
+	"vote_rate_limited":       true,
+	"suggestion_rate_limited": true,
+}
+
+// auditLog persists security events to the audit_events table. Events are
+// queued and written by a background goroutine so that recording one never
+// blocks a request; when the queue is full the event is dropped (it is
+// still logged and traced).
+type auditLog struct {
+	db   *sql.DB
+	now  func() time.Time
+	jobs chan auditJob
+}
+
+// auditJob is either an event to write or a flush barrier, handled in
+// order like markerJob.
+type auditJob struct {
+	event   dbgen.CreateAuditEventParams
+	flushed chan struct{}
+}
+
+// newAuditLog creates an audit log writing to db, timestamping events
+// with now, and starts its writer goroutine.
+func newAuditLog(db *sql.DB, now func() time.Time) *auditLog {
+	a := &auditLog{
+		db:   db,
+		now:  now,
+		jobs: make(chan auditJob, auditBufferSize),
+	}
+	go a.run()
+	return a
+}
+
+// record queues a security event. The user comes from the user.email or
+// user.identity attribute; path and reason from the attributes of the
+// same name. Anonymous events are not persisted, since anyone can trigger
+// them without limit.
+func (a *auditLog) record(event string, attrs []attribute.KeyValue) {
+	if a == nil || unauditedEvents[event] {
+		return
+	}
+
+	e := dbgen.CreateAuditEventParams{Event: event, CreatedAt: a.now()}
+	for _, attr := range attrs {
+		value := attr.Value.Emit()
+		switch attr.Key {
+		case "user.email":
+			e.UserEmail = &value
+		case "user.identity":
+			if e.UserEmail == nil {
+				e.UserEmail = &value
+			}
+		case "path":
+			e.Path = &value
+		case "reason":
+			e.Reason = &value
+		}
+	}
+	if e.UserEmail == nil {
+		return
+	}
+
+	select {
+	case a.jobs <- auditJob{event: e}:
+	default:
+		slog.Warn("audit buffer full, dropping event", "event", event)
+	}
+}
+
+// Flush waits until every event queued before the call has been written,
+// or until ctx is done.
+func (a *auditLog) Flush(ctx context.Context) error {
+	if a == nil {
+		return nil
+	}
+
+	flushed := make(chan struct{})
+	select {
+	case a.jobs <- auditJob{flushed: flushed}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *auditLog) run() {
+	q := dbgen.New(a.db)
+	for job := range a.jobs {
+		if job.flushed != nil {
+			close(job.flushed)
+			continue
+		}
+		if err := q.CreateAuditEvent(context.Background(), job.event); err != nil {
+			slog.Warn("write audit event", "event", job.event.Event, "error", err)
+		}
+	}
+}
+
+// StartAuditCleanup starts a background goroutine that deletes audit
+// events older than auditRetention, on startup and then daily.
+func (s *Server) StartAuditCleanup(ctx context.Context) {
+	go func() {
+		s.purgeOldAuditEvents(ctx)
+
+		ticker := time.NewTicker(24 * time.Hour)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.purgeOldAuditEvents(ctx)
+			}
+		}
+	}()
+}
+
+func (s *Server) purgeOldAuditEvents(ctx context.Context) {
+	cutoff := s.Clock.Now().Add(-auditRetention)
+	purged, err := dbgen.New(s.WriteDB).PurgeAuditEvents(ctx, cutoff)
+	if err != nil {
+		slog.Error("purge old audit events", "error", err)
+		return
+	}
+	slog.Debug("audit cleanup complete", "purged", purged)
+}
+
+// auditLogKey is the context key for the request's audit log
+type auditLogKey struct{}
+
+// auditLogFrom returns the audit log attached by AuditEvents, or nil.
+func auditLogFrom(ctx context.Context) *auditLog {
+	a, _ := ctx.Value(auditLogKey{}).(*auditLog)
+	return a
+}
+
+// AuditEvents middleware attaches the server's audit log to the request
+// context, so RecordSecurityEvent calls made while handling it are
+// persisted.
+func (s *Server) AuditEvents(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), auditLogKey{}, s.audit)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// AuditEventView is an audit event as shown on the admin page
+type AuditEventView struct {
+	Event     string
+	UserEmail string
+	Path      string
+	Reason    string
+	CreatedAt string
+	Timestamp string
+}
+
+// HandleAdminAudit lists recorded security events, newest first, with
+// optional ?event= filtering and ?page= pagination.
+func (s *Server) HandleAdminAudit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auth := s.getAuthInfo(r)
+
+	if !auth.IsAuthenticated {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Redirect(w, r, loginURLForRequest(r), http.StatusSeeOther)
+		return
+	}
+	if !auth.IsAdmin {
+		RecordSecurityEvent(ctx, "admin_required",
+			attribute.String("user.identity", auth.DisplayIdentity()),
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Admin access required", http.StatusForbidden)
+		return
+	}
+
+	page := 1
+	if p := r.URL.Query().Get("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+	eventFilter := strings.TrimSpace(r.URL.Query().Get("event"))
+
+	q := dbgen.New(s.WriteDB)
+	total, err := q.CountAuditEvents(ctx, toStringPtr(eventFilter))
+	if err != nil {
+		slog.Error("count audit events", "error", err)
+		http.Error(w, "Failed to load audit events", http.StatusInternalServerError)
+		return
+	}
+	totalPages := max(int((total+auditPageSize-1)/auditPageSize), 1)
+	page = min(page, totalPages)
+
+	events, err := q.ListAuditEvents(ctx, dbgen.ListAuditEventsParams{
+		Event:  toStringPtr(eventFilter),
+		Limit:  auditPageSize,
+		Offset: int64((page - 1) * auditPageSize),
+	})
+	if err != nil {
+		slog.Error("list audit events", "error", err)
+		http.Error(w, "Failed to load audit events", http.StatusInternalServerError)
+		return
+	}
+	views := make([]AuditEventView, len(events))
+	for i, e := range events {
+		views[i] = AuditEventView{
+			Event:     e.Event,
+			UserEmail: stringVal(e.UserEmail),
+			Path:      stringVal(e.Path),
+			Reason:    stringVal(e.Reason),
+			CreatedAt: s.formatTimeAgo(e.CreatedAt),
+			Timestamp: e.CreatedAt.UTC().Format("2006-01-02 15:04:05 UTC"),
+		}
+	}
+
+	// The filter dropdown is a convenience; show the page without it on failure
+	eventTypes, err := q.ListAuditEventTypes(ctx)
+	if err != nil {
+		slog.Warn("list audit event types", "error", err)
+	}
+
+	logoutURL := "/__exe.dev/logout"
+	if auth.AuthMethod == "twitch" {
+		logoutURL = "/auth/logout"
+	}
+
+	// pageURL keeps the event filter when paging
+	pageURL := func(n int) string {
+		v := url.Values{}
+		if eventFilter != "" {
+			v.Set("event", eventFilter)
+		}
+		v.Set("page", strconv.Itoa(n))
+		return "/admin/audit?" + v.Encode()
+	}
+
+	data := struct {
+		Events          []AuditEventView
+		EventTypes      []string
+		EventFilter     string
+		Total           int64
+		Page            int
+		TotalPages      int
+		HasPrev         bool
+		HasNext         bool
+		PrevURL         string
+		NextURL         string
+		IsAuthenticated bool
+		IsAdmin         bool
+		IsOwner         bool
+		IsPublicPage    bool
+		LogoutURL       string
+		UserEmail       string
+		navBadges
+	}{
+		Events:          views,
+		EventTypes:      eventTypes,
+		EventFilter:     eventFilter,
+		Total:           total,
+		Page:            page,
+		TotalPages:      totalPages,
+		HasPrev:         page > 1,
+		HasNext:         page < totalPages,
+		PrevURL:         pageURL(page - 1),
+		NextURL:         pageURL(page + 1),
+		IsAuthenticated: true,
+		IsAdmin:         true,
+		LogoutURL:       logoutURL,
+		UserEmail:       auth.DisplayIdentity(),
+		navBadges:       s.navBadgesFor(ctx, auth.Email),
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.renderTemplate(w, "admin_audit.html", data); err != nil {
+		slog.Warn("render template", "url", r.URL.Path, "error", err)
+	}
+}
@@ -0,0 +1,208 @@
+package srv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+)
+
+func TestAuditEvents(t *testing.T) {
+	// auditEvents waits for queued events to be written and lists them
+	auditEvents := func(t *testing.T, server *Server, event *string) []dbgen.AuditEvent {
+		t.Helper()
+		if err := server.audit.Flush(context.Background()); err != nil {
+			t.Fatalf("flush audit log: %v", err)
+		}
+		events, err := dbgen.New(server.WriteDB).ListAuditEvents(context.Background(), dbgen.ListAuditEventsParams{
+			Event: event,
+			Limit: 100,
+		})
+		if err != nil {
+			t.Fatalf("list audit events: %v", err)
+		}
+		return events
+	}
+	adminRequest := func(target, email string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("X-ExeDev-UserID", "user-1")
+		req.Header.Set("X-ExeDev-Email", email)
+		return req
+	}
+
+	t.Run("permission denied writes an audit row", func(t *testing.T) {
+		server := testServer(t)
+		now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+		server.Clock = &fakeClock{now: now}
+		addTestOwner(t, server, "ownerchannel", "owner@test.com")
+
+		w := httptest.NewRecorder()
+		handler := server.AuditEvents(http.HandlerFunc(server.HandleChannelSettings))
+		req := adminRequest("/channels/ownerchannel/settings", "stranger@test.com")
+		req.SetPathValue("name", "ownerchannel")
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d", w.Code)
+		}
+
+		events := auditEvents(t, server, nil)
+		if len(events) != 1 {
+			t.Fatalf("expected 1 audit event, got %d", len(events))
+		}
+		e := events[0]
+		if e.Event != "permission_denied" {
+			t.Errorf("expected permission_denied, got %q", e.Event)
+		}
+		if stringVal(e.UserEmail) != "stranger@test.com" {
+			t.Errorf("expected user stranger@test.com, got %q", stringVal(e.UserEmail))
+		}
+		if stringVal(e.Path) != "/channels/ownerchannel/settings" {
+			t.Errorf("unexpected path %q", stringVal(e.Path))
+		}
+		if !e.CreatedAt.Equal(now) {
+			t.Errorf("expected created_at %v from the server clock, got %v", now, e.CreatedAt)
+		}
+	})
+
+	t.Run("events outside the middleware are not persisted", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "ownerchannel", "owner@test.com")
+
+		req := adminRequest("/channels/ownerchannel/settings", "stranger@test.com")
+		req.SetPathValue("name", "ownerchannel")
+		server.HandleChannelSettings(httptest.NewRecorder(), req)
+
+		if events := auditEvents(t, server, nil); len(events) != 0 {
+			t.Errorf("expected no audit events, got %d", len(events))
+		}
+	})
+
+	t.Run("rate limit events are not persisted", func(t *testing.T) {
+		server := testServer(t)
+		limiter := NewRateLimiter(1, time.Minute, 1)
+		handler := server.AuditEvents(limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+		for range 3 {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/quote", nil))
+		}
+
+		if events := auditEvents(t, server, nil); len(events) != 0 {
+			t.Errorf("expected no audit events, got %d", len(events))
+		}
+	})
+
+	t.Run("anonymous events are not persisted", func(t *testing.T) {
+		server := testServer(t)
+		handler := server.AuditEvents(http.HandlerFunc(server.HandleQuotes))
+		for range 3 {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/quotes", nil))
+			if w.Code != http.StatusSeeOther {
+				t.Fatalf("expected 303, got %d", w.Code)
+			}
+		}
+
+		if events := auditEvents(t, server, nil); len(events) != 0 {
+			t.Errorf("expected no audit events, got %d", len(events))
+		}
+	})
+
+	t.Run("cleanup deletes events past the retention period", func(t *testing.T) {
+		server := testServer(t)
+		now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+		server.Clock = &fakeClock{now: now}
+		q := dbgen.New(server.WriteDB)
+		for _, age := range []time.Duration{auditRetention + time.Hour, auditRetention - time.Hour, time.Minute} {
+			if err := q.CreateAuditEvent(context.Background(), dbgen.CreateAuditEventParams{
+				Event:     "permission_denied",
+				Path:      strPtr(age.String()),
+				CreatedAt: now.Add(-age),
+			}); err != nil {
+				t.Fatalf("create audit event: %v", err)
+			}
+		}
+
+		server.purgeOldAuditEvents(context.Background())
+
+		events := auditEvents(t, server, nil)
+		if len(events) != 2 {
+			t.Fatalf("expected 2 audit events to remain, got %d", len(events))
+		}
+		for _, e := range events {
+			if now.Sub(e.CreatedAt) > auditRetention {
+				t.Errorf("expected event from %v to be purged", e.CreatedAt)
+			}
+		}
+	})
+
+	t.Run("audit page requires admin", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "somechannel", "owner@test.com")
+
+		w := httptest.NewRecorder()
+		server.HandleAdminAudit(w, adminRequest("/admin/audit", "owner@test.com"))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected 403 for owner, got %d", w.Code)
+		}
+
+		w = httptest.NewRecorder()
+		server.HandleAdminAudit(w, httptest.NewRequest(http.MethodGet, "/admin/audit", nil))
+		if w.Code != http.StatusSeeOther {
+			t.Errorf("expected redirect without auth, got %d", w.Code)
+		}
+	})
+
+	t.Run("audit page filters by event and paginates", func(t *testing.T) {
+		server := testServer(t)
+		q := dbgen.New(server.WriteDB)
+		start := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+		for i := range auditPageSize + 10 {
+			if err := q.CreateAuditEvent(context.Background(), dbgen.CreateAuditEventParams{
+				Event:     "auth_required",
+				Path:      strPtr(fmt.Sprintf("/api/quote/%d", i)),
+				CreatedAt: start.Add(time.Duration(i) * time.Minute),
+			}); err != nil {
+				t.Fatalf("create audit event: %v", err)
+			}
+		}
+		if err := q.CreateAuditEvent(context.Background(), dbgen.CreateAuditEventParams{
+			Event:     "admin_required",
+			Path:      strPtr("/admin/users"),
+			CreatedAt: start,
+		}); err != nil {
+			t.Fatalf("create audit event: %v", err)
+		}
+
+		page := func(query string) string {
+			t.Helper()
+			w := httptest.NewRecorder()
+			server.HandleAdminAudit(w, adminRequest("/admin/audit"+query, "admin@test.com"))
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", w.Code)
+			}
+			return w.Body.String()
+		}
+
+		body := page("?event=admin_required")
+		if !strings.Contains(body, "/admin/users") || strings.Contains(body, "/api/quote/") {
+			t.Error("expected only admin_required events when filtered")
+		}
+
+		body = page("?event=auth_required")
+		if !strings.Contains(body, fmt.Sprintf("/api/quote/%d<", auditPageSize+9)) {
+			t.Error("expected the newest event on the first page")
+		}
+		if !strings.Contains(body, "event=auth_required&amp;page=2") {
+			t.Error("expected a next page link that keeps the filter")
+		}
+
+		body = page("?event=auth_required&page=2")
+		if !strings.Contains(body, "/api/quote/0<") || strings.Contains(body, fmt.Sprintf("/api/quote/%d<", auditPageSize+9)) {
+			t.Error("expected the oldest events on the second page")
+		}
+	})
+}
@@ -123,7 +123,7 @@ func TestCanManageChannel_OwnerCanManageOwnChannel(t *testing.T) {
 	ctx := context.Background()
 
 	// Add a channel owner
-	q := dbgen.New(server.DB)
+	q := dbgen.New(server.WriteDB)
 	err := q.AddChannelOwner(ctx, dbgen.AddChannelOwnerParams{
 		Channel:   "beastyqt",
 		UserEmail: "streamer@example.com",
@@ -148,7 +148,7 @@ func TestCanManageChannel_OwnerCaseInsensitive(t *testing.T) {
 	ctx := context.Background()
 
 	// Add a channel owner with lowercase
-	q := dbgen.New(server.DB)
+	q := dbgen.New(server.WriteDB)
 	err := q.AddChannelOwner(ctx, dbgen.AddChannelOwnerParams{
 		Channel:   "beastyqt",
 		UserEmail: "streamer@example.com",
@@ -181,7 +181,7 @@ func TestCanManageChannel_MultipleChannelOwner(t *testing.T) {
 	ctx := context.Background()
 
 	// Add owner for multiple channels
-	q := dbgen.New(server.DB)
+	q := dbgen.New(server.WriteDB)
 	for _, ch := range []string{"channel1", "channel2", "channel3"} {
 		err := q.AddChannelOwner(ctx, dbgen.AddChannelOwnerParams{
 			Channel:   ch,
@@ -210,7 +210,7 @@ func TestGetOwnedChannels_ReturnsCorrectChannels(t *testing.T) {
 	ctx := context.Background()
 
 	// Add owner for multiple channels
-	q := dbgen.New(server.DB)
+	q := dbgen.New(server.WriteDB)
 	expected := []string{"alpha", "beta", "gamma"}
 	for _, ch := range expected {
 		err := q.AddChannelOwner(ctx, dbgen.AddChannelOwnerParams{
@@ -262,7 +262,7 @@ func TestGetOwnedChannels_EmailNormalization(t *testing.T) {
 	ctx := context.Background()
 
 	// Add owner with lowercase email
-	q := dbgen.New(server.DB)
+	q := dbgen.New(server.WriteDB)
 	err := q.AddChannelOwner(ctx, dbgen.AddChannelOwnerParams{
 		Channel:   "testchannel",
 		UserEmail: "owner@example.com",
@@ -1,8 +1,10 @@
 package srv
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -13,20 +15,95 @@ import (
 type BotSource string
 
 const (
-	BotSourceNightbot BotSource = "nightbot"
-	BotSourceMoobot   BotSource = "moobot"
-	BotSourceQuery    BotSource = "query"
-	BotSourceNone     BotSource = ""
+	BotSourceNightbot       BotSource = "nightbot"
+	BotSourceMoobot         BotSource = "moobot"
+	BotSourceStreamElements BotSource = "streamelements"
+	BotSourceDiscord        BotSource = "discord"
+	BotSourceQuery          BotSource = "query"
+	BotSourceNone           BotSource = ""
 )
 
 // BotChannel contains channel information extracted from bot headers
 type BotChannel struct {
 	Name   string
 	Source BotSource
+	// GuildID is the Discord guild (server) the request came from; empty
+	// for other sources
+	GuildID string
+}
+
+// DiscordChannel represents the X-Discord-Guild/X-Discord-Channel header pair
+// sent by Discord bots
+type DiscordChannel struct {
+	GuildID string
+	Channel string
+}
+
+// ParseDiscordChannel reads the Discord header pair. Both headers must be
+// set; a bot that sends only one is treated as sending neither.
+func ParseDiscordChannel(h http.Header) *DiscordChannel {
+	guild := strings.TrimSpace(h.Get("X-Discord-Guild"))
+	channel := strings.TrimSpace(h.Get("X-Discord-Channel"))
+	if guild == "" || channel == "" {
+		return nil
+	}
+	return &DiscordChannel{GuildID: guild, Channel: strings.ToLower(channel)}
+}
+
+// StreamElementsChannel represents the StreamElements-Channel header data.
+// Unlike Nightbot, StreamElements sends the bare channel name rather than a
+// query string.
+type StreamElementsChannel struct {
+	Name string
+}
+
+// ParseStreamElementsChannel parses the StreamElements-Channel header
+func ParseStreamElementsChannel(header string) *StreamElementsChannel {
+	name := strings.ToLower(strings.TrimSpace(header))
+	if name == "" {
+		return nil
+	}
+	return &StreamElementsChannel{Name: name}
+}
+
+// StreamElements permission levels, as sent in StreamElements-User-Level
+const (
+	seLevelBroadcaster    = 1500
+	seLevelSuperModerator = 1000
+	seLevelModerator      = 500
+	seLevelVIP            = 400
+	seLevelRegular        = 300
+	seLevelSubscriber     = 250
+)
+
+// StreamElementsUserLevel maps a numeric StreamElements permission level to
+// the equivalent Nightbot userLevel ("owner", "moderator", "twitch_vip",
+// "regular", "subscriber" or "everyone"). Unparseable levels map to
+// "everyone".
+func StreamElementsUserLevel(level string) string {
+	n, err := strconv.Atoi(strings.TrimSpace(level))
+	if err != nil {
+		return "everyone"
+	}
+	switch {
+	case n >= seLevelBroadcaster:
+		return "owner"
+	case n >= seLevelModerator:
+		// Super moderators are moderators with extra bot permissions
+		return "moderator"
+	case n >= seLevelVIP:
+		return "twitch_vip"
+	case n >= seLevelRegular:
+		return "regular"
+	case n >= seLevelSubscriber:
+		return "subscriber"
+	}
+	return "everyone"
 }
 
 // GetBotChannel extracts the channel name from bot headers or query param.
-// Priority: Nightbot header > Moobot header > ?channel= query param
+// Priority: Nightbot header > Moobot header > StreamElements header >
+// Discord headers > ?channel= query param
 func GetBotChannel(r *http.Request) *BotChannel {
 	// Check Nightbot header first
 	if nb := ParseNightbotChannel(r.Header.Get("Nightbot-Channel")); nb != nil && nb.Name != "" {
@@ -38,6 +115,16 @@ func GetBotChannel(r *http.Request) *BotChannel {
 		return &BotChannel{Name: strings.ToLower(moobotChannel), Source: BotSourceMoobot}
 	}
 
+	// Check StreamElements header
+	if se := ParseStreamElementsChannel(r.Header.Get("StreamElements-Channel")); se != nil {
+		return &BotChannel{Name: se.Name, Source: BotSourceStreamElements}
+	}
+
+	// Check Discord headers
+	if dc := ParseDiscordChannel(r.Header); dc != nil {
+		return &BotChannel{Name: dc.Channel, Source: BotSourceDiscord, GuildID: dc.GuildID}
+	}
+
 	// Fall back to query param
 	if ch := r.URL.Query().Get("channel"); ch != "" {
 		return &BotChannel{Name: ch, Source: BotSourceQuery}
@@ -46,6 +133,21 @@ func GetBotChannel(r *http.Request) *BotChannel {
 	return nil
 }
 
+// isBotRequest reports whether a chat bot sent the request, judging by its
+// headers. A bare ?channel= query param doesn't count.
+func isBotRequest(r *http.Request) bool {
+	bc := GetBotChannel(r)
+	return bc != nil && bc.Source != BotSourceQuery
+}
+
+// writeBotError writes an error message for a chat bot to relay verbatim.
+// The status stays 200 because some bots treat any other status as a failed
+// command and show their own generic error instead.
+func writeBotError(w http.ResponseWriter, msg string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, msg)
+}
+
 // NightbotChannel represents parsed Nightbot-Channel header data
 type NightbotChannel struct {
 	Name        string
@@ -142,6 +244,31 @@ func AddBotAttributes(r *http.Request) {
 		if userID := r.Header.Get("Moobot-user-id"); userID != "" {
 			span.SetAttributes(attribute.String("bot.user.id", userID))
 		}
+		return
+	}
+
+	// Check for StreamElements headers
+	if se := ParseStreamElementsChannel(r.Header.Get("StreamElements-Channel")); se != nil {
+		span.SetAttributes(
+			attribute.String("bot.source", "streamelements"),
+			attribute.String("bot.channel.name", se.Name),
+		)
+		if userName := r.Header.Get("StreamElements-User"); userName != "" {
+			span.SetAttributes(attribute.String("bot.user.name", userName))
+		}
+		if level := r.Header.Get("StreamElements-User-Level"); level != "" {
+			span.SetAttributes(attribute.String("bot.user.user_level", StreamElementsUserLevel(level)))
+		}
+		return
+	}
+
+	// Check for Discord headers
+	if dc := ParseDiscordChannel(r.Header); dc != nil {
+		span.SetAttributes(
+			attribute.String("bot.source", "discord"),
+			attribute.String("bot.channel.name", dc.Channel),
+			attribute.String("bot.channel.guild_id", dc.GuildID),
+		)
 	}
 }
 
@@ -169,5 +296,10 @@ func GetBotUser(r *http.Request) string {
 		return userName
 	}
 
+	// Check StreamElements user header
+	if userName := r.Header.Get("StreamElements-User"); userName != "" {
+		return userName
+	}
+
 	return ""
 }
@@ -72,14 +72,45 @@ func TestParseNightbotUser(t *testing.T) {
 	}
 }
 
+func TestParseStreamElementsChannel(t *testing.T) {
+	if got := ParseStreamElementsChannel(" BeastyQT "); got == nil || got.Name != "beastyqt" {
+		t.Errorf("expected channel beastyqt, got %+v", got)
+	}
+	for _, header := range []string{"", "   "} {
+		if got := ParseStreamElementsChannel(header); got != nil {
+			t.Errorf("ParseStreamElementsChannel(%q) = %+v, want nil", header, got)
+		}
+	}
+}
+
+func TestStreamElementsUserLevel(t *testing.T) {
+	tests := map[string]string{
+		"1500": "owner",
+		"1000": "moderator",
+		"500":  "moderator",
+		"400":  "twitch_vip",
+		"300":  "regular",
+		"250":  "subscriber",
+		"100":  "everyone",
+		"":     "everyone",
+		"mod":  "everyone",
+	}
+	for level, want := range tests {
+		if got := StreamElementsUserLevel(level); got != want {
+			t.Errorf("StreamElementsUserLevel(%q) = %q, want %q", level, got, want)
+		}
+	}
+}
+
 func TestGetBotChannel(t *testing.T) {
 	tests := []struct {
-		name       string
-		headers    map[string]string
-		queryParam string
-		wantName   string
-		wantSource BotSource
-		wantNil    bool
+		name        string
+		headers     map[string]string
+		queryParam  string
+		wantName    string
+		wantSource  BotSource
+		wantGuildID string
+		wantNil     bool
 	}{
 		{
 			name:    "no headers or query",
@@ -116,6 +147,72 @@ func TestGetBotChannel(t *testing.T) {
 			wantName:   "moobotchannel",
 			wantSource: BotSourceMoobot,
 		},
+		{
+			name:       "streamelements header",
+			headers:    map[string]string{"StreamElements-Channel": "SomeStreamer"},
+			wantName:   "somestreamer", // lowercased
+			wantSource: BotSourceStreamElements,
+		},
+		{
+			name:       "streamelements takes precedence over query",
+			headers:    map[string]string{"StreamElements-Channel": "sechannel"},
+			queryParam: "querychannel",
+			wantName:   "sechannel",
+			wantSource: BotSourceStreamElements,
+		},
+		{
+			name:       "empty streamelements header falls back to query",
+			headers:    map[string]string{"StreamElements-Channel": " "},
+			queryParam: "querychannel",
+			wantName:   "querychannel",
+			wantSource: BotSourceQuery,
+		},
+		{
+			name:       "moobot takes precedence over streamelements",
+			headers:    map[string]string{"Moobot-channel-name": "moobotch", "StreamElements-Channel": "sechannel"},
+			wantName:   "moobotch",
+			wantSource: BotSourceMoobot,
+		},
+		{
+			name:        "discord headers",
+			headers:     map[string]string{"X-Discord-Guild": "81384788765712384", "X-Discord-Channel": "BeastyQT"},
+			wantName:    "beastyqt", // lowercased
+			wantSource:  BotSourceDiscord,
+			wantGuildID: "81384788765712384",
+		},
+		{
+			name:       "nightbot takes precedence over discord",
+			headers:    map[string]string{"Nightbot-Channel": "name=nightbotch", "X-Discord-Guild": "1", "X-Discord-Channel": "discordch"},
+			wantName:   "nightbotch",
+			wantSource: BotSourceNightbot,
+		},
+		{
+			name:        "discord takes precedence over query",
+			headers:     map[string]string{"X-Discord-Guild": "1", "X-Discord-Channel": "discordch"},
+			queryParam:  "querychannel",
+			wantName:    "discordch",
+			wantSource:  BotSourceDiscord,
+			wantGuildID: "1",
+		},
+		{
+			name:       "discord channel without guild falls back to query",
+			headers:    map[string]string{"X-Discord-Channel": "discordch"},
+			queryParam: "querychannel",
+			wantName:   "querychannel",
+			wantSource: BotSourceQuery,
+		},
+		{
+			name:       "discord guild without channel falls back to query",
+			headers:    map[string]string{"X-Discord-Guild": "1"},
+			queryParam: "querychannel",
+			wantName:   "querychannel",
+			wantSource: BotSourceQuery,
+		},
+		{
+			name:    "discord guild without channel or query",
+			headers: map[string]string{"X-Discord-Guild": "1"},
+			wantNil: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -147,6 +244,9 @@ func TestGetBotChannel(t *testing.T) {
 			if got.Source != tt.wantSource {
 				t.Errorf("Source = %q, want %q", got.Source, tt.wantSource)
 			}
+			if got.GuildID != tt.wantGuildID {
+				t.Errorf("GuildID = %q, want %q", got.GuildID, tt.wantGuildID)
+			}
 		})
 	}
 }
@@ -182,6 +282,11 @@ func TestGetBotUser(t *testing.T) {
 			headers:  map[string]string{"Moobot-user-name": "moobotviewer"},
 			expected: "moobotviewer",
 		},
+		{
+			name:     "streamelements user name",
+			headers:  map[string]string{"StreamElements-User": "SEViewer"},
+			expected: "SEViewer",
+		},
 		{
 			name:     "nightbot takes precedence over moobot",
 			headers:  map[string]string{"Nightbot-User": "name=nbuser&displayName=NBUser", "Moobot-user-display-name": "MBUser"},
@@ -224,6 +329,15 @@ func TestAddBotAttributes(t *testing.T) {
 			"Moobot-user-name":    "testuser",
 			"Moobot-user-id":      "12345",
 		}},
+		{"streamelements headers", map[string]string{
+			"StreamElements-Channel":    "testchannel",
+			"StreamElements-User":       "testuser",
+			"StreamElements-User-Level": "500",
+		}},
+		{"discord headers", map[string]string{
+			"X-Discord-Guild":   "81384788765712384",
+			"X-Discord-Channel": "testchannel",
+		}},
 	}
 
 	for _, tt := range tests {
@@ -1,10 +1,15 @@
 package srv
 
+import (
+	"slices"
+	"strings"
+)
+
 // ChangelogEntry represents a single changelog entry.
 type ChangelogEntry struct {
-	Date    string // YYYY-MM-DD format
-	Version string // optional version tag
-	Changes []string
+	Date    string   `json:"date"`              // YYYY-MM-DD format
+	Version string   `json:"version,omitempty"` // optional version tag
+	Changes []string `json:"changes"`
 }
 
 // Changelog contains all changelog entries, newest first.
@@ -37,3 +42,13 @@ var Changelog = []ChangelogEntry{
 		},
 	},
 }
+
+// changelogNewestFirst returns Changelog sorted by date, newest first, so a
+// misplaced entry doesn't end up out of order on the page or in the API.
+func changelogNewestFirst() []ChangelogEntry {
+	entries := slices.Clone(Changelog)
+	slices.SortStableFunc(entries, func(a, b ChangelogEntry) int {
+		return strings.Compare(b.Date, a.Date)
+	})
+	return entries
+}
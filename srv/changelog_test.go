@@ -0,0 +1,62 @@
+package srv
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandleChangelog(t *testing.T) {
+	// An entry added out of order must still be listed first
+	original := Changelog
+	Changelog = []ChangelogEntry{
+		{Date: "2025-01-01", Changes: []string{"Older change"}},
+		{Date: "2025-03-01", Version: "v2", Changes: []string{"Newest change"}},
+		{Date: "2025-02-01", Changes: []string{"Middle change"}},
+	}
+	t.Cleanup(func() { Changelog = original })
+	server := testServer(t)
+
+	t.Run("html lists newest first", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.HandleChangelog(w, httptest.NewRequest(http.MethodGet, "/changelog", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+			t.Errorf("expected HTML, got %q", ct)
+		}
+		body := w.Body.String()
+		newest := strings.Index(body, "Newest change")
+		middle := strings.Index(body, "Middle change")
+		older := strings.Index(body, "Older change")
+		if newest < 0 || !(newest < middle && middle < older) {
+			t.Errorf("expected entries newest first, got positions %d, %d, %d", newest, middle, older)
+		}
+	})
+
+	t.Run("json lists newest first", func(t *testing.T) {
+		handlers := map[string]http.Handler{
+			"/changelog":     http.HandlerFunc(server.HandleChangelog),
+			"/api/changelog": server.apiHandler(),
+		}
+		for path, handler := range handlers {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set("Accept", "application/json")
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("%s: expected 200, got %d", path, w.Code)
+			}
+			var entries []ChangelogEntry
+			if err := json.Unmarshal(w.Body.Bytes(), &entries); err != nil {
+				t.Fatalf("%s: decode: %v", path, err)
+			}
+			if len(entries) != 3 || entries[0].Date != "2025-03-01" || entries[0].Version != "v2" || entries[2].Date != "2025-01-01" {
+				t.Errorf("%s: expected entries newest first, got %+v", path, entries)
+			}
+		}
+	})
+}
@@ -0,0 +1,256 @@
+package srv
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// channelSettingsTTL is how long a channel's settings are cached before
+// the bot endpoints read them from the database again.
+const channelSettingsTTL = 60 * time.Second
+
+// ChannelSettings is the JSON representation of a channel's settings
+type ChannelSettings struct {
+	Channel                  string `json:"channel"`
+	RequireModForSuggestions bool   `json:"require_mod_for_suggestions"`
+}
+
+type cachedChannelSettings struct {
+	settings  ChannelSettings
+	fetchedAt time.Time
+}
+
+// channelSettingsCache keeps recently read channel settings so bot commands
+// don't hit the database on every request.
+type channelSettingsCache struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	entries map[string]cachedChannelSettings
+}
+
+// newChannelSettingsCache creates a cache that ages entries using now.
+func newChannelSettingsCache(now func() time.Time) *channelSettingsCache {
+	return &channelSettingsCache{now: now, entries: make(map[string]cachedChannelSettings)}
+}
+
+func (c *channelSettingsCache) get(channel string) (ChannelSettings, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[channel]
+	if !ok || c.now().Sub(e.fetchedAt) > channelSettingsTTL {
+		return ChannelSettings{}, false
+	}
+	return e.settings, true
+}
+
+func (c *channelSettingsCache) set(settings ChannelSettings) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[settings.Channel] = cachedChannelSettings{settings: settings, fetchedAt: c.now()}
+}
+
+func (c *channelSettingsCache) invalidate(channel string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, channel)
+}
+
+// getChannelSettings returns the settings for a channel, using the cache
+// when possible. Channels without a row get the defaults.
+func (s *Server) getChannelSettings(ctx context.Context, channel string) (ChannelSettings, error) {
+	channel = strings.ToLower(strings.TrimSpace(channel))
+	if settings, ok := s.channelSettings.get(channel); ok {
+		return settings, nil
+	}
+
+	settings := ChannelSettings{Channel: channel}
+	row, err := dbgen.New(s.WriteDB).GetChannelSettings(ctx, channel)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return settings, err
+	}
+	if err == nil {
+		settings.RequireModForSuggestions = row.RequireModForSuggestions
+	}
+
+	s.channelSettings.set(settings)
+	return settings, nil
+}
+
+// isBotModerator reports whether the bot user sending the request is a
+// moderator or the owner of the channel. Only Nightbot and StreamElements
+// send a user level.
+func isBotModerator(r *http.Request) bool {
+	var level string
+	if user := ParseNightbotUser(r.Header.Get("Nightbot-User")); user != nil {
+		level = user.UserLevel
+	} else if seLevel := r.Header.Get("StreamElements-User-Level"); seLevel != "" {
+		level = StreamElementsUserLevel(seLevel)
+	}
+	switch strings.ToLower(level) {
+	case "moderator", "owner":
+		return true
+	}
+	return false
+}
+
+// HandleChannelSettings shows a channel's settings to anyone who can manage it
+func (s *Server) HandleChannelSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auth := s.getAuthInfo(r)
+	channel := strings.ToLower(strings.TrimSpace(r.PathValue("name")))
+
+	if !auth.IsAuthenticated {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Redirect(w, r, loginURLForRequest(r), http.StatusSeeOther)
+		return
+	}
+
+	if !s.canManageChannelWithTwitch(ctx, auth.Email, auth.TwitchUsername, channel) {
+		RecordSecurityEvent(ctx, "permission_denied",
+			attribute.String("user.identity", auth.DisplayIdentity()),
+			attribute.String("path", r.URL.Path),
+			attribute.String("resource", "channel_settings"),
+			attribute.String("channel", channel),
+			attribute.String("reason", "not_authorized"),
+		)
+		http.Error(w, "You don't have permission to manage this channel", http.StatusForbidden)
+		return
+	}
+
+	settings, err := s.getChannelSettings(ctx, channel)
+	if err != nil {
+		slog.Error("get channel settings", "channel", channel, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if WantsJSON(r) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(settings)
+		return
+	}
+
+	ownedChannels, _ := s.getOwnedChannels(ctx, auth.Email)
+
+	logoutURL := "/__exe.dev/logout"
+	if auth.AuthMethod == "twitch" {
+		logoutURL = "/auth/logout"
+	}
+
+	data := struct {
+		Hostname        string
+		UserEmail       string
+		LogoutURL       string
+		IsAdmin         bool
+		IsOwner         bool
+		IsAuthenticated bool
+		IsPublicPage    bool
+		Success         string
+		Error           string
+		Settings        ChannelSettings
+		navBadges
+	}{
+		Hostname:        s.Hostname,
+		UserEmail:       auth.DisplayIdentity(),
+		LogoutURL:       logoutURL,
+		IsAdmin:         auth.IsAdmin,
+		IsOwner:         len(ownedChannels) > 0,
+		IsAuthenticated: true,
+		Success:         r.URL.Query().Get("success"),
+		Error:           r.URL.Query().Get("error"),
+		Settings:        settings,
+		navBadges:       s.navBadgesFor(r.Context(), auth.Email),
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.renderTemplate(w, "channel_settings.html", data); err != nil {
+		slog.Warn("render template", "url", r.URL.Path, "error", err)
+	}
+}
+
+// HandleUpdateChannelSettings saves a channel's settings. It accepts a form
+// post from the settings page or a JSON body.
+func (s *Server) HandleUpdateChannelSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auth := s.getAuthInfo(r)
+	channel := strings.ToLower(strings.TrimSpace(r.PathValue("name")))
+
+	if !auth.IsAuthenticated {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if channel == "" {
+		http.Error(w, "Channel is required", http.StatusBadRequest)
+		return
+	}
+
+	if !s.canManageChannelWithTwitch(ctx, auth.Email, auth.TwitchUsername, channel) {
+		RecordSecurityEvent(ctx, "permission_denied",
+			attribute.String("user.identity", auth.DisplayIdentity()),
+			attribute.String("path", r.URL.Path),
+			attribute.String("resource", "channel_settings"),
+			attribute.String("channel", channel),
+			attribute.String("reason", "not_authorized"),
+		)
+		http.Error(w, "You don't have permission to manage this channel", http.StatusForbidden)
+		return
+	}
+
+	isJSON := strings.HasPrefix(r.Header.Get("Content-Type"), "application/json")
+	var settings ChannelSettings
+	if isJSON {
+		if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Bad request", http.StatusBadRequest)
+			return
+		}
+		// Unchecked checkboxes are not submitted at all
+		settings.RequireModForSuggestions = r.FormValue("require_mod_for_suggestions") != ""
+	}
+	settings.Channel = channel
+
+	identity := auth.DisplayIdentity()
+	err := dbgen.New(s.WriteDB).UpsertChannelSettings(ctx, dbgen.UpsertChannelSettingsParams{
+		Channel:                  channel,
+		RequireModForSuggestions: settings.RequireModForSuggestions,
+		UpdatedAt:                s.Clock.Now(),
+		UpdatedBy:                &identity,
+	})
+	if err != nil {
+		slog.Error("update channel settings", "channel", channel, "error", err)
+		http.Error(w, "Failed to save settings", http.StatusInternalServerError)
+		return
+	}
+	s.channelSettings.invalidate(channel)
+
+	slog.Info("channel settings updated", "channel", channel,
+		"require_mod_for_suggestions", settings.RequireModForSuggestions, "user", identity)
+
+	if isJSON {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(settings)
+		return
+	}
+	http.Redirect(w, r, "/channels/"+url.PathEscape(channel)+"/settings?success=Settings+saved", http.StatusSeeOther)
+}
@@ -0,0 +1,220 @@
+package srv
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+)
+
+func TestChannelSettingsCache(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	cache := newChannelSettingsCache(clock.Now)
+	cache.set(ChannelSettings{Channel: "cached", RequireModForSuggestions: true})
+
+	clock.Advance(channelSettingsTTL - time.Second)
+	if got, ok := cache.get("cached"); !ok || !got.RequireModForSuggestions {
+		t.Errorf("expected a cache hit within the TTL, got %+v, %v", got, ok)
+	}
+
+	clock.Advance(2 * time.Second)
+	if _, ok := cache.get("cached"); ok {
+		t.Error("expected the entry to expire after the TTL")
+	}
+}
+
+func TestHandleChannelSettings(t *testing.T) {
+	get := func(server *Server, email, channel string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/channels/"+channel+"/settings", nil)
+		req.SetPathValue("name", channel)
+		req.Header.Set("Accept", "application/json")
+		if email != "" {
+			req.Header.Set("X-ExeDev-Email", email)
+		}
+		w := httptest.NewRecorder()
+		server.HandleChannelSettings(w, req)
+		return w
+	}
+
+	t.Run("redirects when not authenticated", func(t *testing.T) {
+		server := testServer(t)
+		if w := get(server, "", "ownerchannel"); w.Code != http.StatusSeeOther {
+			t.Errorf("expected 303, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 403 for non-owner", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "ownerchannel", "owner@test.com")
+		if w := get(server, "notowner@test.com", "ownerchannel"); w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("owner sees defaults", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "ownerchannel", "owner@test.com")
+
+		w := get(server, "owner@test.com", "ownerchannel")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		var settings ChannelSettings
+		if err := json.NewDecoder(w.Body).Decode(&settings); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if settings.Channel != "ownerchannel" || settings.RequireModForSuggestions {
+			t.Errorf("unexpected settings: %+v", settings)
+		}
+	})
+}
+
+// setRequireMod posts the settings form for channel as email.
+func setRequireMod(t *testing.T, server *Server, email, channel string, on bool) *httptest.ResponseRecorder {
+	t.Helper()
+	form := url.Values{}
+	if on {
+		form.Set("require_mod_for_suggestions", "true")
+	}
+	req := httptest.NewRequest(http.MethodPost, "/channels/"+channel+"/settings", strings.NewReader(form.Encode()))
+	req.SetPathValue("name", channel)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-ExeDev-Email", email)
+	w := httptest.NewRecorder()
+	server.HandleUpdateChannelSettings(w, req)
+	return w
+}
+
+func TestHandleUpdateChannelSettings(t *testing.T) {
+	t.Run("returns 403 for non-owner", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "ownerchannel", "owner@test.com")
+
+		if w := setRequireMod(t, server, "notowner@test.com", "ownerchannel", true); w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("owner can toggle setting", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "ownerchannel", "owner@test.com")
+		ctx := context.Background()
+
+		if w := setRequireMod(t, server, "owner@test.com", "ownerchannel", true); w.Code != http.StatusSeeOther {
+			t.Fatalf("expected 303, got %d", w.Code)
+		}
+		settings, err := server.getChannelSettings(ctx, "ownerchannel")
+		if err != nil || !settings.RequireModForSuggestions {
+			t.Fatalf("expected setting on, got %+v (err %v)", settings, err)
+		}
+
+		if w := setRequireMod(t, server, "owner@test.com", "ownerchannel", false); w.Code != http.StatusSeeOther {
+			t.Fatalf("expected 303, got %d", w.Code)
+		}
+		settings, err = server.getChannelSettings(ctx, "ownerchannel")
+		if err != nil || settings.RequireModForSuggestions {
+			t.Errorf("expected setting off after update, got %+v (err %v)", settings, err)
+		}
+	})
+
+	t.Run("caches settings between requests", func(t *testing.T) {
+		server := testServer(t)
+		ctx := context.Background()
+
+		if _, err := server.getChannelSettings(ctx, "cachedchannel"); err != nil {
+			t.Fatalf("get settings: %v", err)
+		}
+		// Write behind the cache's back; the cached value should still win
+		err := dbgen.New(server.WriteDB).UpsertChannelSettings(ctx, dbgen.UpsertChannelSettingsParams{
+			Channel:                  "cachedchannel",
+			RequireModForSuggestions: true,
+			UpdatedAt:                time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("upsert: %v", err)
+		}
+		settings, _ := server.getChannelSettings(ctx, "cachedchannel")
+		if settings.RequireModForSuggestions {
+			t.Error("expected cached value to be served")
+		}
+
+		server.channelSettings.invalidate("cachedchannel")
+		settings, _ = server.getChannelSettings(ctx, "cachedchannel")
+		if !settings.RequireModForSuggestions {
+			t.Error("expected fresh value after invalidation")
+		}
+	})
+}
+
+func TestHandleBotSuggestion_RequireMod(t *testing.T) {
+	suggest := func(server *Server, userLevel string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/suggest?text=A+quote+long+enough+to+pass", nil)
+		req.Header.Set("Nightbot-Channel", "name=ownerchannel&displayName=Owner&provider=twitch&providerId=123")
+		if userLevel != "" {
+			req.Header.Set("Nightbot-User", "name=viewer&displayName=Viewer&provider=twitch&providerId=456&userLevel="+userLevel)
+		}
+		w := httptest.NewRecorder()
+		server.HandleBotSuggestion(w, req)
+		return w
+	}
+
+	t.Run("anyone can suggest by default", func(t *testing.T) {
+		server := testServer(t)
+		if w := suggest(server, "everyone"); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "submitted") {
+			t.Errorf("expected submission, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("viewers are rejected when moderators are required", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "ownerchannel", "owner@test.com")
+		setRequireMod(t, server, "owner@test.com", "ownerchannel", true)
+
+		for _, level := range []string{"everyone", "subscriber", "regular", ""} {
+			w := suggest(server, level)
+			if w.Code != http.StatusOK {
+				t.Errorf("level %q: expected 200 for bot relay, got %d", level, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), "Only moderators") {
+				t.Errorf("level %q: expected friendly message, got: %s", level, w.Body.String())
+			}
+		}
+	})
+
+	t.Run("moderators and owner can suggest when required", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "ownerchannel", "owner@test.com")
+		setRequireMod(t, server, "owner@test.com", "ownerchannel", true)
+
+		for _, level := range []string{"moderator", "owner"} {
+			w := suggest(server, level)
+			if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "submitted") {
+				t.Errorf("level %q: expected submission, got %d: %s", level, w.Code, w.Body.String())
+			}
+		}
+	})
+
+	t.Run("StreamElements levels map to moderator", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "ownerchannel", "owner@test.com")
+		setRequireMod(t, server, "owner@test.com", "ownerchannel", true)
+
+		for level, allowed := range map[string]bool{"500": true, "1500": true, "250": false} {
+			req := httptest.NewRequest(http.MethodGet, "/api/suggest?text=A+quote+long+enough+to+pass", nil)
+			req.Header.Set("StreamElements-Channel", "ownerchannel")
+			req.Header.Set("StreamElements-User", "viewer")
+			req.Header.Set("StreamElements-User-Level", level)
+			w := httptest.NewRecorder()
+			server.HandleBotSuggestion(w, req)
+			if got := strings.Contains(w.Body.String(), "submitted"); got != allowed {
+				t.Errorf("level %s: submitted = %v, want %v: %s", level, got, allowed, w.Body.String())
+			}
+		}
+	})
+}
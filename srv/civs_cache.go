@@ -0,0 +1,69 @@
+package srv
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+)
+
+// loadCivsCache reads every civilization and rebuilds the lookup used by
+// resolveCivName. Names and shortnames are both keys, lowercased, matching
+// what ResolveCivName accepts.
+func (s *Server) loadCivsCache(ctx context.Context) error {
+	civs, err := dbgen.New(s.WriteDB).ListCivs(ctx)
+	if err != nil {
+		return fmt.Errorf("load civs cache: %w", err)
+	}
+
+	cache := make(map[string]string, len(civs)*2)
+	for _, civ := range civs {
+		cache[strings.ToLower(civ.Name)] = civ.Name
+	}
+	// Shortnames win over names, as with the SQL lookup
+	for _, civ := range civs {
+		if civ.Shortname != nil && *civ.Shortname != "" {
+			cache[strings.ToLower(*civ.Shortname)] = civ.Name
+		}
+	}
+
+	s.civsCacheMu.Lock()
+	s.civsCache = cache
+	s.civsCacheMu.Unlock()
+	return nil
+}
+
+// reloadCivsCache refreshes the civs cache after a civilization changes. On
+// failure the cache is dropped so lookups fall back to the database rather
+// than serve stale names.
+func (s *Server) reloadCivsCache() {
+	if err := s.loadCivsCache(context.Background()); err != nil {
+		slog.Error("reload civs cache", "error", err)
+		s.civsCacheMu.Lock()
+		s.civsCache = nil
+		s.civsCacheMu.Unlock()
+	}
+}
+
+// resolveCivName maps a civ name or shortname, in any case, to the full
+// civilization name. It returns sql.ErrNoRows for unknown input, like the
+// ResolveCivName query it replaces.
+func (s *Server) resolveCivName(ctx context.Context, input string) (string, error) {
+	s.civsCacheMu.RLock()
+	cache := s.civsCache
+	s.civsCacheMu.RUnlock()
+
+	if cache == nil {
+		return dbgen.New(s.ReadDB).ResolveCivName(ctx, dbgen.ResolveCivNameParams{
+			Shortname: &input,
+			LOWER:     input,
+		})
+	}
+	if name, ok := cache[strings.ToLower(input)]; ok {
+		return name, nil
+	}
+	return "", sql.ErrNoRows
+}
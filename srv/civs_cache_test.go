@@ -0,0 +1,146 @@
+package srv
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+)
+
+func TestResolveCivName(t *testing.T) {
+	server := testServer(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Holy Roman Empire", "Holy Roman Empire"},
+		{"holy roman empire", "Holy Roman Empire"},
+		{"hre", "Holy Roman Empire"},
+		{"HRE", "Holy Roman Empire"},
+	}
+	for _, tt := range tests {
+		got, err := server.resolveCivName(ctx, tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("resolveCivName(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	if _, err := server.resolveCivName(ctx, "notaciv"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows for unknown civ, got %v", err)
+	}
+
+	t.Run("falls back to the database without a cache", func(t *testing.T) {
+		server := testServer(t)
+		server.civsCache = nil
+
+		got, err := server.resolveCivName(ctx, "hre")
+		if err != nil || got != "Holy Roman Empire" {
+			t.Errorf("expected database lookup to resolve hre, got %q, %v", got, err)
+		}
+	})
+}
+
+func TestCivsCacheReloadsOnCivChanges(t *testing.T) {
+	server := testServer(t)
+	ctx := context.Background()
+
+	post := func(target, form string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-ExeDev-UserID", "admin123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /civs", server.HandleAddCiv)
+		mux.HandleFunc("POST /civs/{id}/edit", server.HandleEditCiv)
+		mux.HandleFunc("POST /civs/{id}/delete", server.HandleDeleteCiv)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		if loc := w.Header().Get("Location"); strings.Contains(loc, "error=") {
+			t.Fatalf("POST %s failed: %s", target, loc)
+		}
+	}
+
+	post("/civs", "name=Atlanteans&shortname=atl")
+	if got, err := server.resolveCivName(ctx, "atl"); err != nil || got != "Atlanteans" {
+		t.Fatalf("expected added civ to resolve, got %q, %v", got, err)
+	}
+
+	civ, err := dbgen.New(server.WriteDB).GetCivByName(ctx, "Atlanteans")
+	if err != nil {
+		t.Fatalf("get civ: %v", err)
+	}
+	post(fmt.Sprintf("/civs/%d/edit", civ.ID), "name=Atlanteans&shortname=atlantis")
+	if _, err := server.resolveCivName(ctx, "atl"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected old shortname to be gone after edit, got %v", err)
+	}
+	if got, err := server.resolveCivName(ctx, "atlantis"); err != nil || got != "Atlanteans" {
+		t.Errorf("expected new shortname to resolve, got %q, %v", got, err)
+	}
+
+	post(fmt.Sprintf("/civs/%d/delete", civ.ID), "")
+	if _, err := server.resolveCivName(ctx, "atlantis"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected deleted civ to be gone, got %v", err)
+	}
+}
+
+func TestCivsCacheConcurrentAccess(t *testing.T) {
+	server := testServer(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if got, err := server.resolveCivName(ctx, "hre"); err != nil || got != "Holy Roman Empire" {
+					t.Errorf("resolveCivName(hre) = %q, %v", got, err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				server.reloadCivsCache()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkResolveCivName(b *testing.B) {
+	server, err := New(b.TempDir()+"/bench.sqlite3", "bench", nil)
+	if err != nil {
+		b.Fatalf("create server: %v", err)
+	}
+	ctx := context.Background()
+	input := "hre"
+
+	b.Run("cache", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := server.resolveCivName(ctx, input); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("database", func(b *testing.B) {
+		q := dbgen.New(server.ReadDB)
+		for i := 0; i < b.N; i++ {
+			if _, err := q.ResolveCivName(ctx, dbgen.ResolveCivNameParams{Shortname: &input, LOWER: input}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,14 @@
+package srv
+
+import "time"
+
+// Clock tells the time. Server reads the current time through its Clock so
+// tests can pin it.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the wall clock used in production.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
@@ -3,29 +3,105 @@ package srv
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"log/slog"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// DefaultServiceName is the OpenTelemetry service name and Honeycomb dataset
+// used when OTEL_SERVICE_NAME is not set.
+const DefaultServiceName = "quoteqt"
+
+// Values for LOG_FORMAT
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// Bounds for SHUTDOWN_TIMEOUT. Values outside them fall back to the default.
+const (
+	MinShutdownTimeout = time.Second
+	MaxShutdownTimeout = 120 * time.Second
+)
+
 // Config holds all configurable server settings.
 type Config struct {
 	// Database
-	DBPath string
+	DBPath         string
+	DBMaxOpenConns int // SQLite allows one writer, so keep this at 1 unless read-heavy
+	// DBReadPath is an optional read-only copy of the database that public
+	// read endpoints query instead of DBPath
+	DBReadPath string
 
 	// Server
 	Hostname    string
 	AdminEmails []string
 
-	// API Rate Limiting
+	// HTTP server timeouts. WriteTimeout must exceed the slowest response,
+	// including streaming exports.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+
+	// GracefulShutdownTimeout bounds how long shutdown waits for in-flight
+	// requests and queued markers
+	GracefulShutdownTimeout time.Duration
+
+	// Transport security headers. HSTSMaxAge of 0 omits Strict-Transport-Security
+	// and ExpectCT of 0 omits Expect-CT. Preload lists require includeSubDomains
+	// and a max-age of at least a year.
+	HSTSMaxAge            int // seconds
+	HSTSIncludeSubDomains bool
+	HSTSPreload           bool
+	ExpectCT              time.Duration
+
+	// AllowedOrigins are the browser origins allowed to read the public API
+	// cross-origin (e.g. stream overlays). "*" allows any origin; empty
+	// disables CORS. Only GET and HEAD are ever allowed.
+	AllowedOrigins []string
+
+	// Observability
+	ServiceName     string // OpenTelemetry service name and Honeycomb dataset
+	HoneycombAPIKey string // enables tracing and markers when set
+
+	// Logging. LogFormat is "text" or "json"; JSON suits log aggregators
+	// like Loki or Datadog.
+	LogFormat string
+	LogLevel  slog.Level
+
+	// API Rate Limiting. Each route group has its own bucket: APIRate* covers
+	// the /api/ routes not listed under the random or list groups.
 	APIRateLimit    int           // requests per interval
 	APIRateInterval time.Duration // interval for rate limit
 	APIRateBurst    int           // max burst capacity
 
+	// RandomRate* limits the cheap single-quote lookups chat bots call
+	RandomRateLimit    int
+	RandomRateInterval time.Duration
+	RandomRateBurst    int
+
+	// ListRate* limits the endpoints that return many quotes or records
+	ListRateLimit    int
+	ListRateInterval time.Duration
+	ListRateBurst    int
+
+	// Validation
+	MinQuoteTextLen int // minimum quote length in characters
+
 	// Suggestion Rate Limiting
 	SuggestionRateLimit    int           // suggestions per interval per IP/channel
 	SuggestionRateInterval time.Duration // interval for suggestion rate limit
 
+	// Vote Rate Limiting, separate from the read API limit
+	VoteRateLimit    int           // votes per interval per IP
+	VoteRateInterval time.Duration // interval for vote rate limit
+
+	// RandomNoRepeatWindow is how many recently served quotes per channel
+	// /api/quote avoids repeating. 0 disables the check.
+	RandomNoRepeatWindow int
+
 	// Nightbot OAuth
 	NightbotClientID     string
 	NightbotClientSecret string
@@ -41,17 +117,51 @@ type Config struct {
 // DefaultConfig returns a Config with sensible defaults.
 func DefaultConfig() Config {
 	return Config{
-		DBPath:   "db.sqlite3",
-		Hostname: "localhost",
+		DBPath:         "db.sqlite3",
+		DBMaxOpenConns: 1,
+		Hostname:       "localhost",
+
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+		IdleTimeout:  120 * time.Second,
+
+		GracefulShutdownTimeout: 10 * time.Second,
+
+		HSTSMaxAge: 31536000, // 1 year
+
+		// The public API is read-only and unauthenticated, so overlays on
+		// any origin may read it
+		AllowedOrigins: []string{"*"},
+
+		ServiceName: DefaultServiceName,
+
+		LogFormat: LogFormatText,
+		LogLevel:  slog.LevelInfo,
 
 		// API: 30 requests per minute, burst of 10
 		APIRateLimit:    30,
 		APIRateInterval: time.Minute,
 		APIRateBurst:    10,
 
+		// Bot commands keep the old shared API budget; listings are tighter
+		RandomRateLimit:    30,
+		RandomRateInterval: time.Minute,
+		RandomRateBurst:    10,
+		ListRateLimit:      10,
+		ListRateInterval:   time.Minute,
+		ListRateBurst:      3,
+
+		MinQuoteTextLen: MinQuoteTextLen,
+
 		// Suggestions: 15 per hour
 		SuggestionRateLimit:    15,
 		SuggestionRateInterval: time.Hour,
+
+		// Votes: 10 per minute
+		VoteRateLimit:    10,
+		VoteRateInterval: time.Minute,
+
+		RandomNoRepeatWindow: 5,
 	}
 }
 
@@ -68,8 +178,104 @@ func ConfigFromEnv() Config {
 		cfg.DBPath = v
 	}
 
+	if v := os.Getenv("DB_READ_PATH"); v != "" {
+		cfg.DBReadPath = v
+	}
+
+	if v := os.Getenv("DB_MAX_OPEN_CONNS"); v != "" {
+		if n, ok := parsePositiveInt(v); ok {
+			cfg.DBMaxOpenConns = n
+		}
+	}
+
+	cfg.AdminEmails = ParseEmailList(os.Getenv("ADMIN_EMAILS"))
+
+	if v := os.Getenv("HTTP_READ_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.ReadTimeout = d
+		}
+	}
+
+	if v := os.Getenv("HTTP_WRITE_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.WriteTimeout = d
+		}
+	}
+
+	if v := os.Getenv("HTTP_IDLE_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.IdleTimeout = d
+		}
+	}
+
+	if v := os.Getenv("SHUTDOWN_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err == nil && d >= MinShutdownTimeout && d <= MaxShutdownTimeout {
+			cfg.GracefulShutdownTimeout = d
+		} else {
+			slog.Warn("invalid SHUTDOWN_TIMEOUT, using default",
+				"value", v, "min", MinShutdownTimeout, "max", MaxShutdownTimeout,
+				"default", cfg.GracefulShutdownTimeout)
+		}
+	}
+
+	if v := os.Getenv("HSTS_MAX_AGE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.HSTSMaxAge = n
+		}
+	}
+
+	if v := os.Getenv("HSTS_INCLUDE_SUBDOMAINS"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.HSTSIncludeSubDomains = b
+		}
+	}
+
+	if v := os.Getenv("HSTS_PRELOAD"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.HSTSPreload = b
+		}
+	}
+
+	if v := os.Getenv("EXPECT_CT_MAX_AGE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.ExpectCT = d
+		}
+	}
+
+	if v := os.Getenv("CORS_ALLOWED_ORIGINS"); v != "" {
+		if strings.EqualFold(strings.TrimSpace(v), "none") {
+			cfg.AllowedOrigins = nil
+		} else {
+			cfg.AllowedOrigins = splitCommaList(v)
+		}
+	}
+
+	if v := os.Getenv("OTEL_SERVICE_NAME"); v != "" {
+		cfg.ServiceName = v
+	}
+	cfg.HoneycombAPIKey = os.Getenv("HONEYCOMB_API_KEY")
+
+	if v := os.Getenv("LOG_FORMAT"); v != "" {
+		switch format := strings.ToLower(strings.TrimSpace(v)); format {
+		case LogFormatText, LogFormatJSON:
+			cfg.LogFormat = format
+		default:
+			slog.Warn("LOG_FORMAT must be text or json, using default", "value", v, "default", cfg.LogFormat)
+		}
+	}
+
+	if v := os.Getenv("LOG_LEVEL"); v != "" {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(strings.TrimSpace(v))); err == nil {
+			cfg.LogLevel = level
+		} else {
+			slog.Warn("invalid LOG_LEVEL, using default", "value", v, "default", cfg.LogLevel)
+		}
+	}
+
 	if v := os.Getenv("API_RATE_LIMIT"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+		if n, ok := parsePositiveInt(v); ok {
 			cfg.APIRateLimit = n
 		}
 	}
@@ -81,13 +287,55 @@ func ConfigFromEnv() Config {
 	}
 
 	if v := os.Getenv("API_RATE_BURST"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+		if n, ok := parsePositiveInt(v); ok {
 			cfg.APIRateBurst = n
 		}
 	}
 
+	if v := os.Getenv("RANDOM_RATE_LIMIT"); v != "" {
+		if n, ok := parsePositiveInt(v); ok {
+			cfg.RandomRateLimit = n
+		}
+	}
+
+	if v := os.Getenv("RANDOM_RATE_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.RandomRateInterval = d
+		}
+	}
+
+	if v := os.Getenv("RANDOM_RATE_BURST"); v != "" {
+		if n, ok := parsePositiveInt(v); ok {
+			cfg.RandomRateBurst = n
+		}
+	}
+
+	if v := os.Getenv("LIST_RATE_LIMIT"); v != "" {
+		if n, ok := parsePositiveInt(v); ok {
+			cfg.ListRateLimit = n
+		}
+	}
+
+	if v := os.Getenv("LIST_RATE_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.ListRateInterval = d
+		}
+	}
+
+	if v := os.Getenv("LIST_RATE_BURST"); v != "" {
+		if n, ok := parsePositiveInt(v); ok {
+			cfg.ListRateBurst = n
+		}
+	}
+
+	if v := os.Getenv("MIN_QUOTE_TEXT_LEN"); v != "" {
+		if n, ok := parsePositiveInt(v); ok {
+			cfg.MinQuoteTextLen = n
+		}
+	}
+
 	if v := os.Getenv("SUGGESTION_RATE_LIMIT"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+		if n, ok := parsePositiveInt(v); ok {
 			cfg.SuggestionRateLimit = n
 		}
 	}
@@ -98,6 +346,24 @@ func ConfigFromEnv() Config {
 		}
 	}
 
+	if v := os.Getenv("VOTE_RATE_LIMIT"); v != "" {
+		if n, ok := parsePositiveInt(v); ok {
+			cfg.VoteRateLimit = n
+		}
+	}
+
+	if v := os.Getenv("VOTE_RATE_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.VoteRateInterval = d
+		}
+	}
+
+	if v := os.Getenv("RANDOM_NO_REPEAT_WINDOW"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.RandomNoRepeatWindow = n
+		}
+	}
+
 	cfg.NightbotClientID = os.Getenv("NIGHTBOT_CLIENT_ID")
 	cfg.NightbotClientSecret = os.Getenv("NIGHTBOT_CLIENT_SECRET")
 	cfg.NightbotImportToken = os.Getenv("NIGHTBOT_IMPORT_TOKEN")
@@ -117,3 +383,31 @@ func ConfigFromEnv() Config {
 
 	return cfg
 }
+
+// parsePositiveInt parses a count from the environment. Zero, negative and
+// values that don't fit in 32 bits are rejected so callers keep their default.
+func parsePositiveInt(v string) (int, bool) {
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// ParseEmailList splits a comma-separated list of email addresses,
+// trimming whitespace and dropping empty entries.
+func ParseEmailList(s string) []string {
+	return splitCommaList(s)
+}
+
+// splitCommaList splits a comma-separated setting, trimming whitespace and
+// dropping empty entries.
+func splitCommaList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
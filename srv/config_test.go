@@ -1,7 +1,10 @@
 package srv
 
 import (
+	"log/slog"
+	"net/http"
 	"os"
+	"slices"
 	"testing"
 	"time"
 )
@@ -9,6 +12,9 @@ import (
 func TestDefaultConfig(t *testing.T) {
 	cfg := DefaultConfig()
 
+	if cfg.ServiceName != "quoteqt" {
+		t.Errorf("expected ServiceName quoteqt, got %s", cfg.ServiceName)
+	}
 	if cfg.APIRateLimit != 30 {
 		t.Errorf("expected APIRateLimit 30, got %d", cfg.APIRateLimit)
 	}
@@ -18,23 +24,96 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.APIRateBurst != 10 {
 		t.Errorf("expected APIRateBurst 10, got %d", cfg.APIRateBurst)
 	}
+	if cfg.RandomRateLimit != 30 || cfg.RandomRateInterval != time.Minute || cfg.RandomRateBurst != 10 {
+		t.Errorf("expected random limit 30/1m burst 10, got %d/%v burst %d",
+			cfg.RandomRateLimit, cfg.RandomRateInterval, cfg.RandomRateBurst)
+	}
+	if cfg.ListRateLimit != 10 || cfg.ListRateInterval != time.Minute || cfg.ListRateBurst != 3 {
+		t.Errorf("expected list limit 10/1m burst 3, got %d/%v burst %d",
+			cfg.ListRateLimit, cfg.ListRateInterval, cfg.ListRateBurst)
+	}
+	if cfg.DBMaxOpenConns != 1 {
+		t.Errorf("expected DBMaxOpenConns 1, got %d", cfg.DBMaxOpenConns)
+	}
+	if cfg.MinQuoteTextLen != 10 {
+		t.Errorf("expected MinQuoteTextLen 10, got %d", cfg.MinQuoteTextLen)
+	}
+	if cfg.GracefulShutdownTimeout != 10*time.Second {
+		t.Errorf("expected GracefulShutdownTimeout 10s, got %v", cfg.GracefulShutdownTimeout)
+	}
 	if cfg.SuggestionRateLimit != 15 {
 		t.Errorf("expected SuggestionRateLimit 15, got %d", cfg.SuggestionRateLimit)
 	}
 	if cfg.SuggestionRateInterval != time.Hour {
 		t.Errorf("expected SuggestionRateInterval 1h, got %v", cfg.SuggestionRateInterval)
 	}
+	if cfg.VoteRateLimit != 10 {
+		t.Errorf("expected VoteRateLimit 10, got %d", cfg.VoteRateLimit)
+	}
+	if cfg.VoteRateInterval != time.Minute {
+		t.Errorf("expected VoteRateInterval 1m, got %v", cfg.VoteRateInterval)
+	}
+	if cfg.RandomNoRepeatWindow != 5 {
+		t.Errorf("expected RandomNoRepeatWindow 5, got %d", cfg.RandomNoRepeatWindow)
+	}
+	if cfg.ReadTimeout != 10*time.Second {
+		t.Errorf("expected ReadTimeout 10s, got %v", cfg.ReadTimeout)
+	}
+	if cfg.WriteTimeout != 30*time.Second {
+		t.Errorf("expected WriteTimeout 30s, got %v", cfg.WriteTimeout)
+	}
+	if cfg.IdleTimeout != 120*time.Second {
+		t.Errorf("expected IdleTimeout 120s, got %v", cfg.IdleTimeout)
+	}
+	if cfg.HSTSMaxAge != 31536000 || cfg.HSTSPreload || cfg.HSTSIncludeSubDomains {
+		t.Errorf("expected 1 year HSTS without preload or subdomains, got %d/%v/%v",
+			cfg.HSTSMaxAge, cfg.HSTSPreload, cfg.HSTSIncludeSubDomains)
+	}
+	if cfg.ExpectCT != 0 {
+		t.Errorf("expected ExpectCT disabled, got %v", cfg.ExpectCT)
+	}
+	if len(cfg.AllowedOrigins) != 1 || cfg.AllowedOrigins[0] != "*" {
+		t.Errorf("expected AllowedOrigins [*], got %v", cfg.AllowedOrigins)
+	}
+	if cfg.LogFormat != LogFormatText || cfg.LogLevel != slog.LevelInfo {
+		t.Errorf("expected text logs at info, got %s/%v", cfg.LogFormat, cfg.LogLevel)
+	}
 }
 
 func TestConfigFromEnv(t *testing.T) {
 	// Save and restore environment
 	envVars := []string{
 		"DB_PATH",
+		"DB_READ_PATH",
+		"ADMIN_EMAILS",
+		"DB_MAX_OPEN_CONNS",
+		"OTEL_SERVICE_NAME",
+		"HONEYCOMB_API_KEY",
 		"API_RATE_LIMIT",
 		"API_RATE_INTERVAL",
 		"API_RATE_BURST",
+		"RANDOM_RATE_LIMIT",
+		"RANDOM_RATE_INTERVAL",
+		"RANDOM_RATE_BURST",
+		"LIST_RATE_LIMIT",
+		"LIST_RATE_INTERVAL",
+		"LIST_RATE_BURST",
+		"MIN_QUOTE_TEXT_LEN",
 		"SUGGESTION_RATE_LIMIT",
 		"SUGGESTION_RATE_INTERVAL",
+		"VOTE_RATE_LIMIT",
+		"VOTE_RATE_INTERVAL",
+		"RANDOM_NO_REPEAT_WINDOW",
+		"HTTP_READ_TIMEOUT",
+		"HTTP_WRITE_TIMEOUT",
+		"HTTP_IDLE_TIMEOUT",
+		"HSTS_MAX_AGE",
+		"HSTS_INCLUDE_SUBDOMAINS",
+		"HSTS_PRELOAD",
+		"EXPECT_CT_MAX_AGE",
+		"CORS_ALLOWED_ORIGINS",
+		"LOG_FORMAT",
+		"LOG_LEVEL",
 	}
 	original := make(map[string]string)
 	for _, k := range envVars {
@@ -62,21 +141,70 @@ func TestConfigFromEnv(t *testing.T) {
 		if cfg.APIRateLimit != defaults.APIRateLimit {
 			t.Errorf("expected default APIRateLimit")
 		}
+		if cfg.ServiceName != DefaultServiceName {
+			t.Errorf("expected default ServiceName, got %s", cfg.ServiceName)
+		}
+		if cfg.HoneycombAPIKey != "" {
+			t.Errorf("expected empty HoneycombAPIKey, got %s", cfg.HoneycombAPIKey)
+		}
+		if len(cfg.AdminEmails) != 0 {
+			t.Errorf("expected no AdminEmails, got %v", cfg.AdminEmails)
+		}
 	})
 
 	t.Run("overrides from env", func(t *testing.T) {
 		os.Setenv("DB_PATH", "custom.db")
+		os.Setenv("DB_READ_PATH", "/dev/shm/replica.db")
+		os.Setenv("DB_MAX_OPEN_CONNS", "4")
+		os.Setenv("ADMIN_EMAILS", " alice@example.com,,bob@example.com ")
+		os.Setenv("OTEL_SERVICE_NAME", "quoteqt-staging")
+		os.Setenv("HONEYCOMB_API_KEY", "test-key")
 		os.Setenv("API_RATE_LIMIT", "100")
 		os.Setenv("API_RATE_INTERVAL", "30s")
 		os.Setenv("API_RATE_BURST", "20")
+		os.Setenv("RANDOM_RATE_LIMIT", "60")
+		os.Setenv("RANDOM_RATE_INTERVAL", "10s")
+		os.Setenv("RANDOM_RATE_BURST", "15")
+		os.Setenv("LIST_RATE_LIMIT", "2")
+		os.Setenv("LIST_RATE_INTERVAL", "5m")
+		os.Setenv("LIST_RATE_BURST", "1")
+		os.Setenv("MIN_QUOTE_TEXT_LEN", "5")
 		os.Setenv("SUGGESTION_RATE_LIMIT", "10")
 		os.Setenv("SUGGESTION_RATE_INTERVAL", "2h")
+		os.Setenv("VOTE_RATE_LIMIT", "3")
+		os.Setenv("VOTE_RATE_INTERVAL", "5m")
+		os.Setenv("RANDOM_NO_REPEAT_WINDOW", "0")
+		os.Setenv("HTTP_READ_TIMEOUT", "5s")
+		os.Setenv("HTTP_WRITE_TIMEOUT", "2m")
+		os.Setenv("HTTP_IDLE_TIMEOUT", "10m")
+		os.Setenv("HSTS_MAX_AGE", "63072000")
+		os.Setenv("HSTS_INCLUDE_SUBDOMAINS", "true")
+		os.Setenv("HSTS_PRELOAD", "true")
+		os.Setenv("EXPECT_CT_MAX_AGE", "24h")
+		os.Setenv("CORS_ALLOWED_ORIGINS", "https://overlay.example.com, https://obs.example.com")
+		os.Setenv("LOG_FORMAT", "JSON")
+		os.Setenv("LOG_LEVEL", "debug")
 
 		cfg := ConfigFromEnv()
 
 		if cfg.DBPath != "custom.db" {
 			t.Errorf("expected DBPath custom.db, got %s", cfg.DBPath)
 		}
+		if cfg.DBReadPath != "/dev/shm/replica.db" {
+			t.Errorf("expected DBReadPath /dev/shm/replica.db, got %s", cfg.DBReadPath)
+		}
+		if cfg.DBMaxOpenConns != 4 {
+			t.Errorf("expected DBMaxOpenConns 4, got %d", cfg.DBMaxOpenConns)
+		}
+		if len(cfg.AdminEmails) != 2 || cfg.AdminEmails[0] != "alice@example.com" || cfg.AdminEmails[1] != "bob@example.com" {
+			t.Errorf("expected AdminEmails [alice@example.com bob@example.com], got %v", cfg.AdminEmails)
+		}
+		if cfg.ServiceName != "quoteqt-staging" {
+			t.Errorf("expected ServiceName quoteqt-staging, got %s", cfg.ServiceName)
+		}
+		if cfg.HoneycombAPIKey != "test-key" {
+			t.Errorf("expected HoneycombAPIKey test-key, got %s", cfg.HoneycombAPIKey)
+		}
 		if cfg.APIRateLimit != 100 {
 			t.Errorf("expected APIRateLimit 100, got %d", cfg.APIRateLimit)
 		}
@@ -86,18 +214,75 @@ func TestConfigFromEnv(t *testing.T) {
 		if cfg.APIRateBurst != 20 {
 			t.Errorf("expected APIRateBurst 20, got %d", cfg.APIRateBurst)
 		}
+		if cfg.RandomRateLimit != 60 || cfg.RandomRateInterval != 10*time.Second || cfg.RandomRateBurst != 15 {
+			t.Errorf("expected random limit 60/10s burst 15, got %d/%v burst %d",
+				cfg.RandomRateLimit, cfg.RandomRateInterval, cfg.RandomRateBurst)
+		}
+		if cfg.ListRateLimit != 2 || cfg.ListRateInterval != 5*time.Minute || cfg.ListRateBurst != 1 {
+			t.Errorf("expected list limit 2/5m burst 1, got %d/%v burst %d",
+				cfg.ListRateLimit, cfg.ListRateInterval, cfg.ListRateBurst)
+		}
+		if cfg.MinQuoteTextLen != 5 {
+			t.Errorf("expected MinQuoteTextLen 5, got %d", cfg.MinQuoteTextLen)
+		}
 		if cfg.SuggestionRateLimit != 10 {
 			t.Errorf("expected SuggestionRateLimit 10, got %d", cfg.SuggestionRateLimit)
 		}
 		if cfg.SuggestionRateInterval != 2*time.Hour {
 			t.Errorf("expected SuggestionRateInterval 2h, got %v", cfg.SuggestionRateInterval)
 		}
+		if cfg.VoteRateLimit != 3 {
+			t.Errorf("expected VoteRateLimit 3, got %d", cfg.VoteRateLimit)
+		}
+		if cfg.VoteRateInterval != 5*time.Minute {
+			t.Errorf("expected VoteRateInterval 5m, got %v", cfg.VoteRateInterval)
+		}
+		if cfg.RandomNoRepeatWindow != 0 {
+			t.Errorf("expected RandomNoRepeatWindow 0, got %d", cfg.RandomNoRepeatWindow)
+		}
+		if cfg.ReadTimeout != 5*time.Second {
+			t.Errorf("expected ReadTimeout 5s, got %v", cfg.ReadTimeout)
+		}
+		if cfg.WriteTimeout != 2*time.Minute {
+			t.Errorf("expected WriteTimeout 2m, got %v", cfg.WriteTimeout)
+		}
+		if cfg.IdleTimeout != 10*time.Minute {
+			t.Errorf("expected IdleTimeout 10m, got %v", cfg.IdleTimeout)
+		}
+		if cfg.HSTSMaxAge != 63072000 || !cfg.HSTSIncludeSubDomains || !cfg.HSTSPreload {
+			t.Errorf("expected HSTS 63072000 with subdomains and preload, got %d/%v/%v",
+				cfg.HSTSMaxAge, cfg.HSTSIncludeSubDomains, cfg.HSTSPreload)
+		}
+		if cfg.ExpectCT != 24*time.Hour {
+			t.Errorf("expected ExpectCT 24h, got %v", cfg.ExpectCT)
+		}
+		if len(cfg.AllowedOrigins) != 2 || cfg.AllowedOrigins[0] != "https://overlay.example.com" || cfg.AllowedOrigins[1] != "https://obs.example.com" {
+			t.Errorf("expected two AllowedOrigins, got %v", cfg.AllowedOrigins)
+		}
+		if cfg.LogFormat != LogFormatJSON {
+			t.Errorf("expected LogFormat json, got %s", cfg.LogFormat)
+		}
+		if cfg.LogLevel != slog.LevelDebug {
+			t.Errorf("expected LogLevel debug, got %v", cfg.LogLevel)
+		}
+	})
+
+	t.Run("none disables CORS", func(t *testing.T) {
+		os.Setenv("CORS_ALLOWED_ORIGINS", "none")
+		if cfg := ConfigFromEnv(); len(cfg.AllowedOrigins) != 0 {
+			t.Errorf("expected no AllowedOrigins, got %v", cfg.AllowedOrigins)
+		}
 	})
 
 	t.Run("invalid values use defaults", func(t *testing.T) {
 		os.Setenv("API_RATE_LIMIT", "invalid")
 		os.Setenv("API_RATE_INTERVAL", "bad")
 		os.Setenv("API_RATE_BURST", "-5")
+		os.Setenv("HTTP_READ_TIMEOUT", "soon")
+		os.Setenv("HSTS_MAX_AGE", "-1")
+		os.Setenv("HSTS_PRELOAD", "maybe")
+		os.Setenv("LOG_FORMAT", "xml")
+		os.Setenv("LOG_LEVEL", "loud")
 
 		cfg := ConfigFromEnv()
 		defaults := DefaultConfig()
@@ -111,5 +296,121 @@ func TestConfigFromEnv(t *testing.T) {
 		if cfg.APIRateBurst != defaults.APIRateBurst {
 			t.Errorf("expected default for invalid APIRateBurst")
 		}
+		if cfg.ReadTimeout != defaults.ReadTimeout {
+			t.Errorf("expected default for invalid ReadTimeout")
+		}
+		if cfg.HSTSMaxAge != defaults.HSTSMaxAge {
+			t.Errorf("expected default for invalid HSTSMaxAge")
+		}
+		if cfg.HSTSPreload != defaults.HSTSPreload {
+			t.Errorf("expected default for invalid HSTSPreload")
+		}
+		if cfg.LogFormat != defaults.LogFormat {
+			t.Errorf("expected default for invalid LogFormat")
+		}
+		if cfg.LogLevel != defaults.LogLevel {
+			t.Errorf("expected default for invalid LogLevel")
+		}
 	})
 }
+
+func TestConfigFromEnv_ShutdownTimeout(t *testing.T) {
+	defaultTimeout := DefaultConfig().GracefulShutdownTimeout
+
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"30s", 30 * time.Second},
+		{"1s", time.Second},       // minimum
+		{"2m", 120 * time.Second}, // maximum
+		{"999ms", defaultTimeout}, // below minimum
+		{"121s", defaultTimeout},  // above maximum
+		{"0", defaultTimeout},     // zero
+		{"-5s", defaultTimeout},   // negative
+		{"soon", defaultTimeout},  // unparseable
+		{"", defaultTimeout},      // unset
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("SHUTDOWN_TIMEOUT", tt.value)
+
+			cfg := ConfigFromEnv()
+
+			if cfg.GracefulShutdownTimeout != tt.want {
+				t.Errorf("SHUTDOWN_TIMEOUT=%q: expected %v, got %v", tt.value, tt.want, cfg.GracefulShutdownTimeout)
+			}
+		})
+	}
+}
+
+func TestConfigFromEnv_EdgeValues(t *testing.T) {
+	defaults := DefaultConfig()
+
+	tests := []struct {
+		name  string
+		key   string
+		value string
+		get   func(Config) any
+		want  any
+	}{
+		{"zero rate limit", "API_RATE_LIMIT", "0", func(c Config) any { return c.APIRateLimit }, defaults.APIRateLimit},
+		{"negative rate limit", "API_RATE_LIMIT", "-1", func(c Config) any { return c.APIRateLimit }, defaults.APIRateLimit},
+		{"overflowing rate limit", "API_RATE_LIMIT", "2147483648", func(c Config) any { return c.APIRateLimit }, defaults.APIRateLimit},
+		{"largest rate limit", "API_RATE_LIMIT", "2147483647", func(c Config) any { return c.APIRateLimit }, 2147483647},
+		{"zero rate interval", "API_RATE_INTERVAL", "0s", func(c Config) any { return c.APIRateInterval }, defaults.APIRateInterval},
+		{"negative rate interval", "API_RATE_INTERVAL", "-1m", func(c Config) any { return c.APIRateInterval }, defaults.APIRateInterval},
+		{"zero list rate limit", "LIST_RATE_LIMIT", "0", func(c Config) any { return c.ListRateLimit }, defaults.ListRateLimit},
+		{"negative random rate interval", "RANDOM_RATE_INTERVAL", "-1s", func(c Config) any { return c.RandomRateInterval }, defaults.RandomRateInterval},
+		{"zero burst", "API_RATE_BURST", "0", func(c Config) any { return c.APIRateBurst }, defaults.APIRateBurst},
+		{"zero suggestion limit", "SUGGESTION_RATE_LIMIT", "0", func(c Config) any { return c.SuggestionRateLimit }, defaults.SuggestionRateLimit},
+		{"negative no-repeat window", "RANDOM_NO_REPEAT_WINDOW", "-1", func(c Config) any { return c.RandomNoRepeatWindow }, defaults.RandomNoRepeatWindow},
+		{"overflowing max open conns", "DB_MAX_OPEN_CONNS", "99999999999", func(c Config) any { return c.DBMaxOpenConns }, defaults.DBMaxOpenConns},
+		{"empty DB path", "DB_PATH", "", func(c Config) any { return c.DBPath }, "db.sqlite3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+
+			got := tt.get(ConfigFromEnv())
+
+			if got != tt.want {
+				t.Errorf("%s=%q: expected %v, got %v", tt.key, tt.value, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewHTTPServer_AppliesTimeouts(t *testing.T) {
+	s := &Server{Config: DefaultConfig()}
+	hs := s.newHTTPServer(":0", http.NotFoundHandler())
+
+	if hs.ReadTimeout != 10*time.Second {
+		t.Errorf("expected ReadTimeout 10s, got %v", hs.ReadTimeout)
+	}
+	if hs.WriteTimeout != 30*time.Second {
+		t.Errorf("expected WriteTimeout 30s, got %v", hs.WriteTimeout)
+	}
+	if hs.IdleTimeout != 120*time.Second {
+		t.Errorf("expected IdleTimeout 120s, got %v", hs.IdleTimeout)
+	}
+}
+
+func TestSplitCommaList(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{" , ,", nil},
+		{"https://a.example.com", []string{"https://a.example.com"}},
+		{" https://A.example.com ,,https://b.example.com:8080 ", []string{"https://A.example.com", "https://b.example.com:8080"}},
+	}
+	for _, tt := range tests {
+		if got := splitCommaList(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("splitCommaList(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
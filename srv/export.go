@@ -0,0 +1,85 @@
+package srv
+
+import (
+	"encoding/csv"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// quoteCSVHeader is the header row of the quotes CSV export
+var quoteCSVHeader = []string{"id", "text", "author", "civilization", "opponent_civ", "channel", "created_by", "created_at"}
+
+// HandleQuotesExportCSV downloads quotes as CSV for backups. Admins get
+// every quote; channel owners get the quotes of the channels they own.
+func (s *Server) HandleQuotesExportCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auth := s.getAuthInfo(r)
+
+	if !auth.IsAuthenticated {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Redirect(w, r, "/auth/twitch?redirect="+url.QueryEscape(r.URL.String()), http.StatusSeeOther)
+		return
+	}
+
+	q := dbgen.New(s.WriteDB)
+	var quotes []dbgen.Quote
+	var err error
+	if auth.IsAdmin {
+		quotes, err = q.ListAllQuotes(ctx)
+	} else {
+		ownedChannels, ownedErr := s.getOwnedChannels(ctx, auth.Email)
+		if ownedErr != nil {
+			slog.Error("get owned channels", "error", ownedErr)
+		}
+		if len(ownedChannels) == 0 {
+			RecordSecurityEvent(ctx, "permission_denied",
+				attribute.String("user.identity", auth.DisplayIdentity()),
+				attribute.String("path", r.URL.Path),
+				attribute.String("reason", "no_owned_channels"),
+			)
+			http.Error(w, "Only admins and channel owners can export quotes", http.StatusForbidden)
+			return
+		}
+		channelPtrs := make([]*string, len(ownedChannels))
+		for i := range ownedChannels {
+			channelPtrs[i] = &ownedChannels[i]
+		}
+		quotes, err = q.ListQuotesByChannels(ctx, channelPtrs)
+	}
+	if err != nil {
+		slog.Error("list quotes for export", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="quotes.csv"`)
+
+	cw := csv.NewWriter(w)
+	cw.Write(quoteCSVHeader)
+	for _, quote := range quotes {
+		cw.Write([]string{
+			strconv.FormatInt(quote.ID, 10),
+			quote.Text,
+			stringVal(quote.Author),
+			stringVal(quote.Civilization),
+			stringVal(quote.OpponentCiv),
+			stringVal(quote.Channel),
+			stringVal(quote.CreatedByEmail),
+			quote.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		// Headers are already sent, so all we can do is log
+		slog.Error("write quotes csv", "error", err)
+	}
+}
@@ -0,0 +1,104 @@
+package srv
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestHandleQuotesExportCSV(t *testing.T) {
+	setup := func(t *testing.T) *Server {
+		server := testServer(t)
+		ch1, ch2 := "streamer1", "streamer2"
+		french := "French"
+		addTestQuote(t, server, "Wall early, then boom, \"always\"", &french, &ch1)
+		addTestQuote(t, server, "Line one\nline two", nil, &ch2)
+		addTestQuote(t, server, "Global quote for everyone", nil, nil)
+		return server
+	}
+	export := func(t *testing.T, server *Server, email string) (*httptest.ResponseRecorder, [][]string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/quotes/export.csv", nil)
+		if email != "" {
+			req.Header.Set("X-ExeDev-UserID", "user-1")
+			req.Header.Set("X-ExeDev-Email", email)
+		}
+		w := httptest.NewRecorder()
+		server.HandleQuotesExportCSV(w, req)
+		if w.Code != http.StatusOK {
+			return w, nil
+		}
+		records, err := csv.NewReader(w.Body).ReadAll()
+		if err != nil {
+			t.Fatalf("parse csv: %v", err)
+		}
+		return w, records
+	}
+	texts := func(records [][]string) []string {
+		var out []string
+		for _, rec := range records[1:] {
+			out = append(out, rec[1])
+		}
+		slices.Sort(out)
+		return out
+	}
+
+	t.Run("admin gets every quote", func(t *testing.T) {
+		server := setup(t)
+		w, records := export(t, server, "admin@test.com")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+			t.Errorf("expected text/csv, got %s", ct)
+		}
+		if cd := w.Header().Get("Content-Disposition"); cd != `attachment; filename="quotes.csv"` {
+			t.Errorf("unexpected Content-Disposition %s", cd)
+		}
+		if !slices.Equal(records[0], quoteCSVHeader) {
+			t.Errorf("expected header %v, got %v", quoteCSVHeader, records[0])
+		}
+		want := []string{"Global quote for everyone", "Line one\nline two", "Wall early, then boom, \"always\""}
+		if got := texts(records); !slices.Equal(got, want) {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("owner gets only owned channels", func(t *testing.T) {
+		server := setup(t)
+		addTestOwner(t, server, "streamer1", "owner@test.com")
+		w, records := export(t, server, "owner@test.com")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if len(records) != 2 {
+			t.Fatalf("expected header and 1 row, got %v", records)
+		}
+		row := records[1]
+		if row[1] != "Wall early, then boom, \"always\"" || row[3] != "French" || row[5] != "streamer1" {
+			t.Errorf("unexpected row %q", row)
+		}
+		if _, err := time.Parse(time.RFC3339, row[7]); err != nil {
+			t.Errorf("expected RFC3339 created_at, got %q", row[7])
+		}
+	})
+
+	t.Run("forbidden without owned channels", func(t *testing.T) {
+		server := setup(t)
+		w, _ := export(t, server, "nobody@test.com")
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("redirects when unauthenticated", func(t *testing.T) {
+		server := setup(t)
+		w, _ := export(t, server, "")
+		if w.Code != http.StatusSeeOther {
+			t.Errorf("expected 303, got %d", w.Code)
+		}
+	})
+}
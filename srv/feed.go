@@ -0,0 +1,149 @@
+package srv
+
+import (
+	"encoding/xml"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// feedItemLimit is how many quotes the RSS feed lists
+	feedItemLimit = 20
+	// feedTitleMaxRunes is where item titles are cut off
+	feedTitleMaxRunes = 80
+)
+
+// rssFeed is an RSS 2.0 document
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// baseURL returns the site's absolute URL without a trailing slash
+func (s *Server) baseURL() string {
+	scheme := "https"
+	if strings.Contains(s.Hostname, "localhost") {
+		scheme = "http"
+	}
+	return scheme + "://" + s.Hostname
+}
+
+// HandleFeed serves the most recently added quotes as an RSS 2.0 feed.
+// ?channel= limits the feed to one channel's quotes.
+func (s *Server) HandleFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := strings.TrimSpace(r.URL.Query().Get("channel"))
+	if err := ValidateChannel(channel); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	params := dbgen.ListRecentQuotesParams{Limit: feedItemLimit}
+	if channel != "" {
+		params.Channel = &channel
+	}
+	q := dbgen.New(s.WriteDB)
+	dbCtx, span := StartDBSpan(ctx, "ListRecentQuotes", attribute.String("channel", channel))
+	quotes, err := q.ListRecentQuotes(dbCtx, params)
+	if err != nil {
+		RecordError(span, err)
+		span.End()
+		slog.Error("list recent quotes", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	span.End()
+
+	base := s.baseURL()
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       "AoE4 Quote Database",
+			Link:        base + "/",
+			Description: "Recently added quotes",
+			Items:       make([]rssItem, 0, len(quotes)),
+		},
+	}
+	if channel != "" {
+		feed.Channel.Title += " - " + channel
+		feed.Channel.Link = base + "/browse?channel=" + url.QueryEscape(channel)
+		feed.Channel.Description = "Recently added quotes for " + channel
+	}
+	if len(quotes) > 0 {
+		feed.Channel.LastBuildDate = quotes[0].CreatedAt.UTC().Format(time.RFC1123Z)
+	}
+	for _, quote := range quotes {
+		link := base + "/api/quotes/" + strconv.FormatInt(quote.ID, 10)
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       truncateRunes(quote.Text, feedTitleMaxRunes),
+			Link:        link,
+			Description: feedItemDescription(quote),
+			GUID:        rssGUID{IsPermaLink: true, Value: link},
+			PubDate:     quote.CreatedAt.UTC().Format(time.RFC1123Z),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(feed); err != nil {
+		// Headers are already sent, so all we can do is log
+		slog.Error("write rss feed", "error", err)
+	}
+}
+
+// feedItemDescription is the full quote text followed by its author and
+// civ matchup, when known.
+func feedItemDescription(quote dbgen.Quote) string {
+	desc := quote.Text
+	if author := stringVal(quote.Author); author != "" {
+		desc += " — " + author
+	}
+	if civ := stringVal(quote.Civilization); civ != "" {
+		if opp := stringVal(quote.OpponentCiv); opp != "" {
+			civ += " vs " + opp
+		}
+		desc += " (" + civ + ")"
+	}
+	return desc
+}
+
+// truncateRunes shortens s to at most max runes, ending with an ellipsis
+// when it had to cut.
+func truncateRunes(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:max-1])) + "…"
+}
@@ -0,0 +1,135 @@
+package srv
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+)
+
+func TestHandleFeed(t *testing.T) {
+	getFeed := func(t *testing.T, server *Server, query string) rssFeed {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/feed.xml"+query, nil)
+		w := httptest.NewRecorder()
+		server.HandleFeed(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/rss+xml") {
+			t.Errorf("expected application/rss+xml, got %s", ct)
+		}
+		var feed rssFeed
+		if err := xml.Unmarshal(w.Body.Bytes(), &feed); err != nil {
+			t.Fatalf("feed is not valid XML: %v\n%s", err, w.Body.String())
+		}
+		if feed.Version != "2.0" {
+			t.Errorf("expected RSS version 2.0, got %q", feed.Version)
+		}
+		return feed
+	}
+
+	t.Run("escapes special characters and fills item fields", func(t *testing.T) {
+		server := testServer(t)
+		created := time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC)
+		text := `Build <walls> & "towers" early`
+		author, civ, opp := "Beasty & Co", "French", "English"
+		if _, err := dbgen.New(server.WriteDB).CreateQuote(context.Background(), dbgen.CreateQuoteParams{
+			Text:         text,
+			Author:       &author,
+			Civilization: &civ,
+			OpponentCiv:  &opp,
+			CreatedAt:    created,
+		}); err != nil {
+			t.Fatalf("create quote: %v", err)
+		}
+
+		feed := getFeed(t, server, "")
+		if len(feed.Channel.Items) != 1 {
+			t.Fatalf("expected 1 item, got %d", len(feed.Channel.Items))
+		}
+		item := feed.Channel.Items[0]
+		if item.Title != text {
+			t.Errorf("expected title %q, got %q", text, item.Title)
+		}
+		want := text + " — Beasty & Co (French vs English)"
+		if item.Description != want {
+			t.Errorf("expected description %q, got %q", want, item.Description)
+		}
+		pub, err := time.Parse(time.RFC1123Z, item.PubDate)
+		if err != nil {
+			t.Fatalf("pubDate %q is not RFC 1123: %v", item.PubDate, err)
+		}
+		if !pub.Equal(created) {
+			t.Errorf("expected pubDate %v, got %v", created, pub)
+		}
+		if !strings.HasSuffix(item.Link, "/api/quotes/1") || item.GUID.Value != item.Link {
+			t.Errorf("unexpected link %q / guid %q", item.Link, item.GUID.Value)
+		}
+	})
+
+	t.Run("truncates long titles", func(t *testing.T) {
+		server := testServer(t)
+		long := strings.Repeat("é", feedTitleMaxRunes+10)
+		addTestQuote(t, server, long, nil, nil)
+
+		item := getFeed(t, server, "").Channel.Items[0]
+		if n := len([]rune(item.Title)); n != feedTitleMaxRunes {
+			t.Errorf("expected title of %d runes, got %d", feedTitleMaxRunes, n)
+		}
+		if !strings.HasSuffix(item.Title, "…") {
+			t.Errorf("expected truncated title to end with an ellipsis, got %q", item.Title)
+		}
+		if item.Description != long {
+			t.Error("expected description to keep the full text")
+		}
+	})
+
+	t.Run("lists the newest quotes up to the limit", func(t *testing.T) {
+		server := testServer(t)
+		for i := range feedItemLimit + 5 {
+			addTestQuote(t, server, fmt.Sprintf("Quote number %d", i), nil, nil)
+		}
+
+		items := getFeed(t, server, "").Channel.Items
+		if len(items) != feedItemLimit {
+			t.Fatalf("expected %d items, got %d", feedItemLimit, len(items))
+		}
+		if want := fmt.Sprintf("Quote number %d", feedItemLimit+4); items[0].Title != want {
+			t.Errorf("expected newest quote first, got %q", items[0].Title)
+		}
+	})
+
+	t.Run("filters by channel", func(t *testing.T) {
+		server := testServer(t)
+		ch1, ch2 := "streamer1", "streamer2"
+		addTestQuote(t, server, "Streamer one quote", nil, &ch1)
+		addTestQuote(t, server, "Streamer two quote", nil, &ch2)
+		addTestQuote(t, server, "Global quote", nil, nil)
+
+		feed := getFeed(t, server, "?channel=streamer1")
+		if len(feed.Channel.Items) != 1 || feed.Channel.Items[0].Title != "Streamer one quote" {
+			t.Errorf("expected only streamer1's quote, got %+v", feed.Channel.Items)
+		}
+		if !strings.Contains(feed.Channel.Title, "streamer1") {
+			t.Errorf("expected channel in feed title, got %q", feed.Channel.Title)
+		}
+
+		if n := len(getFeed(t, server, "").Channel.Items); n != 3 {
+			t.Errorf("expected 3 items without a channel filter, got %d", n)
+		}
+	})
+
+	t.Run("empty feed is valid", func(t *testing.T) {
+		feed := getFeed(t, testServer(t), "")
+		if len(feed.Channel.Items) != 0 {
+			t.Errorf("expected no items, got %d", len(feed.Channel.Items))
+		}
+	})
+}
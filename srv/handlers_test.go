@@ -1,14 +1,23 @@
 package srv
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -32,8 +41,8 @@ func testServer(t *testing.T) *Server {
 // addTestQuote adds a quote to the test database
 func addTestQuote(t *testing.T, s *Server, text string, civ, channel *string) {
 	t.Helper()
-	q := dbgen.New(s.DB)
-	err := q.CreateQuote(context.Background(), dbgen.CreateQuoteParams{
+	q := dbgen.New(s.WriteDB)
+	_, err := q.CreateQuote(context.Background(), dbgen.CreateQuoteParams{
 		Text:         text,
 		Civilization: civ,
 		Channel:      channel,
@@ -46,12 +55,13 @@ func addTestQuote(t *testing.T, s *Server, text string, civ, channel *string) {
 // addTestCiv adds a civilization to the test database (ignores if already exists)
 func addTestCiv(t *testing.T, s *Server, name, shortname string) {
 	t.Helper()
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	_ = q.CreateCiv(context.Background(), dbgen.CreateCivParams{
 		Name:      name,
 		Shortname: &shortname,
 	})
 	// Ignore error - civ may already exist from migrations
+	s.reloadCivsCache()
 }
 
 func TestHandleRandomQuote(t *testing.T) {
@@ -87,6 +97,35 @@ func TestHandleRandomQuote(t *testing.T) {
 		}
 	})
 
+	t.Run("logs served quote for audit", func(t *testing.T) {
+		server := testServer(t)
+		channel := "testchannel"
+		addTestQuote(t, server, "Audited quote", nil, &channel)
+		quotes, _ := dbgen.New(server.WriteDB).ListAllQuotes(context.Background())
+		quoteID := quotes[0].ID
+
+		var buf bytes.Buffer
+		oldLogger := slog.Default()
+		slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+		defer slog.SetDefault(oldLogger)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/quote", nil)
+		req.Header.Set("Nightbot-Channel", "name=testchannel&displayName=TestChannel&provider=twitch&providerId=123")
+		w := httptest.NewRecorder()
+
+		server.HandleRandomQuote(w, req)
+
+		output := buf.String()
+		if !strings.Contains(output, "quote served") {
+			t.Fatalf("expected 'quote served' log, got: %s", output)
+		}
+		for _, want := range []string{fmt.Sprintf("quote_id=%d", quoteID), "channel=testchannel", "source=nightbot"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("expected log to contain %q, got: %s", want, output)
+			}
+		}
+	})
+
 	t.Run("filters by civ full name", func(t *testing.T) {
 		server := testServer(t)
 		hre := "Holy Roman Empire"
@@ -128,6 +167,48 @@ func TestHandleRandomQuote(t *testing.T) {
 		}
 	})
 
+	t.Run("supports Nightbot querystring format", func(t *testing.T) {
+		server := testServer(t)
+		hre := "Holy Roman Empire"
+		french := "French"
+		addTestQuote(t, server, "HRE querystring quote", &hre, nil)
+		addTestQuote(t, server, "French querystring quote", &french, nil)
+
+		// Nightbot sends: /api/quote?hre when a viewer types "!quote hre"
+		for _, target := range []string{"/api/quote?hre", "/api/quote?%20hre%20"} {
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			w := httptest.NewRecorder()
+
+			server.HandleRandomQuote(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Errorf("%s: expected 200, got %d", target, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), "HRE querystring quote") {
+				t.Errorf("%s: expected HRE quote, got: %s", target, w.Body.String())
+			}
+		}
+	})
+
+	t.Run("ignores querystring words alongside key=value params", func(t *testing.T) {
+		server := testServer(t)
+		french := "French"
+		addTestQuote(t, server, "French only quote", &french, nil)
+
+		// Neither the two-word query nor a stray word next to a real
+		// parameter is treated as a civ, so the unfiltered quote is served
+		for _, target := range []string{"/api/quote?hre%20french", "/api/quote?hre&format=text"} {
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			w := httptest.NewRecorder()
+
+			server.HandleRandomQuote(w, req)
+
+			if !strings.Contains(w.Body.String(), "French only quote") {
+				t.Errorf("%s: expected unfiltered quote, got: %s", target, w.Body.String())
+			}
+		}
+	})
+
 	t.Run("returns 200 with message for unknown civ", func(t *testing.T) {
 		server := testServer(t)
 		addTestQuote(t, server, "Some quote", nil, nil)
@@ -221,13 +302,161 @@ func TestHandleRandomQuote(t *testing.T) {
 			t.Errorf("expected text/plain, got %s", ct)
 		}
 	})
+	// randomQuoteIDs fetches n random quotes as JSON and returns their IDs
+	randomQuoteIDs := func(t *testing.T, server *Server, target string, n int) []int64 {
+		t.Helper()
+		var ids []int64
+		for range n {
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			req.Header.Set("Accept", "application/json")
+			w := httptest.NewRecorder()
+			server.HandleRandomQuote(w, req)
+			var resp QuoteResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			ids = append(ids, resp.ID)
+		}
+		return ids
+	}
+
+	t.Run("does not repeat within the window for a channel", func(t *testing.T) {
+		server := testServer(t)
+		channel := "streamer1"
+		for i := range 10 {
+			addTestQuote(t, server, fmt.Sprintf("Channel quote number %d", i), nil, &channel)
+		}
+		window := server.Config.RandomNoRepeatWindow
+
+		ids := randomQuoteIDs(t, server, "/api/quote?channel=streamer1", 50)
+		for i, id := range ids {
+			if slices.Contains(ids[max(0, i-window):i], id) {
+				t.Fatalf("quote %d repeated within %d calls: %v", id, window, ids)
+			}
+		}
+	})
+
+	t.Run("falls back to random when the pool fits in the window", func(t *testing.T) {
+		server := testServer(t)
+		for i := range 3 {
+			addTestQuote(t, server, fmt.Sprintf("Small pool quote %d", i), nil, nil)
+		}
+
+		ids := randomQuoteIDs(t, server, "/api/quote", 10)
+		for _, id := range ids {
+			if id == 0 {
+				t.Fatalf("expected a quote on every call, got %v", ids)
+			}
+		}
+	})
+
+	t.Run("keeps the random pick when the candidate lookup fails", func(t *testing.T) {
+		server := testServer(t)
+		for i := range 10 {
+			addTestQuote(t, server, fmt.Sprintf("Lookup failure quote %d", i), nil, nil)
+		}
+		server.ReadDB = failingDB(t, server.WriteDB, server.Config.DBPath, "ListRandomQuoteCandidateIDs")
+
+		ids := randomQuoteIDs(t, server, "/api/quote", 30)
+		for _, id := range ids {
+			if id == 0 {
+				t.Fatalf("expected a quote on every call, got %v", ids)
+			}
+		}
+	})
+}
+
+func TestQuoteResponseHeaders(t *testing.T) {
+	t.Run("plain text response carries quote metadata headers", func(t *testing.T) {
+		server := testServer(t)
+		civ := "French"
+		channel := "testchannel"
+		addTestQuote(t, server, "Header quote", &civ, &channel)
+		quotes, _ := dbgen.New(server.WriteDB).ListAllQuotes(context.Background())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/quote", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleRandomQuote(w, req)
+
+		want := map[string]string{
+			"X-Quote-ID":      fmt.Sprintf("%d", quotes[0].ID),
+			"X-Quote-Civ":     "French",
+			"X-Quote-Channel": "testchannel",
+		}
+		for name, value := range want {
+			if got := w.Header().Get(name); got != value {
+				t.Errorf("expected %s %q, got %q", name, value, got)
+			}
+		}
+		if got := w.Header().Get("X-Quote-Author"); got != "" {
+			t.Errorf("expected no X-Quote-Author for quote without author, got %q", got)
+		}
+		if got := w.Header().Get("X-No-Results"); got != "" {
+			t.Errorf("expected no X-No-Results header, got %q", got)
+		}
+	})
+
+	t.Run("JSON response omits quote metadata headers", func(t *testing.T) {
+		server := testServer(t)
+		civ := "French"
+		channel := "testchannel"
+		addTestQuote(t, server, "Header quote", &civ, &channel)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/quote", nil)
+		req.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+
+		server.HandleRandomQuote(w, req)
+
+		for _, name := range []string{"X-Quote-ID", "X-Quote-Civ", "X-Quote-Author", "X-Quote-Channel"} {
+			if got := w.Header().Get(name); got != "" {
+				t.Errorf("expected no %s header on JSON response, got %q", name, got)
+			}
+		}
+		var body map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to decode JSON: %v", err)
+		}
+		if _, ok := body["channel"]; ok {
+			t.Errorf("expected JSON body to be unchanged, got channel field: %v", body)
+		}
+	})
+
+	t.Run("plain text no results sets X-No-Results", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/api/quote", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleRandomQuote(w, req)
+
+		if got := w.Header().Get("X-No-Results"); got != "true" {
+			t.Errorf("expected X-No-Results true, got %q", got)
+		}
+		if got := w.Header().Get("X-Quote-ID"); got != "" {
+			t.Errorf("expected no X-Quote-ID on no results, got %q", got)
+		}
+	})
+
+	t.Run("JSON no results omits X-No-Results", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/api/quote", nil)
+		req.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+
+		server.HandleRandomQuote(w, req)
+
+		if got := w.Header().Get("X-No-Results"); got != "" {
+			t.Errorf("expected no X-No-Results on JSON response, got %q", got)
+		}
+	})
 }
 
 // addTestMatchupQuote adds a matchup quote to the test database
 func addTestMatchupQuote(t *testing.T, s *Server, text string, civ, opponentCiv string, channel *string) {
 	t.Helper()
-	q := dbgen.New(s.DB)
-	err := q.CreateQuote(context.Background(), dbgen.CreateQuoteParams{
+	q := dbgen.New(s.WriteDB)
+	_, err := q.CreateQuote(context.Background(), dbgen.CreateQuoteParams{
 		Text:         text,
 		Civilization: &civ,
 		OpponentCiv:  &opponentCiv,
@@ -346,6 +575,74 @@ func TestHandleMatchup(t *testing.T) {
 		}
 	})
 
+	t.Run("finds a tip stored for the reverse pairing", func(t *testing.T) {
+		server := testServer(t)
+		addTestMatchupQuote(t, server, "Wall against the knights", "Holy Roman Empire", "French", nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/matchup?civ=french&vs=hre", nil)
+		w := httptest.NewRecorder()
+		server.HandleMatchup(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if got, want := w.Body.String(), "(from the Holy Roman Empire side) Wall against the knights [Holy Roman Empire]\n"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/api/matchup?civ=french&vs=hre", nil)
+		req.Header.Set("Accept", "application/json")
+		w = httptest.NewRecorder()
+		server.HandleMatchup(w, req)
+
+		var resp QuoteResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if !resp.Reversed {
+			t.Error("expected reversed to be set")
+		}
+		if resp.Civilization == nil || *resp.Civilization != "Holy Roman Empire" {
+			t.Errorf("expected stored civilization, got %v", resp.Civilization)
+		}
+	})
+
+	t.Run("prefers tips written for the requested civ", func(t *testing.T) {
+		server := testServer(t)
+		addTestMatchupQuote(t, server, "Reverse tip", "Holy Roman Empire", "French", nil)
+		addTestMatchupQuote(t, server, "Forward tip", "French", "Holy Roman Empire", nil)
+
+		for i := 0; i < 10; i++ {
+			req := httptest.NewRequest(http.MethodGet, "/api/matchup?civ=french&vs=hre", nil)
+			req.Header.Set("Accept", "application/json")
+			w := httptest.NewRecorder()
+			server.HandleMatchup(w, req)
+
+			var resp QuoteResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Text != "Forward tip" || resp.Reversed {
+				t.Fatalf("expected the forward tip, got %+v", resp)
+			}
+		}
+	})
+
+	t.Run("reverse lookup keeps the channel scope", func(t *testing.T) {
+		server := testServer(t)
+		other := "otherstreamer"
+		addTestMatchupQuote(t, server, "Other channel tip", "Holy Roman Empire", "French", &other)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/matchup?french%20hre", nil)
+		req.Header.Set("Nightbot-Channel", "name=teststreamer&displayName=TestStreamer&provider=twitch&providerId=123")
+		w := httptest.NewRecorder()
+		server.HandleMatchup(w, req)
+
+		if strings.Contains(w.Body.String(), "Other channel tip") {
+			t.Errorf("did not expect another channel's tip, got: %s", w.Body.String())
+		}
+	})
+
 	t.Run("filters by channel", func(t *testing.T) {
 		server := testServer(t)
 		channel := "teststreamer"
@@ -444,7 +741,7 @@ func TestHandleAddQuote(t *testing.T) {
 	t.Run("channel owner can add quote to their channel", func(t *testing.T) {
 		server := testServer(t)
 		// Add channel owner
-		q := dbgen.New(server.DB)
+		q := dbgen.New(server.WriteDB)
 		err := q.AddChannelOwner(context.Background(), dbgen.AddChannelOwnerParams{
 			Channel:   "mychannel",
 			UserEmail: "owner@test.com",
@@ -524,6 +821,22 @@ func TestHandleAddQuote(t *testing.T) {
 		}
 	})
 
+	t.Run("validates minimum text length", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/quotes", strings.NewReader("text=ok"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-ExeDev-UserID", "admin123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleAddQuote(w, req)
+
+		loc := w.Header().Get("Location")
+		if !strings.Contains(loc, "error") || !strings.Contains(loc, "at+least") {
+			t.Errorf("expected redirect with minimum length error, got: %s", loc)
+		}
+	})
+
 	t.Run("stores all fields correctly", func(t *testing.T) {
 		server := testServer(t)
 		formData := "text=Full+quote&author=TestAuthor&civilization=English&opponent_civ=French"
@@ -540,7 +853,7 @@ func TestHandleAddQuote(t *testing.T) {
 		}
 
 		// Verify quote was stored
-		q := dbgen.New(server.DB)
+		q := dbgen.New(server.WriteDB)
 		quotes, err := q.ListAllQuotes(context.Background())
 		if err != nil {
 			t.Fatalf("failed to list quotes: %v", err)
@@ -564,294 +877,322 @@ func TestHandleAddQuote(t *testing.T) {
 	})
 }
 
-func TestHandleDeleteQuote(t *testing.T) {
-	t.Run("returns 401 when not authenticated", func(t *testing.T) {
+// addTestOwner makes email an owner of channel in the test database
+func addTestOwner(t *testing.T, s *Server, channel, email string) {
+	t.Helper()
+	q := dbgen.New(s.WriteDB)
+	err := q.AddChannelOwner(context.Background(), dbgen.AddChannelOwnerParams{
+		Channel:   channel,
+		UserEmail: email,
+		InvitedBy: "admin@test.com",
+	})
+	if err != nil {
+		t.Fatalf("failed to add channel owner: %v", err)
+	}
+}
+
+func TestHandleQuotes(t *testing.T) {
+	ownerRequest := func(target string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("X-ExeDev-UserID", "owner123")
+		req.Header.Set("X-ExeDev-Email", "owner@test.com")
+		return req
+	}
+
+	t.Run("single owner sees only their channel", func(t *testing.T) {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/quotes/1/delete", nil)
-		req.SetPathValue("id", "1")
-		w := httptest.NewRecorder()
+		addTestOwner(t, server, "alpha", "owner@test.com")
+		alpha, other := "alpha", "other"
+		addTestQuote(t, server, "Alpha channel quote", nil, &alpha)
+		addTestQuote(t, server, "Other channel quote", nil, &other)
 
-		server.HandleDeleteQuote(w, req)
+		w := httptest.NewRecorder()
+		server.HandleQuotes(w, ownerRequest("/quotes"))
 
-		if w.Code != http.StatusUnauthorized {
-			t.Errorf("expected 401, got %d", w.Code)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		body := w.Body.String()
+		if !strings.Contains(body, "Alpha channel quote") {
+			t.Error("expected own channel quote in response")
+		}
+		if strings.Contains(body, "Other channel quote") {
+			t.Error("did not expect other channel quote in response")
+		}
+		if strings.Contains(body, `id="channelSelect"`) {
+			t.Error("did not expect channel selector for single channel owner")
 		}
 	})
 
-	t.Run("returns 400 for invalid ID", func(t *testing.T) {
+	t.Run("multi owner sees all owned channels combined", func(t *testing.T) {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/quotes/abc/delete", nil)
-		req.SetPathValue("id", "abc")
-		req.Header.Set("X-ExeDev-UserID", "user123")
-		req.Header.Set("X-ExeDev-Email", "admin@test.com")
-		w := httptest.NewRecorder()
+		addTestOwner(t, server, "alpha", "owner@test.com")
+		addTestOwner(t, server, "beta", "owner@test.com")
+		alpha, beta, other := "alpha", "beta", "other"
+		addTestQuote(t, server, "Alpha channel quote", nil, &alpha)
+		addTestQuote(t, server, "Beta channel quote", nil, &beta)
+		addTestQuote(t, server, "Other channel quote", nil, &other)
 
-		server.HandleDeleteQuote(w, req)
+		w := httptest.NewRecorder()
+		server.HandleQuotes(w, ownerRequest("/quotes"))
 
-		if w.Code != http.StatusBadRequest {
-			t.Errorf("expected 400, got %d", w.Code)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		body := w.Body.String()
+		if !strings.Contains(body, "Alpha channel quote") || !strings.Contains(body, "Beta channel quote") {
+			t.Error("expected quotes from both owned channels")
+		}
+		if strings.Contains(body, "Other channel quote") {
+			t.Error("did not expect other channel quote in response")
+		}
+		if !strings.Contains(body, `id="channelSelect"`) {
+			t.Error("expected channel selector for multi-channel owner")
 		}
 	})
 
-	t.Run("returns 404 for non-existent quote", func(t *testing.T) {
+	t.Run("multi owner can select one channel", func(t *testing.T) {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/quotes/99999/delete", nil)
-		req.SetPathValue("id", "99999")
-		req.Header.Set("X-ExeDev-UserID", "user123")
-		req.Header.Set("X-ExeDev-Email", "admin@test.com")
-		w := httptest.NewRecorder()
+		addTestOwner(t, server, "alpha", "owner@test.com")
+		addTestOwner(t, server, "beta", "owner@test.com")
+		alpha, beta := "alpha", "beta"
+		addTestQuote(t, server, "Alpha channel quote", nil, &alpha)
+		addTestQuote(t, server, "Beta channel quote", nil, &beta)
 
-		server.HandleDeleteQuote(w, req)
+		w := httptest.NewRecorder()
+		server.HandleQuotes(w, ownerRequest("/quotes?channel=beta"))
 
-		if w.Code != http.StatusNotFound {
-			t.Errorf("expected 404, got %d", w.Code)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		body := w.Body.String()
+		if !strings.Contains(body, "Beta channel quote") {
+			t.Error("expected selected channel quote in response")
+		}
+		if strings.Contains(body, "Alpha channel quote") {
+			t.Error("did not expect unselected channel quote in response")
 		}
 	})
 
-	t.Run("returns 403 when user cannot manage channel", func(t *testing.T) {
+	t.Run("returns 403 when selecting unowned channel", func(t *testing.T) {
 		server := testServer(t)
-		channel := "somechannel"
-		addTestQuote(t, server, "Quote to delete", nil, &channel)
-
-		// Get the quote ID
-		q := dbgen.New(server.DB)
-		quotes, _ := q.ListAllQuotes(context.Background())
-		quoteID := quotes[0].ID
+		addTestOwner(t, server, "alpha", "owner@test.com")
 
-		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/quotes/%d/delete", quoteID), nil)
-		req.SetPathValue("id", fmt.Sprintf("%d", quoteID))
-		req.Header.Set("X-ExeDev-UserID", "user123")
-		req.Header.Set("X-ExeDev-Email", "notowner@test.com")
 		w := httptest.NewRecorder()
-
-		server.HandleDeleteQuote(w, req)
+		server.HandleQuotes(w, ownerRequest("/quotes?channel=other"))
 
 		if w.Code != http.StatusForbidden {
 			t.Errorf("expected 403, got %d", w.Code)
 		}
 	})
 
-	t.Run("admin can delete any quote", func(t *testing.T) {
+	t.Run("admin can select any channel", func(t *testing.T) {
 		server := testServer(t)
-		channel := "anychannel"
-		addTestQuote(t, server, "Admin delete test", nil, &channel)
-
-		q := dbgen.New(server.DB)
-		quotes, _ := q.ListAllQuotes(context.Background())
-		quoteID := quotes[0].ID
+		alpha, other := "alpha", "other"
+		addTestQuote(t, server, "Alpha channel quote", nil, &alpha)
+		addTestQuote(t, server, "Other channel quote", nil, &other)
 
-		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/quotes/%d/delete", quoteID), nil)
-		req.SetPathValue("id", fmt.Sprintf("%d", quoteID))
+		req := httptest.NewRequest(http.MethodGet, "/quotes?channel=other", nil)
 		req.Header.Set("X-ExeDev-UserID", "admin123")
 		req.Header.Set("X-ExeDev-Email", "admin@test.com")
 		w := httptest.NewRecorder()
+		server.HandleQuotes(w, req)
 
-		server.HandleDeleteQuote(w, req)
-
-		if w.Code != http.StatusSeeOther {
-			t.Errorf("expected 303 redirect, got %d", w.Code)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
 		}
-
-		// Verify quote was deleted
-		_, err := q.GetQuoteByID(context.Background(), quoteID)
-		if !errors.Is(err, sql.ErrNoRows) {
-			t.Errorf("expected quote to be deleted, got err: %v", err)
+		body := w.Body.String()
+		if !strings.Contains(body, "Other channel quote") || strings.Contains(body, "Alpha channel quote") {
+			t.Error("expected only the selected channel's quotes")
+		}
+		if !strings.Contains(body, `<option value="other" selected>`) {
+			t.Error("expected selected channel to be marked in the dropdown")
+		}
+		if !strings.Contains(body, "Clear filter") {
+			t.Error("expected clear filter link when a channel is selected")
 		}
 	})
 
-	t.Run("channel owner can delete quote from their channel", func(t *testing.T) {
+	t.Run("admin sees all channels in selector by default", func(t *testing.T) {
 		server := testServer(t)
-		channel := "ownerchannel"
-		addTestQuote(t, server, "Owner delete test", nil, &channel)
+		alpha, other := "alpha", "other"
+		addTestQuote(t, server, "Alpha channel quote", nil, &alpha)
+		addTestQuote(t, server, "Other channel quote", nil, &other)
+		addTestQuote(t, server, "Global quote", nil, nil)
 
-		// Add channel owner
-		q := dbgen.New(server.DB)
-		_ = q.AddChannelOwner(context.Background(), dbgen.AddChannelOwnerParams{
-			Channel:   channel,
-			UserEmail: "owner@test.com",
-		})
+		req := httptest.NewRequest(http.MethodGet, "/quotes", nil)
+		req.Header.Set("X-ExeDev-UserID", "admin123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+		server.HandleQuotes(w, req)
 
-		quotes, _ := q.ListAllQuotes(context.Background())
-		quoteID := quotes[0].ID
-
-		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/quotes/%d/delete", quoteID), nil)
-		req.SetPathValue("id", fmt.Sprintf("%d", quoteID))
-		req.Header.Set("X-ExeDev-UserID", "owner123")
-		req.Header.Set("X-ExeDev-Email", "owner@test.com")
-		w := httptest.NewRecorder()
-
-		server.HandleDeleteQuote(w, req)
-
-		if w.Code != http.StatusSeeOther {
-			t.Errorf("expected 303 redirect, got %d", w.Code)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
 		}
-	})
-}
-
-func TestHandleSubmitSuggestion(t *testing.T) {
-	t.Run("returns 400 for invalid JSON", func(t *testing.T) {
-		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/api/suggestions", strings.NewReader("not json"))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		server.HandleSubmitSuggestion(w, req)
-
-		if w.Code != http.StatusBadRequest {
-			t.Errorf("expected 400, got %d", w.Code)
+		body := w.Body.String()
+		for _, want := range []string{"Alpha channel quote", "Other channel quote", "Global quote"} {
+			if !strings.Contains(body, want) {
+				t.Errorf("expected %q in unfiltered admin list", want)
+			}
 		}
-	})
-
-	t.Run("returns 400 when text is empty", func(t *testing.T) {
-		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/api/suggestions", strings.NewReader(`{"text":"","channel":"test"}`))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		server.HandleSubmitSuggestion(w, req)
-
-		if w.Code != http.StatusBadRequest {
-			t.Errorf("expected 400, got %d", w.Code)
+		if !strings.Contains(body, `id="channelSelect"`) {
+			t.Fatal("expected channel selector for admin")
+		}
+		for _, want := range []string{`<option value="alpha">`, `<option value="other">`} {
+			if !strings.Contains(body, want) {
+				t.Errorf("expected selector option %s", want)
+			}
 		}
-		if !strings.Contains(w.Body.String(), "Text is required") {
-			t.Errorf("expected 'Text is required', got: %s", w.Body.String())
+		if strings.Contains(body, "Clear filter") {
+			t.Error("did not expect clear filter link without a selection")
 		}
 	})
 
-	t.Run("returns 400 when channel is empty", func(t *testing.T) {
+	t.Run("single owner has their channel pre-selected", func(t *testing.T) {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/api/suggestions", strings.NewReader(`{"text":"test quote","channel":""}`))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
+		addTestOwner(t, server, "alpha", "owner@test.com")
+		alpha := "alpha"
+		addTestQuote(t, server, "Alpha channel quote", nil, &alpha)
+		addTestQuote(t, server, "Global quote", nil, nil)
 
-		server.HandleSubmitSuggestion(w, req)
+		w := httptest.NewRecorder()
+		server.HandleQuotes(w, ownerRequest("/quotes"))
 
-		if w.Code != http.StatusBadRequest {
-			t.Errorf("expected 400, got %d", w.Code)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
 		}
-		if !strings.Contains(w.Body.String(), "Channel is required") {
-			t.Errorf("expected 'Channel is required', got: %s", w.Body.String())
+		body := w.Body.String()
+		if !strings.Contains(body, "Alpha channel quote") || strings.Contains(body, "Global quote") {
+			t.Error("expected only the owned channel's quotes")
 		}
 	})
 
-	t.Run("returns 400 when text too long", func(t *testing.T) {
+	t.Run("returns 400 for overlong channel", func(t *testing.T) {
 		server := testServer(t)
-		longText := strings.Repeat("a", 501)
-		body := fmt.Sprintf(`{"text":"%s","channel":"test"}`, longText)
-		req := httptest.NewRequest(http.MethodPost, "/api/suggestions", strings.NewReader(body))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
 
-		server.HandleSubmitSuggestion(w, req)
+		req := httptest.NewRequest(http.MethodGet, "/quotes?channel="+strings.Repeat("a", MaxChannelLen+1), nil)
+		req.Header.Set("X-ExeDev-UserID", "admin123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+		server.HandleQuotes(w, req)
 
 		if w.Code != http.StatusBadRequest {
 			t.Errorf("expected 400, got %d", w.Code)
 		}
-		if !strings.Contains(w.Body.String(), "too long") {
-			t.Errorf("expected 'too long' error, got: %s", w.Body.String())
-		}
 	})
+}
 
-	t.Run("creates suggestion successfully", func(t *testing.T) {
+func TestHandleQuotes_JSON(t *testing.T) {
+	setup := func(t *testing.T) *Server {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/api/suggestions", strings.NewReader(`{"text":"Great quote!","channel":"testchannel"}`))
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		server.HandleSubmitSuggestion(w, req)
-
-		if w.Code != http.StatusCreated {
-			t.Errorf("expected 201, got %d", w.Code)
+		addTestOwner(t, server, "alpha", "owner@test.com")
+		alpha, other := "alpha", "other"
+		addTestQuote(t, server, "Alpha channel quote", nil, &alpha)
+		addTestQuote(t, server, "Other channel quote", nil, &other)
+		return server
+	}
+	quotesRequest := func(email, accept string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/quotes", nil)
+		if email != "" {
+			req.Header.Set("X-ExeDev-UserID", "user123")
+			req.Header.Set("X-ExeDev-Email", email)
 		}
-		if !strings.Contains(w.Body.String(), "Suggestion submitted") {
-			t.Errorf("expected success message, got: %s", w.Body.String())
+		if accept != "" {
+			req.Header.Set("Accept", accept)
 		}
-
-		// Verify suggestion was created
-		q := dbgen.New(server.DB)
-		suggestions, err := q.ListPendingSuggestions(context.Background())
-		if err != nil {
-			t.Fatalf("failed to list suggestions: %v", err)
+		return req
+	}
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) QuoteListResponse {
+		t.Helper()
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
 		}
-		if len(suggestions) != 1 {
-			t.Fatalf("expected 1 suggestion, got %d", len(suggestions))
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Fatalf("expected application/json, got %q", ct)
 		}
-		if suggestions[0].Text != "Great quote!" {
-			t.Errorf("expected text 'Great quote!', got %s", suggestions[0].Text)
+		var resp QuoteListResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode response: %v", err)
 		}
-	})
+		return resp
+	}
+	texts := func(resp QuoteListResponse) []string {
+		var out []string
+		for _, q := range resp.Quotes {
+			out = append(out, q.Text)
+		}
+		slices.Sort(out)
+		return out
+	}
 
-	t.Run("returns JSON response", func(t *testing.T) {
-		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/api/suggestions", strings.NewReader(`{"text":"JSON test","channel":"ch"}`))
-		req.Header.Set("Content-Type", "application/json")
+	t.Run("admin gets every quote", func(t *testing.T) {
+		server := setup(t)
 		w := httptest.NewRecorder()
+		server.HandleQuotes(w, quotesRequest("admin@test.com", "application/json"))
 
-		server.HandleSubmitSuggestion(w, req)
-
-		ct := w.Header().Get("Content-Type")
-		if !strings.Contains(ct, "application/json") {
-			t.Errorf("expected application/json, got %s", ct)
+		resp := decode(t, w)
+		if !resp.IsAdmin {
+			t.Error("expected is_admin true")
+		}
+		if got := texts(resp); !slices.Equal(got, []string{"Alpha channel quote", "Other channel quote"}) {
+			t.Errorf("unexpected quotes: %v", got)
 		}
 	})
 
-	t.Run("tracks submitter email when authenticated", func(t *testing.T) {
-		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/api/suggestions", strings.NewReader(`{"text":"Auth quote","channel":"ch"}`))
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("X-ExeDev-Email", "submitter@test.com")
+	t.Run("owner gets only their channel", func(t *testing.T) {
+		server := setup(t)
 		w := httptest.NewRecorder()
+		server.HandleQuotes(w, quotesRequest("owner@test.com", "application/json"))
 
-		server.HandleSubmitSuggestion(w, req)
-
-		if w.Code != http.StatusCreated {
-			t.Errorf("expected 201, got %d", w.Code)
+		resp := decode(t, w)
+		if resp.IsAdmin {
+			t.Error("expected is_admin false")
 		}
-
-		// Verify submitter was recorded
-		q := dbgen.New(server.DB)
-		suggestions, _ := q.ListPendingSuggestions(context.Background())
-		if len(suggestions) == 0 {
-			t.Fatal("expected suggestion")
+		if !slices.Equal(resp.OwnedChannels, []string{"alpha"}) {
+			t.Errorf("expected owned_channels [alpha], got %v", resp.OwnedChannels)
 		}
-		if suggestions[0].SubmittedByUser == nil || *suggestions[0].SubmittedByUser != "submitter@test.com" {
-			t.Errorf("expected submitter email, got %v", suggestions[0].SubmittedByUser)
+		if got := texts(resp); !slices.Equal(got, []string{"Alpha channel quote"}) {
+			t.Errorf("unexpected quotes: %v", got)
 		}
 	})
-}
 
-// addTestSuggestion adds a suggestion to the test database
-func addTestSuggestion(t *testing.T, s *Server, text, channel string) int64 {
-	t.Helper()
-	q := dbgen.New(s.DB)
-	err := q.CreateSuggestion(context.Background(), dbgen.CreateSuggestionParams{
-		Text:          text,
-		Channel:       channel,
-		SubmittedByIp: "127.0.0.1",
-		SubmittedAt:   time.Now(),
+	t.Run("HTML is still the default", func(t *testing.T) {
+		server := setup(t)
+		for _, email := range []string{"admin@test.com", "owner@test.com"} {
+			w := httptest.NewRecorder()
+			server.HandleQuotes(w, quotesRequest(email, "text/html"))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("%s: expected 200, got %d", email, w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+				t.Errorf("%s: expected HTML, got %q", email, ct)
+			}
+			if !strings.Contains(w.Body.String(), "Alpha channel quote") {
+				t.Errorf("%s: expected quote in page", email)
+			}
+		}
 	})
-	if err != nil {
-		t.Fatalf("failed to create suggestion: %v", err)
-	}
-	// Get the ID
-	suggestions, _ := q.ListPendingSuggestions(context.Background())
-	for _, s := range suggestions {
-		if s.Text == text {
-			return s.ID
+
+	t.Run("returns 401 instead of redirecting when not authenticated", func(t *testing.T) {
+		server := setup(t)
+		w := httptest.NewRecorder()
+		server.HandleQuotes(w, quotesRequest("", "application/json"))
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
 		}
-	}
-	t.Fatal("suggestion not found")
-	return 0
+	})
 }
 
-func TestHandleApproveSuggestion(t *testing.T) {
+func TestHandleDeleteQuote(t *testing.T) {
 	t.Run("returns 401 when not authenticated", func(t *testing.T) {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/suggestions/1/approve", nil)
+		req := httptest.NewRequest(http.MethodPost, "/quotes/1/delete", nil)
 		req.SetPathValue("id", "1")
 		w := httptest.NewRecorder()
 
-		server.HandleApproveSuggestion(w, req)
+		server.HandleDeleteQuote(w, req)
 
 		if w.Code != http.StatusUnauthorized {
 			t.Errorf("expected 401, got %d", w.Code)
@@ -860,28 +1201,28 @@ func TestHandleApproveSuggestion(t *testing.T) {
 
 	t.Run("returns 400 for invalid ID", func(t *testing.T) {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/suggestions/abc/approve", nil)
+		req := httptest.NewRequest(http.MethodPost, "/quotes/abc/delete", nil)
 		req.SetPathValue("id", "abc")
 		req.Header.Set("X-ExeDev-UserID", "user123")
 		req.Header.Set("X-ExeDev-Email", "admin@test.com")
 		w := httptest.NewRecorder()
 
-		server.HandleApproveSuggestion(w, req)
+		server.HandleDeleteQuote(w, req)
 
 		if w.Code != http.StatusBadRequest {
 			t.Errorf("expected 400, got %d", w.Code)
 		}
 	})
 
-	t.Run("returns 404 for non-existent suggestion", func(t *testing.T) {
+	t.Run("returns 404 for non-existent quote", func(t *testing.T) {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/suggestions/99999/approve", nil)
+		req := httptest.NewRequest(http.MethodPost, "/quotes/99999/delete", nil)
 		req.SetPathValue("id", "99999")
 		req.Header.Set("X-ExeDev-UserID", "user123")
 		req.Header.Set("X-ExeDev-Email", "admin@test.com")
 		w := httptest.NewRecorder()
 
-		server.HandleApproveSuggestion(w, req)
+		server.HandleDeleteQuote(w, req)
 
 		if w.Code != http.StatusNotFound {
 			t.Errorf("expected 404, got %d", w.Code)
@@ -890,71 +1231,77 @@ func TestHandleApproveSuggestion(t *testing.T) {
 
 	t.Run("returns 403 when user cannot manage channel", func(t *testing.T) {
 		server := testServer(t)
-		sugID := addTestSuggestion(t, server, "Suggestion to approve", "somechannel")
+		channel := "somechannel"
+		addTestQuote(t, server, "Quote to delete", nil, &channel)
 
-		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/suggestions/%d/approve", sugID), nil)
-		req.SetPathValue("id", fmt.Sprintf("%d", sugID))
+		// Get the quote ID
+		q := dbgen.New(server.WriteDB)
+		quotes, _ := q.ListAllQuotes(context.Background())
+		quoteID := quotes[0].ID
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/quotes/%d/delete", quoteID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", quoteID))
 		req.Header.Set("X-ExeDev-UserID", "user123")
 		req.Header.Set("X-ExeDev-Email", "notowner@test.com")
 		w := httptest.NewRecorder()
 
-		server.HandleApproveSuggestion(w, req)
+		server.HandleDeleteQuote(w, req)
 
 		if w.Code != http.StatusForbidden {
 			t.Errorf("expected 403, got %d", w.Code)
 		}
 	})
 
-	t.Run("admin can approve suggestion and creates quote", func(t *testing.T) {
+	t.Run("admin can delete any quote", func(t *testing.T) {
 		server := testServer(t)
-		sugID := addTestSuggestion(t, server, "Admin approved suggestion", "testchannel")
+		channel := "anychannel"
+		addTestQuote(t, server, "Admin delete test", nil, &channel)
 
-		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/suggestions/%d/approve", sugID), nil)
-		req.SetPathValue("id", fmt.Sprintf("%d", sugID))
+		q := dbgen.New(server.WriteDB)
+		quotes, _ := q.ListAllQuotes(context.Background())
+		quoteID := quotes[0].ID
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/quotes/%d/delete", quoteID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", quoteID))
 		req.Header.Set("X-ExeDev-UserID", "admin123")
 		req.Header.Set("X-ExeDev-Email", "admin@test.com")
 		w := httptest.NewRecorder()
 
-		server.HandleApproveSuggestion(w, req)
+		server.HandleDeleteQuote(w, req)
 
 		if w.Code != http.StatusSeeOther {
 			t.Errorf("expected 303 redirect, got %d", w.Code)
 		}
 
-		// Verify quote was created
-		q := dbgen.New(server.DB)
-		quotes, _ := q.ListAllQuotes(context.Background())
-		found := false
-		for _, quote := range quotes {
-			if quote.Text == "Admin approved suggestion" {
-				found = true
-				break
-			}
-		}
-		if !found {
-			t.Error("expected quote to be created from suggestion")
+		// Verify quote was deleted
+		_, err := q.GetQuoteByID(context.Background(), quoteID)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected quote to be deleted, got err: %v", err)
 		}
 	})
 
-	t.Run("channel owner can approve suggestion for their channel", func(t *testing.T) {
+	t.Run("channel owner can delete quote from their channel", func(t *testing.T) {
 		server := testServer(t)
 		channel := "ownerchannel"
-		sugID := addTestSuggestion(t, server, "Owner approved", channel)
+		addTestQuote(t, server, "Owner delete test", nil, &channel)
 
 		// Add channel owner
-		q := dbgen.New(server.DB)
+		q := dbgen.New(server.WriteDB)
 		_ = q.AddChannelOwner(context.Background(), dbgen.AddChannelOwnerParams{
 			Channel:   channel,
 			UserEmail: "owner@test.com",
 		})
 
-		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/suggestions/%d/approve", sugID), nil)
-		req.SetPathValue("id", fmt.Sprintf("%d", sugID))
+		quotes, _ := q.ListAllQuotes(context.Background())
+		quoteID := quotes[0].ID
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/quotes/%d/delete", quoteID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", quoteID))
 		req.Header.Set("X-ExeDev-UserID", "owner123")
 		req.Header.Set("X-ExeDev-Email", "owner@test.com")
 		w := httptest.NewRecorder()
 
-		server.HandleApproveSuggestion(w, req)
+		server.HandleDeleteQuote(w, req)
 
 		if w.Code != http.StatusSeeOther {
 			t.Errorf("expected 303 redirect, got %d", w.Code)
@@ -962,583 +1309,4466 @@ func TestHandleApproveSuggestion(t *testing.T) {
 	})
 }
 
-func TestHandleBotSuggestion(t *testing.T) {
-	t.Run("returns 400 when no channel header", func(t *testing.T) {
+func TestHandleDeleteQuoteAPI(t *testing.T) {
+	setup := func(t *testing.T) (*Server, http.Handler, int64) {
+		t.Helper()
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodGet, "/api/suggest?text=test+quote", nil)
+		channel := "apichannel"
+		addTestQuote(t, server, "API delete test", nil, &channel)
+		quotes, err := dbgen.New(server.WriteDB).ListAllQuotes(context.Background())
+		if err != nil || len(quotes) != 1 {
+			t.Fatalf("expected 1 seeded quote, got %d (%v)", len(quotes), err)
+		}
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /api/quote/{id}", server.HandleGetQuote)
+		mux.HandleFunc("DELETE /api/quotes/{id}", server.HandleDeleteQuoteAPI)
+		return server, server.APILimiter.Middleware(mux), quotes[0].ID
+	}
+
+	deleteAs := func(handler http.Handler, email string, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, "/api/quotes/"+id, nil)
+		if email != "" {
+			req.Header.Set("X-ExeDev-UserID", "user123")
+			req.Header.Set("X-ExeDev-Email", email)
+		}
 		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
 
-		server.HandleBotSuggestion(w, req)
+	t.Run("admin deletes quote and it is gone", func(t *testing.T) {
+		_, handler, id := setup(t)
 
-		if w.Code != http.StatusBadRequest {
-			t.Errorf("expected 400, got %d", w.Code)
+		w := deleteAs(handler, "admin@test.com", fmt.Sprintf("%d", id))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
 		}
-		if !strings.Contains(w.Body.String(), "channel") {
-			t.Errorf("expected channel error, got: %s", w.Body.String())
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("expected application/json, got %q", ct)
+		}
+		var resp DeleteQuoteResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if !resp.Deleted || resp.ID != id {
+			t.Errorf("expected {deleted: true, id: %d}, got %+v", id, resp)
 		}
-	})
 
-	t.Run("returns 400 when no text", func(t *testing.T) {
-		server := testServer(t)
-		req := httptest.NewRequest(http.MethodGet, "/api/suggest", nil)
-		req.Header.Set("Nightbot-Channel", "name=testchannel&displayName=Test&provider=twitch&providerId=123")
-		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/quote/%d", id), nil)
+		get := httptest.NewRecorder()
+		handler.ServeHTTP(get, req)
+		if get.Code != http.StatusGone {
+			t.Errorf("expected deleted quote to return 410, got %d", get.Code)
+		}
+	})
 
-		server.HandleBotSuggestion(w, req)
+	t.Run("owner can delete own channel quote", func(t *testing.T) {
+		server, handler, id := setup(t)
+		addTestOwner(t, server, "apichannel", "owner@test.com")
 
-		if w.Code != http.StatusBadRequest {
-			t.Errorf("expected 400, got %d", w.Code)
-		}
-		if !strings.Contains(w.Body.String(), "Usage") {
-			t.Errorf("expected usage message, got: %s", w.Body.String())
+		if w := deleteAs(handler, "owner@test.com", fmt.Sprintf("%d", id)); w.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", w.Code)
 		}
 	})
 
-	t.Run("returns 400 when text too short", func(t *testing.T) {
-		server := testServer(t)
-		req := httptest.NewRequest(http.MethodGet, "/api/suggest?text=ab", nil)
-		req.Header.Set("Nightbot-Channel", "name=testchannel&displayName=Test&provider=twitch&providerId=123")
-		w := httptest.NewRecorder()
-
-		server.HandleBotSuggestion(w, req)
+	t.Run("returns 403 when user cannot manage channel", func(t *testing.T) {
+		server, handler, id := setup(t)
 
-		if w.Code != http.StatusBadRequest {
-			t.Errorf("expected 400, got %d", w.Code)
+		if w := deleteAs(handler, "notowner@test.com", fmt.Sprintf("%d", id)); w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
 		}
-		if !strings.Contains(w.Body.String(), "too short") {
-			t.Errorf("expected 'too short', got: %s", w.Body.String())
+		if _, err := dbgen.New(server.WriteDB).GetQuoteByID(context.Background(), id); err != nil {
+			t.Errorf("expected quote to survive forbidden delete: %v", err)
 		}
 	})
 
-	t.Run("creates suggestion with Nightbot header", func(t *testing.T) {
-		server := testServer(t)
-		req := httptest.NewRequest(http.MethodGet, "/api/suggest?text=Bot+suggested+quote", nil)
-		req.Header.Set("Nightbot-Channel", "name=botchannel&displayName=BotChannel&provider=twitch&providerId=123")
-		w := httptest.NewRecorder()
-
-		server.HandleBotSuggestion(w, req)
+	t.Run("returns 401 when not authenticated", func(t *testing.T) {
+		_, handler, id := setup(t)
 
-		if w.Code != http.StatusOK {
-			t.Errorf("expected 200, got %d", w.Code)
-		}
-		if !strings.Contains(w.Body.String(), "submitted") {
-			t.Errorf("expected success message, got: %s", w.Body.String())
+		if w := deleteAs(handler, "", fmt.Sprintf("%d", id)); w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
 		}
+	})
 
-		// Verify suggestion was created with correct channel
-		q := dbgen.New(server.DB)
-		suggestions, _ := q.ListPendingSuggestionsByChannel(context.Background(), "botchannel")
+	t.Run("returns 404 for unknown quote", func(t *testing.T) {
+		_, handler, _ := setup(t)
+
+		if w := deleteAs(handler, "admin@test.com", "99999"); w.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 400 for invalid ID", func(t *testing.T) {
+		_, handler, _ := setup(t)
+
+		if w := deleteAs(handler, "admin@test.com", "abc"); w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+}
+
+func bulkRequest(t *testing.T, server *Server, email string, body BulkRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	payload, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("marshal bulk request: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/quotes/bulk", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	if email != "" {
+		req.Header.Set("X-ExeDev-UserID", "user123")
+		req.Header.Set("X-ExeDev-Email", email)
+	}
+	w := httptest.NewRecorder()
+	server.HandleBulkQuotes(w, req)
+	return w
+}
+
+func TestHandleBulkQuotes(t *testing.T) {
+	// setup creates three quotes in ownerchannel and one in otherchannel,
+	// returning their IDs in insertion order.
+	setup := func(t *testing.T) (*Server, []int64) {
+		server := testServer(t)
+		owned, other := "ownerchannel", "otherchannel"
+		addTestQuote(t, server, "Bulk one", nil, &owned)
+		addTestQuote(t, server, "Bulk two", nil, &owned)
+		addTestQuote(t, server, "Bulk three", nil, &owned)
+		addTestQuote(t, server, "Bulk other", nil, &other)
+		addTestOwner(t, server, owned, "owner@test.com")
+
+		quotes, err := dbgen.New(server.WriteDB).ListAllQuotes(context.Background())
+		if err != nil {
+			t.Fatalf("list quotes: %v", err)
+		}
+		byText := make(map[string]int64)
+		for _, q := range quotes {
+			byText[q.Text] = q.ID
+		}
+		return server, []int64{byText["Bulk one"], byText["Bulk two"], byText["Bulk three"], byText["Bulk other"]}
+	}
+
+	civOf := func(t *testing.T, server *Server, id int64) string {
+		t.Helper()
+		quote, err := dbgen.New(server.WriteDB).GetQuoteByID(context.Background(), id)
+		if err != nil {
+			t.Fatalf("get quote %d: %v", id, err)
+		}
+		if quote.Civilization == nil {
+			return ""
+		}
+		return *quote.Civilization
+	}
+
+	t.Run("returns 401 when not authenticated", func(t *testing.T) {
+		server, ids := setup(t)
+		w := bulkRequest(t, server, "", BulkRequest{IDs: ids[:1], Action: "delete"})
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("owner can update quotes in their channel", func(t *testing.T) {
+		server, ids := setup(t)
+		w := bulkRequest(t, server, "owner@test.com", BulkRequest{IDs: ids[:3], Action: "civilization", Value: "English"})
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		for _, id := range ids[:3] {
+			if got := civOf(t, server, id); got != "English" {
+				t.Errorf("quote %d: expected English, got %q", id, got)
+			}
+		}
+	})
+
+	t.Run("returns 403 when any quote is in an unmanaged channel", func(t *testing.T) {
+		server, ids := setup(t)
+		w := bulkRequest(t, server, "owner@test.com", BulkRequest{IDs: ids, Action: "civilization", Value: "English"})
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d", w.Code)
+		}
+		for _, id := range ids {
+			if got := civOf(t, server, id); got != "" {
+				t.Errorf("quote %d: expected no change, got %q", id, got)
+			}
+		}
+	})
+
+	t.Run("returns 403 when quotes fall outside the requested channel", func(t *testing.T) {
+		server, ids := setup(t)
+		w := bulkRequest(t, server, "admin@test.com", BulkRequest{IDs: ids, Action: "delete", Channel: "ownerchannel"})
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d", w.Code)
+		}
+		count, _ := dbgen.New(server.WriteDB).CountQuotes(context.Background())
+		if count != 4 {
+			t.Errorf("expected 4 quotes to remain, got %d", count)
+		}
+	})
+
+	channelOf := func(t *testing.T, server *Server, id int64) string {
+		t.Helper()
+		quote, err := dbgen.New(server.WriteDB).GetQuoteByID(context.Background(), id)
+		if err != nil {
+			t.Fatalf("get quote %d: %v", id, err)
+		}
+		if quote.Channel == nil {
+			return ""
+		}
+		return *quote.Channel
+	}
+
+	t.Run("returns 403 when moving quotes into an unmanaged channel", func(t *testing.T) {
+		server, ids := setup(t)
+		w := bulkRequest(t, server, "owner@test.com", BulkRequest{IDs: ids[:3], Action: "channel", Value: "otherchannel"})
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d", w.Code)
+		}
+		for _, id := range ids[:3] {
+			if got := channelOf(t, server, id); got != "ownerchannel" {
+				t.Errorf("quote %d: expected ownerchannel, got %q", id, got)
+			}
+		}
+	})
+
+	t.Run("returns 403 when a non-admin clears the channel", func(t *testing.T) {
+		server, ids := setup(t)
+		for _, req := range []BulkRequest{
+			{IDs: ids[:3], Action: "clear-channel"},
+			{IDs: ids[:3], Action: "channel", Value: ""},
+		} {
+			w := bulkRequest(t, server, "owner@test.com", req)
+			if w.Code != http.StatusForbidden {
+				t.Fatalf("%s %q: expected 403, got %d", req.Action, req.Value, w.Code)
+			}
+		}
+		for _, id := range ids[:3] {
+			if got := channelOf(t, server, id); got != "ownerchannel" {
+				t.Errorf("quote %d: expected ownerchannel, got %q", id, got)
+			}
+		}
+	})
+
+	t.Run("admin can move quotes between channels", func(t *testing.T) {
+		server, ids := setup(t)
+		w := bulkRequest(t, server, "admin@test.com", BulkRequest{IDs: ids[:3], Action: "channel", Value: "otherchannel"})
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		for _, id := range ids[:3] {
+			if got := channelOf(t, server, id); got != "otherchannel" {
+				t.Errorf("quote %d: expected otherchannel, got %q", id, got)
+			}
+		}
+	})
+
+	t.Run("admin can update quotes across channels", func(t *testing.T) {
+		server, ids := setup(t)
+		w := bulkRequest(t, server, "admin@test.com", BulkRequest{IDs: ids, Action: "delete"})
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		count, _ := dbgen.New(server.WriteDB).CountQuotes(context.Background())
+		if count != 0 {
+			t.Errorf("expected all quotes deleted, got %d", count)
+		}
+	})
+
+	t.Run("set-author and clear-author update the author", func(t *testing.T) {
+		server, ids := setup(t)
+		authorOf := func(id int64) *string {
+			quote, err := dbgen.New(server.WriteDB).GetQuoteByID(context.Background(), id)
+			if err != nil {
+				t.Fatalf("get quote %d: %v", id, err)
+			}
+			return quote.Author
+		}
+
+		w := bulkRequest(t, server, "owner@test.com", BulkRequest{IDs: ids[:2], Action: "set-author", Value: " Beasty "})
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		for _, id := range ids[:2] {
+			if got := authorOf(id); got == nil || *got != "Beasty" {
+				t.Errorf("quote %d: expected author Beasty, got %v", id, got)
+			}
+		}
+		if got := authorOf(ids[2]); got != nil {
+			t.Errorf("unselected quote: expected no author, got %q", *got)
+		}
+
+		w = bulkRequest(t, server, "owner@test.com", BulkRequest{IDs: ids[:1], Action: "clear-author"})
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if got := authorOf(ids[0]); got != nil {
+			t.Errorf("expected author cleared, got %q", *got)
+		}
+		if got := authorOf(ids[1]); got == nil || *got != "Beasty" {
+			t.Errorf("expected other author untouched, got %v", got)
+		}
+	})
+
+	t.Run("set-author rejects empty and overlong values", func(t *testing.T) {
+		server, ids := setup(t)
+		for _, value := range []string{"  ", strings.Repeat("a", MaxAuthorLen+1)} {
+			w := bulkRequest(t, server, "owner@test.com", BulkRequest{IDs: ids[:1], Action: "set-author", Value: value})
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("value of length %d: expected 400, got %d", len(value), w.Code)
+			}
+		}
+	})
+
+	t.Run("rolls back every change when the action fails mid-way", func(t *testing.T) {
+		server, ids := setup(t)
+		// Fail the update on the last selected quote, after earlier rows
+		// have already been written.
+		_, err := server.WriteDB.Exec(fmt.Sprintf(`CREATE TRIGGER fail_bulk BEFORE UPDATE ON quotes
+			WHEN NEW.id = %d BEGIN SELECT RAISE(ABORT, 'injected failure'); END`, ids[2]))
+		if err != nil {
+			t.Fatalf("create trigger: %v", err)
+		}
+
+		w := bulkRequest(t, server, "owner@test.com", BulkRequest{IDs: ids[:3], Action: "civilization", Value: "English"})
+		if w.Code != http.StatusInternalServerError {
+			t.Fatalf("expected 500, got %d", w.Code)
+		}
+		for _, id := range ids[:3] {
+			if got := civOf(t, server, id); got != "" {
+				t.Errorf("quote %d: expected rollback, got %q", id, got)
+			}
+		}
+	})
+}
+
+// submitSuggestion posts a JSON suggestion body from the given client IP.
+func submitSuggestion(t *testing.T, server *Server, body, ip string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/suggestions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = ip + ":12345"
+	w := httptest.NewRecorder()
+	server.HandleSubmitSuggestion(w, req)
+	return w
+}
+
+func TestHandleSubmitSuggestion(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantBody    string
+		wantPending int
+	}{
+		{
+			name:       "invalid JSON",
+			body:       "not json",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing text",
+			body:       `{"text":"","channel":"test"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "Text is required",
+		},
+		{
+			name:       "missing channel",
+			body:       `{"text":"test quote","channel":""}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "Channel is required",
+		},
+		{
+			name:       "text over 500 chars",
+			body:       fmt.Sprintf(`{"text":"%s","channel":"test"}`, strings.Repeat("a", 501)),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "too long",
+		},
+		{
+			name:        "valid body creates suggestion",
+			body:        `{"text":"Great quote!","channel":"testchannel"}`,
+			wantStatus:  http.StatusCreated,
+			wantBody:    "Suggestion submitted",
+			wantPending: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testServer(t)
+
+			w := submitSuggestion(t, server, tt.body, "192.0.2.1")
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantBody != "" && !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("expected %q in body, got: %s", tt.wantBody, w.Body.String())
+			}
+
+			q := dbgen.New(server.WriteDB)
+			suggestions, err := q.ListPendingSuggestions(context.Background())
+			if err != nil {
+				t.Fatalf("failed to list suggestions: %v", err)
+			}
+			if len(suggestions) != tt.wantPending {
+				t.Errorf("expected %d pending suggestions, got %d", tt.wantPending, len(suggestions))
+			}
+		})
+	}
+
+	t.Run("stores submitted text", func(t *testing.T) {
+		server := testServer(t)
+		submitSuggestion(t, server, `{"text":"Great quote!","channel":"testchannel"}`, "192.0.2.1")
+
+		q := dbgen.New(server.WriteDB)
+		suggestions, err := q.ListPendingSuggestions(context.Background())
+		if err != nil {
+			t.Fatalf("failed to list suggestions: %v", err)
+		}
 		if len(suggestions) != 1 {
 			t.Fatalf("expected 1 suggestion, got %d", len(suggestions))
 		}
-		if suggestions[0].Text != "Bot suggested quote" {
-			t.Errorf("expected 'Bot suggested quote', got %s", suggestions[0].Text)
+		if suggestions[0].Text != "Great quote!" || suggestions[0].Channel != "testchannel" {
+			t.Errorf("unexpected suggestion: %+v", suggestions[0])
+		}
+	})
+
+	t.Run("rate limits after 5 requests from same IP", func(t *testing.T) {
+		server := testServer(t)
+		server.Config.SuggestionRateLimit = 5
+
+		for i := 0; i < 5; i++ {
+			w := submitSuggestion(t, server, fmt.Sprintf(`{"text":"Quote number %d","channel":"ch"}`, i), "192.0.2.7")
+			if w.Code != http.StatusCreated {
+				t.Fatalf("request %d: expected 201, got %d", i+1, w.Code)
+			}
+		}
+
+		w := submitSuggestion(t, server, `{"text":"One too many","channel":"ch"}`, "192.0.2.7")
+		if w.Code != http.StatusTooManyRequests {
+			t.Errorf("expected 429, got %d", w.Code)
+		}
+
+		// A different IP is unaffected
+		w = submitSuggestion(t, server, `{"text":"From elsewhere","channel":"ch"}`, "192.0.2.8")
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected 201 for other IP, got %d", w.Code)
+		}
+	})
+
+	t.Run("rate limit window reopens after the interval", func(t *testing.T) {
+		server := testServer(t)
+		server.Config.SuggestionRateLimit = 1
+		clock := &fakeClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+		server.Clock = clock
+
+		if w := submitSuggestion(t, server, `{"text":"First in the window","channel":"ch"}`, "192.0.2.9"); w.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d", w.Code)
+		}
+
+		clock.Advance(server.Config.SuggestionRateInterval - time.Minute)
+		if w := submitSuggestion(t, server, `{"text":"Still in the window","channel":"ch"}`, "192.0.2.9"); w.Code != http.StatusTooManyRequests {
+			t.Errorf("expected 429 inside the window, got %d", w.Code)
+		}
+
+		clock.Advance(2 * time.Minute)
+		if w := submitSuggestion(t, server, `{"text":"After the window","channel":"ch"}`, "192.0.2.9"); w.Code != http.StatusCreated {
+			t.Errorf("expected 201 once the window has passed, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns JSON response", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/api/suggestions", strings.NewReader(`{"text":"JSON test","channel":"ch"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		server.HandleSubmitSuggestion(w, req)
+
+		ct := w.Header().Get("Content-Type")
+		if !strings.Contains(ct, "application/json") {
+			t.Errorf("expected application/json, got %s", ct)
+		}
+	})
+
+	t.Run("tracks submitter email when authenticated", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/api/suggestions", strings.NewReader(`{"text":"Auth quote","channel":"ch"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-ExeDev-Email", "submitter@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleSubmitSuggestion(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected 201, got %d", w.Code)
+		}
+
+		// Verify submitter was recorded
+		q := dbgen.New(server.WriteDB)
+		suggestions, _ := q.ListPendingSuggestions(context.Background())
+		if len(suggestions) == 0 {
+			t.Fatal("expected suggestion")
+		}
+		if suggestions[0].SubmittedByUser == nil || *suggestions[0].SubmittedByUser != "submitter@test.com" {
+			t.Errorf("expected submitter email, got %v", suggestions[0].SubmittedByUser)
+		}
+	})
+}
+
+func TestHandleSubmitSuggestion_BotChannelHeader(t *testing.T) {
+	submit := func(server *Server, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/suggestions", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Nightbot-Channel", "name=botchannel&displayName=BotChannel&provider=twitch&providerId=123")
+		w := httptest.NewRecorder()
+		server.HandleSubmitSuggestion(w, req)
+		return w
+	}
+
+	t.Run("uses Nightbot channel when body has none", func(t *testing.T) {
+		server := testServer(t)
+
+		w := submit(server, `{"text":"Quote from a bot command"}`)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+		suggestions, _ := dbgen.New(server.WriteDB).ListPendingSuggestionsByChannel(context.Background(), "botchannel")
+		if len(suggestions) != 1 {
+			t.Errorf("expected 1 suggestion for botchannel, got %d", len(suggestions))
+		}
+	})
+
+	t.Run("JSON channel takes precedence over header", func(t *testing.T) {
+		server := testServer(t)
+
+		w := submit(server, `{"text":"Quote for another channel","channel":"bodychannel"}`)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+		q := dbgen.New(server.WriteDB)
+		if got, _ := q.ListPendingSuggestionsByChannel(context.Background(), "bodychannel"); len(got) != 1 {
+			t.Errorf("expected 1 suggestion for bodychannel, got %d", len(got))
+		}
+		if got, _ := q.ListPendingSuggestionsByChannel(context.Background(), "botchannel"); len(got) != 0 {
+			t.Errorf("expected no suggestion for header channel, got %d", len(got))
+		}
+	})
+
+	t.Run("error mentions both sources when channel is missing", func(t *testing.T) {
+		server := testServer(t)
+
+		w := submitSuggestion(t, server, `{"text":"Quote without a channel"}`, "192.0.2.1")
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+		body := w.Body.String()
+		if !strings.Contains(body, `"channel"`) || !strings.Contains(body, "Nightbot-Channel") {
+			t.Errorf("expected error to mention body field and header, got: %s", body)
+		}
+	})
+}
+
+func TestHandleSubmitSuggestion_FormEncodings(t *testing.T) {
+	fields := map[string]string{
+		"text":         "Form posted quote",
+		"channel":      "formchannel",
+		"author":       "FormAuthor",
+		"civilization": "",
+	}
+
+	urlencoded := func() (io.Reader, string) {
+		v := url.Values{}
+		for k, val := range fields {
+			v.Set(k, val)
+		}
+		return strings.NewReader(v.Encode()), "application/x-www-form-urlencoded"
+	}
+	multipartBody := func() (io.Reader, string) {
+		var buf bytes.Buffer
+		mw := multipart.NewWriter(&buf)
+		for k, val := range fields {
+			mw.WriteField(k, val)
+		}
+		mw.Close()
+		return &buf, mw.FormDataContentType()
+	}
+	jsonBody := func() (io.Reader, string) {
+		return strings.NewReader(`{"text":"Form posted quote","channel":"formchannel","author":"FormAuthor"}`), "application/json"
+	}
+
+	for name, build := range map[string]func() (io.Reader, string){
+		"urlencoded": urlencoded,
+		"multipart":  multipartBody,
+		"json":       jsonBody,
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := testServer(t)
+			body, ct := build()
+			req := httptest.NewRequest(http.MethodPost, "/api/suggestions", body)
+			req.Header.Set("Content-Type", ct)
+			w := httptest.NewRecorder()
+
+			server.HandleSubmitSuggestion(w, req)
+
+			if w.Code != http.StatusCreated {
+				t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+			}
+			if !strings.Contains(w.Header().Get("Content-Type"), "application/json") {
+				t.Errorf("expected JSON response, got %s", w.Header().Get("Content-Type"))
+			}
+
+			q := dbgen.New(server.WriteDB)
+			suggestions, err := q.ListPendingSuggestions(context.Background())
+			if err != nil || len(suggestions) != 1 {
+				t.Fatalf("expected 1 suggestion, got %d (err %v)", len(suggestions), err)
+			}
+			sg := suggestions[0]
+			if sg.Text != "Form posted quote" || sg.Channel != "formchannel" {
+				t.Errorf("unexpected suggestion: %+v", sg)
+			}
+			if sg.Author == nil || *sg.Author != "FormAuthor" {
+				t.Errorf("expected author FormAuthor, got %v", sg.Author)
+			}
+			if sg.Civilization != nil {
+				t.Errorf("expected empty civilization to be nil, got %q", *sg.Civilization)
+			}
+		})
+	}
+
+	t.Run("form values are validated", func(t *testing.T) {
+		server := testServer(t)
+		v := url.Values{"text": {"missing channel"}}
+		req := httptest.NewRequest(http.MethodPost, "/api/suggestions", strings.NewReader(v.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		server.HandleSubmitSuggestion(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "Channel is required") {
+			t.Errorf("expected channel error, got: %s", w.Body.String())
+		}
+	})
+}
+
+func TestHandleSubmitSuggestion_Honeypot(t *testing.T) {
+	pending := func(t *testing.T, server *Server) int {
+		t.Helper()
+		suggestions, err := dbgen.New(server.WriteDB).ListPendingSuggestions(context.Background())
+		if err != nil {
+			t.Fatalf("list suggestions: %v", err)
+		}
+		return len(suggestions)
+	}
+
+	t.Run("filled honeypot fakes success without storing", func(t *testing.T) {
+		server := testServer(t)
+		v := url.Values{
+			"text":    {"Spam bot quote"},
+			"channel": {"formchannel"},
+			"website": {"http://spam.example"},
+		}
+		req := httptest.NewRequest(http.MethodPost, "/api/suggestions", strings.NewReader(v.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		server.HandleSubmitSuggestion(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+		if !strings.Contains(w.Body.String(), "Suggestion submitted for review") {
+			t.Errorf("expected the normal success message, got: %s", w.Body.String())
+		}
+		if n := pending(t, server); n != 0 {
+			t.Errorf("expected no suggestion stored, got %d", n)
+		}
+	})
+
+	t.Run("empty honeypot is stored", func(t *testing.T) {
+		server := testServer(t)
+		v := url.Values{
+			"text":    {"Real person quote"},
+			"channel": {"formchannel"},
+			"website": {""},
+		}
+		req := httptest.NewRequest(http.MethodPost, "/api/suggestions", strings.NewReader(v.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		server.HandleSubmitSuggestion(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+		if n := pending(t, server); n != 1 {
+			t.Errorf("expected 1 suggestion stored, got %d", n)
+		}
+	})
+
+	t.Run("JSON API ignores website field", func(t *testing.T) {
+		server := testServer(t)
+
+		w := submitSuggestion(t, server, `{"text":"JSON quote text","channel":"formchannel","website":"x"}`, "10.0.0.1")
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+		if n := pending(t, server); n != 1 {
+			t.Errorf("expected 1 suggestion stored, got %d", n)
+		}
+	})
+}
+
+func TestSubmitThenApproveSuggestion(t *testing.T) {
+	server := testServer(t)
+
+	w := submitSuggestion(t, server, `{"text":"Submitted then approved","channel":"flowchannel"}`, "192.0.2.1")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("submit: expected 201, got %d", w.Code)
+	}
+
+	q := dbgen.New(server.WriteDB)
+	pending, err := q.ListPendingSuggestions(context.Background())
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("expected 1 pending suggestion, got %d (err %v)", len(pending), err)
+	}
+	id := fmt.Sprintf("%d", pending[0].ID)
+
+	req := httptest.NewRequest(http.MethodPost, "/suggestions/"+id+"/approve", nil)
+	req.SetPathValue("id", id)
+	req.Header.Set("X-ExeDev-UserID", "admin123")
+	req.Header.Set("X-ExeDev-Email", "admin@test.com")
+	aw := httptest.NewRecorder()
+
+	server.HandleApproveSuggestion(aw, req)
+
+	if aw.Code != http.StatusSeeOther {
+		t.Fatalf("approve: expected 303, got %d", aw.Code)
+	}
+
+	quotes, err := q.ListAllQuotes(context.Background())
+	if err != nil {
+		t.Fatalf("list quotes: %v", err)
+	}
+	found := false
+	for _, quote := range quotes {
+		if quote.Text == "Submitted then approved" {
+			found = true
+			if quote.Channel == nil || *quote.Channel != "flowchannel" {
+				t.Errorf("expected channel flowchannel, got %v", quote.Channel)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected approved suggestion to appear in quotes")
+	}
+}
+
+// addTestSuggestion adds a suggestion to the test database
+func addTestSuggestion(t *testing.T, s *Server, text, channel string) int64 {
+	t.Helper()
+	q := dbgen.New(s.WriteDB)
+	err := q.CreateSuggestion(context.Background(), dbgen.CreateSuggestionParams{
+		Text:          text,
+		Channel:       channel,
+		SubmittedByIp: "127.0.0.1",
+		SubmittedAt:   time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("failed to create suggestion: %v", err)
+	}
+	// Get the ID
+	suggestions, _ := q.ListPendingSuggestions(context.Background())
+	for _, s := range suggestions {
+		if s.Text == text {
+			return s.ID
+		}
+	}
+	t.Fatal("suggestion not found")
+	return 0
+}
+
+func TestHandleApproveSuggestion(t *testing.T) {
+	t.Run("returns 401 when not authenticated", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/suggestions/1/approve", nil)
+		req.SetPathValue("id", "1")
+		w := httptest.NewRecorder()
+
+		server.HandleApproveSuggestion(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 400 for invalid ID", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/suggestions/abc/approve", nil)
+		req.SetPathValue("id", "abc")
+		req.Header.Set("X-ExeDev-UserID", "user123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleApproveSuggestion(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 404 for non-existent suggestion", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/suggestions/99999/approve", nil)
+		req.SetPathValue("id", "99999")
+		req.Header.Set("X-ExeDev-UserID", "user123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleApproveSuggestion(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 403 when user cannot manage channel", func(t *testing.T) {
+		server := testServer(t)
+		sugID := addTestSuggestion(t, server, "Suggestion to approve", "somechannel")
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/suggestions/%d/approve", sugID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", sugID))
+		req.Header.Set("X-ExeDev-UserID", "user123")
+		req.Header.Set("X-ExeDev-Email", "notowner@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleApproveSuggestion(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("admin can approve suggestion and creates quote", func(t *testing.T) {
+		server := testServer(t)
+		sugID := addTestSuggestion(t, server, "Admin approved suggestion", "testchannel")
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/suggestions/%d/approve", sugID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", sugID))
+		req.Header.Set("X-ExeDev-UserID", "admin123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleApproveSuggestion(w, req)
+
+		if w.Code != http.StatusSeeOther {
+			t.Errorf("expected 303 redirect, got %d", w.Code)
+		}
+
+		// Verify quote was created
+		q := dbgen.New(server.WriteDB)
+		quotes, _ := q.ListAllQuotes(context.Background())
+		found := false
+		for _, quote := range quotes {
+			if quote.Text == "Admin approved suggestion" {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Error("expected quote to be created from suggestion")
+		}
+	})
+
+	t.Run("channel owner can approve suggestion for their channel", func(t *testing.T) {
+		server := testServer(t)
+		channel := "ownerchannel"
+		sugID := addTestSuggestion(t, server, "Owner approved", channel)
+
+		// Add channel owner
+		q := dbgen.New(server.WriteDB)
+		_ = q.AddChannelOwner(context.Background(), dbgen.AddChannelOwnerParams{
+			Channel:   channel,
+			UserEmail: "owner@test.com",
+		})
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/suggestions/%d/approve", sugID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", sugID))
+		req.Header.Set("X-ExeDev-UserID", "owner123")
+		req.Header.Set("X-ExeDev-Email", "owner@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleApproveSuggestion(w, req)
+
+		if w.Code != http.StatusSeeOther {
+			t.Errorf("expected 303 redirect, got %d", w.Code)
+		}
+	})
+}
+
+func TestHandleBotSuggestion(t *testing.T) {
+	// Bot errors are relayed to chat verbatim, so they must be a single line
+	// of plain text with a 200 status
+	assertBotError := func(t *testing.T, w *httptest.ResponseRecorder, want string) {
+		t.Helper()
+		if w.Code != http.StatusOK {
+			t.Errorf("expected 200 for bot relay, got %d", w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+			t.Errorf("expected text/plain, got %s", ct)
+		}
+		if got := w.Body.String(); got != want+"\n" {
+			t.Errorf("expected %q, got %q", want+"\n", got)
+		}
+	}
+	nightbotRequest := func(target string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Nightbot-Channel", "name=testchannel&displayName=Test&provider=twitch&providerId=123")
+		return req
+	}
+
+	t.Run("no channel header", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/api/suggest?text=test+quote", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleBotSuggestion(w, req)
+
+		assertBotError(t, w, "Could not determine channel - make sure your bot sends channel headers")
+	})
+
+	t.Run("no text", func(t *testing.T) {
+		server := testServer(t)
+		w := httptest.NewRecorder()
+
+		server.HandleBotSuggestion(w, nightbotRequest("/api/suggest"))
+
+		assertBotError(t, w, "Usage: !addquote <quote text>")
+	})
+
+	t.Run("text too short", func(t *testing.T) {
+		server := testServer(t)
+		w := httptest.NewRecorder()
+
+		server.HandleBotSuggestion(w, nightbotRequest("/api/suggest?text=ab"))
+
+		assertBotError(t, w, fmt.Sprintf("Quote text must be at least %d characters", server.Config.MinQuoteTextLen))
+	})
+
+	t.Run("text too long", func(t *testing.T) {
+		server := testServer(t)
+		w := httptest.NewRecorder()
+
+		server.HandleBotSuggestion(w, nightbotRequest("/api/suggest?text="+strings.Repeat("a", 501)))
+
+		assertBotError(t, w, "Quote too long - max 500 characters")
+	})
+
+	t.Run("moderator required", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "testchannel", "owner@test.com")
+		setRequireMod(t, server, "owner@test.com", "testchannel", true)
+		w := httptest.NewRecorder()
+
+		server.HandleBotSuggestion(w, nightbotRequest("/api/suggest?text=A+quote+long+enough+to+pass"))
+
+		assertBotError(t, w, "Only moderators can suggest quotes in this channel")
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		server := testServer(t)
+		server.Config.SuggestionRateLimit = 1
+		first := httptest.NewRecorder()
+		server.HandleBotSuggestion(first, nightbotRequest("/api/suggest?text=First+suggested+quote"))
+		if !strings.Contains(first.Body.String(), "submitted") {
+			t.Fatalf("expected first suggestion to succeed, got: %s", first.Body.String())
+		}
+
+		w := httptest.NewRecorder()
+		server.HandleBotSuggestion(w, nightbotRequest("/api/suggest?text=Second+suggested+quote"))
+
+		assertBotError(t, w, "Too many suggestions for this channel - try again later")
+	})
+
+	t.Run("database failure", func(t *testing.T) {
+		server := testServer(t)
+		server.WriteDB.Close()
+		w := httptest.NewRecorder()
+
+		server.HandleBotSuggestion(w, nightbotRequest("/api/suggest?text=A+quote+long+enough+to+pass"))
+
+		assertBotError(t, w, "Something went wrong - try again later")
+	})
+
+	t.Run("creates suggestion with Nightbot header", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/api/suggest?text=Bot+suggested+quote", nil)
+		req.Header.Set("Nightbot-Channel", "name=botchannel&displayName=BotChannel&provider=twitch&providerId=123")
+		w := httptest.NewRecorder()
+
+		server.HandleBotSuggestion(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "submitted") {
+			t.Errorf("expected success message, got: %s", w.Body.String())
+		}
+
+		// Verify suggestion was created with correct channel
+		q := dbgen.New(server.WriteDB)
+		suggestions, _ := q.ListPendingSuggestionsByChannel(context.Background(), "botchannel")
+		if len(suggestions) != 1 {
+			t.Fatalf("expected 1 suggestion, got %d", len(suggestions))
+		}
+		if suggestions[0].Text != "Bot suggested quote" {
+			t.Errorf("expected 'Bot suggested quote', got %s", suggestions[0].Text)
+		}
+	})
+
+	t.Run("creates suggestion with channel query param", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/api/suggest?text=Query+param+quote&channel=querychannel", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleBotSuggestion(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", w.Code)
+		}
+	})
+}
+
+func TestHandleGetQuote(t *testing.T) {
+	t.Run("returns 400 for invalid ID", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/api/quote/abc", nil)
+		req.SetPathValue("id", "abc")
+		w := httptest.NewRecorder()
+
+		server.HandleGetQuote(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 404 for non-existent quote", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/api/quote/99999", nil)
+		req.SetPathValue("id", "99999")
+		w := httptest.NewRecorder()
+
+		server.HandleGetQuote(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 410 for a deleted quote", func(t *testing.T) {
+		server := testServer(t)
+		addTestQuote(t, server, "Deleted by ID test", nil, nil)
+
+		q := dbgen.New(server.WriteDB)
+		quotes, _ := q.ListAllQuotes(context.Background())
+		quoteID := quotes[0].ID
+		if err := q.DeleteQuoteByID(context.Background(), quoteID); err != nil {
+			t.Fatalf("delete quote: %v", err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/quote/%d", quoteID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", quoteID))
+		req.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+
+		server.HandleGetQuote(w, req)
+
+		if w.Code != http.StatusGone {
+			t.Fatalf("expected 410, got %d", w.Code)
+		}
+		var body map[string]string
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if body["error"] != "quote has been deleted" {
+			t.Errorf("unexpected error %q", body["error"])
+		}
+		if strings.Contains(w.Body.String(), "Deleted by ID test") {
+			t.Error("expected the deleted quote text to be withheld")
+		}
+
+		req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/quote/%d", quoteID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", quoteID))
+		w = httptest.NewRecorder()
+		server.HandleGetQuote(w, req)
+		if w.Code != http.StatusGone {
+			t.Errorf("expected 410 for plain-text clients, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns quote by ID", func(t *testing.T) {
+		server := testServer(t)
+		addTestQuote(t, server, "Quote by ID test", nil, nil)
+
+		q := dbgen.New(server.WriteDB)
+		quotes, _ := q.ListAllQuotes(context.Background())
+		quoteID := quotes[0].ID
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/quote/%d", quoteID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", quoteID))
+		w := httptest.NewRecorder()
+
+		server.HandleGetQuote(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "Quote by ID test") {
+			t.Errorf("expected quote text, got: %s", w.Body.String())
+		}
+	})
+
+	t.Run("returns JSON when Accept header requests it", func(t *testing.T) {
+		server := testServer(t)
+		addTestQuote(t, server, "JSON ID test", nil, nil)
+
+		q := dbgen.New(server.WriteDB)
+		quotes, _ := q.ListAllQuotes(context.Background())
+		quoteID := quotes[0].ID
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/quote/%d", quoteID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", quoteID))
+		req.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+
+		server.HandleGetQuote(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", w.Code)
+		}
+		ct := w.Header().Get("Content-Type")
+		if !strings.Contains(ct, "application/json") {
+			t.Errorf("expected application/json, got %s", ct)
+		}
+	})
+
+	t.Run("includes previous and next quote IDs", func(t *testing.T) {
+		server := testServer(t)
+		addTestQuote(t, server, "First quote", nil, nil)
+		addTestQuote(t, server, "Middle quote", nil, nil)
+		addTestQuote(t, server, "Last quote", nil, nil)
+
+		quotes, _ := dbgen.New(server.WriteDB).ListAllQuotes(context.Background())
+		ids := make([]int64, 0, len(quotes))
+		for _, q := range quotes {
+			ids = append(ids, q.ID)
+		}
+		slices.Sort(ids)
+
+		get := func(id int64) QuoteResponse {
+			t.Helper()
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/quote/%d", id), nil)
+			req.SetPathValue("id", fmt.Sprintf("%d", id))
+			req.Header.Set("Accept", "application/json")
+			w := httptest.NewRecorder()
+			server.HandleGetQuote(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", w.Code)
+			}
+			var resp QuoteResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			return resp
+		}
+
+		first := get(ids[0])
+		if first.PrevID != nil {
+			t.Errorf("first quote: expected no prev_id, got %d", *first.PrevID)
+		}
+		if first.NextID == nil || *first.NextID != ids[1] {
+			t.Errorf("first quote: expected next_id %d, got %v", ids[1], first.NextID)
+		}
+
+		middle := get(ids[1])
+		if middle.PrevID == nil || *middle.PrevID != ids[0] {
+			t.Errorf("middle quote: expected prev_id %d, got %v", ids[0], middle.PrevID)
+		}
+		if middle.NextID == nil || *middle.NextID != ids[2] {
+			t.Errorf("middle quote: expected next_id %d, got %v", ids[2], middle.NextID)
+		}
+
+		last := get(ids[2])
+		if last.PrevID == nil || *last.PrevID != ids[1] {
+			t.Errorf("last quote: expected prev_id %d, got %v", ids[1], last.PrevID)
+		}
+		if last.NextID != nil {
+			t.Errorf("last quote: expected no next_id, got %d", *last.NextID)
+		}
+	})
+
+	t.Run("singular and plural paths return the same quote", func(t *testing.T) {
+		server := testServer(t)
+		civ := "French"
+		addTestQuote(t, server, "Same quote either way", &civ, nil)
+		quotes, _ := dbgen.New(server.WriteDB).ListAllQuotes(context.Background())
+		id := fmt.Sprint(quotes[0].ID)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /api/quotes/{id}", server.HandleGetQuote)
+		mux.HandleFunc("GET /api/quote/{id}", server.HandleGetQuote)
+
+		for _, accept := range []string{"", "application/json"} {
+			var bodies []string
+			for _, path := range []string{"/api/quotes/" + id, "/api/quote/" + id} {
+				req := httptest.NewRequest(http.MethodGet, path, nil)
+				if accept != "" {
+					req.Header.Set("Accept", accept)
+				}
+				w := httptest.NewRecorder()
+				mux.ServeHTTP(w, req)
+				if w.Code != http.StatusOK {
+					t.Fatalf("GET %s: expected 200, got %d", path, w.Code)
+				}
+				bodies = append(bodies, w.Body.String())
+			}
+			if bodies[0] != bodies[1] {
+				t.Errorf("Accept %q: responses differ:\n%s\n%s", accept, bodies[0], bodies[1])
+			}
+			if !strings.Contains(bodies[0], "Same quote either way") {
+				t.Errorf("expected quote text, got %s", bodies[0])
+			}
+		}
+	})
+}
+
+// stringOrEmpty dereferences a nullable column for comparison
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func TestHandleEditQuote(t *testing.T) {
+	tests := []struct {
+		name         string
+		email        string // empty means unauthenticated
+		quoteChannel string
+		id           string // defaults to the seeded quote's ID
+		form         url.Values
+		wantCode     int
+		wantLocation string // substring of the redirect target
+		wantText     string // expected stored text afterwards
+		wantChannel  string // expected stored channel afterwards
+		wantAuthor   string
+		wantCiv      string
+		wantOpponent string
+	}{
+		{
+			name:         "admin can edit any quote",
+			email:        "admin@test.com",
+			quoteChannel: "editchannel",
+			form: url.Values{
+				"text":         {"Edited text"},
+				"author":       {"Edited author"},
+				"civilization": {"Rus"},
+				"opponent_civ": {"Mongols"},
+				"channel":      {"otherchannel"},
+			},
+			wantCode:     http.StatusSeeOther,
+			wantLocation: "success=",
+			wantText:     "Edited text",
+			wantChannel:  "otherchannel",
+			wantAuthor:   "Edited author",
+			wantCiv:      "Rus",
+			wantOpponent: "Mongols",
+		},
+		{
+			name:         "owner can edit own channel quote",
+			email:        "owner@test.com",
+			quoteChannel: "ownedchannel",
+			form:         url.Values{"text": {"Owner edit"}, "channel": {"ownedchannel"}},
+			wantCode:     http.StatusSeeOther,
+			wantLocation: "success=",
+			wantText:     "Owner edit",
+			wantChannel:  "ownedchannel",
+		},
+		{
+			name:         "owner cannot edit another channel's quote",
+			email:        "owner@test.com",
+			quoteChannel: "otherchannel",
+			form:         url.Values{"text": {"Hacked"}, "channel": {"otherchannel"}},
+			wantCode:     http.StatusForbidden,
+			wantText:     "Original text",
+			wantChannel:  "otherchannel",
+		},
+		{
+			name:         "owner cannot move quote to unowned channel",
+			email:        "owner@test.com",
+			quoteChannel: "ownedchannel",
+			form:         url.Values{"text": {"Moved"}, "channel": {"otherchannel"}},
+			wantCode:     http.StatusForbidden,
+			wantText:     "Original text",
+			wantChannel:  "ownedchannel",
+		},
+		{
+			name:         "unauthenticated redirects to login",
+			quoteChannel: "ownedchannel",
+			form:         url.Values{"text": {"edited"}},
+			wantCode:     http.StatusSeeOther,
+			wantLocation: "/auth/twitch",
+			wantText:     "Original text",
+			wantChannel:  "ownedchannel",
+		},
+		{
+			name:         "invalid ID returns 400",
+			email:        "admin@test.com",
+			quoteChannel: "ownedchannel",
+			id:           "abc",
+			form:         url.Values{"text": {"edited"}},
+			wantCode:     http.StatusBadRequest,
+			wantText:     "Original text",
+			wantChannel:  "ownedchannel",
+		},
+		{
+			name:         "unknown ID returns 404",
+			email:        "admin@test.com",
+			quoteChannel: "ownedchannel",
+			id:           "99999",
+			form:         url.Values{"text": {"edited"}},
+			wantCode:     http.StatusNotFound,
+			wantText:     "Original text",
+			wantChannel:  "ownedchannel",
+		},
+		{
+			name:         "empty text redirects with validation error",
+			email:        "admin@test.com",
+			quoteChannel: "ownedchannel",
+			form:         url.Values{"text": {"   "}, "channel": {"ownedchannel"}},
+			wantCode:     http.StatusSeeOther,
+			wantLocation: "error=",
+			wantText:     "Original text",
+			wantChannel:  "ownedchannel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testServer(t)
+			addTestOwner(t, server, "ownedchannel", "owner@test.com")
+			channel := tt.quoteChannel
+			addTestQuote(t, server, "Original text", nil, &channel)
+
+			q := dbgen.New(server.WriteDB)
+			quotes, err := q.ListAllQuotes(context.Background())
+			if err != nil || len(quotes) != 1 {
+				t.Fatalf("expected 1 seeded quote, got %d (%v)", len(quotes), err)
+			}
+			quoteID := quotes[0].ID
+
+			id := tt.id
+			if id == "" {
+				id = fmt.Sprintf("%d", quoteID)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/quotes/"+id+"/edit", strings.NewReader(tt.form.Encode()))
+			req.SetPathValue("id", id)
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.email != "" {
+				req.Header.Set("X-ExeDev-UserID", "user123")
+				req.Header.Set("X-ExeDev-Email", tt.email)
+			}
+			w := httptest.NewRecorder()
+
+			server.HandleEditQuote(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantLocation != "" && !strings.Contains(w.Header().Get("Location"), tt.wantLocation) {
+				t.Errorf("expected redirect containing %q, got %q", tt.wantLocation, w.Header().Get("Location"))
+			}
+
+			stored, err := q.GetQuoteByID(context.Background(), quoteID)
+			if err != nil {
+				t.Fatalf("get quote: %v", err)
+			}
+			if stored.Text != tt.wantText {
+				t.Errorf("expected text %q, got %q", tt.wantText, stored.Text)
+			}
+			if stored.Channel == nil || *stored.Channel != tt.wantChannel {
+				t.Errorf("expected channel %q, got %v", tt.wantChannel, stored.Channel)
+			}
+			if got := stringOrEmpty(stored.Author); got != tt.wantAuthor {
+				t.Errorf("expected author %q, got %q", tt.wantAuthor, got)
+			}
+			if got := stringOrEmpty(stored.Civilization); got != tt.wantCiv {
+				t.Errorf("expected civilization %q, got %q", tt.wantCiv, got)
+			}
+			if got := stringOrEmpty(stored.OpponentCiv); got != tt.wantOpponent {
+				t.Errorf("expected opponent civ %q, got %q", tt.wantOpponent, got)
+			}
+		})
+	}
+}
+
+func TestHandleListAllQuotes(t *testing.T) {
+	t.Run("returns empty array when no quotes", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/api/quotes", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleListAllQuotes(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", w.Code)
+		}
+		if w.Body.String() != "[]\n" {
+			t.Errorf("expected empty array, got: %s", w.Body.String())
+		}
+	})
+
+	t.Run("returns JSON array of quotes", func(t *testing.T) {
+		server := testServer(t)
+		addTestQuote(t, server, "Quote 1", nil, nil)
+		addTestQuote(t, server, "Quote 2", nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/quotes", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleListAllQuotes(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", w.Code)
+		}
+		ct := w.Header().Get("Content-Type")
+		if !strings.Contains(ct, "application/json") {
+			t.Errorf("expected application/json, got %s", ct)
+		}
+		if !strings.Contains(w.Body.String(), "Quote 1") || !strings.Contains(w.Body.String(), "Quote 2") {
+			t.Errorf("expected both quotes, got: %s", w.Body.String())
+		}
+	})
+
+	// list fetches /api/quotes with query and decodes the page of quotes
+	list := func(t *testing.T, server *Server, query string) ([]QuoteResponse, *httptest.ResponseRecorder) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		server.HandleListAllQuotes(w, httptest.NewRequest(http.MethodGet, "/api/quotes"+query, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		var quotes []QuoteResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &quotes); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return quotes, w
+	}
+	seed := func(t *testing.T, n int) *Server {
+		server := testServer(t)
+		for i := range n {
+			addTestQuote(t, server, fmt.Sprintf("Quote %d", i), nil, nil)
+		}
+		return server
+	}
+
+	t.Run("defaults to 50 quotes with pagination headers", func(t *testing.T) {
+		server := seed(t, defaultQuotesListLimit+10)
+		quotes, w := list(t, server, "")
+		if len(quotes) != defaultQuotesListLimit {
+			t.Fatalf("expected %d quotes, got %d", defaultQuotesListLimit, len(quotes))
+		}
+		if got := w.Header().Get("X-Total-Count"); got != strconv.Itoa(defaultQuotesListLimit+10) {
+			t.Errorf("expected X-Total-Count %d, got %q", defaultQuotesListLimit+10, got)
+		}
+		if link := w.Header().Get("Link"); !strings.Contains(link, `page=2>; rel="next"`) {
+			t.Errorf("expected a next link, got %q", link)
+		}
+
+		quotes, w = list(t, server, "?page=2")
+		if len(quotes) != 10 {
+			t.Errorf("expected 10 quotes on page 2, got %d", len(quotes))
+		}
+		if link := w.Header().Get("Link"); strings.Contains(link, "next") || !strings.Contains(link, `rel="prev"`) {
+			t.Errorf("expected only a prev link on the last page, got %q", link)
+		}
+	})
+
+	t.Run("clamps the limit", func(t *testing.T) {
+		server := seed(t, maxQuotesListLimit+5)
+		if quotes, _ := list(t, server, "?limit=1000"); len(quotes) != maxQuotesListLimit {
+			t.Errorf("expected limit clamped to %d, got %d", maxQuotesListLimit, len(quotes))
+		}
+		if quotes, _ := list(t, server, "?limit=3"); len(quotes) != 3 {
+			t.Errorf("expected 3 quotes, got %d", len(quotes))
+		}
+		if quotes, _ := list(t, server, "?limit=-1"); len(quotes) != defaultQuotesListLimit {
+			t.Errorf("expected invalid limit to fall back to %d, got %d", defaultQuotesListLimit, len(quotes))
+		}
+	})
+
+	t.Run("offset skips quotes", func(t *testing.T) {
+		server := seed(t, 10)
+		all, _ := list(t, server, "?limit=10")
+		quotes, w := list(t, server, "?limit=3&offset=4")
+		if len(quotes) != 3 {
+			t.Fatalf("expected 3 quotes, got %d", len(quotes))
+		}
+		for i, quote := range quotes {
+			if quote.ID != all[i+4].ID {
+				t.Errorf("quote %d: expected ID %d, got %d", i, all[i+4].ID, quote.ID)
+			}
+		}
+		if link := w.Header().Get("Link"); link != "" {
+			t.Errorf("expected no Link header for offset requests, got %q", link)
+		}
+		if quotes, _ := list(t, server, "?offset=10"); len(quotes) != 0 {
+			t.Errorf("expected no quotes past the end, got %d", len(quotes))
+		}
+	})
+
+	t.Run("meta wraps the page", func(t *testing.T) {
+		server := seed(t, 5)
+		w := httptest.NewRecorder()
+		server.HandleListAllQuotes(w, httptest.NewRequest(http.MethodGet, "/api/quotes?meta=1&limit=2&page=2", nil))
+		var resp QuotePageResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if len(resp.Quotes) != 2 || resp.Total != 5 || resp.Page != 2 || resp.Limit != 2 || resp.Offset != 2 {
+			t.Errorf("unexpected page metadata %+v", resp)
+		}
+	})
+}
+
+func TestHandleSearchQuotes(t *testing.T) {
+	setup := func(t *testing.T) *Server {
+		server := testServer(t)
+		q := dbgen.New(server.WriteDB)
+		ch := "streamer1"
+		french, english := "French", "English"
+		for _, p := range []dbgen.CreateQuoteParams{
+			{Text: "Royal Knights win the early fight", Civilization: &french, Channel: &ch},
+			{Text: "Never skip the knight upgrade", Author: strPtr("BeastyQT"), Civilization: &english},
+			{Text: "Feudal rush with 100% of your villagers", Channel: &ch},
+			{Text: "Wall early and boom", Author: strPtr("Knightfall")},
+			{Text: "Use the_keep for defense"},
+		} {
+			if _, err := q.CreateQuote(context.Background(), p); err != nil {
+				t.Fatalf("create quote: %v", err)
+			}
+		}
+		return server
+	}
+	search := func(server *Server, target, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		server.HandleSearchQuotes(w, req)
+		return w
+	}
+	// decode returns the sorted texts of a JSON response, which is a single
+	// object for one match and an array for several
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) []string {
+		t.Helper()
+		var quotes []QuoteResponse
+		body := w.Body.Bytes()
+		if strings.HasPrefix(string(body), "{") {
+			quotes = make([]QuoteResponse, 1)
+			if err := json.Unmarshal(body, &quotes[0]); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+		} else if err := json.Unmarshal(body, &quotes); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		var texts []string
+		for _, q := range quotes {
+			texts = append(texts, q.Text)
+		}
+		slices.Sort(texts)
+		return texts
+	}
+
+	t.Run("returns 400 with usage when q is empty", func(t *testing.T) {
+		server := setup(t)
+		w := search(server, "/api/quotes/search?q=%20", "")
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "Usage:") {
+			t.Errorf("expected usage string, got: %s", w.Body.String())
+		}
+	})
+
+	t.Run("matches text and author case-insensitively", func(t *testing.T) {
+		server := setup(t)
+		w := search(server, "/api/quotes/search?q=KNIGHT", "application/json")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		want := []string{"Never skip the knight upgrade", "Royal Knights win the early fight", "Wall early and boom"}
+		if got := decode(t, w); !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("filters by channel and civ", func(t *testing.T) {
+		server := setup(t)
+		w := search(server, "/api/quotes/search?q=knight&channel=streamer1", "application/json")
+		if got := decode(t, w); !slices.Equal(got, []string{"Royal Knights win the early fight"}) {
+			t.Errorf("channel filter: got %v", got)
+		}
+
+		w = search(server, "/api/quotes/search?q=knight&civ=english", "application/json")
+		if got := decode(t, w); !slices.Equal(got, []string{"Never skip the knight upgrade"}) {
+			t.Errorf("civ filter: got %v", got)
+		}
+	})
+
+	t.Run("single match honors Accept", func(t *testing.T) {
+		server := setup(t)
+		w := search(server, "/api/quotes/search?q=feudal", "")
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+			t.Errorf("expected plain text, got %q", ct)
+		}
+		if !strings.Contains(w.Body.String(), "Feudal rush") {
+			t.Errorf("expected quote text, got: %s", w.Body.String())
+		}
+
+		w = search(server, "/api/quotes/search?q=feudal", "application/json")
+		var quote QuoteResponse
+		if err := json.NewDecoder(w.Body).Decode(&quote); err != nil {
+			t.Fatalf("expected a single JSON object: %v", err)
+		}
+		if !strings.Contains(quote.Text, "Feudal rush") {
+			t.Errorf("unexpected quote: %+v", quote)
+		}
+	})
+
+	t.Run("plain-text clients get one line for several matches", func(t *testing.T) {
+		server := setup(t)
+		w := search(server, "/api/quotes/search?q=knight", "")
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+			t.Errorf("expected plain text, got %q", ct)
+		}
+		if lines := strings.Count(strings.TrimSpace(w.Body.String()), "\n"); lines != 0 {
+			t.Errorf("expected a single line, got: %s", w.Body.String())
+		}
+	})
+
+	t.Run("wildcards in the term match literally", func(t *testing.T) {
+		server := setup(t)
+		w := search(server, "/api/quotes/search?q=100%25", "application/json")
+		if got := decode(t, w); !slices.Equal(got, []string{"Feudal rush with 100% of your villagers"}) {
+			t.Errorf("%%: got %v", got)
+		}
+
+		for _, target := range []string{"/api/quotes/search?q=%25", "/api/quotes/search?q=_"} {
+			w := search(server, target, "application/json")
+			body := w.Body.String()
+			if strings.Contains(body, "Wall early") || strings.Contains(body, "knight upgrade") {
+				t.Errorf("%s: wildcard matched unrelated quotes: %s", target, body)
+			}
+		}
+	})
+
+	t.Run("multi-word queries match every word in any order", func(t *testing.T) {
+		server := setup(t)
+		w := search(server, "/api/quotes/search?q=early+royal", "application/json")
+		if got := decode(t, w); !slices.Equal(got, []string{"Royal Knights win the early fight"}) {
+			t.Errorf("got %v", got)
+		}
+
+		w = search(server, "/api/quotes/search?q=wall+beasty", "")
+		if !strings.Contains(w.Body.String(), "No quotes match") {
+			t.Errorf("expected no match when words are in different quotes, got: %s", w.Body.String())
+		}
+	})
+
+	t.Run("ranks the best match first", func(t *testing.T) {
+		server := testServer(t)
+		addTestQuote(t, server, "Scout early, and keep scouting the map so you know what the opponent is building before they hit", nil, nil)
+		addTestQuote(t, server, "Scout scout scout", nil, nil)
+		addTestQuote(t, server, "Villagers first", nil, nil)
+
+		w := search(server, "/api/quotes/search?q=scout", "application/json")
+		var quotes []QuoteResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &quotes); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if len(quotes) != 2 || quotes[0].Text != "Scout scout scout" {
+			t.Errorf("expected the denser match first, got %+v", quotes)
+		}
+
+		// Plain-text clients get the best match too
+		w = search(server, "/api/quotes/search?q=scout", "")
+		if !strings.Contains(w.Body.String(), "Scout scout scout") {
+			t.Errorf("expected best match, got: %s", w.Body.String())
+		}
+	})
+
+	t.Run("falls back to LIKE when the index is unavailable", func(t *testing.T) {
+		server := setup(t)
+		for _, stmt := range []string{
+			"DROP TRIGGER quotes_fts_insert",
+			"DROP TRIGGER quotes_fts_delete",
+			"DROP TRIGGER quotes_fts_update",
+			"DROP TABLE quotes_fts",
+		} {
+			if _, err := server.WriteDB.Exec(stmt); err != nil {
+				t.Fatalf("%s: %v", stmt, err)
+			}
+		}
+
+		w := search(server, "/api/quotes/search?q=knight", "application/json")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		want := []string{"Never skip the knight upgrade", "Royal Knights win the early fight", "Wall early and boom"}
+		if got := decode(t, w); !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("caps results", func(t *testing.T) {
+		server := testServer(t)
+		for i := 0; i < maxSearchResults+5; i++ {
+			addTestQuote(t, server, fmt.Sprintf("Repeated tip %d", i), nil, nil)
+		}
+		w := search(server, "/api/quotes/search?q=repeated", "application/json")
+		if got := decode(t, w); len(got) != maxSearchResults {
+			t.Errorf("expected %d results, got %d", maxSearchResults, len(got))
+		}
+	})
+
+	t.Run("returns 200 with message when nothing matches", func(t *testing.T) {
+		server := setup(t)
+		w := search(server, "/api/quotes/search?q=trebuchet", "")
+		if w.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "No quotes match trebuchet") {
+			t.Errorf("expected no-results message, got: %s", w.Body.String())
+		}
+	})
+
+	t.Run("route is not shadowed by the quote ID route", func(t *testing.T) {
+		server := setup(t)
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /api/quotes/search", server.HandleSearchQuotes)
+		mux.HandleFunc("GET /api/quotes/{id}", server.HandleGetQuote)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/quotes/search?q=feudal", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		if !strings.Contains(w.Body.String(), "Feudal rush") {
+			t.Errorf("expected search result, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+}
+
+func TestHandleListSuggestions(t *testing.T) {
+	t.Run("redirects when not authenticated", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/suggestions", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleListSuggestions(w, req)
+
+		if w.Code != http.StatusSeeOther {
+			t.Errorf("expected 303, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 403 for non-admin non-owner", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/suggestions", nil)
+		req.Header.Set("X-ExeDev-UserID", "user123")
+		req.Header.Set("X-ExeDev-Email", "nobody@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleListSuggestions(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("admin can list all suggestions", func(t *testing.T) {
+		server := testServer(t)
+		addTestSuggestion(t, server, "Test suggestion", "testchannel")
+
+		req := httptest.NewRequest(http.MethodGet, "/suggestions", nil)
+		req.Header.Set("X-ExeDev-UserID", "admin123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleListSuggestions(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "Test suggestion") {
+			t.Errorf("expected suggestion in response")
+		}
+	})
+
+	t.Run("channel owner can list their channel suggestions", func(t *testing.T) {
+		server := testServer(t)
+		q := dbgen.New(server.WriteDB)
+		_ = q.AddChannelOwner(context.Background(), dbgen.AddChannelOwnerParams{
+			Channel:   "ownedchannel",
+			UserEmail: "owner@test.com",
+			InvitedBy: "admin@test.com",
+		})
+		addTestSuggestion(t, server, "Owned channel suggestion", "ownedchannel")
+
+		req := httptest.NewRequest(http.MethodGet, "/suggestions", nil)
+		req.Header.Set("X-ExeDev-UserID", "owner123")
+		req.Header.Set("X-ExeDev-Email", "owner@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleListSuggestions(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", w.Code)
+		}
+	})
+}
+
+func TestNavPendingSuggestionBadge(t *testing.T) {
+	server := testServer(t)
+	addTestOwner(t, server, "ownerchannel", "owner@test.com")
+	addTestSuggestion(t, server, "Suggestion for someone else", "otherchannel")
+
+	quotesPage := func(email string) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/quotes", nil)
+		req.Header.Set("X-ExeDev-UserID", "user123")
+		req.Header.Set("X-ExeDev-Email", email)
+		w := httptest.NewRecorder()
+		server.HandleQuotes(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		return w.Body.String()
+	}
+
+	if body := quotesPage("owner@test.com"); strings.Contains(body, `<span class="badge badge-channel">`) {
+		t.Error("expected no badge without pending suggestions for the owner's channel")
+	}
+
+	if w := submitSuggestion(t, server, `{"text": "Always be scouting the enemy", "channel": "ownerchannel"}`, "10.0.0.1"); w.Code != http.StatusCreated {
+		t.Fatalf("submit suggestion: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if body := quotesPage("owner@test.com"); !strings.Contains(body, `Suggestions <span class="badge badge-channel">1</span>`) {
+		t.Error("expected owner badge to count only their channel's suggestion")
+	}
+	if body := quotesPage("admin@test.com"); !strings.Contains(body, `Suggestions <span class="badge badge-channel">2</span>`) {
+		t.Error("expected admin badge to count every pending suggestion")
+	}
+}
+
+func TestHandleRejectSuggestion(t *testing.T) {
+	t.Run("returns 401 when not authenticated", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/suggestions/1/reject", nil)
+		req.SetPathValue("id", "1")
+		w := httptest.NewRecorder()
+
+		server.HandleRejectSuggestion(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 400 for invalid ID", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/suggestions/abc/reject", nil)
+		req.SetPathValue("id", "abc")
+		req.Header.Set("X-ExeDev-UserID", "admin123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleRejectSuggestion(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 404 for non-existent suggestion", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/suggestions/99999/reject", nil)
+		req.SetPathValue("id", "99999")
+		req.Header.Set("X-ExeDev-UserID", "admin123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleRejectSuggestion(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 403 when user cannot manage channel", func(t *testing.T) {
+		server := testServer(t)
+		id := addTestSuggestion(t, server, "Protected suggestion", "otherchannel")
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/suggestions/%d/reject", id), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", id))
+		req.Header.Set("X-ExeDev-UserID", "user123")
+		req.Header.Set("X-ExeDev-Email", "nobody@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleRejectSuggestion(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("admin can reject any suggestion", func(t *testing.T) {
+		server := testServer(t)
+		id := addTestSuggestion(t, server, "To be rejected", "anychannel")
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/suggestions/%d/reject", id), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", id))
+		req.Header.Set("X-ExeDev-UserID", "admin123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleRejectSuggestion(w, req)
+
+		if w.Code != http.StatusSeeOther {
+			t.Errorf("expected 303, got %d", w.Code)
+		}
+
+		// Verify suggestion was rejected
+		q := dbgen.New(server.WriteDB)
+		suggestion, _ := q.GetSuggestionByID(context.Background(), id)
+		if suggestion.Status != "rejected" {
+			t.Errorf("expected rejected status, got %s", suggestion.Status)
+		}
+	})
+}
+
+func TestHandleBatchRejectSuggestions(t *testing.T) {
+	bulkReject := func(server *Server, email string, body BulkRejectRequest) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/suggestions/bulk-reject", bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if email != "" {
+			req.Header.Set("X-ExeDev-Email", email)
+		}
+		w := httptest.NewRecorder()
+		server.HandleBatchRejectSuggestions(w, req)
+		return w
+	}
+	statusOf := func(t *testing.T, server *Server, id int64) dbgen.QuoteSuggestion {
+		t.Helper()
+		sg, err := dbgen.New(server.WriteDB).GetSuggestionByID(context.Background(), id)
+		if err != nil {
+			t.Fatalf("get suggestion %d: %v", id, err)
+		}
+		return sg
+	}
+
+	t.Run("returns 401 when not authenticated", func(t *testing.T) {
+		server := testServer(t)
+		id := addTestSuggestion(t, server, "Unauthenticated bulk reject", "ownerchannel")
+		if w := bulkReject(server, "", BulkRejectRequest{IDs: []int64{id}}); w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("owner rejects suggestions in their channel with shared reason", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "ownerchannel", "owner@test.com")
+		id1 := addTestSuggestion(t, server, "Spam suggestion one", "ownerchannel")
+		id2 := addTestSuggestion(t, server, "Spam suggestion two", "ownerchannel")
+
+		w := bulkReject(server, "owner@test.com", BulkRejectRequest{IDs: []int64{id1, id2}, Reason: "spam"})
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp BulkRejectResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if resp.Rejected != 2 || len(resp.Errors) != 0 {
+			t.Errorf("expected 2 rejected and no errors, got %+v", resp)
+		}
+		for _, id := range []int64{id1, id2} {
+			sg := statusOf(t, server, id)
+			if sg.Status != "rejected" {
+				t.Errorf("suggestion %d: expected rejected, got %s", id, sg.Status)
+			}
+			if sg.RejectReason == nil || *sg.RejectReason != "spam" {
+				t.Errorf("suggestion %d: expected reason spam, got %v", id, sg.RejectReason)
+			}
+		}
+	})
+
+	t.Run("skips suggestions in channels the caller cannot manage", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "ownerchannel", "owner@test.com")
+		own := addTestSuggestion(t, server, "Suggestion in own channel", "ownerchannel")
+		other := addTestSuggestion(t, server, "Suggestion in other channel", "otherchannel")
+
+		w := bulkReject(server, "owner@test.com", BulkRejectRequest{IDs: []int64{own, other, 99999}})
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		var resp BulkRejectResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if resp.Rejected != 1 {
+			t.Errorf("expected 1 rejected, got %d", resp.Rejected)
+		}
+		if len(resp.Errors) != 2 || resp.Errors[0].ID != other || resp.Errors[1].ID != 99999 {
+			t.Errorf("expected errors for %d and 99999, got %+v", other, resp.Errors)
+		}
+		if sg := statusOf(t, server, other); sg.Status != "pending" {
+			t.Errorf("expected other channel's suggestion to stay pending, got %s", sg.Status)
+		}
+	})
+
+	t.Run("non-owner rejects nothing", func(t *testing.T) {
+		server := testServer(t)
+		id := addTestSuggestion(t, server, "Suggestion nobody owns", "ownerchannel")
+
+		w := bulkReject(server, "nobody@test.com", BulkRejectRequest{IDs: []int64{id}, Reason: "spam"})
+		var resp BulkRejectResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if resp.Rejected != 0 || len(resp.Errors) != 1 || resp.Errors[0].Error != "permission denied" {
+			t.Errorf("expected permission denied for the only suggestion, got %+v", resp)
+		}
+		if sg := statusOf(t, server, id); sg.Status != "pending" {
+			t.Errorf("expected suggestion to stay pending, got %s", sg.Status)
+		}
+	})
+
+	t.Run("returns 400 for empty selection", func(t *testing.T) {
+		server := testServer(t)
+		if w := bulkReject(server, "admin@test.com", BulkRejectRequest{}); w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestHandleAddChannelOwner(t *testing.T) {
+	t.Run("returns 401 when not authenticated", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/admin/owners", strings.NewReader("channel=test&email=user@test.com"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		server.HandleAddChannelOwner(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 403 for non-admin", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/admin/owners", strings.NewReader("channel=test&email=user@test.com"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-ExeDev-UserID", "user123")
+		req.Header.Set("X-ExeDev-Email", "user@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleAddChannelOwner(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("redirects with error when channel or email missing", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/admin/owners", strings.NewReader("channel=test"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-ExeDev-UserID", "admin123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleAddChannelOwner(w, req)
+
+		if w.Code != http.StatusSeeOther {
+			t.Errorf("expected 303, got %d", w.Code)
+		}
+		loc := w.Header().Get("Location")
+		if !strings.Contains(loc, "error=") {
+			t.Errorf("expected error in redirect, got %s", loc)
+		}
+	})
+
+	t.Run("admin can add channel owner", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/admin/owners", strings.NewReader("channel=newchannel&email=newowner@test.com"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-ExeDev-UserID", "admin123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleAddChannelOwner(w, req)
+
+		if w.Code != http.StatusSeeOther {
+			t.Errorf("expected 303, got %d", w.Code)
+		}
+		loc := w.Header().Get("Location")
+		if !strings.Contains(loc, "success=") {
+			t.Errorf("expected success in redirect, got %s", loc)
+		}
+
+		// Verify owner was added
+		q := dbgen.New(server.WriteDB)
+		channels, _ := q.GetChannelsByOwner(context.Background(), "newowner@test.com")
+		if len(channels) != 1 || channels[0] != "newchannel" {
+			t.Errorf("expected newchannel in owned channels, got %v", channels)
+		}
+	})
+
+	t.Run("records who granted access", func(t *testing.T) {
+		server := testServer(t)
+		before := time.Now().Add(-time.Minute)
+		req := httptest.NewRequest(http.MethodPost, "/admin/owners", strings.NewReader("channel=auditchannel&email=audited@test.com"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleAddChannelOwner(w, req)
+
+		if w.Code != http.StatusSeeOther {
+			t.Fatalf("expected 303, got %d", w.Code)
+		}
+		owners, err := dbgen.New(server.WriteDB).ListAllChannelOwners(context.Background())
+		if err != nil || len(owners) != 1 {
+			t.Fatalf("expected one owner, got %v (err %v)", owners, err)
+		}
+		if owners[0].InvitedBy != "admin@test.com" {
+			t.Errorf("expected InvitedBy admin@test.com, got %q", owners[0].InvitedBy)
+		}
+		if owners[0].InvitedAt.Before(before) {
+			t.Errorf("expected InvitedAt to be set on insert, got %v", owners[0].InvitedAt)
+		}
+
+		// The admin page shows who granted access
+		req = httptest.NewRequest(http.MethodGet, "/admin/owners", nil)
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w = httptest.NewRecorder()
+		server.HandleListChannelOwners(w, req)
+		if body := w.Body.String(); !strings.Contains(body, "<td>admin@test.com</td>") {
+			t.Error("expected inviting admin in owners table")
+		}
+	})
+}
+
+func TestHandleRemoveChannelOwner(t *testing.T) {
+	t.Run("returns 401 when not authenticated", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/admin/owners/remove", strings.NewReader("channel=test&email=user@test.com"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		server.HandleRemoveChannelOwner(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 403 for non-admin", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/admin/owners/remove", strings.NewReader("channel=test&email=user@test.com"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-ExeDev-UserID", "user123")
+		req.Header.Set("X-ExeDev-Email", "user@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleRemoveChannelOwner(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("admin can remove channel owner", func(t *testing.T) {
+		server := testServer(t)
+		q := dbgen.New(server.WriteDB)
+
+		// First add an owner
+		_ = q.AddChannelOwner(context.Background(), dbgen.AddChannelOwnerParams{
+			Channel:   "removechannel",
+			UserEmail: "toremove@test.com",
+			InvitedBy: "admin@test.com",
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/owners/remove", strings.NewReader("channel=removechannel&email=toremove@test.com"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-ExeDev-UserID", "admin123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleRemoveChannelOwner(w, req)
+
+		if w.Code != http.StatusSeeOther {
+			t.Errorf("expected 303, got %d", w.Code)
+		}
+
+		// Verify owner was removed
+		channels, _ := q.GetChannelsByOwner(context.Background(), "toremove@test.com")
+		if len(channels) != 0 {
+			t.Errorf("expected no channels, got %v", channels)
+		}
+	})
+}
+
+// failingConnector opens connections through the real driver but fails
+// the named sqlc queries, so handlers can be tested against partial
+// database failures.
+type failingConnector struct {
+	driver  driver.Driver
+	dsn     string
+	queries []string
+}
+
+func (c failingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return failingConn{Conn: conn, queries: c.queries}, nil
+}
+
+func (c failingConnector) Driver() driver.Driver { return c.driver }
+
+type failingConn struct {
+	driver.Conn
+	queries []string
+}
+
+// Prepare fails statements generated for one of the named queries.
+func (c failingConn) Prepare(query string) (driver.Stmt, error) {
+	for _, name := range c.queries {
+		if strings.HasPrefix(query, "-- name: "+name+" :") {
+			return nil, fmt.Errorf("simulated failure of %s", name)
+		}
+	}
+	return c.Conn.Prepare(query)
+}
+
+// failingDB returns a *sql.DB over the same database file as base on which
+// the named sqlc queries fail and every other query works.
+func failingDB(t *testing.T, base *sql.DB, path string, queries ...string) *sql.DB {
+	t.Helper()
+	fdb := sql.OpenDB(failingConnector{driver: base.Driver(), dsn: path, queries: queries})
+	t.Cleanup(func() { fdb.Close() })
+	return fdb
+}
+
+func TestHandleQuotesPublic_Degraded(t *testing.T) {
+	t.Run("returns 500 when database is closed", func(t *testing.T) {
+		server := testServer(t)
+		server.WriteDB.Close()
+
+		req := httptest.NewRequest(http.MethodGet, "/browse", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleQuotesPublic(w, req)
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("expected 500, got %d", w.Code)
+		}
+	})
+
+	t.Run("renders warning banner and unknown count when side queries fail", func(t *testing.T) {
+		server := testServer(t)
+		channel := "degraded"
+		addTestQuote(t, server, "Degraded browse quote", nil, &channel)
+		server.ReadDB = failingDB(t, server.WriteDB, server.Config.DBPath, "ListChannels", "CountQuotesByChannel")
+
+		req := httptest.NewRequest(http.MethodGet, "/browse?channel=degraded", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleQuotesPublic(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		body := w.Body.String()
+		if !strings.Contains(body, "Channel list unavailable") {
+			t.Error("expected channel list warning in page")
+		}
+		if !strings.Contains(body, "? quotes") {
+			t.Error("expected unknown count placeholder in page")
+		}
+		if !strings.Contains(body, "Degraded browse quote") {
+			t.Error("expected quotes from the main query")
+		}
+		if w.Header().Get("X-Total-Count") != "" {
+			t.Error("expected no X-Total-Count without a count")
+		}
+	})
+
+	t.Run("no banner when queries succeed", func(t *testing.T) {
+		server := testServer(t)
+		addTestQuote(t, server, "Healthy browse quote", nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/browse", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleQuotesPublic(w, req)
+
+		body := w.Body.String()
+		if strings.Contains(body, "Channel list unavailable") {
+			t.Error("did not expect warning banner")
+		}
+		if !strings.Contains(body, "1 quotes") {
+			t.Error("expected quote count in page")
+		}
+	})
+}
+
+func TestHandleAdminUsers_Online(t *testing.T) {
+	server := testServer(t)
+	if err := dbgen.New(server.WriteDB).UpsertUser(context.Background(), dbgen.UpsertUserParams{
+		UserID: "viewer-1",
+		Email:  "viewer@test.com",
+	}); err != nil {
+		t.Fatalf("upsert user: %v", err)
+	}
+	users, err := dbgen.New(server.WriteDB).GetAllUsers(context.Background())
+	if err != nil || len(users) != 1 {
+		t.Fatalf("expected 1 user, got %d (%v)", len(users), err)
+	}
+	lastSeen := users[0].LastSeenAt
+
+	page := func() string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/admin/users", nil)
+		req.Header.Set("X-ExeDev-UserID", "admin-1")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+		server.HandleAdminUsers(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		return w.Body.String()
+	}
+
+	server.Clock = &fakeClock{now: lastSeen.Add(time.Minute)}
+	if body := page(); !strings.Contains(body, `title="Online"`) || !strings.Contains(body, "1 minute ago") {
+		t.Error("expected the user online and last seen a minute ago")
+	}
+
+	server.Clock = &fakeClock{now: lastSeen.Add(time.Hour)}
+	if body := page(); strings.Contains(body, `title="Online"`) {
+		t.Error("expected the user offline an hour after last seen")
+	}
+}
+
+func TestHandleAdminMigrations(t *testing.T) {
+	server := testServer(t)
+
+	t.Run("non-admin forbidden", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin/migrations", nil)
+		req.Header.Set("X-ExeDev-Email", "notadmin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleAdminMigrations(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("admin gets JSON", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin/migrations", nil)
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		req.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+
+		server.HandleAdminMigrations(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), `"filename":"001-base.sql","applied":true`) {
+			t.Errorf("expected applied base migration in response, got: %s", w.Body.String())
+		}
+	})
+
+	t.Run("admin gets HTML", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin/migrations", nil)
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleAdminMigrations(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "001-base.sql") {
+			t.Error("expected migration filename in page")
+		}
+	})
+}
+
+func TestHandleQuotesPublic_AuthorFilter(t *testing.T) {
+	server := testServer(t)
+	q := dbgen.New(server.WriteDB)
+	ch := "streamer1"
+	other := "streamer2"
+	for _, p := range []dbgen.CreateQuoteParams{
+		{Text: "Boom headshot from the keep", Author: strPtr("BeastyQT"), Channel: &ch},
+		{Text: "Always wall your base early", Author: strPtr("beastyqt"), Channel: &other},
+		{Text: "Rush the sacred sites now", Author: strPtr("MarineLorD"), Channel: &ch},
+		{Text: "Anonymous wisdom for everyone"},
+	} {
+		if _, err := q.CreateQuote(context.Background(), p); err != nil {
+			t.Fatalf("create quote: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		want     []string
+		dontWant []string
+	}{
+		{
+			name:     "author substring case-insensitive",
+			query:    "?author=beasty",
+			want:     []string{"Boom headshot", "Always wall", "2 quotes", "Author: beasty"},
+			dontWant: []string{"Rush the sacred", "Anonymous wisdom"},
+		},
+		{
+			name:     "author combined with channel",
+			query:    "?author=BEASTY&channel=streamer1",
+			want:     []string{"Boom headshot", "1 quotes"},
+			dontWant: []string{"Always wall", "Rush the sacred"},
+		},
+		{
+			name:     "author wildcards match literally",
+			query:    "?author=%25",
+			want:     []string{"0 quotes"},
+			dontWant: []string{"Boom headshot", "Rush the sacred"},
+		},
+		{
+			name:     "author underscore matches literally",
+			query:    "?author=beasty_t",
+			want:     []string{"0 quotes"},
+			dontWant: []string{"Boom headshot", "Always wall"},
+		},
+		{
+			name:     "no author filter",
+			query:    "",
+			want:     []string{"4 quotes"},
+			dontWant: []string{"Author:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/browse"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			server.HandleQuotesPublic(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", w.Code)
+			}
+			body := w.Body.String()
+			for _, s := range tt.want {
+				if !strings.Contains(body, s) {
+					t.Errorf("expected %q in page", s)
+				}
+			}
+			for _, s := range tt.dontWant {
+				if strings.Contains(body, s) {
+					t.Errorf("did not expect %q in page", s)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleQuotesPublic_CivFilter(t *testing.T) {
+	server := testServer(t)
+	q := dbgen.New(server.WriteDB)
+	ch := "streamer1"
+	other := "streamer2"
+	hre, english, french := "Holy Roman Empire", "English", "French"
+	for _, p := range []dbgen.CreateQuoteParams{
+		{Text: "Prelates boost your economy", Civilization: &hre, Channel: &ch},
+		{Text: "Push with landsknecht against archers", Civilization: &hre, OpponentCiv: &english, Channel: &ch},
+		{Text: "Relics in the regnitz cathedral", Civilization: &hre, Channel: &other},
+		{Text: "Longbows outrange towers", Civilization: &english, OpponentCiv: &french, Channel: &other},
+		{Text: "Anonymous wisdom for everyone"},
+	} {
+		if _, err := q.CreateQuote(context.Background(), p); err != nil {
+			t.Fatalf("create quote: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		want     []string
+		dontWant []string
+	}{
+		{
+			name:     "civ by full name",
+			query:    "?civ=Holy+Roman+Empire",
+			want:     []string{"Prelates", "Push with", "Relics", "3 quotes for Holy Roman Empire"},
+			dontWant: []string{"Longbows", "Anonymous wisdom"},
+		},
+		{
+			name:  "civ by shortname",
+			query: "?civ=hre",
+			want:  []string{"3 quotes for Holy Roman Empire", `<option value="Holy Roman Empire" selected>`},
+		},
+		{
+			name:     "civ combined with channel",
+			query:    "?civ=hre&channel=streamer1",
+			want:     []string{"Prelates", "Push with", "2 quotes in #streamer1 for Holy Roman Empire"},
+			dontWant: []string{"Relics", "Longbows"},
+		},
+		{
+			name:     "matchup only",
+			query:    "?matchup=1",
+			want:     []string{"Push with", "Longbows", "2 quotes (matchups only)"},
+			dontWant: []string{"Prelates", "Relics", "Anonymous wisdom"},
+		},
+		{
+			name:     "matchup only with civ and channel",
+			query:    "?matchup=1&civ=hre&channel=streamer1",
+			want:     []string{"Push with", "1 quotes"},
+			dontWant: []string{"Prelates", "Relics", "Longbows"},
+		},
+		{
+			name:     "unknown civ matches nothing",
+			query:    "?civ=nosuchciv",
+			want:     []string{"0 quotes for nosuchciv"},
+			dontWant: []string{"Prelates", "Longbows"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/browse"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			server.HandleQuotesPublic(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", w.Code)
+			}
+			body := w.Body.String()
+			for _, s := range tt.want {
+				if !strings.Contains(body, s) {
+					t.Errorf("expected %q in page", s)
+				}
+			}
+			for _, s := range tt.dontWant {
+				if strings.Contains(body, s) {
+					t.Errorf("did not expect %q in page", s)
+				}
+			}
+		})
+	}
+
+	t.Run("pagination links keep the civ filters", func(t *testing.T) {
+		for i := 0; i < defaultPageSize; i++ {
+			_, err := q.CreateQuote(context.Background(), dbgen.CreateQuoteParams{
+				Text:         fmt.Sprintf("Matchup tip %d", i),
+				Civilization: &hre,
+				OpponentCiv:  &french,
+			})
+			if err != nil {
+				t.Fatalf("create quote: %v", err)
+			}
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/browse?civ=hre&matchup=1", nil)
+		w := httptest.NewRecorder()
+		server.HandleQuotesPublic(w, req)
+
+		body := w.Body.String()
+		if !strings.Contains(body, "&civ=Holy%20Roman%20Empire&matchup=1") {
+			t.Errorf("expected next page link to keep civ and matchup, got:\n%s", body)
+		}
+		if link := w.Header().Get("Link"); !strings.Contains(link, "civ=hre") || !strings.Contains(link, "matchup=1") {
+			t.Errorf("expected Link header to keep filters, got %q", link)
+		}
+	})
+}
+
+func TestHandleQuotesPublic_Search(t *testing.T) {
+	server := testServer(t)
+	q := dbgen.New(server.WriteDB)
+	ctx := context.Background()
+	ch := "streamer1"
+	other := "streamer2"
+	for _, p := range []dbgen.CreateQuoteParams{
+		{Text: "Boom headshot from the keep", Author: strPtr("BeastyQT"), Channel: &ch},
+		{Text: "Always wall your base early", Channel: &other},
+		{Text: "Wall the sacred sites now", Channel: &ch},
+		{Text: "Villagers <b>never</b> sleep"},
+	} {
+		if _, err := q.CreateQuote(ctx, p); err != nil {
+			t.Fatalf("create quote: %v", err)
+		}
+	}
+
+	browse := func(t *testing.T, query string) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/browse"+query, nil)
+		w := httptest.NewRecorder()
+		server.HandleQuotesPublic(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		return w.Body.String()
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		want     []string
+		dontWant []string
+	}{
+		{
+			name:     "matches word case-insensitively and highlights it",
+			query:    "?q=WALL",
+			want:     []string{"2 quotes", "Always <mark>wall</mark> your base", "<mark>Wall</mark> the sacred"},
+			dontWant: []string{"Boom headshot"},
+		},
+		{
+			name:     "combined with channel",
+			query:    "?q=wall&channel=streamer1",
+			want:     []string{"1 quotes", "the sacred sites", `name="channel" value="streamer1"`},
+			dontWant: []string{"your base"},
+		},
+		{
+			name:     "all words must match",
+			query:    "?q=wall+sacred",
+			want:     []string{"1 quotes", "the <mark>sacred</mark> sites"},
+			dontWant: []string{"your base"},
+		},
+		{
+			name:  "fts syntax is treated literally",
+			query: `?q=%22wall%22+AND+(`,
+			want:  []string{"No quotes match your search."},
+		},
+		{
+			name:     "search text is escaped",
+			query:    "?q=never",
+			want:     []string{"&lt;b&gt;<mark>never</mark>&lt;/b&gt;"},
+			dontWant: []string{"<b>never</b>"},
+		},
+		{
+			name:     "search overrides author filter",
+			query:    "?q=wall&author=beasty",
+			want:     []string{"2 quotes"},
+			dontWant: []string{"Author:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := browse(t, tt.query)
+			for _, s := range tt.want {
+				if !strings.Contains(body, s) {
+					t.Errorf("expected %q in page", s)
+				}
+			}
+			for _, s := range tt.dontWant {
+				if strings.Contains(body, s) {
+					t.Errorf("did not expect %q in page", s)
+				}
+			}
+		})
+	}
+
+	t.Run("index follows edits and deletes", func(t *testing.T) {
+		quotes, err := q.ListAllQuotes(ctx)
+		if err != nil {
+			t.Fatalf("list quotes: %v", err)
+		}
+		for _, quote := range quotes {
+			switch quote.Text {
+			case "Boom headshot from the keep":
+				if err := q.UpdateQuote(ctx, dbgen.UpdateQuoteParams{
+					Text: "Boom trebuchet from the keep", Author: quote.Author, Channel: quote.Channel, ID: quote.ID,
+				}); err != nil {
+					t.Fatalf("update quote: %v", err)
+				}
+			case "Always wall your base early":
+				if err := q.DeleteQuoteByID(ctx, quote.ID); err != nil {
+					t.Fatalf("delete quote: %v", err)
+				}
+			}
+		}
+
+		if body := browse(t, "?q=headshot"); !strings.Contains(body, "0 quotes") {
+			t.Error("expected old text to be removed from the index")
+		}
+		if body := browse(t, "?q=trebuchet"); !strings.Contains(body, "1 quotes") {
+			t.Error("expected new text to be indexed")
+		}
+		if body := browse(t, "?q=wall"); !strings.Contains(body, "1 quotes") {
+			t.Error("expected deleted quote to be removed from the index")
+		}
+	})
+}
+
+func TestHandleQuotesPublic_PaginationHeaders(t *testing.T) {
+	server := testServer(t)
+	q := dbgen.New(server.WriteDB)
+	ch := "streamer1"
+	for i := 0; i < 2*defaultPageSize+5; i++ {
+		if _, err := q.CreateQuote(context.Background(), dbgen.CreateQuoteParams{
+			Text:    fmt.Sprintf("Paginated quote number %d", i),
+			Channel: &ch,
+		}); err != nil {
+			t.Fatalf("create quote: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		link  string
+	}{
+		{
+			name:  "first page",
+			query: "?channel=streamer1",
+			link:  `</browse?channel=streamer1&page=2>; rel="next"`,
+		},
+		{
+			name:  "middle page",
+			query: "?channel=streamer1&page=2",
+			link:  `</browse?channel=streamer1&page=3>; rel="next", </browse?channel=streamer1&page=1>; rel="prev"`,
+		},
+		{
+			name:  "last page",
+			query: "?page=3&channel=streamer1",
+			link:  `</browse?channel=streamer1&page=2>; rel="prev"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/browse"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			server.HandleQuotesPublic(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", w.Code)
+			}
+			if got := w.Header().Get("X-Total-Count"); got != "45" {
+				t.Errorf("X-Total-Count = %q, want 45", got)
+			}
+			if got := w.Header().Get("Link"); got != tt.link {
+				t.Errorf("Link = %q, want %q", got, tt.link)
+			}
+		})
+	}
+
+	t.Run("single page has no links", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/browse?channel=nobody", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleQuotesPublic(w, req)
+
+		if got := w.Header().Get("X-Total-Count"); got != "0" {
+			t.Errorf("X-Total-Count = %q, want 0", got)
+		}
+		if got := w.Header().Get("Link"); got != "" {
+			t.Errorf("expected no Link header, got %q", got)
+		}
+	})
+}
+
+func TestHandleRoot(t *testing.T) {
+	t.Run("no recent section when empty", func(t *testing.T) {
+		server := testServer(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleRoot(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if strings.Contains(w.Body.String(), "Recent Quotes") {
+			t.Error("did not expect recent quotes section with no quotes")
+		}
+	})
+
+	t.Run("shows recent quotes", func(t *testing.T) {
+		server := testServer(t)
+		addTestQuote(t, server, "Fresh quote from the front lines", nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleRoot(w, req)
+
+		body := w.Body.String()
+		if !strings.Contains(body, "Recent Quotes") {
+			t.Error("expected recent quotes section")
+		}
+		if !strings.Contains(body, "Fresh quote from the front lines") {
+			t.Error("expected seeded quote text in page")
+		}
+		if !strings.Contains(body, "1 quotes") {
+			t.Error("expected quote count in page")
+		}
+	})
+
+	t.Run("social sharing meta tags", func(t *testing.T) {
+		server := testServer(t)
+		server.Hostname = "quotes.example.com"
+		addTestQuote(t, server, "First", nil, nil)
+		addTestQuote(t, server, "Second", nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleRoot(w, req)
+
+		body := w.Body.String()
+		for _, tag := range []string{
+			`<meta property="og:title" content="AoE4 Quote Database">`,
+			`<meta property="og:description" content="Over 2 AoE4 quotes and matchup tips">`,
+			`<meta property="og:url" content="https://quotes.example.com/">`,
+			`<meta property="og:image" content="https://quotes.example.com/static/favicon.svg">`,
+			`<meta name="twitter:card" content="summary">`,
+		} {
+			if !strings.Contains(body, tag) {
+				t.Errorf("expected %s in page", tag)
+			}
+		}
+	})
+}
+
+func TestHandleGetSuggestion(t *testing.T) {
+	get := func(server *Server, email, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/suggestions/"+id, nil)
+		req.SetPathValue("id", id)
+		if email != "" {
+			req.Header.Set("X-ExeDev-Email", email)
+		}
+		w := httptest.NewRecorder()
+		server.HandleGetSuggestion(w, req)
+		return w
+	}
+
+	t.Run("returns 401 when not authenticated", func(t *testing.T) {
+		server := testServer(t)
+		id := addTestSuggestion(t, server, "Unauthenticated fetch", "ownerchannel")
+		if w := get(server, "", fmt.Sprint(id)); w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("owner gets suggestion with review fields", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "ownerchannel", "owner@test.com")
+		id := addTestSuggestion(t, server, "Reviewed suggestion", "ownerchannel")
+		reviewer := "owner@test.com"
+		reviewedAt := time.Now()
+		if err := dbgen.New(server.WriteDB).RejectSuggestion(context.Background(), dbgen.RejectSuggestionParams{
+			ReviewedBy: &reviewer,
+			ReviewedAt: &reviewedAt,
+			ID:         id,
+		}); err != nil {
+			t.Fatalf("reject suggestion: %v", err)
+		}
+
+		w := get(server, "owner@test.com", fmt.Sprint(id))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp SuggestionResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if resp.ID != id || resp.Text != "Reviewed suggestion" || resp.Status != "rejected" {
+			t.Errorf("unexpected suggestion: %+v", resp)
+		}
+		if resp.SubmittedAt == "" {
+			t.Error("expected submitted_at")
+		}
+		if resp.ReviewedBy == nil || *resp.ReviewedBy != reviewer || resp.ReviewedAt == nil {
+			t.Errorf("expected review fields, got %+v", resp)
+		}
+	})
+
+	t.Run("admin can get any suggestion", func(t *testing.T) {
+		server := testServer(t)
+		id := addTestSuggestion(t, server, "Admin fetch", "otherchannel")
+		if w := get(server, "admin@test.com", fmt.Sprint(id)); w.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 403 for other channel", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "ownerchannel", "owner@test.com")
+		id := addTestSuggestion(t, server, "Someone else's suggestion", "otherchannel")
+		if w := get(server, "owner@test.com", fmt.Sprint(id)); w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 404 for missing suggestion", func(t *testing.T) {
+		server := testServer(t)
+		if w := get(server, "admin@test.com", "99999"); w.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 400 for invalid ID", func(t *testing.T) {
+		server := testServer(t)
+		if w := get(server, "admin@test.com", "abc"); w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestHandleSuggestionStatus(t *testing.T) {
+	status := func(server *Server, id, nightbotChannel, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/suggestions/"+id+"/status", nil)
+		req.SetPathValue("id", id)
+		if nightbotChannel != "" {
+			req.Header.Set("Nightbot-Channel", "name="+nightbotChannel+"&displayName="+nightbotChannel+"&provider=twitch&providerId=1")
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		server.HandleSuggestionStatus(w, req)
+		return w
+	}
+
+	server := testServer(t)
+	q := dbgen.New(server.WriteDB)
+	reviewer := "owner@test.com"
+	reviewedAt := time.Now()
+	pending := addTestSuggestion(t, server, "Still pending", "ownerchannel")
+	approved := addTestSuggestion(t, server, "Gets approved", "ownerchannel")
+	if err := q.ApproveSuggestion(context.Background(), dbgen.ApproveSuggestionParams{
+		ReviewedBy: &reviewer, ReviewedAt: &reviewedAt, ID: approved,
+	}); err != nil {
+		t.Fatalf("approve suggestion: %v", err)
+	}
+	rejected := addTestSuggestion(t, server, "Gets rejected", "ownerchannel")
+	if err := q.RejectSuggestion(context.Background(), dbgen.RejectSuggestionParams{
+		ReviewedBy: &reviewer, ReviewedAt: &reviewedAt, ID: rejected,
+	}); err != nil {
+		t.Fatalf("reject suggestion: %v", err)
+	}
+
+	for _, tc := range []struct {
+		status string
+		id     int64
+	}{
+		{"pending", pending},
+		{"approved", approved},
+		{"rejected", rejected},
+	} {
+		t.Run(tc.status+" as plain text", func(t *testing.T) {
+			w := status(server, fmt.Sprint(tc.id), "OwnerChannel", "")
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+				t.Errorf("expected text/plain, got %q", ct)
+			}
+			if got, want := strings.TrimSpace(w.Body.String()), suggestionStatusMessages[tc.status]; got != want {
+				t.Errorf("expected %q, got %q", want, got)
+			}
+		})
+
+		t.Run(tc.status+" as JSON", func(t *testing.T) {
+			w := status(server, fmt.Sprint(tc.id), "ownerchannel", "application/json")
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", w.Code)
+			}
+			var resp SuggestionStatusResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Status != tc.status {
+				t.Errorf("expected status %q, got %q", tc.status, resp.Status)
+			}
+			if resp.Message != suggestionStatusMessages[tc.status] {
+				t.Errorf("unexpected message %q", resp.Message)
+			}
+		})
+	}
+
+	t.Run("other channel looks like not found", func(t *testing.T) {
+		w := status(server, fmt.Sprint(pending), "otherchannel", "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200 for bots, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "not found") {
+			t.Errorf("expected not found message, got %q", w.Body.String())
+		}
+
+		w = status(server, fmt.Sprint(pending), "otherchannel", "application/json")
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("missing suggestion returns 404 for JSON", func(t *testing.T) {
+		if w := status(server, "99999", "ownerchannel", "application/json"); w.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("requires a channel", func(t *testing.T) {
+		w := status(server, fmt.Sprint(pending), "", "")
+		if !strings.Contains(w.Body.String(), "Could not determine channel") {
+			t.Errorf("expected channel error, got %q", w.Body.String())
+		}
+		if w := status(server, fmt.Sprint(pending), "", "application/json"); w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("channel query param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/suggestions/%d/status?channel=ownerchannel", approved), nil)
+		req.SetPathValue("id", fmt.Sprint(approved))
+		w := httptest.NewRecorder()
+		server.HandleSuggestionStatus(w, req)
+		if got := strings.TrimSpace(w.Body.String()); got != suggestionStatusMessages["approved"] {
+			t.Errorf("unexpected body %q", got)
+		}
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		if w := status(server, "abc", "ownerchannel", "application/json"); w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestHandleAPIListSuggestions(t *testing.T) {
+	list := func(server *Server, email, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/suggestions?"+query, nil)
+		if email != "" {
+			req.Header.Set("X-ExeDev-Email", email)
+		}
+		w := httptest.NewRecorder()
+		server.HandleAPIListSuggestions(w, req)
+		return w
+	}
+
+	t.Run("returns 401 when not authenticated", func(t *testing.T) {
+		server := testServer(t)
+		if w := list(server, "", "channel=ownerchannel"); w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 400 without channel or with unsupported status", func(t *testing.T) {
+		server := testServer(t)
+		if w := list(server, "admin@test.com", ""); w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 without channel, got %d", w.Code)
+		}
+		if w := list(server, "admin@test.com", "channel=ownerchannel&status=approved"); w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for approved status, got %d", w.Code)
+		}
+	})
+
+	t.Run("owner lists pending suggestions for their channel", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "ownerchannel", "owner@test.com")
+		addTestSuggestion(t, server, "Pending in owned channel", "ownerchannel")
+		addTestSuggestion(t, server, "Pending elsewhere", "otherchannel")
+
+		w := list(server, "owner@test.com", "channel=OwnerChannel&status=pending")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp []SuggestionResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(resp) != 1 || resp[0].Text != "Pending in owned channel" || resp[0].Status != "pending" {
+			t.Errorf("unexpected suggestions: %+v", resp)
+		}
+	})
+
+	t.Run("returns empty array when channel has none", func(t *testing.T) {
+		server := testServer(t)
+		w := list(server, "admin@test.com", "channel=quietchannel")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if body := strings.TrimSpace(w.Body.String()); body != "[]" {
+			t.Errorf("expected [], got %s", body)
+		}
+	})
+
+	t.Run("returns 403 for other channel", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "ownerchannel", "owner@test.com")
+		if w := list(server, "owner@test.com", "channel=otherchannel"); w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+}
+
+func TestHandleMarkSuggestionViewed(t *testing.T) {
+	markViewed := func(server *Server, id int64, email string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/suggestions/%d/view", id), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", id))
+		if email != "" {
+			req.Header.Set("X-ExeDev-Email", email)
+		}
+		w := httptest.NewRecorder()
+		server.HandleMarkSuggestionViewed(w, req)
+		return w
+	}
+	listSuggestions := func(server *Server, query string) string {
+		req := httptest.NewRequest(http.MethodGet, "/suggestions"+query, nil)
+		req.Header.Set("X-ExeDev-Email", "owner@test.com")
+		w := httptest.NewRecorder()
+		server.HandleListSuggestions(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("list suggestions: expected 200, got %d", w.Code)
+		}
+		return w.Body.String()
+	}
+
+	t.Run("returns 401 when not authenticated", func(t *testing.T) {
+		server := testServer(t)
+		id := addTestSuggestion(t, server, "Unauthenticated view", "viewchannel")
+
+		w := markViewed(server, id, "")
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 403 for other channel", func(t *testing.T) {
+		server := testServer(t)
+		id := addTestSuggestion(t, server, "Not your channel", "viewchannel")
+
+		w := markViewed(server, id, "notowner@test.com")
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 404 for missing suggestion", func(t *testing.T) {
+		server := testServer(t)
+
+		w := markViewed(server, 9999, "admin@test.com")
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("viewed suggestions leave the default list", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "viewchannel", "owner@test.com")
+		viewedID := addTestSuggestion(t, server, "Already looked at this one", "viewchannel")
+		addTestSuggestion(t, server, "Still waiting for review", "viewchannel")
+
+		w := markViewed(server, viewedID, "owner@test.com")
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d", w.Code)
+		}
+
+		sg, err := dbgen.New(server.WriteDB).GetSuggestionByID(context.Background(), viewedID)
+		if err != nil {
+			t.Fatalf("get suggestion: %v", err)
+		}
+		if sg.ViewedAt == nil || sg.ViewedBy == nil || *sg.ViewedBy != "owner@test.com" {
+			t.Errorf("expected viewed_at/viewed_by set, got %v / %v", sg.ViewedAt, sg.ViewedBy)
+		}
+		if sg.Status != "pending" {
+			t.Errorf("expected status to stay pending, got %s", sg.Status)
+		}
+
+		body := listSuggestions(server, "")
+		if strings.Contains(body, "Already looked at this one") {
+			t.Error("viewed suggestion should be hidden by default")
+		}
+		if !strings.Contains(body, "Still waiting for review") {
+			t.Error("unviewed suggestion should be listed")
+		}
+		if !strings.Contains(body, "1 unread") {
+			t.Error("expected unread count of 1")
+		}
+
+		body = listSuggestions(server, "?include_viewed=true")
+		if !strings.Contains(body, "Already looked at this one") {
+			t.Error("viewed suggestion should be listed with include_viewed=true")
+		}
+		if !strings.Contains(body, "1 unread") {
+			t.Error("expected unread count of 1 with include_viewed=true")
+		}
+	})
+}
+
+func TestHandlePurgeSuggestions(t *testing.T) {
+	purge := func(server *Server, query, email string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/admin/suggestions/purge"+query, nil)
+		if email != "" {
+			req.Header.Set("X-ExeDev-Email", email)
+		}
+		w := httptest.NewRecorder()
+		server.HandlePurgeSuggestions(w, req)
+		return w
+	}
+	review := func(t *testing.T, server *Server, id int64, status string, at time.Time) {
+		t.Helper()
+		q := dbgen.New(server.WriteDB)
+		reviewer := "admin@test.com"
+		var err error
+		if status == "rejected" {
+			err = q.RejectSuggestion(context.Background(), dbgen.RejectSuggestionParams{ReviewedBy: &reviewer, ReviewedAt: &at, ID: id})
+		} else {
+			err = q.ApproveSuggestion(context.Background(), dbgen.ApproveSuggestionParams{ReviewedBy: &reviewer, ReviewedAt: &at, ID: id})
+		}
+		if err != nil {
+			t.Fatalf("review suggestion: %v", err)
+		}
+	}
+
+	t.Run("returns 401 when not authenticated", func(t *testing.T) {
+		server := testServer(t)
+		if w := purge(server, "", ""); w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 403 for non-admin", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "somechannel", "owner@test.com")
+		if w := purge(server, "", "owner@test.com"); w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("rejects older_than_days below minimum", func(t *testing.T) {
+		server := testServer(t)
+		if w := purge(server, "?older_than_days=3", "admin@test.com"); w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+		if w := purge(server, "?older_than_days=soon", "admin@test.com"); w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for non-numeric, got %d", w.Code)
+		}
+	})
+
+	t.Run("admin purges old rejected suggestions only", func(t *testing.T) {
+		server := testServer(t)
+		old := time.Now().AddDate(0, 0, -100)
+		recent := time.Now().AddDate(0, 0, -10)
+
+		oldRejected := addTestSuggestion(t, server, "Old rejected spam", "ch")
+		review(t, server, oldRejected, "rejected", old)
+		recentRejected := addTestSuggestion(t, server, "Recent rejected spam", "ch")
+		review(t, server, recentRejected, "rejected", recent)
+		oldApproved := addTestSuggestion(t, server, "Old approved quote", "ch")
+		review(t, server, oldApproved, "approved", old)
+		pending := addTestSuggestion(t, server, "Still pending quote", "ch")
+
+		w := purge(server, "", "admin@test.com")
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if strings.TrimSpace(w.Body.String()) != `{"purged":1}` {
+			t.Errorf("unexpected body: %s", w.Body.String())
+		}
+
+		q := dbgen.New(server.WriteDB)
+		if _, err := q.GetSuggestionByID(context.Background(), oldRejected); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected old rejected suggestion to be purged, got err %v", err)
+		}
+		for _, id := range []int64{recentRejected, oldApproved, pending} {
+			if _, err := q.GetSuggestionByID(context.Background(), id); err != nil {
+				t.Errorf("suggestion %d should remain: %v", id, err)
+			}
+		}
+	})
+
+	t.Run("custom window purges recent rejections", func(t *testing.T) {
+		server := testServer(t)
+		id := addTestSuggestion(t, server, "Rejected two weeks ago", "ch")
+		review(t, server, id, "rejected", time.Now().AddDate(0, 0, -14))
+
+		w := purge(server, "?older_than_days=7", "admin@test.com")
+
+		if strings.TrimSpace(w.Body.String()) != `{"purged":1}` {
+			t.Errorf("unexpected body: %s", w.Body.String())
+		}
+	})
+}
+
+func TestHandleListChannelOwners_Search(t *testing.T) {
+	server := testServer(t)
+	addTestOwner(t, server, "beastyqt", "beasty@example.com")
+	addTestOwner(t, server, "beastyqt", "mod@beasty.tv")
+	addTestOwner(t, server, "marinelord", "marine@example.com")
+	for i := 0; i < 55; i++ {
+		addTestOwner(t, server, fmt.Sprintf("bulk%02d", i), fmt.Sprintf("bulk%02d@example.com", i))
+	}
+
+	list := func(query string) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/admin/owners"+query, nil)
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+		server.HandleListChannelOwners(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		return w.Body.String()
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		want     []string
+		dontWant []string
+	}{
+		{
+			name:     "channel partial match",
+			query:    "?channel=beasty",
+			want:     []string{"beasty@example.com", "mod@beasty.tv", "2 owners matching"},
+			dontWant: []string{"marine@example.com", "Page 1 of"},
+		},
+		{
+			name:     "email partial match",
+			query:    "?email=example.com&channel=marine",
+			want:     []string{"marine@example.com", "1 owner matching"},
+			dontWant: []string{"beasty@example.com"},
+		},
+		{
+			name:  "no match",
+			query: "?email=nobody",
+			want:  []string{"No owners match your search"},
+		},
+		{
+			name:  "wildcards match literally",
+			query: "?channel=%25&email=bulk_0",
+			want:  []string{"No owners match your search"},
+		},
+		{
+			name:     "first page is capped at 50",
+			query:    "",
+			want:     []string{"58 owners", "Page 1 of 2", "bulk00@example.com"},
+			dontWant: []string{"marine@example.com"},
+		},
+		{
+			name:     "second page",
+			query:    "?page=2",
+			want:     []string{"Page 2 of 2", "marine@example.com", "← Previous"},
+			dontWant: []string{"bulk00@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := list(tt.query)
+			for _, s := range tt.want {
+				if !strings.Contains(body, s) {
+					t.Errorf("expected %q in page", s)
+				}
+			}
+			for _, s := range tt.dontWant {
+				if strings.Contains(body, s) {
+					t.Errorf("did not expect %q in page", s)
+				}
+			}
+		})
+	}
+
+	t.Run("non-admin forbidden", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin/owners?channel=beasty", nil)
+		req.Header.Set("X-ExeDev-Email", "beasty@example.com")
+		w := httptest.NewRecorder()
+		server.HandleListChannelOwners(w, req)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+}
+
+func TestChannelOwnersAPI(t *testing.T) {
+	mux := func(server *Server) *http.ServeMux {
+		m := http.NewServeMux()
+		m.HandleFunc("GET /api/admin/owners", server.HandleAPIListChannelOwners)
+		m.HandleFunc("POST /api/admin/owners", server.HandleAPIAddChannelOwner)
+		m.HandleFunc("DELETE /api/admin/owners", server.HandleAPIRemoveChannelOwner)
+		return m
+	}
+	do := func(m *http.ServeMux, method, target, email, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if email != "" {
+			req.Header.Set("X-ExeDev-UserID", "user123")
+			req.Header.Set("X-ExeDev-Email", email)
+		}
+		w := httptest.NewRecorder()
+		m.ServeHTTP(w, req)
+		return w
+	}
+	body := `{"channel": "OwnerChannel", "email": "Owner@Test.com"}`
+
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodDelete} {
+		t.Run(method+" requires authentication", func(t *testing.T) {
+			m := mux(testServer(t))
+			if w := do(m, method, "/api/admin/owners", "", body); w.Code != http.StatusUnauthorized {
+				t.Errorf("expected 401, got %d", w.Code)
+			}
+		})
+
+		t.Run(method+" requires admin", func(t *testing.T) {
+			server := testServer(t)
+			addTestOwner(t, server, "ownerchannel", "owner@test.com")
+			m := mux(server)
+			if w := do(m, method, "/api/admin/owners", "owner@test.com", body); w.Code != http.StatusForbidden {
+				t.Errorf("expected 403, got %d", w.Code)
+			}
+		})
+	}
+
+	t.Run("lists owners with optional channel filter", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "alpha", "a@test.com")
+		addTestOwner(t, server, "beta", "b@test.com")
+		addTestOwner(t, server, "alphabet", "c@test.com")
+		m := mux(server)
+
+		w := do(m, http.MethodGet, "/api/admin/owners", "admin@test.com", "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("expected application/json, got %q", ct)
+		}
+		var owners []ChannelOwnerResponse
+		if err := json.NewDecoder(w.Body).Decode(&owners); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if len(owners) != 3 {
+			t.Fatalf("expected 3 owners, got %d", len(owners))
+		}
+		if owners[0].Channel != "alpha" || owners[0].UserEmail != "a@test.com" || owners[0].AddedAt.IsZero() {
+			t.Errorf("unexpected first owner: %+v", owners[0])
+		}
+
+		// The filter is an exact match, unlike the admin page search
+		w = do(m, http.MethodGet, "/api/admin/owners?channel=Alpha", "admin@test.com", "")
+		owners = nil
+		if err := json.NewDecoder(w.Body).Decode(&owners); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if len(owners) != 1 || owners[0].UserEmail != "a@test.com" {
+			t.Errorf("expected only alpha's owner, got %+v", owners)
+		}
+	})
+
+	t.Run("empty list is an empty array", func(t *testing.T) {
+		m := mux(testServer(t))
+		w := do(m, http.MethodGet, "/api/admin/owners?channel=nobody", "admin@test.com", "")
+		if got := strings.TrimSpace(w.Body.String()); got != "[]" {
+			t.Errorf("expected [], got %s", got)
 		}
 	})
 
-	t.Run("creates suggestion with channel query param", func(t *testing.T) {
+	t.Run("adds and removes an owner", func(t *testing.T) {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodGet, "/api/suggest?text=Query+param+quote&channel=querychannel", nil)
-		w := httptest.NewRecorder()
+		m := mux(server)
+		q := dbgen.New(server.WriteDB)
 
-		server.HandleBotSuggestion(w, req)
+		w := do(m, http.MethodPost, "/api/admin/owners", "admin@test.com", body)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+		var added ChannelOwnerResponse
+		if err := json.NewDecoder(w.Body).Decode(&added); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if added.Channel != "ownerchannel" || added.UserEmail != "owner@test.com" || added.InvitedBy != "admin@test.com" {
+			t.Errorf("unexpected response: %+v", added)
+		}
+		isOwner, err := q.IsChannelOwner(context.Background(), dbgen.IsChannelOwnerParams{Channel: "ownerchannel", UserEmail: "owner@test.com"})
+		if err != nil || !isOwner {
+			t.Fatalf("expected owner to be stored, got %v, %v", isOwner, err)
+		}
 
-		if w.Code != http.StatusOK {
-			t.Errorf("expected 200, got %d", w.Code)
+		if w := do(m, http.MethodPost, "/api/admin/owners", "admin@test.com", body); w.Code != http.StatusConflict {
+			t.Errorf("expected 409 for a duplicate, got %d", w.Code)
+		}
+
+		if w := do(m, http.MethodDelete, "/api/admin/owners", "admin@test.com", body); w.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d: %s", w.Code, w.Body.String())
+		}
+		isOwner, err = q.IsChannelOwner(context.Background(), dbgen.IsChannelOwnerParams{Channel: "ownerchannel", UserEmail: "owner@test.com"})
+		if err != nil || isOwner {
+			t.Errorf("expected owner to be removed, got %v, %v", isOwner, err)
+		}
+
+		if w := do(m, http.MethodDelete, "/api/admin/owners", "admin@test.com", body); w.Code != http.StatusNotFound {
+			t.Errorf("expected 404 when removing again, got %d", w.Code)
+		}
+	})
+
+	t.Run("rejects bad bodies", func(t *testing.T) {
+		m := mux(testServer(t))
+		for _, method := range []string{http.MethodPost, http.MethodDelete} {
+			if w := do(m, method, "/api/admin/owners", "admin@test.com", "not json"); w.Code != http.StatusBadRequest {
+				t.Errorf("%s invalid JSON: expected 400, got %d", method, w.Code)
+			}
+			if w := do(m, method, "/api/admin/owners", "admin@test.com", `{"channel": "x"}`); w.Code != http.StatusBadRequest {
+				t.Errorf("%s missing email: expected 400, got %d", method, w.Code)
+			}
 		}
 	})
 }
 
-func TestHandleGetQuote(t *testing.T) {
-	t.Run("returns 400 for invalid ID", func(t *testing.T) {
-		server := testServer(t)
-		req := httptest.NewRequest(http.MethodGet, "/api/quote/abc", nil)
-		req.SetPathValue("id", "abc")
+func TestHandleSuggestForm_Defaults(t *testing.T) {
+	server := testServer(t)
+
+	t.Run("channel from Nightbot header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/suggest", nil)
+		req.Header.Set("Nightbot-Channel", "name=beastyqt&displayName=BeastyQT&provider=twitch&providerId=123")
 		w := httptest.NewRecorder()
 
-		server.HandleGetQuote(w, req)
+		server.HandleSuggestForm(w, req)
 
-		if w.Code != http.StatusBadRequest {
-			t.Errorf("expected 400, got %d", w.Code)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), `value="beastyqt"`) {
+			t.Error("expected channel pre-filled from Nightbot header")
 		}
 	})
 
-	t.Run("returns 404 for non-existent quote", func(t *testing.T) {
-		server := testServer(t)
-		req := httptest.NewRequest(http.MethodGet, "/api/quote/99999", nil)
-		req.SetPathValue("id", "99999")
+	t.Run("civ and opponent from query", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/suggest?civ=HRE&vs=French", nil)
 		w := httptest.NewRecorder()
 
-		server.HandleGetQuote(w, req)
+		server.HandleSuggestForm(w, req)
 
-		if w.Code != http.StatusNotFound {
-			t.Errorf("expected 404, got %d", w.Code)
+		body := w.Body.String()
+		if !strings.Contains(body, `<option value="hre" selected>`) {
+			t.Error("expected hre selected as civilization")
+		}
+		if !strings.Contains(body, `<option value="french" selected>`) {
+			t.Error("expected french selected as opponent")
 		}
 	})
 
-	t.Run("returns quote by ID", func(t *testing.T) {
-		server := testServer(t)
-		addTestQuote(t, server, "Quote by ID test", nil, nil)
-
-		q := dbgen.New(server.DB)
-		quotes, _ := q.ListAllQuotes(context.Background())
-		quoteID := quotes[0].ID
-
-		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/quote/%d", quoteID), nil)
-		req.SetPathValue("id", fmt.Sprintf("%d", quoteID))
+	t.Run("blank form without context", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/suggest?civ=notaciv", nil)
 		w := httptest.NewRecorder()
 
-		server.HandleGetQuote(w, req)
+		server.HandleSuggestForm(w, req)
 
-		if w.Code != http.StatusOK {
-			t.Errorf("expected 200, got %d", w.Code)
+		body := w.Body.String()
+		if strings.Contains(body, " selected>") {
+			t.Error("did not expect any civ to be selected")
 		}
-		if !strings.Contains(w.Body.String(), "Quote by ID test") {
-			t.Errorf("expected quote text, got: %s", w.Body.String())
+		if !strings.Contains(body, `placeholder="e.g., beastyqt" value=""`) {
+			t.Error("expected empty channel value")
 		}
 	})
+}
 
-	t.Run("returns JSON when Accept header requests it", func(t *testing.T) {
-		server := testServer(t)
-		addTestQuote(t, server, "JSON ID test", nil, nil)
-
-		q := dbgen.New(server.DB)
-		quotes, _ := q.ListAllQuotes(context.Background())
-		quoteID := quotes[0].ID
+func TestHandleSuggestForm_OwnedChannels(t *testing.T) {
+	server := testServer(t)
+	addTestOwner(t, server, "ownedone", "owner@test.com")
+	addTestOwner(t, server, "ownedtwo", "owner@test.com")
 
-		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/quote/%d", quoteID), nil)
-		req.SetPathValue("id", fmt.Sprintf("%d", quoteID))
-		req.Header.Set("Accept", "application/json")
+	suggestForm := func(target, email string) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if email != "" {
+			req.Header.Set("X-ExeDev-UserID", "user1")
+			req.Header.Set("X-ExeDev-Email", email)
+		}
 		w := httptest.NewRecorder()
+		server.HandleSuggestForm(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		return w.Body.String()
+	}
 
-		server.HandleGetQuote(w, req)
+	t.Run("owner gets a dropdown of owned channels", func(t *testing.T) {
+		body := suggestForm("/suggest", "owner@test.com")
+		if !strings.Contains(body, `<select id="channel" name="channel" required>`) {
+			t.Error("expected channel dropdown")
+		}
+		for _, ch := range []string{"ownedone", "ownedtwo"} {
+			if !strings.Contains(body, `<option value="`+ch+`"`) {
+				t.Errorf("expected %s in dropdown", ch)
+			}
+		}
+	})
 
-		if w.Code != http.StatusOK {
-			t.Errorf("expected 200, got %d", w.Code)
+	t.Run("bot channel is pre-selected when owned", func(t *testing.T) {
+		body := suggestForm("/suggest?channel=OwnedTwo", "owner@test.com")
+		if !strings.Contains(body, `<option value="ownedtwo" selected>`) {
+			t.Error("expected ownedtwo to be selected")
 		}
-		ct := w.Header().Get("Content-Type")
-		if !strings.Contains(ct, "application/json") {
-			t.Errorf("expected application/json, got %s", ct)
+	})
+
+	t.Run("unowned bot channel keeps the text input", func(t *testing.T) {
+		body := suggestForm("/suggest?channel=elsewhere", "owner@test.com")
+		if strings.Contains(body, `<select id="channel"`) {
+			t.Error("did not expect channel dropdown")
+		}
+		if !strings.Contains(body, `value="elsewhere"`) {
+			t.Error("expected channel pre-filled as text")
+		}
+	})
+
+	t.Run("signed-in user without channels gets the text input", func(t *testing.T) {
+		body := suggestForm("/suggest", "viewer@test.com")
+		if strings.Contains(body, `<select id="channel"`) {
+			t.Error("did not expect channel dropdown")
+		}
+		if !strings.Contains(body, "viewer@test.com") {
+			t.Error("expected signed-in nav")
+		}
+	})
+
+	t.Run("anonymous user gets the text input", func(t *testing.T) {
+		body := suggestForm("/suggest", "")
+		if strings.Contains(body, `<select id="channel"`) {
+			t.Error("did not expect channel dropdown")
 		}
 	})
 }
 
-func TestHandleEditQuote(t *testing.T) {
-	t.Run("redirects to login when not authenticated", func(t *testing.T) {
-		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/quotes/1/edit", strings.NewReader("text=edited"))
-		req.SetPathValue("id", "1")
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-		w := httptest.NewRecorder()
+func TestHandleSuggestForm_CheckLimit(t *testing.T) {
+	server := testServer(t)
+	server.Config.SuggestionRateLimit = 5
 
-		server.HandleEditQuote(w, req)
+	checkLimit := func(target string) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		w := httptest.NewRecorder()
+		server.HandleSuggestForm(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		return w.Body.String()
+	}
 
-		if w.Code != http.StatusSeeOther {
-			t.Errorf("expected 303, got %d", w.Code)
+	t.Run("hidden without check_limit", func(t *testing.T) {
+		body := checkLimit("/suggest?channel=limitchannel")
+		if strings.Contains(body, `id="suggestionLimit"`) {
+			t.Error("did not expect limit notice without check_limit")
 		}
-		loc := w.Header().Get("Location")
-		// Accept either exe.dev login or Twitch auth redirect
-		if !strings.Contains(loc, "login") && !strings.Contains(loc, "/auth/twitch") {
-			t.Errorf("expected redirect to login or auth, got: %s", loc)
+	})
+
+	t.Run("hidden without channel", func(t *testing.T) {
+		body := checkLimit("/suggest?check_limit=true")
+		if strings.Contains(body, `id="suggestionLimit"`) {
+			t.Error("did not expect limit notice without a channel")
 		}
 	})
 
-	t.Run("returns 404 for non-existent quote", func(t *testing.T) {
-		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/quotes/99999/edit", strings.NewReader("text=edited"))
-		req.SetPathValue("id", "99999")
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-		req.Header.Set("X-ExeDev-UserID", "admin123")
-		req.Header.Set("X-ExeDev-Email", "admin@test.com")
-		w := httptest.NewRecorder()
+	t.Run("count increases after a suggestion", func(t *testing.T) {
+		body := checkLimit("/suggest?channel=limitchannel&check_limit=true")
+		if !strings.Contains(body, "0 of 5 suggestions used this hour") {
+			t.Fatalf("expected empty budget, got: %s", body)
+		}
 
-		server.HandleEditQuote(w, req)
+		req := httptest.NewRequest(http.MethodGet, "/api/suggest?channel=limitchannel&text=A+quote+long+enough+to+pass", nil)
+		w := httptest.NewRecorder()
+		server.HandleBotSuggestion(w, req)
+		if !strings.Contains(w.Body.String(), "submitted") {
+			t.Fatalf("expected suggestion to be submitted, got: %s", w.Body.String())
+		}
 
-		if w.Code != http.StatusNotFound {
-			t.Errorf("expected 404, got %d", w.Code)
+		body = checkLimit("/suggest?channel=limitchannel&check_limit=true")
+		if !strings.Contains(body, "1 of 5 suggestions used this hour") {
+			t.Errorf("expected one suggestion used, got: %s", body)
 		}
 	})
+}
 
-	t.Run("admin can edit any quote", func(t *testing.T) {
-		server := testServer(t)
-		channel := "editchannel"
-		addTestQuote(t, server, "Original text", nil, &channel)
+// countQuotesForCiv returns how many quotes have civName as their civilization
+func countQuotesForCiv(t *testing.T, s *Server, civName string) int64 {
+	t.Helper()
+	count, err := dbgen.New(s.WriteDB).CountQuotesByCiv(context.Background(), &civName)
+	if err != nil {
+		t.Fatalf("count quotes for %s: %v", civName, err)
+	}
+	return count
+}
 
-		q := dbgen.New(server.DB)
-		quotes, _ := q.ListAllQuotes(context.Background())
-		quoteID := quotes[0].ID
+func TestHandleDeleteCiv(t *testing.T) {
+	const civName = "Testland"
 
-		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/quotes/%d/edit", quoteID), 
-			strings.NewReader("text=Edited+text&channel=editchannel"))
-		req.SetPathValue("id", fmt.Sprintf("%d", quoteID))
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-		req.Header.Set("X-ExeDev-UserID", "admin123")
-		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+	setup := func(t *testing.T, quotes int) (*Server, int64) {
+		t.Helper()
+		server := testServer(t)
+		addTestCiv(t, server, civName, "tst")
+		civ, err := dbgen.New(server.WriteDB).GetCivByName(context.Background(), civName)
+		if err != nil {
+			t.Fatalf("get civ: %v", err)
+		}
+		name := civName
+		for i := 0; i < quotes; i++ {
+			addTestQuote(t, server, fmt.Sprintf("Testland quote %d", i), &name, nil)
+		}
+		return server, civ.ID
+	}
+
+	deleteAs := func(server *Server, email, target, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, nil)
+		req.SetPathValue("id", id)
+		if email != "" {
+			req.Header.Set("X-ExeDev-UserID", "user123")
+			req.Header.Set("X-ExeDev-Email", email)
+		}
 		w := httptest.NewRecorder()
+		server.HandleDeleteCiv(w, req)
+		return w
+	}
+
+	civExists := func(t *testing.T, server *Server) bool {
+		t.Helper()
+		_, err := dbgen.New(server.WriteDB).GetCivByName(context.Background(), civName)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			t.Fatalf("get civ: %v", err)
+		}
+		return err == nil
+	}
 
-		server.HandleEditQuote(w, req)
+	t.Run("deletes civ without quotes", func(t *testing.T) {
+		server, id := setup(t, 0)
 
+		w := deleteAs(server, "user@test.com", fmt.Sprintf("/civs/%d/delete", id), fmt.Sprintf("%d", id))
 		if w.Code != http.StatusSeeOther {
-			t.Errorf("expected 303, got %d", w.Code)
+			t.Fatalf("expected 303, got %d", w.Code)
+		}
+		if loc := w.Header().Get("Location"); !strings.HasPrefix(loc, "/civs?success=") {
+			t.Errorf("expected success redirect, got %q", loc)
+		}
+		if civExists(t, server) {
+			t.Error("expected civ to be deleted")
 		}
+	})
+
+	t.Run("refuses civ with quotes", func(t *testing.T) {
+		server, id := setup(t, 2)
 
-		// Verify quote was updated
-		updated, _ := q.GetQuoteByID(context.Background(), quoteID)
-		if updated.Text != "Edited text" {
-			t.Errorf("expected 'Edited text', got %s", updated.Text)
+		w := deleteAs(server, "admin@test.com", fmt.Sprintf("/civs/%d/delete", id), fmt.Sprintf("%d", id))
+		loc := w.Header().Get("Location")
+		if !strings.HasPrefix(loc, "/civs?error=") || !strings.Contains(loc, "2+quotes") {
+			t.Errorf("expected error redirect with the quote count, got %q", loc)
+		}
+		if !civExists(t, server) {
+			t.Error("expected civ to remain")
+		}
+		if got := countQuotesForCiv(t, server, civName); got != 2 {
+			t.Errorf("expected 2 quotes to keep the civ, got %d", got)
 		}
 	})
 
-	t.Run("returns 403 when user cannot manage channel", func(t *testing.T) {
-		server := testServer(t)
-		channel := "otherchannel"
-		addTestQuote(t, server, "Protected quote", nil, &channel)
+	t.Run("admin force-delete clears quote civ", func(t *testing.T) {
+		server, id := setup(t, 2)
+		name := civName
+		mongols := "Mongols"
+		q := dbgen.New(server.WriteDB)
+		if _, err := q.CreateQuote(context.Background(), dbgen.CreateQuoteParams{
+			Text:         "Mongols vs Testland tip",
+			Civilization: &mongols,
+			OpponentCiv:  &name,
+		}); err != nil {
+			t.Fatalf("create matchup quote: %v", err)
+		}
 
-		q := dbgen.New(server.DB)
-		quotes, _ := q.ListAllQuotes(context.Background())
-		quoteID := quotes[0].ID
+		w := deleteAs(server, "admin@test.com", fmt.Sprintf("/civs/%d/delete?force=true", id), fmt.Sprintf("%d", id))
+		if loc := w.Header().Get("Location"); !strings.HasPrefix(loc, "/civs?success=") {
+			t.Fatalf("expected success redirect, got %d %q", w.Code, loc)
+		}
+		if civExists(t, server) {
+			t.Error("expected civ to be deleted")
+		}
+		if got := countQuotesForCiv(t, server, civName); got != 0 {
+			t.Errorf("expected no quotes to reference the civ, got %d", got)
+		}
 
-		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/quotes/%d/edit", quoteID), 
-			strings.NewReader("text=Hacked"))
-		req.SetPathValue("id", fmt.Sprintf("%d", quoteID))
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-		req.Header.Set("X-ExeDev-UserID", "user123")
-		req.Header.Set("X-ExeDev-Email", "hacker@test.com")
-		w := httptest.NewRecorder()
+		remaining, _ := q.ListAllQuotes(context.Background())
+		if len(remaining) != 3 {
+			t.Fatalf("expected all 3 quotes to survive, got %d", len(remaining))
+		}
+		for _, quote := range remaining {
+			if quote.Civilization != nil && *quote.Civilization == civName {
+				t.Errorf("quote %d still has civilization %q", quote.ID, civName)
+			}
+			if quote.OpponentCiv != nil && *quote.OpponentCiv == civName {
+				t.Errorf("quote %d still has opponent civ %q", quote.ID, civName)
+			}
+		}
+	})
 
-		server.HandleEditQuote(w, req)
+	t.Run("non-admin force-delete returns 403", func(t *testing.T) {
+		server, id := setup(t, 1)
 
+		w := deleteAs(server, "user@test.com", fmt.Sprintf("/civs/%d/delete?force=true", id), fmt.Sprintf("%d", id))
 		if w.Code != http.StatusForbidden {
 			t.Errorf("expected 403, got %d", w.Code)
 		}
+		if !civExists(t, server) {
+			t.Error("expected civ to remain")
+		}
+	})
+
+	t.Run("unknown civ redirects with error", func(t *testing.T) {
+		server, _ := setup(t, 0)
+
+		w := deleteAs(server, "admin@test.com", "/civs/99999/delete", "99999")
+		if loc := w.Header().Get("Location"); !strings.HasPrefix(loc, "/civs?error=") {
+			t.Errorf("expected error redirect, got %d %q", w.Code, loc)
+		}
+	})
+
+	t.Run("unauthenticated redirects to login", func(t *testing.T) {
+		server, id := setup(t, 0)
+
+		w := deleteAs(server, "", fmt.Sprintf("/civs/%d/delete", id), fmt.Sprintf("%d", id))
+		if w.Code != http.StatusSeeOther {
+			t.Errorf("expected 303, got %d", w.Code)
+		}
+		if loc := w.Header().Get("Location"); !strings.Contains(loc, "login") {
+			t.Errorf("expected login redirect, got %q", loc)
+		}
+		if !civExists(t, server) {
+			t.Error("expected civ to remain")
+		}
 	})
 }
 
-func TestHandleListAllQuotes(t *testing.T) {
-	t.Run("returns empty array when no quotes", func(t *testing.T) {
+func TestHandleListCivQuotes(t *testing.T) {
+	setup := func(t *testing.T) (*Server, http.Handler) {
+		t.Helper()
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodGet, "/api/quotes", nil)
+		hre := "Holy Roman Empire"
+		french := "French"
+		alpha := "alpha"
+		beta := "beta"
+		addTestQuote(t, server, "HRE global quote", &hre, nil)
+		addTestQuote(t, server, "HRE alpha quote", &hre, &alpha)
+		addTestQuote(t, server, "HRE beta quote", &hre, &beta)
+		addTestQuote(t, server, "French quote", &french, nil)
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /api/civs/{civ}/quotes", server.HandleListCivQuotes)
+		return server, mux
+	}
+
+	get := func(handler http.Handler, target string) *httptest.ResponseRecorder {
 		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w
+	}
 
-		server.HandleListAllQuotes(w, req)
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) []QuoteResponse {
+		t.Helper()
+		var quotes []QuoteResponse
+		if err := json.NewDecoder(w.Body).Decode(&quotes); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return quotes
+	}
+
+	t.Run("resolves shortname", func(t *testing.T) {
+		_, handler := setup(t)
 
+		w := get(handler, "/api/civs/hre/quotes")
 		if w.Code != http.StatusOK {
-			t.Errorf("expected 200, got %d", w.Code)
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
 		}
-		if w.Body.String() != "[]\n" {
-			t.Errorf("expected empty array, got: %s", w.Body.String())
+		if got := w.Header().Get("X-Total-Count"); got != "3" {
+			t.Errorf("expected X-Total-Count 3, got %q", got)
+		}
+		quotes := decode(t, w)
+		if len(quotes) != 3 {
+			t.Fatalf("expected 3 quotes, got %d", len(quotes))
+		}
+		for _, q := range quotes {
+			if q.Civilization == nil || *q.Civilization != "Holy Roman Empire" {
+				t.Errorf("expected only HRE quotes, got %v", q.Civilization)
+			}
 		}
 	})
 
-	t.Run("returns JSON array of quotes", func(t *testing.T) {
-		server := testServer(t)
-		addTestQuote(t, server, "Quote 1", nil, nil)
-		addTestQuote(t, server, "Quote 2", nil, nil)
+	t.Run("paginates with limit", func(t *testing.T) {
+		_, handler := setup(t)
 
-		req := httptest.NewRequest(http.MethodGet, "/api/quotes", nil)
-		w := httptest.NewRecorder()
+		w := get(handler, "/api/civs/Holy%20Roman%20Empire/quotes?limit=2&page=2")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if got := w.Header().Get("X-Total-Count"); got != "3" {
+			t.Errorf("expected X-Total-Count 3, got %q", got)
+		}
+		if quotes := decode(t, w); len(quotes) != 1 {
+			t.Errorf("expected 1 quote on page 2, got %d", len(quotes))
+		}
+	})
 
-		server.HandleListAllQuotes(w, req)
+	t.Run("filters by channel", func(t *testing.T) {
+		_, handler := setup(t)
 
+		w := get(handler, "/api/civs/hre/quotes?channel=alpha")
 		if w.Code != http.StatusOK {
-			t.Errorf("expected 200, got %d", w.Code)
+			t.Fatalf("expected 200, got %d", w.Code)
 		}
-		ct := w.Header().Get("Content-Type")
-		if !strings.Contains(ct, "application/json") {
-			t.Errorf("expected application/json, got %s", ct)
+		if got := w.Header().Get("X-Total-Count"); got != "1" {
+			t.Errorf("expected X-Total-Count 1, got %q", got)
 		}
-		if !strings.Contains(w.Body.String(), "Quote 1") || !strings.Contains(w.Body.String(), "Quote 2") {
-			t.Errorf("expected both quotes, got: %s", w.Body.String())
+		quotes := decode(t, w)
+		if len(quotes) != 1 || quotes[0].Text != "HRE alpha quote" {
+			t.Errorf("expected only the alpha quote, got %+v", quotes)
+		}
+	})
+
+	t.Run("unknown civ returns 400", func(t *testing.T) {
+		_, handler := setup(t)
+
+		w := get(handler, "/api/civs/notaciv/quotes")
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "Unknown civilization") {
+			t.Errorf("expected unknown civilization message, got %q", w.Body.String())
+		}
+	})
+
+	t.Run("known civ without quotes returns 404", func(t *testing.T) {
+		_, handler := setup(t)
+
+		w := get(handler, "/api/civs/mongols/quotes")
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "No quotes for Mongols yet") {
+			t.Errorf("expected helpful message, got %q", w.Body.String())
 		}
 	})
 }
 
-func TestHandleListSuggestions(t *testing.T) {
-	t.Run("redirects when not authenticated", func(t *testing.T) {
-		server := testServer(t)
-		req := httptest.NewRequest(http.MethodGet, "/suggestions", nil)
+func TestHandleGetRandomCiv(t *testing.T) {
+	getCiv := func(t *testing.T, server *Server, query string) map[string]any {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/civs/random"+query, nil)
 		w := httptest.NewRecorder()
+		server.HandleGetRandomCiv(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		var resp map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		return resp
+	}
 
-		server.HandleListSuggestions(w, req)
+	t.Run("returns civ fields and varies between calls", func(t *testing.T) {
+		server := testServer(t)
+		seen := make(map[string]bool)
+		for i := 0; i < 30; i++ {
+			resp := getCiv(t, server, "")
+			for _, field := range []string{"id", "name", "shortname", "dlc"} {
+				if _, ok := resp[field]; !ok {
+					t.Fatalf("missing field %q in %v", field, resp)
+				}
+			}
+			seen[resp["name"].(string)] = true
+		}
+		if len(seen) < 2 {
+			t.Errorf("expected different civs across calls, got %v", seen)
+		}
+	})
 
-		if w.Code != http.StatusSeeOther {
-			t.Errorf("expected 303, got %d", w.Code)
+	t.Run("exclude skips listed civs", func(t *testing.T) {
+		server := testServer(t)
+		q := dbgen.New(server.WriteDB)
+		civs, err := q.ListCivs(context.Background())
+		if err != nil {
+			t.Fatalf("list civs: %v", err)
+		}
+		// Exclude all but English, mixing names and shortnames
+		var exclude []string
+		for i, c := range civs {
+			if c.Name == "English" {
+				continue
+			}
+			if i%2 == 0 && c.Shortname != nil {
+				exclude = append(exclude, strings.ToUpper(*c.Shortname))
+			} else {
+				exclude = append(exclude, c.Name)
+			}
+		}
+		query := "?exclude=" + url.QueryEscape(strings.Join(exclude, ", "))
+		for i := 0; i < 5; i++ {
+			if resp := getCiv(t, server, query); resp["name"] != "English" {
+				t.Fatalf("expected English, got %v", resp["name"])
+			}
 		}
 	})
 
-	t.Run("returns 403 for non-admin non-owner", func(t *testing.T) {
+	t.Run("has_quotes limits to civs with quotes", func(t *testing.T) {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodGet, "/suggestions", nil)
-		req.Header.Set("X-ExeDev-UserID", "user123")
-		req.Header.Set("X-ExeDev-Email", "nobody@test.com")
-		w := httptest.NewRecorder()
-
-		server.HandleListSuggestions(w, req)
+		civ := "Mongols"
+		addTestQuote(t, server, "Mongols raid early and often", &civ, nil)
 
-		if w.Code != http.StatusForbidden {
-			t.Errorf("expected 403, got %d", w.Code)
+		for i := 0; i < 5; i++ {
+			if resp := getCiv(t, server, "?has_quotes=true"); resp["name"] != "Mongols" {
+				t.Fatalf("expected Mongols, got %v", resp["name"])
+			}
 		}
 	})
 
-	t.Run("admin can list all suggestions", func(t *testing.T) {
+	t.Run("no match returns error JSON", func(t *testing.T) {
 		server := testServer(t)
-		addTestSuggestion(t, server, "Test suggestion", "testchannel")
 
-		req := httptest.NewRequest(http.MethodGet, "/suggestions", nil)
-		req.Header.Set("X-ExeDev-UserID", "admin123")
-		req.Header.Set("X-ExeDev-Email", "admin@test.com")
-		w := httptest.NewRecorder()
+		resp := getCiv(t, server, "?has_quotes=true")
 
-		server.HandleListSuggestions(w, req)
+		if resp["error"] != "no civilizations" {
+			t.Errorf("expected no civilizations error, got %v", resp)
+		}
+	})
+}
 
+func TestHandleListCivs(t *testing.T) {
+	listCivs := func(t *testing.T, server *Server, query string) []CivSummaryResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/civs"+query, nil)
+		w := httptest.NewRecorder()
+		server.HandleListCivs(w, req)
 		if w.Code != http.StatusOK {
-			t.Errorf("expected 200, got %d", w.Code)
+			t.Fatalf("expected 200, got %d", w.Code)
 		}
-		if !strings.Contains(w.Body.String(), "Test suggestion") {
-			t.Errorf("expected suggestion in response")
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("expected application/json, got %s", ct)
 		}
-	})
+		var civs []CivSummaryResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &civs); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		return civs
+	}
 
-	t.Run("channel owner can list their channel suggestions", func(t *testing.T) {
+	setup := func(t *testing.T) *Server {
 		server := testServer(t)
-		q := dbgen.New(server.DB)
-		_ = q.AddChannelOwner(context.Background(), dbgen.AddChannelOwnerParams{
-			Channel:   "ownedchannel",
-			UserEmail: "owner@test.com",
-			InvitedBy: "admin@test.com",
-		})
-		addTestSuggestion(t, server, "Owned channel suggestion", "ownedchannel")
+		french, english := "French", "English"
+		addTestQuote(t, server, "Royal Knights win the early fight", &french, nil)
+		addTestQuote(t, server, "Use the Royal Institute early", &french, nil)
+		addTestQuote(t, server, "Longbows behind palisades", &english, nil)
+		return server
+	}
 
-		req := httptest.NewRequest(http.MethodGet, "/suggestions", nil)
-		req.Header.Set("X-ExeDev-UserID", "owner123")
-		req.Header.Set("X-ExeDev-Email", "owner@test.com")
-		w := httptest.NewRecorder()
+	t.Run("lists every civ with quote counts", func(t *testing.T) {
+		server := setup(t)
+		all, err := dbgen.New(server.WriteDB).ListCivs(context.Background())
+		if err != nil {
+			t.Fatalf("list civs: %v", err)
+		}
 
-		server.HandleListSuggestions(w, req)
+		civs := listCivs(t, server, "")
+		if len(civs) != len(all) {
+			t.Fatalf("expected %d civs, got %d", len(all), len(civs))
+		}
+		if !slices.IsSortedFunc(civs, func(a, b CivSummaryResponse) int { return strings.Compare(a.Name, b.Name) }) {
+			t.Errorf("expected civs sorted by name")
+		}
+		counts := make(map[string]int64)
+		for _, civ := range civs {
+			counts[civ.Name] = civ.QuoteCount
+		}
+		if counts["French"] != 2 || counts["English"] != 1 {
+			t.Errorf("expected French 2 and English 1, got %v", counts)
+		}
+	})
 
-		if w.Code != http.StatusOK {
-			t.Errorf("expected 200, got %d", w.Code)
+	t.Run("withQuotes skips civs without quotes", func(t *testing.T) {
+		server := setup(t)
+		civs := listCivs(t, server, "?withQuotes=true")
+		var names []string
+		for _, civ := range civs {
+			names = append(names, civ.Name)
+		}
+		if !slices.Equal(names, []string{"English", "French"}) {
+			t.Errorf("expected [English French], got %v", names)
 		}
 	})
-}
 
-func TestHandleRejectSuggestion(t *testing.T) {
-	t.Run("returns 401 when not authenticated", func(t *testing.T) {
+	t.Run("returns an empty array when nothing matches", func(t *testing.T) {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/suggestions/1/reject", nil)
-		req.SetPathValue("id", "1")
+		req := httptest.NewRequest(http.MethodGet, "/api/civs?withQuotes=true", nil)
 		w := httptest.NewRecorder()
-
-		server.HandleRejectSuggestion(w, req)
-
-		if w.Code != http.StatusUnauthorized {
-			t.Errorf("expected 401, got %d", w.Code)
+		server.HandleListCivs(w, req)
+		if got := strings.TrimSpace(w.Body.String()); got != "[]" {
+			t.Errorf("expected [], got %s", got)
 		}
 	})
+}
 
-	t.Run("returns 400 for invalid ID", func(t *testing.T) {
-		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/suggestions/abc/reject", nil)
-		req.SetPathValue("id", "abc")
-		req.Header.Set("X-ExeDev-UserID", "admin123")
-		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+func TestHandleVoteQuote(t *testing.T) {
+	vote := func(server *Server, id, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/quotes/"+id+"/vote", strings.NewReader(body))
+		req.SetPathValue("id", id)
+		req.RemoteAddr = "192.0.2.1:1234"
 		w := httptest.NewRecorder()
+		server.HandleVoteQuote(w, req)
+		return w
+	}
 
-		server.HandleRejectSuggestion(w, req)
+	t.Run("counts up and down votes", func(t *testing.T) {
+		server := testServer(t)
+		addTestQuote(t, server, "Boom behind walls", nil, nil)
 
-		if w.Code != http.StatusBadRequest {
-			t.Errorf("expected 400, got %d", w.Code)
+		vote(server, "1", `{"direction": "up"}`)
+		vote(server, "1", `{"direction": "up"}`)
+		w := vote(server, "1", `{"direction": "down"}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+
+		var resp QuoteResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if resp.Upvotes != 2 || resp.Downvotes != 1 {
+			t.Errorf("expected 2 up and 1 down, got %d up and %d down", resp.Upvotes, resp.Downvotes)
 		}
 	})
 
-	t.Run("returns 404 for non-existent suggestion", func(t *testing.T) {
+	t.Run("returns 404 for missing quote", func(t *testing.T) {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/suggestions/99999/reject", nil)
-		req.SetPathValue("id", "99999")
-		req.Header.Set("X-ExeDev-UserID", "admin123")
-		req.Header.Set("X-ExeDev-Email", "admin@test.com")
-		w := httptest.NewRecorder()
-
-		server.HandleRejectSuggestion(w, req)
-
+		w := vote(server, "999", `{"direction": "up"}`)
 		if w.Code != http.StatusNotFound {
 			t.Errorf("expected 404, got %d", w.Code)
 		}
 	})
 
-	t.Run("returns 403 when user cannot manage channel", func(t *testing.T) {
+	t.Run("rejects invalid input", func(t *testing.T) {
 		server := testServer(t)
-		id := addTestSuggestion(t, server, "Protected suggestion", "otherchannel")
-
-		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/suggestions/%d/reject", id), nil)
-		req.SetPathValue("id", fmt.Sprintf("%d", id))
-		req.Header.Set("X-ExeDev-UserID", "user123")
-		req.Header.Set("X-ExeDev-Email", "nobody@test.com")
-		w := httptest.NewRecorder()
-
-		server.HandleRejectSuggestion(w, req)
-
-		if w.Code != http.StatusForbidden {
-			t.Errorf("expected 403, got %d", w.Code)
+		addTestQuote(t, server, "Boom behind walls", nil, nil)
+		for _, tc := range []struct{ id, body string }{
+			{"1", `{"direction": "sideways"}`},
+			{"1", `{}`},
+			{"1", `not json`},
+			{"abc", `{"direction": "up"}`},
+		} {
+			if w := vote(server, tc.id, tc.body); w.Code != http.StatusBadRequest {
+				t.Errorf("id %q body %q: expected 400, got %d", tc.id, tc.body, w.Code)
+			}
 		}
 	})
 
-	t.Run("admin can reject any suggestion", func(t *testing.T) {
+	t.Run("rate limits votes per IP", func(t *testing.T) {
 		server := testServer(t)
-		id := addTestSuggestion(t, server, "To be rejected", "anychannel")
-
-		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/suggestions/%d/reject", id), nil)
-		req.SetPathValue("id", fmt.Sprintf("%d", id))
-		req.Header.Set("X-ExeDev-UserID", "admin123")
-		req.Header.Set("X-ExeDev-Email", "admin@test.com")
-		w := httptest.NewRecorder()
+		server.VoteLimiter = NewRateLimiter(1, time.Hour, 1)
+		addTestQuote(t, server, "Boom behind walls", nil, nil)
 
-		server.HandleRejectSuggestion(w, req)
+		if w := vote(server, "1", `{"direction": "up"}`); w.Code != http.StatusOK {
+			t.Fatalf("first vote: expected 200, got %d", w.Code)
+		}
+		if w := vote(server, "1", `{"direction": "up"}`); w.Code != http.StatusTooManyRequests {
+			t.Errorf("second vote: expected 429, got %d", w.Code)
+		}
 
-		if w.Code != http.StatusSeeOther {
-			t.Errorf("expected 303, got %d", w.Code)
+		quote, err := dbgen.New(server.WriteDB).GetQuoteByID(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("get quote: %v", err)
 		}
+		if quote.Upvotes != 1 {
+			t.Errorf("expected the limited vote not to count, got %d upvotes", quote.Upvotes)
+		}
+	})
 
-		// Verify suggestion was rejected
-		q := dbgen.New(server.DB)
-		suggestion, _ := q.GetSuggestionByID(context.Background(), id)
-		if suggestion.Status != "rejected" {
-			t.Errorf("expected rejected status, got %s", suggestion.Status)
+	t.Run("both quote paths route to the vote handler", func(t *testing.T) {
+		server := testServer(t)
+		addTestQuote(t, server, "Boom behind walls", nil, nil)
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /api/quotes/{id}/vote", server.HandleVoteQuote)
+		mux.HandleFunc("POST /api/quote/{id}/vote", server.HandleVoteQuote)
+
+		for _, path := range []string{"/api/quotes/1/vote", "/api/quote/1/vote"} {
+			req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"direction": "up"}`))
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Errorf("%s: expected 200, got %d", path, w.Code)
+			}
 		}
 	})
 }
 
-func TestHandleAddChannelOwner(t *testing.T) {
-	t.Run("returns 401 when not authenticated", func(t *testing.T) {
+func TestHandleTopQuotes(t *testing.T) {
+	setup := func(t *testing.T) *Server {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/admin/owners", strings.NewReader("channel=test&email=user@test.com"))
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		ch := "streamer1"
+		addTestQuote(t, server, "Net one", nil, nil)
+		addTestQuote(t, server, "Net three", nil, &ch)
+		addTestQuote(t, server, "Net minus one", nil, nil)
+		addTestQuote(t, server, "Net one with more votes", nil, nil)
+
+		q := dbgen.New(server.WriteDB)
+		ctx := context.Background()
+		votes := map[int64][2]int{1: {1, 0}, 2: {3, 0}, 3: {0, 1}, 4: {2, 1}}
+		for id, v := range votes {
+			for range v[0] {
+				if _, err := q.IncrementUpvote(ctx, id); err != nil {
+					t.Fatalf("upvote: %v", err)
+				}
+			}
+			for range v[1] {
+				if _, err := q.IncrementDownvote(ctx, id); err != nil {
+					t.Fatalf("downvote: %v", err)
+				}
+			}
+		}
+		return server
+	}
+	top := func(t *testing.T, server *Server, target string) []string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, target, nil)
 		w := httptest.NewRecorder()
+		server.HandleTopQuotes(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var quotes []QuoteResponse
+		if err := json.NewDecoder(w.Body).Decode(&quotes); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		var texts []string
+		for _, q := range quotes {
+			texts = append(texts, q.Text)
+		}
+		return texts
+	}
 
-		server.HandleAddChannelOwner(w, req)
+	t.Run("orders by net score then upvotes", func(t *testing.T) {
+		server := setup(t)
+		got := top(t, server, "/api/quotes/top")
+		want := []string{"Net three", "Net one with more votes", "Net one", "Net minus one"}
+		if !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
 
-		if w.Code != http.StatusUnauthorized {
-			t.Errorf("expected 401, got %d", w.Code)
+	t.Run("applies limit", func(t *testing.T) {
+		server := setup(t)
+		got := top(t, server, "/api/quotes/top?limit=2")
+		if len(got) != 2 || got[0] != "Net three" {
+			t.Errorf("expected top 2 starting with Net three, got %v", got)
 		}
 	})
 
-	t.Run("returns 403 for non-admin", func(t *testing.T) {
-		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/admin/owners", strings.NewReader("channel=test&email=user@test.com"))
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-		req.Header.Set("X-ExeDev-UserID", "user123")
-		req.Header.Set("X-ExeDev-Email", "user@test.com")
-		w := httptest.NewRecorder()
+	t.Run("filters by channel", func(t *testing.T) {
+		server := setup(t)
+		got := top(t, server, "/api/quotes/top?channel=streamer1")
+		if !slices.Equal(got, []string{"Net three"}) {
+			t.Errorf("expected only the channel quote, got %v", got)
+		}
+	})
 
-		server.HandleAddChannelOwner(w, req)
+	t.Run("rejects invalid limit", func(t *testing.T) {
+		server := setup(t)
+		for _, limit := range []string{"0", "-1", "abc"} {
+			req := httptest.NewRequest(http.MethodGet, "/api/quotes/top?limit="+limit, nil)
+			w := httptest.NewRecorder()
+			server.HandleTopQuotes(w, req)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("limit %q: expected 400, got %d", limit, w.Code)
+			}
+		}
+	})
 
-		if w.Code != http.StatusForbidden {
-			t.Errorf("expected 403, got %d", w.Code)
+	t.Run("route is not shadowed by the quote ID route", func(t *testing.T) {
+		server := setup(t)
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /api/quotes/top", server.HandleTopQuotes)
+		mux.HandleFunc("GET /api/quotes/{id}", server.HandleGetQuote)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/quotes/top", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		if !strings.HasPrefix(strings.TrimSpace(w.Body.String()), "[") {
+			t.Errorf("expected a JSON array, got %d: %s", w.Code, w.Body.String())
 		}
 	})
+}
 
-	t.Run("redirects with error when channel or email missing", func(t *testing.T) {
-		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/admin/owners", strings.NewReader("channel=test"))
+func TestDuplicateQuoteDetection(t *testing.T) {
+	ch := "streamer1"
+	addQuote := func(server *Server, form string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/quotes", strings.NewReader(form))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		req.Header.Set("X-ExeDev-UserID", "admin123")
 		req.Header.Set("X-ExeDev-Email", "admin@test.com")
 		w := httptest.NewRecorder()
-
-		server.HandleAddChannelOwner(w, req)
-
-		if w.Code != http.StatusSeeOther {
-			t.Errorf("expected 303, got %d", w.Code)
+		server.HandleAddQuote(w, req)
+		return w
+	}
+	approve := func(server *Server, id int64, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/suggestions/%d/approve%s", id, query), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", id))
+		req.Header.Set("X-ExeDev-UserID", "admin123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+		server.HandleApproveSuggestion(w, req)
+		return w
+	}
+	countQuotes := func(t *testing.T, server *Server) int {
+		t.Helper()
+		quotes, err := dbgen.New(server.WriteDB).ListAllQuotes(context.Background())
+		if err != nil {
+			t.Fatalf("list quotes: %v", err)
 		}
-		loc := w.Header().Get("Location")
-		if !strings.Contains(loc, "error=") {
-			t.Errorf("expected error in redirect, got %s", loc)
+		return len(quotes)
+	}
+
+	t.Run("finds exact matches ignoring case and whitespace", func(t *testing.T) {
+		server := testServer(t)
+		addTestQuote(t, server, "Wall early and boom", nil, &ch)
+		addTestQuote(t, server, "Global quote text", nil, nil)
+		ctx := context.Background()
+
+		tests := []struct {
+			text    string
+			channel *string
+			want    int64
+		}{
+			{"Wall early and boom", &ch, 1},
+			{"  WALL EARLY AND BOOM ", strPtr("Streamer1"), 1},
+			{"Wall early and boom!", &ch, 0},
+			{"Wall early and boom", strPtr("streamer2"), 0},
+			{"Wall early and boom", nil, 0},
+			{"global quote text", nil, 2},
+			{"Global quote text", &ch, 0},
+		}
+		for _, tt := range tests {
+			if got := server.findDuplicateQuote(ctx, tt.text, tt.channel); got != tt.want {
+				t.Errorf("findDuplicateQuote(%q, %v) = %d, want %d", tt.text, stringOrEmpty(tt.channel), got, tt.want)
+			}
 		}
 	})
 
-	t.Run("admin can add channel owner", func(t *testing.T) {
+	t.Run("add quote rejects a duplicate", func(t *testing.T) {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/admin/owners", strings.NewReader("channel=newchannel&email=newowner@test.com"))
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-		req.Header.Set("X-ExeDev-UserID", "admin123")
-		req.Header.Set("X-ExeDev-Email", "admin@test.com")
-		w := httptest.NewRecorder()
-
-		server.HandleAddChannelOwner(w, req)
+		addTestQuote(t, server, "Wall early and boom", nil, &ch)
 
-		if w.Code != http.StatusSeeOther {
-			t.Errorf("expected 303, got %d", w.Code)
-		}
+		w := addQuote(server, "text=wall+early+and+boom+&channel=streamer1")
 		loc := w.Header().Get("Location")
-		if !strings.Contains(loc, "success=") {
-			t.Errorf("expected success in redirect, got %s", loc)
+		if !strings.Contains(loc, url.QueryEscape("A nearly identical quote already exists (#1)")) {
+			t.Errorf("expected duplicate error redirect, got %s", loc)
 		}
-
-		// Verify owner was added
-		q := dbgen.New(server.DB)
-		channels, _ := q.GetChannelsByOwner(context.Background(), "newowner@test.com")
-		if len(channels) != 1 || channels[0] != "newchannel" {
-			t.Errorf("expected newchannel in owned channels, got %v", channels)
+		if n := countQuotes(t, server); n != 1 {
+			t.Errorf("expected the duplicate not to be saved, got %d quotes", n)
 		}
 	})
-}
 
-func TestHandleRemoveChannelOwner(t *testing.T) {
-	t.Run("returns 401 when not authenticated", func(t *testing.T) {
+	t.Run("add quote with force saves the duplicate", func(t *testing.T) {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/admin/owners/remove", strings.NewReader("channel=test&email=user@test.com"))
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-		w := httptest.NewRecorder()
-
-		server.HandleRemoveChannelOwner(w, req)
+		addTestQuote(t, server, "Wall early and boom", nil, &ch)
 
-		if w.Code != http.StatusUnauthorized {
-			t.Errorf("expected 401, got %d", w.Code)
+		w := addQuote(server, "text=Wall+early+and+boom&channel=streamer1&force=1")
+		if loc := w.Header().Get("Location"); !strings.Contains(loc, "success") {
+			t.Errorf("expected success redirect, got %s", loc)
+		}
+		if n := countQuotes(t, server); n != 2 {
+			t.Errorf("expected 2 quotes, got %d", n)
 		}
 	})
 
-	t.Run("returns 403 for non-admin", func(t *testing.T) {
+	t.Run("approve rejects a duplicate unless forced", func(t *testing.T) {
 		server := testServer(t)
-		req := httptest.NewRequest(http.MethodPost, "/admin/owners/remove", strings.NewReader("channel=test&email=user@test.com"))
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-		req.Header.Set("X-ExeDev-UserID", "user123")
-		req.Header.Set("X-ExeDev-Email", "user@test.com")
-		w := httptest.NewRecorder()
+		addTestQuote(t, server, "Wall early and boom", nil, &ch)
+		sugID := addTestSuggestion(t, server, "Wall Early and Boom", "streamer1")
 
-		server.HandleRemoveChannelOwner(w, req)
+		w := approve(server, sugID, "")
+		loc := w.Header().Get("Location")
+		if !strings.HasPrefix(loc, "/suggestions?error=") || !strings.Contains(loc, "%231") {
+			t.Errorf("expected duplicate error redirect, got %s", loc)
+		}
+		sg, err := dbgen.New(server.WriteDB).GetSuggestionByID(context.Background(), sugID)
+		if err != nil {
+			t.Fatalf("get suggestion: %v", err)
+		}
+		if sg.Status != "pending" {
+			t.Errorf("expected suggestion to stay pending, got %s", sg.Status)
+		}
+		if n := countQuotes(t, server); n != 1 {
+			t.Errorf("expected no new quote, got %d quotes", n)
+		}
 
-		if w.Code != http.StatusForbidden {
-			t.Errorf("expected 403, got %d", w.Code)
+		w = approve(server, sugID, "?force=1")
+		if loc := w.Header().Get("Location"); loc != "/suggestions" {
+			t.Errorf("expected redirect to /suggestions, got %s", loc)
+		}
+		if n := countQuotes(t, server); n != 2 {
+			t.Errorf("expected forced approval to add the quote, got %d quotes", n)
 		}
 	})
 
-	t.Run("admin can remove channel owner", func(t *testing.T) {
+	t.Run("review page warns about duplicates", func(t *testing.T) {
 		server := testServer(t)
-		q := dbgen.New(server.DB)
-
-		// First add an owner
-		_ = q.AddChannelOwner(context.Background(), dbgen.AddChannelOwnerParams{
-			Channel:   "removechannel",
-			UserEmail: "toremove@test.com",
-			InvitedBy: "admin@test.com",
-		})
+		addTestQuote(t, server, "Wall early and boom", nil, &ch)
+		dupID := addTestSuggestion(t, server, "wall early and boom", "streamer1")
+		addTestSuggestion(t, server, "A brand new suggestion", "streamer1")
 
-		req := httptest.NewRequest(http.MethodPost, "/admin/owners/remove", strings.NewReader("channel=removechannel&email=toremove@test.com"))
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req := httptest.NewRequest(http.MethodGet, "/suggestions", nil)
 		req.Header.Set("X-ExeDev-UserID", "admin123")
 		req.Header.Set("X-ExeDev-Email", "admin@test.com")
 		w := httptest.NewRecorder()
+		server.HandleListSuggestions(w, req)
 
-		server.HandleRemoveChannelOwner(w, req)
-
-		if w.Code != http.StatusSeeOther {
-			t.Errorf("expected 303, got %d", w.Code)
+		body := w.Body.String()
+		if strings.Count(body, "A nearly identical quote already exists (#1)") != 1 {
+			t.Errorf("expected one duplicate warning, got: %s", body)
 		}
-
-		// Verify owner was removed
-		channels, _ := q.GetChannelsByOwner(context.Background(), "toremove@test.com")
-		if len(channels) != 0 {
-			t.Errorf("expected no channels, got %v", channels)
+		if !strings.Contains(body, fmt.Sprintf(`action="/suggestions/%d/approve?force=1"`, dupID)) {
+			t.Errorf("expected approve anyway form for the duplicate")
 		}
 	})
 }
@@ -0,0 +1,171 @@
+package srv
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	impersonateCookieName = "X-Impersonate"
+	impersonateMaxAge     = 3600 // 1 hour
+	impersonatePathPrefix = "/admin/impersonate"
+)
+
+// signImpersonation returns the cookie value letting adminEmail act as
+// targetEmail. The signature covers both addresses so the cookie is only
+// honoured for the admin who created it.
+func (s *Server) signImpersonation(adminEmail, targetEmail string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(targetEmail)) + "." + s.impersonationMAC(adminEmail, targetEmail)
+}
+
+func (s *Server) impersonationMAC(adminEmail, targetEmail string) string {
+	mac := hmac.New(sha256.New, []byte(s.Config.SessionSecret))
+	mac.Write([]byte(strings.ToLower(adminEmail) + "\x00" + strings.ToLower(targetEmail)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyImpersonation returns the target email from a signed cookie value,
+// or "" if the value is malformed or was not issued to adminEmail.
+func (s *Server) verifyImpersonation(adminEmail, value string) string {
+	encoded, sig, ok := strings.Cut(value, ".")
+	if !ok {
+		return ""
+	}
+	target, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(target) == 0 {
+		return ""
+	}
+	expected := s.impersonationMAC(adminEmail, string(target))
+	if !hmac.Equal([]byte(sig), []byte(expected)) {
+		return ""
+	}
+	return string(target)
+}
+
+// ImpersonateMiddleware swaps the effective X-ExeDev-Email for the
+// impersonated user when an admin holds a valid impersonation cookie.
+// The admin's own address is passed on in X-Impersonated-By. Requests to
+// the impersonation endpoints themselves are left untouched so the admin
+// can always stop.
+func (s *Server) ImpersonateMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Never trust a client-supplied impersonation header
+		r.Header.Del("X-Impersonated-By")
+
+		if strings.HasPrefix(r.URL.Path, impersonatePathPrefix) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cookie, err := r.Cookie(impersonateCookieName)
+		if err != nil || cookie.Value == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		adminEmail := getAuthEmail(r)
+		if adminEmail == "" || !s.isAdmin(adminEmail) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		target := s.verifyImpersonation(adminEmail, cookie.Value)
+		if target == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		r = r.Clone(r.Context())
+		r.Header.Set("X-ExeDev-Email", target)
+		r.Header.Set("X-Impersonated-By", adminEmail)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HandleAdminImpersonate starts viewing the site as another user (admin only)
+func (s *Server) HandleAdminImpersonate(w http.ResponseWriter, r *http.Request) {
+	userEmail := getAuthEmail(r)
+	ctx := r.Context()
+
+	if userEmail == "" {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Redirect(w, r, loginURLForRequest(r), http.StatusSeeOther)
+		return
+	}
+
+	if !s.isAdmin(userEmail) {
+		RecordSecurityEvent(ctx, "admin_required",
+			attribute.String("user.email", userEmail),
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Admin access required", http.StatusForbidden)
+		return
+	}
+
+	target := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("as")))
+	if target == "" {
+		http.Error(w, "Missing 'as' parameter", http.StatusBadRequest)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     impersonateCookieName,
+		Value:    s.signImpersonation(userEmail, target),
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   impersonateMaxAge,
+	})
+
+	RecordSecurityEvent(ctx, "impersonation_started",
+		attribute.String("user.email", userEmail),
+		attribute.String("impersonated.email", target),
+	)
+
+	http.Redirect(w, r, "/quotes", http.StatusSeeOther)
+}
+
+// HandleAdminImpersonateStop clears the impersonation cookie
+func (s *Server) HandleAdminImpersonateStop(w http.ResponseWriter, r *http.Request) {
+	userEmail := getAuthEmail(r)
+	ctx := r.Context()
+
+	if userEmail == "" {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	target := ""
+	if cookie, err := r.Cookie(impersonateCookieName); err == nil {
+		target = s.verifyImpersonation(userEmail, cookie.Value)
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     impersonateCookieName,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+	})
+
+	RecordSecurityEvent(ctx, "impersonation_stopped",
+		attribute.String("user.email", userEmail),
+		attribute.String("impersonated.email", target),
+	)
+
+	http.Redirect(w, r, "/admin/owners", http.StatusSeeOther)
+}
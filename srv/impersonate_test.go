@@ -0,0 +1,202 @@
+package srv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+)
+
+func TestHandleAdminImpersonate(t *testing.T) {
+	t.Run("returns 403 for non-admin", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/admin/impersonate?as=owner@test.com", nil)
+		req.Header.Set("X-ExeDev-Email", "owner@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleAdminImpersonate(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+		if len(w.Result().Cookies()) != 0 {
+			t.Error("expected no cookie to be set")
+		}
+	})
+
+	t.Run("returns 400 without target", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/admin/impersonate", nil)
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleAdminImpersonate(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("sets signed cookie and redirects", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/admin/impersonate?as=Owner@Test.com", nil)
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+
+		server.HandleAdminImpersonate(w, req)
+
+		if w.Code != http.StatusSeeOther {
+			t.Fatalf("expected 303, got %d", w.Code)
+		}
+		cookie := findCookie(w.Result().Cookies(), impersonateCookieName)
+		if cookie == nil {
+			t.Fatal("expected impersonation cookie")
+		}
+		if got := server.verifyImpersonation("admin@test.com", cookie.Value); got != "owner@test.com" {
+			t.Errorf("expected cookie for owner@test.com, got %q", got)
+		}
+		if got := server.verifyImpersonation("other-admin@test.com", cookie.Value); got != "" {
+			t.Errorf("cookie should not verify for another admin, got %q", got)
+		}
+	})
+
+	t.Run("stop clears cookie", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodPost, "/admin/impersonate/stop", nil)
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		req.AddCookie(&http.Cookie{Name: impersonateCookieName, Value: server.signImpersonation("admin@test.com", "owner@test.com")})
+		w := httptest.NewRecorder()
+
+		server.HandleAdminImpersonateStop(w, req)
+
+		if w.Code != http.StatusSeeOther {
+			t.Fatalf("expected 303, got %d", w.Code)
+		}
+		cookie := findCookie(w.Result().Cookies(), impersonateCookieName)
+		if cookie == nil || cookie.MaxAge >= 0 {
+			t.Errorf("expected cookie to be cleared, got %+v", cookie)
+		}
+	})
+}
+
+func TestImpersonateMiddleware(t *testing.T) {
+	// setup adds one quote in the owner's channel and one elsewhere,
+	// returning their IDs.
+	setup := func(t *testing.T) (*Server, int64, int64) {
+		server := testServer(t)
+		owned, other := "ownerchannel", "otherchannel"
+		addTestQuote(t, server, "Owned quote", nil, &owned)
+		addTestQuote(t, server, "Other quote", nil, &other)
+		addTestOwner(t, server, owned, "owner@test.com")
+
+		quotes, err := dbgen.New(server.WriteDB).ListAllQuotes(context.Background())
+		if err != nil {
+			t.Fatalf("list quotes: %v", err)
+		}
+		var ownedID, otherID int64
+		for _, q := range quotes {
+			switch q.Text {
+			case "Owned quote":
+				ownedID = q.ID
+			case "Other quote":
+				otherID = q.ID
+			}
+		}
+		return server, ownedID, otherID
+	}
+
+	deleteAs := func(server *Server, email, cookieValue string, id int64) int {
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /quotes/{id}/delete", server.HandleDeleteQuote)
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/quotes/%d/delete", id), nil)
+		req.Header.Set("X-ExeDev-Email", email)
+		if cookieValue != "" {
+			req.AddCookie(&http.Cookie{Name: impersonateCookieName, Value: cookieValue})
+		}
+		w := httptest.NewRecorder()
+		server.ImpersonateMiddleware(mux).ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("admin gets impersonated user's channel access", func(t *testing.T) {
+		server, ownedID, otherID := setup(t)
+		cookie := server.signImpersonation("admin@test.com", "owner@test.com")
+
+		if code := deleteAs(server, "admin@test.com", cookie, otherID); code != http.StatusForbidden {
+			t.Errorf("expected 403 outside owner's channel, got %d", code)
+		}
+		if code := deleteAs(server, "admin@test.com", cookie, ownedID); code != http.StatusSeeOther {
+			t.Errorf("expected 303 in owner's channel, got %d", code)
+		}
+	})
+
+	t.Run("sets effective email and impersonator header", func(t *testing.T) {
+		server := testServer(t)
+		var gotEmail, gotBy string
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotEmail = getAuthEmail(r)
+			gotBy = r.Header.Get("X-Impersonated-By")
+		})
+		req := httptest.NewRequest(http.MethodGet, "/quotes", nil)
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		req.AddCookie(&http.Cookie{Name: impersonateCookieName, Value: server.signImpersonation("admin@test.com", "owner@test.com")})
+
+		server.ImpersonateMiddleware(next).ServeHTTP(httptest.NewRecorder(), req)
+
+		if gotEmail != "owner@test.com" {
+			t.Errorf("expected effective email owner@test.com, got %q", gotEmail)
+		}
+		if gotBy != "admin@test.com" {
+			t.Errorf("expected X-Impersonated-By admin@test.com, got %q", gotBy)
+		}
+	})
+
+	t.Run("ignores cookie for non-admin", func(t *testing.T) {
+		server, _, otherID := setup(t)
+		// A cookie signed for a non-admin must not let them act as anyone else
+		cookie := server.signImpersonation("owner@test.com", "admin@test.com")
+
+		if code := deleteAs(server, "owner@test.com", cookie, otherID); code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", code)
+		}
+	})
+
+	t.Run("ignores tampered cookie", func(t *testing.T) {
+		server, _, otherID := setup(t)
+		cookie := server.signImpersonation("admin@test.com", "owner@test.com") + "00"
+
+		// Admin keeps their own access when the cookie is invalid
+		if code := deleteAs(server, "admin@test.com", cookie, otherID); code != http.StatusSeeOther {
+			t.Errorf("expected 303, got %d", code)
+		}
+	})
+
+	t.Run("does not apply to impersonation endpoints", func(t *testing.T) {
+		server := testServer(t)
+		var gotEmail string
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotEmail = getAuthEmail(r)
+		})
+		req := httptest.NewRequest(http.MethodPost, "/admin/impersonate/stop", nil)
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		req.AddCookie(&http.Cookie{Name: impersonateCookieName, Value: server.signImpersonation("admin@test.com", "owner@test.com")})
+
+		server.ImpersonateMiddleware(next).ServeHTTP(httptest.NewRecorder(), req)
+
+		if gotEmail != "admin@test.com" {
+			t.Errorf("expected admin email on stop endpoint, got %q", gotEmail)
+		}
+	})
+}
+
+func findCookie(cookies []*http.Cookie, name string) *http.Cookie {
+	for _, c := range cookies {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package srv
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ImportQuote is one quote in a bulk import. CSV imports use the same
+// names as column headers, so a CSV export can be imported again.
+type ImportQuote struct {
+	Text         string `json:"text"`
+	Author       string `json:"author"`
+	Civilization string `json:"civilization"`
+	OpponentCiv  string `json:"opponent_civ"`
+	Channel      string `json:"channel"`
+}
+
+// ImportRowError describes why one row of an import was rejected. Row is
+// 1-based and does not count the CSV header.
+type ImportRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// ImportResponse summarizes a bulk import
+type ImportResponse struct {
+	Imported int              `json:"imported"`
+	Skipped  int              `json:"skipped"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+// HandleImportQuotes imports quotes from a CSV or JSON body, picked by
+// Content-Type. Every row is validated and permission-checked like a quote
+// added from the form. With ?onError=abort (the default) any bad row fails
+// the whole import; with ?onError=skip bad rows are reported and the rest
+// are imported. Accepted rows are inserted in a single transaction.
+func (s *Server) HandleImportQuotes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auth := s.getAuthInfo(r)
+
+	if !auth.IsAuthenticated {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	onError := r.URL.Query().Get("onError")
+	switch onError {
+	case "":
+		onError = "abort"
+	case "abort", "skip":
+	default:
+		http.Error(w, `onError must be "skip" or "abort"`, http.StatusBadRequest)
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	var rows []ImportQuote
+	var err error
+	switch mediaType {
+	case "text/csv":
+		rows, err = parseImportCSV(r.Body)
+	case "application/json":
+		err = json.NewDecoder(r.Body).Decode(&rows)
+	default:
+		http.Error(w, "Content-Type must be text/csv or application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Invalid import: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(rows) == 0 {
+		http.Error(w, "No quotes to import", http.StatusBadRequest)
+		return
+	}
+
+	var creatorPtr *string
+	if creator := auth.DisplayIdentity(); creator != "" {
+		creatorPtr = &creator
+	}
+	now := s.Clock.Now()
+
+	// Validate and permission-check every row before the transaction starts:
+	// the permission lookups use their own connections, and the pool may
+	// only hold one.
+	resp := ImportResponse{Errors: []ImportRowError{}}
+	canManage := make(map[string]bool)
+	var quotes []dbgen.CreateQuoteParams
+	for i, row := range rows {
+		row.Text = strings.TrimSpace(row.Text)
+		row.Author = strings.TrimSpace(row.Author)
+		row.Civilization = strings.TrimSpace(row.Civilization)
+		row.OpponentCiv = strings.TrimSpace(row.OpponentCiv)
+		row.Channel = strings.TrimSpace(row.Channel)
+
+		if err := s.validateImportRow(ctx, r.URL.Path, auth, row, canManage); err != nil {
+			resp.Errors = append(resp.Errors, ImportRowError{Row: i + 1, Error: err.Error()})
+			continue
+		}
+		quotes = append(quotes, dbgen.CreateQuoteParams{
+			UserID:         auth.UserID,
+			CreatedByEmail: creatorPtr,
+			Text:           row.Text,
+			Author:         toStringPtr(row.Author),
+			Civilization:   toStringPtr(row.Civilization),
+			OpponentCiv:    toStringPtr(row.OpponentCiv),
+			Channel:        toStringPtr(row.Channel),
+			CreatedAt:      now,
+		})
+	}
+
+	if len(resp.Errors) > 0 && onError == "abort" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	if len(quotes) > 0 {
+		if err := s.CreateQuotesBatch(ctx, quotes); err != nil {
+			slog.Error("import quotes", "error", err)
+			http.Error(w, "Failed to import quotes", http.StatusInternalServerError)
+			return
+		}
+		s.Markers.CreateBulkOperationMarker("Bulk import", len(quotes))
+	}
+	resp.Imported = len(quotes)
+	resp.Skipped = len(resp.Errors)
+
+	slog.Info("quotes imported", "imported", resp.Imported, "skipped", resp.Skipped, "user", auth.DisplayIdentity())
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// validateImportRow applies the add-quote form's checks to one import row.
+// canManage caches permission lookups by lowercased channel.
+func (s *Server) validateImportRow(ctx context.Context, path string, auth AuthInfo, row ImportQuote, canManage map[string]bool) error {
+	if err := ValidateQuoteTextMin(row.Text, s.Config.MinQuoteTextLen); err != nil {
+		return err
+	}
+	if err := ValidateAuthor(row.Author); err != nil {
+		return err
+	}
+	if err := ValidateChannel(row.Channel); err != nil {
+		return err
+	}
+
+	key := strings.ToLower(row.Channel)
+	allowed, ok := canManage[key]
+	if !ok {
+		allowed = s.canManageChannelWithTwitch(ctx, auth.Email, auth.TwitchUsername, row.Channel)
+		canManage[key] = allowed
+		if !allowed {
+			RecordSecurityEvent(ctx, "permission_denied",
+				attribute.String("user.identity", auth.DisplayIdentity()),
+				attribute.String("path", path),
+				attribute.String("resource", "quote"),
+				attribute.String("channel", row.Channel),
+				attribute.String("reason", "not_authorized"),
+			)
+		}
+	}
+	if !allowed {
+		if row.Channel == "" {
+			return errors.New("only admins can import global quotes")
+		}
+		return fmt.Errorf("you don't have permission to add quotes to %s", row.Channel)
+	}
+	return nil
+}
+
+// parseImportCSV reads import rows from CSV with a header row. Columns are
+// matched by name, case-insensitively; unknown columns such as the id and
+// created_at of an export are ignored. A text column is required.
+func parseImportCSV(r io.Reader) ([]ImportQuote, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	col := make(map[string]int)
+	for i, name := range header {
+		col[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := col["text"]; !ok {
+		return nil, errors.New("CSV header must include a text column")
+	}
+	field := func(record []string, name string) string {
+		if i, ok := col[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	var rows []ImportQuote
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, ImportQuote{
+			Text:         field(record, "text"),
+			Author:       field(record, "author"),
+			Civilization: field(record, "civilization"),
+			OpponentCiv:  field(record, "opponent_civ"),
+			Channel:      field(record, "channel"),
+		})
+	}
+}
+
+// CreateQuotesBatch inserts quotes in a single transaction, so either all
+// of them are saved or none are.
+func (s *Server) CreateQuotesBatch(ctx context.Context, quotes []dbgen.CreateQuoteParams) error {
+	tx, err := s.WriteDB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	q := dbgen.New(tx)
+	for i, quote := range quotes {
+		if _, err := q.CreateQuote(ctx, quote); err != nil {
+			return fmt.Errorf("create quote %d: %w", i+1, err)
+		}
+	}
+	return tx.Commit()
+}
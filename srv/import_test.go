@@ -0,0 +1,156 @@
+package srv
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+)
+
+func TestHandleImportQuotes(t *testing.T) {
+	importQuotes := func(t *testing.T, server *Server, email, target, contentType, body string) (*httptest.ResponseRecorder, ImportResponse) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		if email != "" {
+			req.Header.Set("X-ExeDev-UserID", "user-1")
+			req.Header.Set("X-ExeDev-Email", email)
+		}
+		w := httptest.NewRecorder()
+		server.HandleImportQuotes(w, req)
+		var resp ImportResponse
+		if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+		}
+		return w, resp
+	}
+	countQuotes := func(t *testing.T, server *Server) int {
+		t.Helper()
+		quotes, err := dbgen.New(server.WriteDB).ListAllQuotes(context.Background())
+		if err != nil {
+			t.Fatalf("list quotes: %v", err)
+		}
+		return len(quotes)
+	}
+
+	t.Run("imports CSV with quoted fields", func(t *testing.T) {
+		server := testServer(t)
+		body := "id,text,author,civilization,channel,created_at\n" +
+			"7,\"Wall early, then boom\",Beasty,French,streamer1,2024-01-01T00:00:00Z\n" +
+			"8,\"Line one\nline two of the quote\",,,,\n"
+		w, resp := importQuotes(t, server, "admin@test.com", "/quotes/import", "text/csv; charset=utf-8", body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if resp.Imported != 2 || resp.Skipped != 0 {
+			t.Errorf("expected 2 imported, got %+v", resp)
+		}
+
+		quotes, _ := dbgen.New(server.WriteDB).ListAllQuotes(context.Background())
+		var found bool
+		for _, q := range quotes {
+			if q.Text == "Wall early, then boom" {
+				found = true
+				if stringVal(q.Author) != "Beasty" || stringVal(q.Civilization) != "French" || stringVal(q.Channel) != "streamer1" {
+					t.Errorf("unexpected imported quote %+v", q)
+				}
+				if stringVal(q.CreatedByEmail) != "admin@test.com" {
+					t.Errorf("expected created_by admin@test.com, got %q", stringVal(q.CreatedByEmail))
+				}
+			}
+		}
+		if !found {
+			t.Errorf("expected imported quote, got %+v", quotes)
+		}
+	})
+
+	t.Run("imports JSON", func(t *testing.T) {
+		server := testServer(t)
+		body := `[{"text": "Scout the opponent early", "author": "Beasty"}, {"text": "Always keep producing villagers", "channel": "streamer1"}]`
+		w, resp := importQuotes(t, server, "admin@test.com", "/quotes/import", "application/json", body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if resp.Imported != 2 || countQuotes(t, server) != 2 {
+			t.Errorf("expected 2 imported quotes, got %+v", resp)
+		}
+	})
+
+	body := `[
+		{"text": "Valid quote for streamer1", "channel": "streamer1"},
+		{"text": "short", "channel": "streamer1"},
+		{"text": "Quote for a channel I don't own", "channel": "streamer2"},
+		{"text": "Global quote needs an admin"}
+	]`
+
+	t.Run("abort rejects the whole import", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "streamer1", "owner@test.com")
+		w, resp := importQuotes(t, server, "owner@test.com", "/quotes/import", "application/json", body)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", w.Code)
+		}
+		if resp.Imported != 0 || len(resp.Errors) != 3 {
+			t.Errorf("expected 3 errors and nothing imported, got %+v", resp)
+		}
+		if n := countQuotes(t, server); n != 0 {
+			t.Errorf("expected no quotes saved, got %d", n)
+		}
+	})
+
+	t.Run("skip imports the valid rows", func(t *testing.T) {
+		server := testServer(t)
+		addTestOwner(t, server, "streamer1", "owner@test.com")
+		w, resp := importQuotes(t, server, "owner@test.com", "/quotes/import?onError=skip", "application/json", body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if resp.Imported != 1 || resp.Skipped != 3 {
+			t.Errorf("expected 1 imported and 3 skipped, got %+v", resp)
+		}
+		var rows []int
+		for _, e := range resp.Errors {
+			rows = append(rows, e.Row)
+		}
+		if len(rows) != 3 || rows[0] != 2 || rows[1] != 3 || rows[2] != 4 {
+			t.Errorf("expected errors for rows 2-4, got %+v", resp.Errors)
+		}
+		if n := countQuotes(t, server); n != 1 {
+			t.Errorf("expected 1 quote saved, got %d", n)
+		}
+	})
+
+	t.Run("rejects bad requests", func(t *testing.T) {
+		server := testServer(t)
+		tests := []struct {
+			name, target, contentType, body string
+			want                            int
+		}{
+			{"unsupported content type", "/quotes/import", "text/plain", "hello", http.StatusUnsupportedMediaType},
+			{"invalid onError", "/quotes/import?onError=retry", "application/json", "[]", http.StatusBadRequest},
+			{"invalid JSON", "/quotes/import", "application/json", "{", http.StatusBadRequest},
+			{"CSV without text column", "/quotes/import", "text/csv", "author\nBeasty\n", http.StatusBadRequest},
+			{"empty import", "/quotes/import", "application/json", "[]", http.StatusBadRequest},
+		}
+		for _, tt := range tests {
+			w, _ := importQuotes(t, server, "admin@test.com", tt.target, tt.contentType, tt.body)
+			if w.Code != tt.want {
+				t.Errorf("%s: expected %d, got %d", tt.name, tt.want, w.Code)
+			}
+		}
+	})
+
+	t.Run("requires authentication", func(t *testing.T) {
+		server := testServer(t)
+		w, _ := importQuotes(t, server, "", "/quotes/import", "application/json", `[{"text": "Scout the opponent early"}]`)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
+		}
+	})
+}
@@ -47,7 +47,7 @@ func (s *Server) StartManagedChannelSync(ctx context.Context) {
 }
 
 func (s *Server) syncDueChannels(ctx context.Context) {
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 
 	channels, err := q.GetManagedChannelsDueForSync(ctx)
 	if err != nil {
@@ -74,7 +74,7 @@ func (s *Server) syncDueChannels(ctx context.Context) {
 }
 
 func (s *Server) syncManagedChannel(ctx context.Context, ch dbgen.NightbotManagedChannel) error {
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 
 	// Decrypt session token
 	sessionToken, err := s.Encryptor.Decrypt(ch.SessionTokenEncrypted)
@@ -230,7 +230,7 @@ func (s *Server) HandleManagedChannelsAdmin(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	channels, err := q.GetAllManagedChannels(ctx)
 	if err != nil {
 		slog.Error("get managed channels", "error", err)
@@ -259,7 +259,7 @@ func (s *Server) HandleManagedChannelsAdmin(w http.ResponseWriter, r *http.Reque
 			SyncIntervalMinutes: ch.SyncIntervalMinutes,
 		}
 		if ch.LastSyncAt != nil {
-			cv.LastSyncAt = formatTimeAgo(*ch.LastSyncAt)
+			cv.LastSyncAt = s.formatTimeAgo(*ch.LastSyncAt)
 		}
 		if ch.LastSyncStatus != nil {
 			cv.LastSyncStatus = *ch.LastSyncStatus
@@ -290,6 +290,7 @@ func (s *Server) HandleManagedChannelsAdmin(w http.ResponseWriter, r *http.Reque
 		Success         string
 		Error           string
 		Channels        []ChannelView
+		navBadges
 	}{
 		Hostname:        s.Hostname,
 		UserEmail:       userEmail,
@@ -300,6 +301,7 @@ func (s *Server) HandleManagedChannelsAdmin(w http.ResponseWriter, r *http.Reque
 		Success:         r.URL.Query().Get("success"),
 		Error:           r.URL.Query().Get("error"),
 		Channels:        channelViews,
+		navBadges:       s.navBadgesFor(r.Context(), userEmail),
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -354,7 +356,7 @@ func (s *Server) HandleManagedChannelAdd(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	_, err = q.CreateManagedChannel(ctx, dbgen.CreateManagedChannelParams{
 		UserEmail:             userEmail,
 		ChannelID:             channelID,
@@ -404,7 +406,7 @@ func (s *Server) HandleManagedChannelToggle(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 
 	if action == "enable" {
 		err = q.EnableManagedChannelSync(ctx, id)
@@ -443,7 +445,7 @@ func (s *Server) HandleManagedChannelDelete(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	if err := q.DeleteManagedChannel(ctx, id); err != nil {
 		slog.Error("delete managed channel", "error", err)
 		http.Redirect(w, r, "/admin/nightbot/managed?error="+url.QueryEscape("Failed to delete channel"), http.StatusSeeOther)
@@ -481,7 +483,7 @@ func (s *Server) HandleManagedChannelSyncNow(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	ch, err := q.GetManagedChannel(ctx, id)
 	if err != nil {
 		http.Redirect(w, r, "/admin/nightbot/managed?error="+url.QueryEscape("Channel not found"), http.StatusSeeOther)
@@ -537,7 +539,7 @@ func (s *Server) HandleManagedChannelUpdateToken(w http.ResponseWriter, r *http.
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	if err := q.UpdateManagedChannelToken(ctx, dbgen.UpdateManagedChannelTokenParams{
 		SessionTokenEncrypted: encryptedToken,
 		ID:                    id,
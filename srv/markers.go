@@ -2,11 +2,11 @@ package srv
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
-	"os"
 	"time"
 )
 
@@ -22,6 +22,7 @@ const (
 var (
 	Version   = "dev"
 	CommitSHA = "unknown"
+	BuiltAt   = "unknown"
 )
 
 // Marker represents a Honeycomb marker
@@ -33,73 +34,161 @@ type Marker struct {
 	URL       string `json:"url,omitempty"`
 }
 
-// MarkerClient handles communication with Honeycomb Markers API
+// Buffered marker delivery settings
+const (
+	markerBufferSize  = 50
+	markerMaxAttempts = 3
+	markerAPIBaseURL  = "https://api.honeycomb.io"
+)
+
+// MarkerClient handles communication with Honeycomb Markers API.
+// Markers are queued and sent by a background goroutine so that request
+// handlers never wait on Honeycomb.
 type MarkerClient struct {
 	apiKey  string
 	dataset string
 	client  *http.Client
+	baseURL string
+	// retryBackoff is the base delay between attempts, doubled each retry
+	retryBackoff time.Duration
+	// now stamps markers queued without a start time
+	now  func() time.Time
+	jobs chan markerJob
+}
+
+// markerJob is either a marker to send or a flush barrier. The worker
+// handles jobs in order, so closing flushed means every earlier marker
+// has been sent (or given up on).
+type markerJob struct {
+	marker  Marker
+	flushed chan struct{}
 }
 
-// NewMarkerClient creates a new marker client from environment variables.
-// Returns nil if HONEYCOMB_API_KEY is not set.
-func NewMarkerClient() *MarkerClient {
-	apiKey := os.Getenv("HONEYCOMB_API_KEY")
+// NewMarkerClient creates a new marker client for the given Honeycomb API key.
+// Markers are sent to the dataset named after serviceName (default "quoteqt")
+// and timestamped with now. Returns nil if apiKey is empty.
+func NewMarkerClient(apiKey, serviceName string, now func() time.Time) *MarkerClient {
 	if apiKey == "" {
 		return nil
 	}
 
-	dataset := os.Getenv("OTEL_SERVICE_NAME")
+	dataset := serviceName
 	if dataset == "" {
-		dataset = "quoteqt"
+		dataset = DefaultServiceName
 	}
 
-	return &MarkerClient{
-		apiKey:  apiKey,
-		dataset: dataset,
-		client:  &http.Client{Timeout: 10 * time.Second},
+	return newMarkerClient(apiKey, dataset, markerAPIBaseURL, 250*time.Millisecond, now)
+}
+
+// newMarkerClient creates a marker client for the given API base URL and
+// starts its delivery goroutine.
+func newMarkerClient(apiKey, dataset, baseURL string, retryBackoff time.Duration, now func() time.Time) *MarkerClient {
+	mc := &MarkerClient{
+		apiKey:       apiKey,
+		dataset:      dataset,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		baseURL:      baseURL,
+		retryBackoff: retryBackoff,
+		now:          now,
+		jobs:         make(chan markerJob, markerBufferSize),
 	}
+	go mc.run()
+	return mc
 }
 
-// CreateMarker sends a marker to Honeycomb.
-// Logs errors but doesn't return them - markers are best-effort.
+// CreateMarker queues a marker to be sent to Honeycomb.
+// Markers are best-effort: if the buffer is full the marker is dropped.
 func (mc *MarkerClient) CreateMarker(m Marker) {
 	if mc == nil {
 		return
 	}
 
 	if m.StartTime == 0 {
-		m.StartTime = time.Now().Unix()
+		m.StartTime = mc.now().Unix()
 	}
 
-	body, err := json.Marshal(m)
-	if err != nil {
-		slog.Error("marshal marker", "error", err)
-		return
+	select {
+	case mc.jobs <- markerJob{marker: m}:
+	default:
+		slog.Warn("marker buffer full, dropping marker", "type", m.Type, "message", m.Message)
 	}
+}
 
-	url := fmt.Sprintf("https://api.honeycomb.io/1/markers/%s", mc.dataset)
-	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
-	if err != nil {
-		slog.Error("create marker request", "error", err)
-		return
+// Flush waits until every marker queued before the call has been handled,
+// or until ctx is done.
+func (mc *MarkerClient) Flush(ctx context.Context) error {
+	if mc == nil {
+		return nil
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-Honeycomb-Team", mc.apiKey)
+	flushed := make(chan struct{})
+	select {
+	case mc.jobs <- markerJob{flushed: flushed}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (mc *MarkerClient) run() {
+	for job := range mc.jobs {
+		if job.flushed != nil {
+			close(job.flushed)
+			continue
+		}
+		mc.send(job.marker)
+	}
+}
 
-	resp, err := mc.client.Do(req)
+// send posts a marker, retrying network errors, rate limiting and server
+// errors with exponential backoff. Errors are logged, not returned.
+func (mc *MarkerClient) send(m Marker) {
+	body, err := json.Marshal(m)
 	if err != nil {
-		slog.Error("send marker", "error", err)
+		slog.Error("marshal marker", "error", err)
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 300 {
-		slog.Error("marker API error", "status", resp.StatusCode, "type", m.Type, "message", m.Message)
+	url := fmt.Sprintf("%s/1/markers/%s", mc.baseURL, mc.dataset)
+	for attempt := 0; attempt < markerMaxAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(1<<(attempt-1)) * mc.retryBackoff)
+		}
+
+		req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+		if err != nil {
+			slog.Error("create marker request", "error", err)
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Honeycomb-Team", mc.apiKey)
+
+		resp, err := mc.client.Do(req)
+		if err != nil {
+			slog.Warn("send marker", "attempt", attempt+1, "error", err)
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			slog.Warn("marker API error, will retry", "attempt", attempt+1, "status", resp.StatusCode)
+			continue
+		}
+		if resp.StatusCode >= 300 {
+			slog.Error("marker API error", "status", resp.StatusCode, "type", m.Type, "message", m.Message)
+			return
+		}
+
+		slog.Info("marker created", "type", m.Type, "message", m.Message)
 		return
 	}
 
-	slog.Info("marker created", "type", m.Type, "message", m.Message)
+	slog.Error("marker not sent", "attempts", markerMaxAttempts, "type", m.Type, "message", m.Message)
 }
 
 // CreateDeployMarker creates a deploy marker with version and commit info
@@ -127,7 +216,7 @@ func (mc *MarkerClient) CreateDeployMarker() {
 }
 
 // CreateMigrationMarker creates a marker for a database migration
-func (mc *MarkerClient) CreateMigrationMarker(filename string, startTime, endTime time.Time) {
+func (mc *MarkerClient) CreateMigrationMarker(number int, filename string, startTime, endTime time.Time) {
 	if mc == nil {
 		return
 	}
@@ -135,7 +224,7 @@ func (mc *MarkerClient) CreateMigrationMarker(filename string, startTime, endTim
 	mc.CreateMarker(Marker{
 		StartTime: startTime.Unix(),
 		EndTime:   endTime.Unix(),
-		Message:   fmt.Sprintf("Migration: %s", filename),
+		Message:   fmt.Sprintf("Migration %03d: %s", number, filename),
 		Type:      MarkerTypeMigration,
 	})
 }
@@ -0,0 +1,124 @@
+package srv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeHoneycomb records markers posted to it. The first failFirst requests
+// get a 500 response.
+type fakeHoneycomb struct {
+	mu        sync.Mutex
+	failFirst int
+	requests  int
+	markers   []Marker
+}
+
+func (f *fakeHoneycomb) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests++
+	if f.requests <= f.failFirst {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if r.URL.Path != "/1/markers/test-dataset" || r.Header.Get("X-Honeycomb-Team") != "test-key" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var m Marker
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.markers = append(f.markers, m)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func TestMarkerClient_DeliversBufferedMarkers(t *testing.T) {
+	fake := &fakeHoneycomb{}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	mc := newMarkerClient("test-key", "test-dataset", ts.URL, time.Millisecond, (&fakeClock{now: now}).Now)
+	for i := 0; i < 10; i++ {
+		mc.CreateMarker(Marker{Message: fmt.Sprintf("marker %d", i), Type: MarkerTypeConfigChange})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := mc.Flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if len(fake.markers) != 10 {
+		t.Fatalf("expected 10 markers delivered, got %d", len(fake.markers))
+	}
+	for i, m := range fake.markers {
+		if want := fmt.Sprintf("marker %d", i); m.Message != want {
+			t.Errorf("marker %d: expected %q, got %q", i, want, m.Message)
+		}
+		if m.StartTime != now.Unix() {
+			t.Errorf("marker %d: expected start time %d from the clock, got %d", i, now.Unix(), m.StartTime)
+		}
+	}
+}
+
+func TestMarkerClient_RetriesServerErrors(t *testing.T) {
+	fake := &fakeHoneycomb{failFirst: markerMaxAttempts - 1}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	mc := newMarkerClient("test-key", "test-dataset", ts.URL, time.Millisecond, time.Now)
+	mc.CreateMarker(Marker{Message: "retried", Type: MarkerTypeDeploy})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := mc.Flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if fake.requests != markerMaxAttempts {
+		t.Errorf("expected %d attempts, got %d", markerMaxAttempts, fake.requests)
+	}
+	if len(fake.markers) != 1 {
+		t.Errorf("expected marker delivered after retries, got %d", len(fake.markers))
+	}
+}
+
+func TestMarkerClient_FlushTimeout(t *testing.T) {
+	block := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer ts.Close()
+	defer close(block)
+
+	mc := newMarkerClient("test-key", "test-dataset", ts.URL, time.Millisecond, time.Now)
+	mc.CreateMarker(Marker{Message: "stuck", Type: MarkerTypeDeploy})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := mc.Flush(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestMarkerClient_Nil(t *testing.T) {
+	var mc *MarkerClient
+	mc.CreateMarker(Marker{Message: "ignored"})
+	if err := mc.Flush(context.Background()); err != nil {
+		t.Errorf("expected nil client flush to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package srv
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Prometheus metrics, served at /metrics for setups without Honeycomb.
+// The collectors are process-wide, so registerMetrics registers them once
+// however many servers (or tests) call NewWithConfig.
+var (
+	metricsRegistry = prometheus.NewRegistry()
+	metricsOnce     sync.Once
+
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "quoteqt_http_requests_total",
+		Help: "HTTP requests by route pattern, method and status code.",
+	}, []string{"handler", "method", "code"})
+
+	quotesServedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "quoteqt_quotes_served_total",
+		Help: "Single quotes served by the API.",
+	})
+
+	suggestionsSubmittedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "quoteqt_suggestions_submitted_total",
+		Help: "Quote suggestions submitted through the API or chat bots.",
+	})
+
+	rateLimitedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "quoteqt_rate_limited_total",
+		Help: "Requests rejected by a rate limit, by limiter.",
+	}, []string{"limiter"})
+
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "quoteqt_db_query_duration_seconds",
+		Help:    "Duration of database operations started with StartDBSpan.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"operation"})
+)
+
+// registerMetrics registers the collectors with metricsRegistry on first use
+func registerMetrics() {
+	metricsOnce.Do(func() {
+		metricsRegistry.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+			httpRequestsTotal,
+			quotesServedTotal,
+			suggestionsSubmittedTotal,
+			rateLimitedTotal,
+			dbQueryDuration,
+		)
+	})
+}
+
+// metricsHandler serves the registry in the Prometheus text format.
+// Compression is left to the Gzip middleware.
+func metricsHandler() http.Handler {
+	return promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{DisableCompression: true})
+}
+
+// Metrics counts requests by the route pattern the mux matched. It must
+// wrap the mux directly, since the mux records the pattern on the request
+// it is given.
+func Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &responseRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+
+		handler := r.Pattern
+		if handler == "" {
+			handler = "unmatched"
+		}
+		code := rec.status
+		if code == 0 {
+			code = http.StatusOK
+		}
+		httpRequestsTotal.WithLabelValues(handler, r.Method, strconv.Itoa(code)).Inc()
+	})
+}
+
+// dbSpan observes the operation's duration in dbQueryDuration when the span
+// ends
+type dbSpan struct {
+	trace.Span
+	operation string
+	start     time.Time
+}
+
+func (s dbSpan) End(options ...trace.SpanEndOption) {
+	dbQueryDuration.WithLabelValues(s.operation).Observe(time.Since(s.start).Seconds())
+	s.Span.End(options...)
+}
@@ -0,0 +1,89 @@
+package srv
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scrapeMetric fetches /metrics and returns the value of the sample whose
+// name and labels are exactly series, or 0 if it isn't present yet.
+func scrapeMetric(t *testing.T, series string) float64 {
+	t.Helper()
+	registerMetrics()
+	w := httptest.NewRecorder()
+	metricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 from /metrics, got %d", w.Code)
+	}
+	for line := range strings.Lines(w.Body.String()) {
+		// Label values may contain spaces, so the value is after the last one
+		line = strings.TrimSpace(line)
+		i := strings.LastIndex(line, " ")
+		if i < 0 || line[:i] != series {
+			continue
+		}
+		value := line[i+1:]
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			t.Fatalf("parse %s: %v", series, err)
+		}
+		return v
+	}
+	return 0
+}
+
+func TestMetrics(t *testing.T) {
+	t.Run("quotes served counter increments", func(t *testing.T) {
+		server := testServer(t)
+		// A second server must not register the collectors again
+		testServer(t)
+		addTestQuote(t, server, "A quote worth counting", nil, nil)
+
+		before := scrapeMetric(t, "quoteqt_quotes_served_total")
+		w := httptest.NewRecorder()
+		server.HandleRandomQuote(w, httptest.NewRequest(http.MethodGet, "/api/quote", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if after := scrapeMetric(t, "quoteqt_quotes_served_total"); after != before+1 {
+			t.Errorf("expected quotes served to go from %v to %v, got %v", before, before+1, after)
+		}
+		if scrapeMetric(t, `quoteqt_db_query_duration_seconds_count{operation="GetRandomQuoteGlobal"}`) == 0 {
+			t.Error("expected a DB duration observation for GetRandomQuoteGlobal")
+		}
+	})
+
+	t.Run("requests are counted by route pattern", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /things/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		})
+		handler := Metrics(mux)
+		series := `quoteqt_http_requests_total{code="202",handler="GET /things/{id}",method="GET"}`
+
+		before := scrapeMetric(t, series)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/things/1", nil))
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/things/2", nil))
+		if after := scrapeMetric(t, series); after != before+2 {
+			t.Errorf("expected 2 more requests for the pattern, got %v", after-before)
+		}
+	})
+
+	t.Run("rate limit rejections are counted", func(t *testing.T) {
+		limiter := NewRateLimiter(1, time.Minute, 1)
+		handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		series := `quoteqt_rate_limited_total{limiter="api"}`
+
+		before := scrapeMetric(t, series)
+		for range 2 {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/quote", nil))
+		}
+		if after := scrapeMetric(t, series); after != before+1 {
+			t.Errorf("expected 1 more rejection, got %v", after-before)
+		}
+	})
+}
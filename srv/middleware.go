@@ -1,10 +1,14 @@
 package srv
 
 import (
+	"bytes"
 	"compress/gzip"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -32,7 +36,7 @@ var gzipPool = sync.Pool{
 func Gzip(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check if client accepts gzip
-		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		if !acceptsGzip(r.Header.Get("Accept-Encoding")) {
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -52,6 +56,58 @@ func Gzip(next http.Handler) http.Handler {
 	})
 }
 
+// parseAcceptEncoding returns the quality value for each coding listed in an
+// Accept-Encoding header, keyed by lowercased coding name. Codings without a
+// q parameter get 1; entries with an unparseable q are dropped.
+func parseAcceptEncoding(header string) map[string]float64 {
+	prefs := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+		q := 1.0
+		valid := true
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(param, "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(key), "q") {
+				continue
+			}
+			v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || v < 0 || v > 1 {
+				valid = false
+				break
+			}
+			q = v
+		}
+		if valid {
+			prefs[coding] = q
+		}
+	}
+	return prefs
+}
+
+// acceptsGzip reports whether a response may be gzipped for the given
+// Accept-Encoding header. gzip must be acceptable (directly or via "*") and
+// not ranked below an uncompressed response.
+func acceptsGzip(header string) bool {
+	prefs := parseAcceptEncoding(header)
+	quality := func(coding string, fallback float64) float64 {
+		if q, ok := prefs[coding]; ok {
+			return q
+		}
+		if q, ok := prefs["*"]; ok {
+			return q
+		}
+		return fallback
+	}
+	gzipQ := quality("gzip", 0)
+	// identity is acceptable unless explicitly excluded
+	identityQ := quality("identity", 1)
+	return gzipQ > 0 && gzipQ >= identityQ
+}
+
 // StaticFileServer returns a handler for static files with cache headers
 func StaticFileServer(dir string) http.Handler {
 	fs := http.FileServer(http.Dir(dir))
@@ -69,10 +125,197 @@ func StaticFileServer(dir string) http.Handler {
 			// Default: cache for 1 hour
 			w.Header().Set("Cache-Control", "public, max-age=3600")
 		}
+		// http.FileServer answers If-None-Match itself once ETag is set
+		if etag := staticETag(dir, path); etag != "" {
+			w.Header().Set("ETag", etag)
+		}
 		fs.ServeHTTP(w, r)
 	})
 }
 
+// staticETag returns a weak ETag for the file at path under dir, built from
+// its size and modification time, or "" if it isn't a readable file.
+func staticETag(dir, path string) string {
+	f, err := http.Dir(dir).Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		return ""
+	}
+	return fmt.Sprintf(`W/"%x-%x"`, info.Size(), info.ModTime().UnixNano())
+}
+
+// NoStore marks HTML page responses as uncacheable unless the handler sets
+// its own Cache-Control. Most pages render per-user data from the auth
+// headers, so a shared cache must never keep them; public handlers opt back
+// in. The JSON API and the feed are left alone so clients can revalidate
+// them with ETags.
+func NoStore(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/api/") && r.URL.Path != "/feed.xml" {
+			w.Header().Set("Cache-Control", "no-store, no-cache")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// conditionalGetMaxBody caps how much of a response ConditionalGet buffers
+// to hash. Larger responses are streamed without an ETag.
+const conditionalGetMaxBody = 1 << 20
+
+// ConditionalGet adds an ETag to successful GET and HEAD JSON responses,
+// hashed from the body, and answers 304 Not Modified when the client's
+// If-None-Match matches. Responses that already carry an ETag, such as the
+// API docs, are left alone. Responses without their own Cache-Control get
+// "private, no-cache" so browsers keep them and revalidate instead of
+// refetching. This saves bandwidth for overlays that poll the API; the
+// handler still runs, so it doesn't save database work.
+func ConditionalGet(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ew := &etagWriter{ResponseWriter: w}
+		next.ServeHTTP(ew, r)
+		ew.finish(r)
+	})
+}
+
+// etagWriter buffers a response so ConditionalGet can hash it. Responses
+// that can't get an ETag, or outgrow conditionalGetMaxBody, switch to
+// passthrough and are written straight to the client.
+type etagWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	passthrough bool
+	buf         bytes.Buffer
+}
+
+func (w *etagWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = code
+	h := w.Header()
+	if code != http.StatusOK || h.Get("ETag") != "" ||
+		!strings.HasPrefix(h.Get("Content-Type"), "application/json") {
+		w.passthrough = true
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (w *etagWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.passthrough {
+		return w.ResponseWriter.Write(b)
+	}
+	if w.buf.Len()+len(b) > conditionalGetMaxBody {
+		w.passthrough = true
+		w.ResponseWriter.WriteHeader(w.status)
+		if _, err := w.ResponseWriter.Write(w.buf.Bytes()); err != nil {
+			return 0, err
+		}
+		w.buf.Reset()
+		return w.ResponseWriter.Write(b)
+	}
+	return w.buf.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *etagWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// finish sends the buffered response, or 304 if the client already has it
+func (w *etagWriter) finish(r *http.Request) {
+	if !w.wroteHeader || w.passthrough {
+		return
+	}
+	etag := computeETag(w.buf.Bytes())
+	w.Header().Set("ETag", etag)
+	if w.Header().Get("Cache-Control") == "" {
+		w.Header().Set("Cache-Control", "private, no-cache")
+	}
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.Header().Del("Content-Length")
+		w.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.Write(w.buf.Bytes())
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag,
+// using the weak comparison RFC 9110 specifies for If-None-Match.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// corsExposedHeaders are the response headers overlays may read from
+// cross-origin API responses
+const corsExposedHeaders = "ETag, Link, Retry-After, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, " +
+	"X-Total-Count, X-No-Results, X-Quote-ID, X-Quote-Civ, X-Quote-Author, X-Quote-Channel"
+
+// CORS lets browser pages on the allowed origins, such as stream overlays,
+// read the public API. Only GET and HEAD are allowed and preflights for any
+// other method get no CORS headers, so browsers block cross-origin writes.
+// /api/admin/ routes are never shared. An origin of "*" allows any origin.
+func CORS(origins []string, next http.Handler) http.Handler {
+	allowAll := slices.Contains(origins, "*")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || strings.HasPrefix(r.URL.Path, "/api/admin/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		allowed := allowAll || slices.Contains(origins, origin)
+
+		h := w.Header()
+		if !allowAll {
+			h.Add("Vary", "Origin")
+		}
+		allowOrigin := origin
+		if allowAll {
+			allowOrigin = "*"
+		}
+
+		if method := r.Header.Get("Access-Control-Request-Method"); r.Method == http.MethodOptions && method != "" {
+			if allowed && (method == http.MethodGet || method == http.MethodHead) {
+				h.Set("Access-Control-Allow-Origin", allowOrigin)
+				h.Set("Access-Control-Allow-Methods", "GET, HEAD")
+				h.Set("Access-Control-Allow-Headers", "Accept, If-None-Match")
+				h.Set("Access-Control-Max-Age", "86400")
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if allowed && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+			h.Set("Access-Control-Allow-Origin", allowOrigin)
+			h.Set("Access-Control-Expose-Headers", corsExposedHeaders)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // responseRecorder wraps http.ResponseWriter to capture status code
 type responseRecorder struct {
 	http.ResponseWriter
@@ -92,7 +335,7 @@ func (r *responseRecorder) Write(b []byte) (int, error) {
 }
 
 // RequestLogger logs slow requests (>500ms) and errors
-// Skips /health and /static/* paths to reduce noise
+// Skips the health probes and /static/* paths to reduce noise
 func RequestLogger(next http.Handler) http.Handler {
 	const slowThreshold = 500 * time.Millisecond
 
@@ -100,7 +343,7 @@ func RequestLogger(next http.Handler) http.Handler {
 		path := r.URL.Path
 
 		// Skip noisy endpoints
-		if path == "/health" || strings.HasPrefix(path, "/static/") {
+		if path == "/health" || path == "/livez" || path == "/readyz" || strings.HasPrefix(path, "/static/") {
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -132,9 +375,39 @@ func RequestLogger(next http.Handler) http.Handler {
 	})
 }
 
+// hstsHeader builds the Strict-Transport-Security value from cfg, or returns
+// "" when HSTS is disabled.
+func hstsHeader(cfg Config) string {
+	if cfg.HSTSMaxAge <= 0 {
+		return ""
+	}
+	v := "max-age=" + strconv.Itoa(cfg.HSTSMaxAge)
+	if cfg.HSTSIncludeSubDomains {
+		v += "; includeSubDomains"
+	}
+	if cfg.HSTSPreload {
+		v += "; preload"
+	}
+	return v
+}
+
 // SecurityHeaders adds security-related HTTP headers to responses
-func SecurityHeaders(next http.Handler) http.Handler {
+func SecurityHeaders(cfg Config, next http.Handler) http.Handler {
+	hsts := hstsHeader(cfg)
+	var expectCT string
+	if cfg.ExpectCT > 0 {
+		expectCT = "max-age=" + strconv.Itoa(int(cfg.ExpectCT.Seconds())) + ", enforce"
+	}
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Require HTTPS on future visits
+		if hsts != "" {
+			w.Header().Set("Strict-Transport-Security", hsts)
+		}
+		if expectCT != "" {
+			w.Header().Set("Expect-CT", expectCT)
+		}
+
 		// Prevent clickjacking
 		w.Header().Set("X-Frame-Options", "DENY")
 		
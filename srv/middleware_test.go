@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestSecurityHeaders(t *testing.T) {
@@ -16,39 +17,77 @@ func TestSecurityHeaders(t *testing.T) {
 	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
+	serve := func(cfg Config) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/", nil)
+		rec := httptest.NewRecorder()
+		SecurityHeaders(cfg, inner).ServeHTTP(rec, req)
+		return rec
+	}
 
-	handler := SecurityHeaders(inner)
+	t.Run("default config", func(t *testing.T) {
+		rec := serve(DefaultConfig())
+
+		// Check all security headers are set
+		tests := []struct {
+			header string
+			want   string
+		}{
+			{"X-Frame-Options", "DENY"},
+			{"X-Content-Type-Options", "nosniff"},
+			{"Referrer-Policy", "strict-origin-when-cross-origin"},
+			{"Strict-Transport-Security", "max-age=31536000"},
+			{"Expect-CT", ""},
+		}
 
-	req := httptest.NewRequest("GET", "/", nil)
-	rec := httptest.NewRecorder()
+		for _, tt := range tests {
+			got := rec.Header().Get(tt.header)
+			if got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.header, got, tt.want)
+			}
+		}
 
-	handler.ServeHTTP(rec, req)
+		// CSP should be set and contain key directives
+		csp := rec.Header().Get("Content-Security-Policy")
+		if csp == "" {
+			t.Error("Content-Security-Policy header not set")
+		}
+		if !strings.Contains(csp, "default-src 'self'") {
+			t.Error("CSP missing default-src 'self'")
+		}
+	})
 
-	// Check all security headers are set
-	tests := []struct {
-		header string
-		want   string
-	}{
-		{"X-Frame-Options", "DENY"},
-		{"X-Content-Type-Options", "nosniff"},
-		{"Referrer-Policy", "strict-origin-when-cross-origin"},
-	}
+	t.Run("preload and expect-ct enabled", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.HSTSMaxAge = 63072000
+		cfg.HSTSIncludeSubDomains = true
+		cfg.HSTSPreload = true
+		cfg.ExpectCT = 24 * time.Hour
+		rec := serve(cfg)
 
-	for _, tt := range tests {
-		got := rec.Header().Get(tt.header)
-		if got != tt.want {
-			t.Errorf("%s = %q, want %q", tt.header, got, tt.want)
+		if got, want := rec.Header().Get("Strict-Transport-Security"), "max-age=63072000; includeSubDomains; preload"; got != want {
+			t.Errorf("Strict-Transport-Security = %q, want %q", got, want)
 		}
-	}
+		if got, want := rec.Header().Get("Expect-CT"), "max-age=86400, enforce"; got != want {
+			t.Errorf("Expect-CT = %q, want %q", got, want)
+		}
+	})
 
-	// CSP should be set and contain key directives
-	csp := rec.Header().Get("Content-Security-Policy")
-	if csp == "" {
-		t.Error("Content-Security-Policy header not set")
-	}
-	if !strings.Contains(csp, "default-src 'self'") {
-		t.Error("CSP missing default-src 'self'")
-	}
+	t.Run("disabled", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.HSTSMaxAge = 0
+		cfg.HSTSPreload = true
+		rec := serve(cfg)
+
+		if got := rec.Header().Get("Strict-Transport-Security"); got != "" {
+			t.Errorf("expected no Strict-Transport-Security, got %q", got)
+		}
+		if got := rec.Header().Get("Expect-CT"); got != "" {
+			t.Errorf("expected no Expect-CT, got %q", got)
+		}
+		if rec.Header().Get("X-Frame-Options") != "DENY" {
+			t.Error("other security headers should still be set")
+		}
+	})
 }
 
 func TestGzip_WithAcceptEncoding(t *testing.T) {
@@ -116,6 +155,70 @@ func TestGzip_WithoutAcceptEncoding(t *testing.T) {
 	}
 }
 
+func TestParseAcceptEncoding(t *testing.T) {
+	tests := []struct {
+		header string
+		want   map[string]float64
+	}{
+		{"", map[string]float64{}},
+		{"gzip, deflate", map[string]float64{"gzip": 1, "deflate": 1}},
+		{"gzip;q=0", map[string]float64{"gzip": 0}},
+		{"gzip, identity;q=0", map[string]float64{"gzip": 1, "identity": 0}},
+		{"identity;q=1, *;q=0", map[string]float64{"identity": 1, "*": 0}},
+		{"GZIP ; Q=0.5, br;q=0.8", map[string]float64{"gzip": 0.5, "br": 0.8}},
+		{"gzip;q=abc, br", map[string]float64{"br": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got := parseAcceptEncoding(tt.header)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for coding, q := range tt.want {
+				if gotQ, ok := got[coding]; !ok || gotQ != q {
+					t.Errorf("%s: got %v (present=%v), want %v", coding, gotQ, ok, q)
+				}
+			}
+		})
+	}
+}
+
+func TestGzip_RespectsQualityValues(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello world"))
+	})
+	handler := Gzip(inner)
+
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"gzip, deflate", true},
+		{"gzip;q=0", false},
+		{"gzip, identity;q=0", true},
+		{"identity;q=1, *;q=0", false},
+		{"*", true},
+		{"deflate", false},
+		{"gzip;q=0.5, identity", false},
+		{"gzip, identity;q=0.5", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set("Accept-Encoding", tt.header)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Content-Encoding") == "gzip"; got != tt.want {
+				t.Errorf("gzipped = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRequestLogger_SkipsHealth(t *testing.T) {
 	called := false
 	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -220,6 +323,314 @@ func TestStaticFileServer_CacheHeaders(t *testing.T) {
 	}
 }
 
+func TestStaticFileServer_ETag(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "theme.css"), []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	handler := StaticFileServer(tmpDir)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/theme.css", nil))
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("expected 200 with a weak ETag, got %d %q", rec.Code, etag)
+	}
+
+	req := httptest.NewRequest("GET", "/theme.css", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("expected 304 for matching ETag, got %d", rec.Code)
+	}
+
+	// Changing the file changes the ETag
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(tmpDir, "theme.css"), later, later); err != nil {
+		t.Fatal(err)
+	}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Errorf("expected 200 with a new ETag after modification, got %d %q", rec.Code, rec.Header().Get("ETag"))
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/missing.css", nil))
+	if rec.Header().Get("ETag") != "" {
+		t.Error("expected no ETag for a missing file")
+	}
+}
+
+func TestConditionalGet(t *testing.T) {
+	body := `{"quotes":[]}`
+	handler := ConditionalGet(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, body)
+		case "/text":
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			io.WriteString(w, "hello")
+		case "/error":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			io.WriteString(w, `{"error":"boom"}`)
+		case "/large":
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, strings.Repeat(" ", conditionalGetMaxBody))
+			io.WriteString(w, "{}")
+		}
+	}))
+	get := func(method, path, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("GET", "/json", "")
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || rec.Body.String() != body || etag != computeETag([]byte(body)) {
+		t.Fatalf("expected 200 with body and ETag, got %d %q %q", rec.Code, rec.Body.String(), etag)
+	}
+
+	for _, header := range []string{etag, strings.TrimPrefix(etag, "W/"), `"other", ` + etag, "*"} {
+		rec = get("GET", "/json", header)
+		if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+			t.Errorf("If-None-Match %s: expected empty 304, got %d %q", header, rec.Code, rec.Body.String())
+		}
+		if rec.Header().Get("ETag") != etag {
+			t.Errorf("If-None-Match %s: expected ETag on 304", header)
+		}
+	}
+
+	rec = get("GET", "/json", `W/"stale"`)
+	if rec.Code != http.StatusOK || rec.Body.String() != body || rec.Header().Get("ETag") != etag {
+		t.Errorf("expected 200 with a fresh ETag for a stale one, got %d %q", rec.Code, rec.Header().Get("ETag"))
+	}
+
+	for _, path := range []string{"/text", "/error", "/large"} {
+		rec = get("GET", path, "*")
+		if rec.Header().Get("ETag") != "" || rec.Code == http.StatusNotModified {
+			t.Errorf("%s: expected no ETag handling, got %d %q", path, rec.Code, rec.Header().Get("ETag"))
+		}
+	}
+	if rec.Body.Len() != conditionalGetMaxBody+2 {
+		t.Errorf("expected large body passed through intact, got %d bytes", rec.Body.Len())
+	}
+
+	rec = get("POST", "/json", etag)
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") != "" {
+		t.Errorf("expected POST to bypass ETags, got %d %q", rec.Code, rec.Header().Get("ETag"))
+	}
+}
+
+func TestAPIHandler_ConditionalGet(t *testing.T) {
+	server := testServer(t)
+	addTestQuote(t, server, "Poll me", nil, nil)
+	// Mounted behind NoStore as in Serve, so its header can't shadow ours
+	mux := http.NewServeMux()
+	mux.Handle("/api/", server.apiHandler())
+	handler := NoStore(mux)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/api/quotes", nil))
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag == "" {
+		t.Fatalf("expected 200 with ETag, got %d %q", rec.Code, etag)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "private, no-cache" {
+		t.Errorf("expected a revalidatable Cache-Control, got %q", got)
+	}
+
+	req := httptest.NewRequest("GET", "/api/quotes", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("expected 304 for unchanged quotes, got %d", rec.Code)
+	}
+
+	addTestQuote(t, server, "Something new", nil, nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Errorf("expected 200 with a new ETag after a change, got %d", rec.Code)
+	}
+}
+
+func TestCORS(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{}`)
+	})
+	request := func(handler http.Handler, method, path, origin, preflightMethod string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if preflightMethod != "" {
+			req.Header.Set("Access-Control-Request-Method", preflightMethod)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("any origin", func(t *testing.T) {
+		handler := CORS([]string{"*"}, next)
+
+		rec := request(handler, "OPTIONS", "/api/quote", "https://overlay.example.com", "GET")
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected 204 for preflight, got %d", rec.Code)
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+			t.Errorf("expected Allow-Origin *, got %q", got)
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Methods"); got != "GET, HEAD" {
+			t.Errorf("expected only safe methods, got %q", got)
+		}
+
+		rec = request(handler, "GET", "/api/quote", "https://overlay.example.com", "")
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+			t.Errorf("expected Allow-Origin * on GET, got %q", got)
+		}
+		if !strings.Contains(rec.Header().Get("Access-Control-Expose-Headers"), "X-Total-Count") {
+			t.Errorf("expected X-Total-Count to be exposed, got %q", rec.Header().Get("Access-Control-Expose-Headers"))
+		}
+	})
+
+	t.Run("unsafe methods get no CORS headers", func(t *testing.T) {
+		handler := CORS([]string{"*"}, next)
+
+		rec := request(handler, "OPTIONS", "/api/suggestions", "https://evil.example.com", "POST")
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+			t.Errorf("expected no Allow-Origin for POST preflight, got %q", got)
+		}
+		rec = request(handler, "POST", "/api/suggestions", "https://evil.example.com", "")
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+			t.Errorf("expected no Allow-Origin on POST, got %q", got)
+		}
+	})
+
+	t.Run("admin routes are not shared", func(t *testing.T) {
+		handler := CORS([]string{"*"}, next)
+
+		for _, method := range []string{"GET", "OPTIONS"} {
+			rec := request(handler, method, "/api/admin/owners", "https://overlay.example.com", "GET")
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+				t.Errorf("%s: expected no Allow-Origin on admin route, got %q", method, got)
+			}
+		}
+	})
+
+	t.Run("listed origins", func(t *testing.T) {
+		handler := CORS([]string{"https://overlay.example.com"}, next)
+
+		rec := request(handler, "GET", "/api/quote", "https://overlay.example.com", "")
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://overlay.example.com" {
+			t.Errorf("expected the origin echoed back, got %q", got)
+		}
+		if got := rec.Header().Get("Vary"); got != "Origin" {
+			t.Errorf("expected Vary: Origin, got %q", got)
+		}
+
+		rec = request(handler, "GET", "/api/quote", "https://other.example.com", "")
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+			t.Errorf("expected no Allow-Origin for an unlisted origin, got %q", got)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		handler := CORS(nil, next)
+
+		rec := request(handler, "GET", "/api/quote", "https://overlay.example.com", "")
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+			t.Errorf("expected no Allow-Origin when disabled, got %q", got)
+		}
+	})
+}
+
+func TestAPIHandler_CORS(t *testing.T) {
+	server := testServer(t)
+	addTestQuote(t, server, "Overlay quote", nil, nil)
+	handler := server.apiHandler()
+
+	req := httptest.NewRequest("OPTIONS", "/api/quote", nil)
+	req.Header.Set("Origin", "https://overlay.example.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent || rec.Header().Get("Access-Control-Allow-Origin") != "*" {
+		t.Errorf("expected 204 preflight allowing any origin, got %d %q",
+			rec.Code, rec.Header().Get("Access-Control-Allow-Origin"))
+	}
+
+	req = httptest.NewRequest("GET", "/api/quote", nil)
+	req.Header.Set("Origin", "https://overlay.example.com")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Header().Get("Access-Control-Allow-Origin") != "*" {
+		t.Errorf("expected 200 with Allow-Origin *, got %d %q",
+			rec.Code, rec.Header().Get("Access-Control-Allow-Origin"))
+	}
+}
+
+func TestNoStore(t *testing.T) {
+	server := testServer(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", server.HandleRoot)
+	mux.HandleFunc("GET /health", server.HandleHealth)
+	mux.HandleFunc("GET /browse", server.HandleQuotesPublic)
+	mux.HandleFunc("GET /quotes", server.HandleQuotes)
+	mux.HandleFunc("GET /api/{$}", server.HandleAPIDocs)
+	mux.HandleFunc("GET /api/quote", server.HandleRandomQuote)
+	mux.HandleFunc("GET /feed.xml", server.HandleFeed)
+	handler := NoStore(mux)
+
+	tests := []struct {
+		name      string
+		path      string
+		email     string
+		wantCache string
+	}{
+		{"root anonymous", "/", "", "no-store, no-cache"},
+		{"root authenticated", "/", "user@test.com", "no-store, no-cache"},
+		{"quotes authenticated", "/quotes", "admin@test.com", "no-store, no-cache"},
+		{"browse authenticated", "/browse", "user@test.com", "no-store, no-cache"},
+		{"browse anonymous", "/browse", "", "public, max-age=60"},
+		{"health", "/health", "", "public, max-age=60"},
+		{"api docs", "/api/", "", "public, max-age=3600"},
+		{"api json", "/api/quote", "", ""},
+		{"feed", "/feed.xml", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.email != "" {
+				req.Header.Set("X-ExeDev-UserID", "user123")
+				req.Header.Set("X-ExeDev-Email", tt.email)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", rec.Code)
+			}
+			if got := rec.Header().Get("Cache-Control"); got != tt.wantCache {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.wantCache)
+			}
+		})
+	}
+}
+
 func TestResponseRecorder_DefaultStatus(t *testing.T) {
 	// Test that responseRecorder defaults to 200 when Write is called without WriteHeader
 	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
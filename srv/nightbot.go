@@ -125,7 +125,7 @@ func (s *Server) StartSnapshotCleanup(ctx context.Context) {
 
 func (s *Server) purgeOldDeletedSnapshots() {
 	ctx := context.Background()
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	if err := q.PurgeOldDeletedSnapshots(ctx); err != nil {
 		slog.Error("purge old deleted snapshots", "error", err)
 	} else {
@@ -135,11 +135,7 @@ func (s *Server) purgeOldDeletedSnapshots() {
 
 // nightbotRedirectURI returns the OAuth callback URL
 func (s *Server) nightbotRedirectURI() string {
-	scheme := "https"
-	if strings.Contains(s.Hostname, "localhost") {
-		scheme = "http"
-	}
-	return fmt.Sprintf("%s://%s/admin/nightbot/callback", scheme, s.Hostname)
+	return s.baseURL() + "/admin/nightbot/callback"
 }
 
 // HandleNightbotAdmin shows the Nightbot backup/restore admin page
@@ -162,7 +158,7 @@ func (s *Server) HandleNightbotAdmin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get all connected channels for this user
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	tokens, err := q.GetNightbotTokensByUser(ctx, userEmail)
 	if err != nil {
 		slog.Warn("get nightbot tokens", "error", err)
@@ -213,8 +209,8 @@ func (s *Server) HandleNightbotAdmin(w http.ResponseWriter, r *http.Request) {
 			IsManaged:   managedSet[t.ChannelName],
 		}
 		if lastTime, ok := lastSnapshotMap[t.ChannelName]; ok {
-			info.LastSnapshotAt = formatTimeAgo(lastTime)
-			info.IsStale = time.Since(lastTime) > 7*24*time.Hour
+			info.LastSnapshotAt = s.formatTimeAgo(lastTime)
+			info.IsStale = s.Clock.Now().Sub(lastTime) > 7*24*time.Hour
 		}
 		channels = append(channels, info)
 		seenChannels[t.ChannelName] = true
@@ -238,8 +234,8 @@ func (s *Server) HandleNightbotAdmin(w http.ResponseWriter, r *http.Request) {
 				IsManaged:   isManaged,
 			}
 			if lastTime, ok := lastSnapshotMap[name]; ok {
-				info.LastSnapshotAt = formatTimeAgo(lastTime)
-				info.IsStale = time.Since(lastTime) > 7*24*time.Hour
+				info.LastSnapshotAt = s.formatTimeAgo(lastTime)
+				info.IsStale = s.Clock.Now().Sub(lastTime) > 7*24*time.Hour
 			}
 			channels = append(channels, info)
 		}
@@ -258,6 +254,7 @@ func (s *Server) HandleNightbotAdmin(w http.ResponseWriter, r *http.Request) {
 		HasOAuthChannels bool
 		ConnectURL      string
 		ImportToken     string
+		navBadges
 	}{
 		Hostname:        s.Hostname,
 		UserEmail:       userEmail,
@@ -271,6 +268,7 @@ func (s *Server) HandleNightbotAdmin(w http.ResponseWriter, r *http.Request) {
 		HasOAuthChannels: len(tokens) > 0,
 		ConnectURL:      s.nightbotAuthURL(),
 		ImportToken:     s.Config.NightbotImportToken,
+		navBadges:       s.navBadgesFor(r.Context(), userEmail),
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -351,7 +349,7 @@ func (s *Server) HandleNightbotCallback(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	expiresAt := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
 
 	err = q.UpsertNightbotToken(ctx, dbgen.UpsertNightbotTokenParams{
@@ -498,7 +496,7 @@ func (s *Server) getNightbotChannel(ctx context.Context, accessToken string) (*n
 
 // getValidNightbotToken returns a valid access token, refreshing if needed
 func (s *Server) getValidNightbotToken(ctx context.Context, userEmail, channelName string) (string, error) {
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	token, err := q.GetNightbotToken(ctx, dbgen.GetNightbotTokenParams{
 		UserEmail:   userEmail,
 		ChannelName: channelName,
@@ -835,7 +833,7 @@ func (s *Server) HandleNightbotDisconnect(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	if err := q.DeleteNightbotToken(ctx, dbgen.DeleteNightbotTokenParams{
 		UserEmail:   userEmail,
 		ChannelName: channelName,
@@ -911,7 +909,7 @@ func (s *Server) HandleNightbotSaveSnapshot(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Save snapshot
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	_, err = q.CreateNightbotSnapshot(ctx, dbgen.CreateNightbotSnapshotParams{
 		ChannelName:  channelName,
 		CommandCount: int64(len(commands)),
@@ -963,7 +961,7 @@ func (s *Server) HandleNightbotSnapshots(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	snapshots, err := q.GetNightbotSnapshots(ctx, dbgen.GetNightbotSnapshotsParams{
 		ChannelName: channelName,
 		Limit:       50,
@@ -1011,6 +1009,7 @@ func (s *Server) HandleNightbotSnapshots(w http.ResponseWriter, r *http.Request)
 		IsPublicPage    bool
 		LogoutURL       string
 		UserEmail       string
+		navBadges
 	}{
 		ChannelName:     channelName,
 		Snapshots:       snapshots,
@@ -1025,6 +1024,7 @@ func (s *Server) HandleNightbotSnapshots(w http.ResponseWriter, r *http.Request)
 		IsPublicPage:    false,
 		LogoutURL:       logoutURL,
 		UserEmail:       auth.DisplayIdentity(),
+		navBadges:       s.navBadgesFor(r.Context(), auth.Email),
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -1056,7 +1056,7 @@ func (s *Server) HandleNightbotSnapshotDownload(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	snapshot, err := q.GetNightbotSnapshot(ctx, id)
 	if err != nil {
 		http.Error(w, "Snapshot not found", http.StatusNotFound)
@@ -1123,7 +1123,7 @@ func (s *Server) HandleNightbotSnapshotDiff(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	snapshot, err := q.GetNightbotSnapshot(ctx, id)
 	if err != nil {
 		http.Redirect(w, r, "/admin/nightbot?error="+url.QueryEscape("Snapshot not found"), http.StatusSeeOther)
@@ -1279,6 +1279,7 @@ func (s *Server) HandleNightbotSnapshotDiff(w http.ResponseWriter, r *http.Reque
 		IsPublicPage     bool
 		LogoutURL        string
 		UserEmail        string
+		navBadges
 	}{
 		ChannelName:      snapshot.ChannelName,
 		SnapshotAt:       snapshot.SnapshotAt.Format("Jan 2, 2006 3:04 PM"),
@@ -1296,6 +1297,7 @@ func (s *Server) HandleNightbotSnapshotDiff(w http.ResponseWriter, r *http.Reque
 		IsPublicPage:     false,
 		LogoutURL:        "/__exe.dev/logout",
 		UserEmail:        userEmail,
+		navBadges:        s.navBadgesFor(r.Context(), userEmail),
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -1332,7 +1334,7 @@ func (s *Server) HandleNightbotSnapshotCompare(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	fromSnapshot, err := q.GetNightbotSnapshot(ctx, fromID)
 	if err != nil {
 		http.Redirect(w, r, "/admin/nightbot?error="+url.QueryEscape("From snapshot not found"), http.StatusSeeOther)
@@ -1490,6 +1492,7 @@ func (s *Server) HandleNightbotSnapshotCompare(w http.ResponseWriter, r *http.Re
 		IsPublicPage    bool
 		LogoutURL       string
 		UserEmail       string
+		navBadges
 	}{
 		ChannelName:     fromSnapshot.ChannelName,
 		FromSnapshot:    fromSnapshot,
@@ -1508,6 +1511,7 @@ func (s *Server) HandleNightbotSnapshotCompare(w http.ResponseWriter, r *http.Re
 		IsPublicPage:    false,
 		LogoutURL:       logoutURL,
 		UserEmail:       auth.DisplayIdentity(),
+		navBadges:       s.navBadgesFor(r.Context(), auth.Email),
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -1549,7 +1553,7 @@ func (s *Server) HandleNightbotSnapshotRestore(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	snapshot, err := q.GetNightbotSnapshot(ctx, id)
 	if err != nil {
 		http.Redirect(w, r, "/admin/nightbot?error="+url.QueryEscape("Snapshot not found"), http.StatusSeeOther)
@@ -1730,7 +1734,7 @@ func (s *Server) HandleNightbotImportSnapshot(w http.ResponseWriter, r *http.Req
 	}
 
 	// Create snapshot with custom timestamp
-	_, err = s.DB.ExecContext(ctx,
+	_, err = s.WriteDB.ExecContext(ctx,
 		`INSERT INTO nightbot_snapshots (channel_name, snapshot_at, command_count, commands_json, created_by, note)
 		 VALUES (?, ?, ?, ?, ?, ?)`,
 		channelName, snapshotAt, len(backup.Commands), string(commandsJSON), authenticatedAs, "Imported via Tampermonkey")
@@ -1788,7 +1792,7 @@ func (s *Server) HandleNightbotSnapshotDelete(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	snapshot, err := q.GetNightbotSnapshot(ctx, id)
 	if err != nil {
 		http.Redirect(w, r, "/admin/nightbot?error="+url.QueryEscape("Snapshot not found"), http.StatusSeeOther)
@@ -1837,7 +1841,7 @@ func (s *Server) HandleNightbotSnapshotUpdateNote(w http.ResponseWriter, r *http
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	snapshot, err := q.GetNightbotSnapshot(ctx, id)
 	if err != nil {
 		http.Redirect(w, r, "/admin/nightbot?error="+url.QueryEscape("Snapshot not found"), http.StatusSeeOther)
@@ -1889,7 +1893,7 @@ func (s *Server) HandleNightbotSnapshotUndelete(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	snapshot, err := q.GetNightbotSnapshot(ctx, id)
 	if err != nil {
 		http.Redirect(w, r, "/admin/nightbot?error="+url.QueryEscape("Snapshot not found"), http.StatusSeeOther)
@@ -1921,7 +1925,7 @@ func (s *Server) HandleNightbotDeletedSnapshots(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 
 	// Check for a specific channel filter
 	channelName := r.URL.Query().Get("channel")
@@ -1952,6 +1956,7 @@ func (s *Server) HandleNightbotDeletedSnapshots(w http.ResponseWriter, r *http.R
 		IsPublicPage    bool
 		LogoutURL       string
 		UserEmail       string
+		navBadges
 	}{
 		Snapshots:       snapshots,
 		ChannelName:     channelName,
@@ -1962,6 +1967,7 @@ func (s *Server) HandleNightbotDeletedSnapshots(w http.ResponseWriter, r *http.R
 		IsPublicPage:    false,
 		LogoutURL:       "/__exe.dev/logout",
 		UserEmail:       userEmail,
+		navBadges:       s.navBadgesFor(r.Context(), userEmail),
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -2001,7 +2007,7 @@ func (s *Server) HandleNightbotSearch(w http.ResponseWriter, r *http.Request) {
 	var results []SearchResult
 
 	if query != "" && len(query) >= 2 {
-		q := dbgen.New(s.DB)
+		q := dbgen.New(s.WriteDB)
 		var snapshots []dbgen.NightbotSnapshot
 		var err error
 
@@ -2012,7 +2018,7 @@ func (s *Server) HandleNightbotSearch(w http.ResponseWriter, r *http.Request) {
 			})
 		} else {
 			// Search across all channels - get recent snapshots
-			rows, err2 := s.DB.QueryContext(ctx, 
+			rows, err2 := s.WriteDB.QueryContext(ctx, 
 				`SELECT id, channel_name, snapshot_at, command_count, commands_json, created_by, note,
 				        last_diff_added, last_diff_removed, last_diff_modified, last_diff_at, deleted_at, deleted_by
 				 FROM nightbot_snapshots 
@@ -2070,6 +2076,7 @@ func (s *Server) HandleNightbotSearch(w http.ResponseWriter, r *http.Request) {
 		IsPublicPage    bool
 		LogoutURL       string
 		UserEmail       string
+		navBadges
 	}{
 		Query:           query,
 		ChannelName:     channelName,
@@ -2080,6 +2087,7 @@ func (s *Server) HandleNightbotSearch(w http.ResponseWriter, r *http.Request) {
 		IsPublicPage:    false,
 		LogoutURL:       "/__exe.dev/logout",
 		UserEmail:       userEmail,
+		navBadges:       s.navBadgesFor(r.Context(), userEmail),
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
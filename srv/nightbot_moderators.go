@@ -30,7 +30,7 @@ func (s *Server) HandleNightbotModerators(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 
 	// Get all moderators
 	moderators, err := q.GetAllModerators(ctx)
@@ -71,7 +71,7 @@ func (s *Server) HandleNightbotModerators(w http.ResponseWriter, r *http.Request
 			UserEmail:      userEmail,
 			TwitchUsername: twitchUsername,
 			AddedBy:        m.AddedBy,
-			AddedAt:        formatTimeAgo(m.AddedAt),
+			AddedAt:        s.formatTimeAgo(m.AddedAt),
 		})
 	}
 
@@ -91,6 +91,7 @@ func (s *Server) HandleNightbotModerators(w http.ResponseWriter, r *http.Request
 		Error           string
 		Moderators      []ModeratorView
 		Channels        []string
+		navBadges
 	}{
 		Hostname:        s.Hostname,
 		UserEmail:       userEmail,
@@ -102,6 +103,7 @@ func (s *Server) HandleNightbotModerators(w http.ResponseWriter, r *http.Request
 		Error:           r.URL.Query().Get("error"),
 		Moderators:      modViews,
 		Channels:        channelNames,
+		navBadges:       s.navBadgesFor(r.Context(), userEmail),
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -136,7 +138,7 @@ func (s *Server) HandleNightbotModeratorAdd(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	var identifier string
 
 	if authType == "twitch" && twitchUsername != "" {
@@ -200,7 +202,7 @@ func (s *Server) HandleNightbotModeratorRemove(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	if err := q.RemoveChannelModerator(ctx, id); err != nil {
 		slog.Error("remove moderator", "error", err)
 		http.Redirect(w, r, "/admin/nightbot/moderators?error="+url.QueryEscape("Failed to remove moderator"), http.StatusSeeOther)
@@ -8,9 +8,12 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/webframp/quoteqt/db/dbgen"
 )
 
 func TestNightbotAPICallRetry(t *testing.T) {
@@ -386,3 +389,44 @@ func TestHandleNightbotImportSnapshot(t *testing.T) {
 		}
 	})
 }
+
+func TestHandleNightbotAdmin_Stale(t *testing.T) {
+	server := setupNightbotTestServer(t, "", []string{"admin@test.com"})
+	q := dbgen.New(server.WriteDB)
+	if _, err := q.CreateNightbotSnapshot(context.Background(), dbgen.CreateNightbotSnapshotParams{
+		ChannelName:  "importedchan",
+		CommandCount: 1,
+		CommandsJson: "[]",
+		CreatedBy:    "admin@test.com",
+	}); err != nil {
+		t.Fatalf("create snapshot: %v", err)
+	}
+	last, err := q.GetAllChannelsLastSnapshot(context.Background())
+	if err != nil || len(last) != 1 {
+		t.Fatalf("expected 1 channel snapshot, got %d (%v)", len(last), err)
+	}
+	snapshotAt := last[0].LastSnapshotAt
+
+	page := func() string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/admin/nightbot", nil)
+		req.Header.Set("X-ExeDev-UserID", "admin-1")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		rr := httptest.NewRecorder()
+		server.HandleNightbotAdmin(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rr.Code)
+		}
+		return rr.Body.String()
+	}
+
+	server.Clock = &fakeClock{now: snapshotAt.Add(24 * time.Hour)}
+	if body := page(); strings.Contains(body, `last-backup stale`) || !strings.Contains(body, "yesterday") {
+		t.Error("expected a fresh backup from yesterday")
+	}
+
+	server.Clock = &fakeClock{now: snapshotAt.Add(8 * 24 * time.Hour)}
+	if body := page(); !strings.Contains(body, `last-backup stale`) {
+		t.Error("expected the backup to be stale after 8 days")
+	}
+}
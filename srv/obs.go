@@ -0,0 +1,77 @@
+package srv
+
+import (
+	"html/template"
+	"log/slog"
+	"net/http"
+)
+
+// ObsOverlayTemplate renders a quote as a standalone page for an OBS browser
+// source. The background is transparent and the page reloads itself, so it
+// needs no JavaScript.
+const ObsOverlayTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="{{.Refresh}}">
+<title>quoteqt</title>
+<style>
+html, body { margin: 0; background: transparent; }
+body { padding: 1rem; font-family: "Segoe UI", Helvetica, Arial, sans-serif; color: #fff; text-shadow: 0 2px 4px rgba(0, 0, 0, 0.85); }
+.quote { margin: 0 0 0.5rem; font-size: 2rem; line-height: 1.3; }
+.author { margin: 0 0 0.25rem; font-size: 1.25rem; opacity: 0.9; }
+.civ { margin: 0; font-size: 1.1rem; opacity: 0.8; }
+</style>
+</head>
+<body>
+<p class="quote">{{.Text}}</p>
+{{- if .Author}}
+<p class="author">— {{.Author}}</p>
+{{- end}}
+{{- if .Civ}}
+<p class="civ">{{.Civ}}</p>
+{{- end}}
+</body>
+</html>
+`
+
+// obsRefreshSeconds is how often the overlay page reloads for a new quote
+const obsRefreshSeconds = 30
+
+var obsOverlayTmpl = template.Must(template.New("obs").Parse(ObsOverlayTemplate))
+
+type obsOverlay struct {
+	Refresh int
+	Text    string
+	Author  string
+	Civ     string
+}
+
+// WantsOBS reports whether the client asked for the OBS overlay page with
+// ?format=obs
+func WantsOBS(r *http.Request) bool {
+	return r.URL.Query().Get("format") == "obs"
+}
+
+// writeObsOverlay writes the overlay page for quote, or for message alone
+// when quote is nil.
+func writeObsOverlay(w http.ResponseWriter, quote *QuoteResponse, message string) {
+	data := obsOverlay{Refresh: obsRefreshSeconds, Text: message}
+	if quote != nil {
+		data.Text = quote.Text
+		if quote.Author != nil {
+			data.Author = *quote.Author
+		}
+		if quote.Civilization != nil {
+			data.Civ = *quote.Civilization
+			if quote.OpponentCiv != nil && *quote.OpponentCiv != "" {
+				data.Civ += " vs " + *quote.OpponentCiv
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := obsOverlayTmpl.Execute(w, data); err != nil {
+		slog.Warn("render obs overlay", "error", err)
+	}
+}
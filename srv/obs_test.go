@@ -0,0 +1,85 @@
+package srv
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+)
+
+func TestObsOverlay(t *testing.T) {
+	assertOverlay := func(t *testing.T, w *httptest.ResponseRecorder, want ...string) {
+		t.Helper()
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+			t.Errorf("expected text/html, got %q", ct)
+		}
+		body := w.Body.String()
+		if !strings.HasPrefix(body, "<!DOCTYPE html>") {
+			t.Errorf("expected a full HTML page, got: %s", body)
+		}
+		if !strings.Contains(body, `<meta http-equiv="refresh" content="30">`) {
+			t.Errorf("expected auto refresh, got: %s", body)
+		}
+		if strings.Contains(body, "<script") {
+			t.Errorf("expected no JavaScript, got: %s", body)
+		}
+		for _, s := range want {
+			if !strings.Contains(body, s) {
+				t.Errorf("expected body to contain %q, got: %s", s, body)
+			}
+		}
+	}
+
+	t.Run("random quote", func(t *testing.T) {
+		server := testServer(t)
+		civ := "French"
+		addTestQuote(t, server, "Scout <early> & often", &civ, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/quote?format=obs", nil)
+		w := httptest.NewRecorder()
+		server.HandleRandomQuote(w, req)
+
+		assertOverlay(t, w,
+			`<p class="quote">Scout &lt;early&gt; &amp; often</p>`,
+			`<p class="civ">French</p>`,
+		)
+	})
+
+	t.Run("matchup", func(t *testing.T) {
+		server := testServer(t)
+		hre := "Holy Roman Empire"
+		french := "French"
+		if _, err := dbgen.New(server.WriteDB).CreateQuote(context.Background(), dbgen.CreateQuoteParams{
+			Text:         "Wall up against the knights",
+			Civilization: &hre,
+			OpponentCiv:  &french,
+		}); err != nil {
+			t.Fatalf("create quote: %v", err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/matchup?civ=hre&vs=french&format=obs", nil)
+		w := httptest.NewRecorder()
+		server.HandleMatchup(w, req)
+
+		assertOverlay(t, w,
+			"Wall up against the knights",
+			`<p class="civ">Holy Roman Empire vs French</p>`,
+		)
+	})
+
+	t.Run("no quotes", func(t *testing.T) {
+		server := testServer(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/quote?format=obs", nil)
+		w := httptest.NewRecorder()
+		server.HandleRandomQuote(w, req)
+
+		assertOverlay(t, w, "No quotes available.")
+	})
+}
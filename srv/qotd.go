@@ -0,0 +1,97 @@
+package srv
+
+import (
+	"database/sql"
+	"errors"
+	"hash/fnv"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// quoteOfTheDayIndex picks an index into n candidates from the UTC date
+// and channel, so every request on the same day gets the same answer and
+// the pick changes at midnight UTC.
+func quoteOfTheDayIndex(day time.Time, channel string, n int) int {
+	h := fnv.New64a()
+	h.Write([]byte(day.UTC().Format(time.DateOnly)))
+	h.Write([]byte{0})
+	h.Write([]byte(channel))
+	return int(h.Sum64() % uint64(n))
+}
+
+// HandleQuoteOfTheDay godoc
+// @Summary Get the quote of the day
+// @Description Returns the same quote to everyone for the whole UTC day, changing at midnight UTC.
+// @Description With a channel, the pick comes from that channel's quotes plus global ones, like /quote.
+// @Description Adding or deleting quotes can change the day's pick.
+// @Tags quotes
+// @Produce plain
+// @Produce json
+// @Param channel query string false "Channel name for channel-specific quotes"
+// @Success 200 {object} QuoteResponse "Quote found (JSON when Accept: application/json)"
+// @Success 200 {string} string "Quote text (plain text default)"
+// @Router /quote/today [get]
+func (s *Server) HandleQuoteOfTheDay(w http.ResponseWriter, r *http.Request) {
+	AddNightbotAttributes(r)
+	ctx := r.Context()
+
+	var channel string
+	var channelPtr *string
+	if bc := GetBotChannel(r); bc != nil {
+		channel = bc.Name
+		channelPtr = &channel
+	}
+
+	q := dbgen.New(s.ReadDB)
+	dbCtx, span := StartDBSpan(ctx, "ListRandomQuoteCandidateIDs",
+		attribute.String("channel", channel))
+	ids, err := q.ListRandomQuoteCandidateIDs(dbCtx, dbgen.ListRandomQuoteCandidateIDsParams{Channel: channelPtr})
+	if err != nil {
+		RecordError(span, err)
+	}
+	span.End()
+	if err != nil {
+		slog.Error("list quote of the day candidates", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if len(ids) == 0 {
+		// Return 200 so bots like Nightbot don't treat it as an error
+		WriteNoResultsResponse(w, r, "No quotes available.")
+		return
+	}
+
+	// The query has no ORDER BY; sort so the index means the same quote
+	// on every request
+	slices.Sort(ids)
+	id := ids[quoteOfTheDayIndex(s.Clock.Now(), channel, len(ids))]
+
+	quote, err := q.GetQuoteByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Deleted since the candidates were listed
+			WriteNoResultsResponse(w, r, "No quotes available.")
+			return
+		}
+		slog.Error("get quote of the day", "id", id, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	WriteQuoteResponse(w, r, QuoteResponse{
+		ID:           quote.ID,
+		Text:         quote.Text,
+		Author:       quote.Author,
+		Civilization: quote.Civilization,
+		OpponentCiv:  quote.OpponentCiv,
+		CreatedAt:    quote.CreatedAt.Format(time.RFC3339),
+		Channel:      quote.Channel,
+		Upvotes:      quote.Upvotes,
+		Downvotes:    quote.Downvotes,
+	})
+}
@@ -0,0 +1,92 @@
+package srv
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandleQuoteOfTheDay(t *testing.T) {
+	// today returns the quote served at now
+	today := func(t *testing.T, server *Server, now time.Time, query string) QuoteResponse {
+		t.Helper()
+		server.Clock = &fakeClock{now: now}
+		req := httptest.NewRequest(http.MethodGet, "/api/quote/today"+query, nil)
+		req.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+		server.HandleQuoteOfTheDay(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var quote QuoteResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &quote); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return quote
+	}
+	setup := func(t *testing.T) *Server {
+		server := testServer(t)
+		for i := range 20 {
+			addTestQuote(t, server, fmt.Sprintf("Daily tip %d", i), nil, nil)
+		}
+		return server
+	}
+	morning := time.Date(2026, 10, 16, 0, 0, 1, 0, time.UTC)
+
+	t.Run("same quote all day", func(t *testing.T) {
+		server := setup(t)
+		first := today(t, server, morning, "")
+		for _, later := range []time.Time{
+			morning.Add(6 * time.Hour),
+			time.Date(2026, 10, 16, 23, 59, 59, 0, time.UTC),
+			// Oct 15 locally, but already Oct 16 in UTC
+			time.Date(2026, 10, 15, 20, 0, 0, 0, time.FixedZone("PDT", -7*3600)),
+		} {
+			if got := today(t, server, later, ""); got.ID != first.ID {
+				t.Errorf("at %v: got quote %d, want %d", later, got.ID, first.ID)
+			}
+		}
+	})
+
+	t.Run("changes at midnight UTC", func(t *testing.T) {
+		server := setup(t)
+		first := today(t, server, morning, "")
+		changed := false
+		for day := 1; day <= 10; day++ {
+			if today(t, server, morning.AddDate(0, 0, day), "").ID != first.ID {
+				changed = true
+				break
+			}
+		}
+		if !changed {
+			t.Error("expected the quote to change on some later day")
+		}
+	})
+
+	t.Run("channel picks from channel and global quotes", func(t *testing.T) {
+		server := testServer(t)
+		ch, other := "streamer1", "streamer2"
+		addTestQuote(t, server, "Channel tip", nil, &ch)
+		addTestQuote(t, server, "Other channel tip", nil, &other)
+		for day := range 10 {
+			got := today(t, server, morning.AddDate(0, 0, day), "?channel=streamer1")
+			if got.Text == "Other channel tip" {
+				t.Fatalf("day %d: got another channel's quote", day)
+			}
+		}
+	})
+
+	t.Run("no quotes", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/api/quote/today", nil)
+		w := httptest.NewRecorder()
+		server.HandleQuoteOfTheDay(w, req)
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "No quotes available") {
+			t.Errorf("expected 200 with no-results message, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+}
@@ -1,7 +1,10 @@
 package srv
 
 import (
+	"fmt"
+	"net"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -12,9 +15,10 @@ import (
 type RateLimiter struct {
 	mu       sync.Mutex
 	visitors map[string]*visitor
-	rate     int           // tokens per interval
-	interval time.Duration // refill interval
-	burst    int           // max tokens
+	rate     int              // tokens per interval
+	interval time.Duration    // refill interval
+	burst    int              // max tokens
+	clockFn  func() time.Time // time source, replaced in tests
 }
 
 type visitor struct {
@@ -30,6 +34,7 @@ func NewRateLimiter(rate int, interval time.Duration, burst int) *RateLimiter {
 		rate:     rate,
 		interval: interval,
 		burst:    burst,
+		clockFn:  time.Now,
 	}
 	// Cleanup stale entries every minute
 	go rl.cleanup()
@@ -41,7 +46,7 @@ func (rl *RateLimiter) cleanup() {
 		time.Sleep(time.Minute)
 		rl.mu.Lock()
 		for ip, v := range rl.visitors {
-			if time.Since(v.lastSeen) > 5*time.Minute {
+			if rl.clockFn().Sub(v.lastSeen) > 5*time.Minute {
 				delete(rl.visitors, ip)
 			}
 		}
@@ -49,33 +54,64 @@ func (rl *RateLimiter) cleanup() {
 	}
 }
 
+// RateLimitStatus is the outcome of a rate limit check, used for the
+// RateLimit-* response headers.
+type RateLimitStatus struct {
+	Allowed   bool
+	Limit     int           // burst capacity
+	Remaining int           // tokens left after this request
+	Reset     time.Duration // until the bucket is full again
+	// RetryAfter is how long until the next token is added. Tokens are
+	// refilled a whole interval after the key's last request.
+	RetryAfter time.Duration
+}
+
 // Allow checks if a request from the given IP should be allowed.
 func (rl *RateLimiter) Allow(ip string) bool {
+	return rl.Take(ip).Allowed
+}
+
+// Take consumes a token for key if one is available and reports the
+// resulting state of its bucket.
+func (rl *RateLimiter) Take(key string) RateLimitStatus {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	v, exists := rl.visitors[ip]
-	now := time.Now()
+	v, exists := rl.visitors[key]
+	now := rl.clockFn()
 
+	allowed := true
 	if !exists {
-		rl.visitors[ip] = &visitor{tokens: rl.burst - 1, lastSeen: now}
-		return true
-	}
+		v = &visitor{tokens: rl.burst - 1, lastSeen: now}
+		rl.visitors[key] = v
+	} else {
+		// Refill tokens based on elapsed time
+		elapsed := now.Sub(v.lastSeen)
+		refill := int(elapsed / rl.interval) * rl.rate
+		v.tokens += refill
+		if v.tokens > rl.burst {
+			v.tokens = rl.burst
+		}
+		v.lastSeen = now
 
-	// Refill tokens based on elapsed time
-	elapsed := now.Sub(v.lastSeen)
-	refill := int(elapsed / rl.interval) * rl.rate
-	v.tokens += refill
-	if v.tokens > rl.burst {
-		v.tokens = rl.burst
+		if v.tokens > 0 {
+			v.tokens--
+		} else {
+			allowed = false
+		}
 	}
-	v.lastSeen = now
 
-	if v.tokens > 0 {
-		v.tokens--
-		return true
+	status := RateLimitStatus{
+		Allowed:    allowed,
+		Limit:      rl.burst,
+		Remaining:  v.tokens,
+		RetryAfter: rl.interval,
+	}
+	if missing := rl.burst - v.tokens; missing > 0 {
+		intervals := (missing + rl.rate - 1) / rl.rate
+		status.Reset = time.Duration(intervals) * rl.interval
 	}
-	return false
+	return status
 }
 
 // getRateLimitKey returns the key to use for rate limiting.
@@ -96,21 +132,52 @@ func getRateLimitKey(r *http.Request) (key string, keyType string) {
 	return "ip:" + ip, "ip"
 }
 
+// clientIP returns the caller's IP address, preferring X-Forwarded-For as set
+// by the proxy and stripping the port from RemoteAddr.
+func clientIP(r *http.Request) string {
+	if ip := r.Header.Get("X-Forwarded-For"); ip != "" {
+		return ip
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
 // Middleware wraps an http.Handler with rate limiting.
 // Uses per-channel rate limiting for Nightbot requests, per-IP otherwise.
 func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		key, keyType := getRateLimitKey(r)
 
-		if !rl.Allow(key) {
+		status := rl.Take(key)
+		h := w.Header()
+		h.Set("RateLimit-Limit", strconv.Itoa(status.Limit))
+		h.Set("RateLimit-Remaining", strconv.Itoa(status.Remaining))
+		h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(status.Reset)))
+		if !status.Allowed {
+			retryAfter := max(ceilSeconds(status.RetryAfter), 1)
+			h.Set("Retry-After", strconv.Itoa(retryAfter))
+			rateLimitedTotal.WithLabelValues("api").Inc()
 			RecordSecurityEvent(r.Context(), "rate_limited",
 				attribute.String("rate_limit.key", key),
 				attribute.String("rate_limit.key_type", keyType),
 				attribute.String("path", r.URL.Path),
 			)
+			if isBotRequest(r) {
+				// Chat bots show a 429 as a generic failure, so give
+				// them a message to relay instead
+				writeBotError(w, fmt.Sprintf("Slow down! Try again in %ds.", retryAfter))
+				return
+			}
 			http.Error(w, "Rate limit exceeded. Try again later.", http.StatusTooManyRequests)
 			return
 		}
 		next.ServeHTTP(w, r)
 	})
 }
+
+// ceilSeconds rounds d up to whole seconds, as the rate limit headers use
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
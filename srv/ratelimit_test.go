@@ -3,6 +3,7 @@ package srv
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -11,14 +12,30 @@ import (
 // newTestRateLimiter creates a rate limiter without the cleanup goroutine
 // for deterministic testing.
 func newTestRateLimiter(rate int, interval time.Duration, burst int) *RateLimiter {
+	return newTestRateLimiterWithClock(rate, interval, burst, time.Now)
+}
+
+// newTestRateLimiterWithClock is like newTestRateLimiter but reads the time
+// from clock, so tests can advance time without sleeping.
+func newTestRateLimiterWithClock(rate int, interval time.Duration, burst int, clock func() time.Time) *RateLimiter {
 	return &RateLimiter{
 		visitors: make(map[string]*visitor),
 		rate:     rate,
 		interval: interval,
 		burst:    burst,
+		clockFn:  clock,
 	}
 }
 
+// fakeClock is a manually advanced clock for rate limiter and server tests
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
 func TestRateLimiter_FirstRequestAllowed(t *testing.T) {
 	rl := newTestRateLimiter(1, time.Second, 5)
 
@@ -64,7 +81,8 @@ func TestRateLimiter_DifferentIPsIndependent(t *testing.T) {
 
 func TestRateLimiter_TokenRefill(t *testing.T) {
 	// 1 token per 100ms, burst of 2
-	rl := newTestRateLimiter(1, 100*time.Millisecond, 2)
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	rl := newTestRateLimiterWithClock(1, 100*time.Millisecond, 2, clock.Now)
 	ip := "192.168.1.1"
 
 	// Use both tokens
@@ -75,8 +93,14 @@ func TestRateLimiter_TokenRefill(t *testing.T) {
 		t.Error("should be denied after burst exhausted")
 	}
 
-	// Wait for 1 token to refill
-	time.Sleep(150 * time.Millisecond)
+	// Not a full interval yet, so no refill
+	clock.Advance(99 * time.Millisecond)
+	if rl.Allow(ip) {
+		t.Error("should be denied before a full interval has passed")
+	}
+
+	// One interval refills 1 token
+	clock.Advance(150 * time.Millisecond)
 
 	if !rl.Allow(ip) {
 		t.Error("should be allowed after token refill")
@@ -90,14 +114,15 @@ func TestRateLimiter_TokenRefill(t *testing.T) {
 
 func TestRateLimiter_RefillCapsAtBurst(t *testing.T) {
 	// 10 tokens per 10ms, burst of 3
-	rl := newTestRateLimiter(10, 10*time.Millisecond, 3)
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	rl := newTestRateLimiterWithClock(10, 10*time.Millisecond, 3, clock.Now)
 	ip := "192.168.1.1"
 
 	// Use 1 token
 	rl.Allow(ip)
 
-	// Wait long enough for many refills
-	time.Sleep(100 * time.Millisecond)
+	// Advance long enough for many refills
+	clock.Advance(100 * time.Millisecond)
 
 	// Should only have burst capacity (3), not unlimited
 	allowed := 0
@@ -268,3 +293,176 @@ func TestRateLimiterMiddleware_BlocksExcessRequests(t *testing.T) {
 		t.Errorf("handler should have been called 2 times, got %d", callCount)
 	}
 }
+
+func TestAPIHandler_SeparateLimiters(t *testing.T) {
+	server := testServer(t)
+	server.APILimiter = newTestRateLimiter(1, time.Hour, 1)
+	server.RandomLimiter = newTestRateLimiter(1, time.Hour, 5)
+	server.ListLimiter = newTestRateLimiter(1, time.Hour, 2)
+	addTestQuote(t, server, "Test quote text", nil, nil)
+	handler := server.apiHandler()
+
+	get := func(path string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Exhaust the list limiter
+	for i := range 2 {
+		if code := get("/api/quotes"); code != http.StatusOK {
+			t.Fatalf("list request %d: expected 200, got %d", i+1, code)
+		}
+	}
+	if code := get("/api/quotes"); code != http.StatusTooManyRequests {
+		t.Fatalf("expected list limiter to be exhausted, got %d", code)
+	}
+	if code := get("/api/quotes/top"); code != http.StatusTooManyRequests {
+		t.Errorf("expected /api/quotes/top to share the list limiter, got %d", code)
+	}
+
+	// Single-quote lookups have their own bucket
+	for _, path := range []string{"/api/quote", "/api/quotes/1", "/api/matchup?civ=hre&vs=french"} {
+		if code := get(path); code != http.StatusOK {
+			t.Errorf("%s: expected 200 after list limit was hit, got %d", path, code)
+		}
+	}
+
+	// So do the remaining routes
+	if code := get("/api/openapi.json"); code != http.StatusOK {
+		t.Errorf("expected general limiter to be unaffected, got %d", code)
+	}
+	if code := get("/api/openapi.json"); code != http.StatusTooManyRequests {
+		t.Errorf("expected general limiter to apply to docs, got %d", code)
+	}
+	if code := get("/api/quote"); code != http.StatusOK {
+		t.Errorf("expected /api/quote unaffected by the general limiter, got %d", code)
+	}
+}
+
+func TestRateLimiter_TakeStatus(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	rl := newTestRateLimiterWithClock(2, time.Minute, 5, clock.Now)
+
+	rl.Take("ip1")
+	status := rl.Take("ip1")
+	if !status.Allowed || status.Limit != 5 || status.Remaining != 3 {
+		t.Errorf("expected allowed with 3 of 5 remaining, got %+v", status)
+	}
+	// 2 missing tokens at 2 per interval is one interval
+	if status.Reset != time.Minute || status.RetryAfter != time.Minute {
+		t.Errorf("expected reset and retry after of 1m, got %+v", status)
+	}
+
+	rl.Take("ip1")
+	status = rl.Take("ip1")
+	// 4 missing tokens take 2 intervals
+	if status.Remaining != 1 || status.Reset != 2*time.Minute {
+		t.Errorf("expected 1 remaining and reset of 2m, got %+v", status)
+	}
+}
+
+func TestRateLimiterMiddleware_Headers(t *testing.T) {
+	rl := newTestRateLimiter(1, 30*time.Second, 3)
+	handler := rl.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	request := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/quote", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// Partial burst consumption
+	request()
+	w := request()
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	for header, want := range map[string]string{
+		"RateLimit-Limit":     "3",
+		"RateLimit-Remaining": "1",
+		"RateLimit-Reset":     "60",
+	} {
+		if got := w.Header().Get(header); got != want {
+			t.Errorf("%s: expected %q, got %q", header, want, got)
+		}
+	}
+	if got := w.Header().Get("Retry-After"); got != "" {
+		t.Errorf("expected no Retry-After on an allowed request, got %q", got)
+	}
+
+	request()
+	w = request()
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", w.Code)
+	}
+	for header, want := range map[string]string{
+		"RateLimit-Limit":     "3",
+		"RateLimit-Remaining": "0",
+		"RateLimit-Reset":     "90",
+		"Retry-After":         "30",
+	} {
+		if got := w.Header().Get(header); got != want {
+			t.Errorf("%s: expected %q, got %q", header, want, got)
+		}
+	}
+}
+
+func TestRateLimiterMiddleware_BotFriendly(t *testing.T) {
+	rl := newTestRateLimiter(1, 30*time.Second, 1)
+	handler := rl.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	request := func(headers map[string]string, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/quote"+query, nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	nightbot := map[string]string{"Nightbot-Channel": "name=streamer&provider=twitch"}
+	request(nightbot, "")
+	w := request(nightbot, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 for a rate-limited bot, got %d", w.Code)
+	}
+	if body := strings.TrimSpace(w.Body.String()); body != "Slow down! Try again in 30s." {
+		t.Errorf("unexpected body %q", body)
+	}
+	if got := w.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("expected Retry-After 30, got %q", got)
+	}
+
+	// A ?channel= param alone isn't bot traffic
+	request(nil, "?channel=other")
+	if w := request(nil, "?channel=other"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected 429 without bot headers, got %d", w.Code)
+	}
+}
+
+func TestCeilSeconds(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want int
+	}{
+		{0, 0},
+		{time.Millisecond, 1},
+		{time.Second, 1},
+		{1500 * time.Millisecond, 2},
+		{time.Minute, 60},
+	}
+	for _, tt := range tests {
+		if got := ceilSeconds(tt.in); got != tt.want {
+			t.Errorf("ceilSeconds(%v) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package srv
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"sync"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// recentQuotes remembers the last few quote IDs served to each channel so
+// back-to-back random quote commands don't show the same quote twice.
+// Requests without a channel share the "" entry.
+type recentQuotes struct {
+	mu     sync.Mutex
+	window int
+	served map[string][]int64 // oldest first, at most window IDs
+}
+
+func newRecentQuotes(window int) *recentQuotes {
+	return &recentQuotes{window: window, served: make(map[string][]int64)}
+}
+
+// ids returns a copy of the IDs recently served to channel
+func (rq *recentQuotes) ids(channel string) []int64 {
+	if rq == nil || rq.window <= 0 {
+		return nil
+	}
+	rq.mu.Lock()
+	defer rq.mu.Unlock()
+	return slices.Clone(rq.served[channel])
+}
+
+// add records id as served to channel, dropping the oldest ID once the
+// window is full.
+func (rq *recentQuotes) add(channel string, id int64) {
+	if rq == nil || rq.window <= 0 {
+		return
+	}
+	rq.mu.Lock()
+	defer rq.mu.Unlock()
+	ids := rq.served[channel]
+	if len(ids) >= rq.window {
+		copy(ids, ids[len(ids)-rq.window+1:])
+		ids = ids[:rq.window-1]
+	}
+	rq.served[channel] = append(ids, id)
+}
+
+// avoidRecentQuote replaces quote with a random one that was not recently
+// served to channel. It only does so when more quotes match the civ, channel
+// and tag filters than the window holds; smaller pools keep the original,
+// truly random pick. Avoiding repeats is best-effort, so a failed lookup
+// also keeps the original pick.
+func (s *Server) avoidRecentQuote(ctx context.Context, q *dbgen.Queries, quote dbgen.Quote, channel, civ, tag string) dbgen.Quote {
+	recent := s.recentQuotes.ids(channel)
+	if !slices.Contains(recent, quote.ID) {
+		return quote
+	}
+
+	params := dbgen.ListRandomQuoteCandidateIDsParams{}
+	if civ != "" {
+		params.Civilization = &civ
+	}
+	if channel != "" {
+		params.Channel = &channel
+	}
+	if tag != "" {
+		params.Tag = &tag
+	}
+	dbCtx, span := StartDBSpan(ctx, "ListRandomQuoteCandidateIDs",
+		attribute.String("civ", civ),
+		attribute.String("channel", channel),
+		attribute.String("tag", tag))
+	ids, err := q.ListRandomQuoteCandidateIDs(dbCtx, params)
+	if err != nil {
+		RecordError(span, err)
+		span.End()
+		slog.Warn("list random quote candidates", "error", err, "channel", channel)
+		return quote
+	}
+	span.SetAttributes(attribute.Int("quote.candidates", len(ids)))
+	span.End()
+
+	if len(ids) <= s.recentQuotes.window {
+		return quote
+	}
+	// At most window IDs are excluded, so at least one candidate remains
+	candidates := slices.DeleteFunc(ids, func(id int64) bool {
+		return slices.Contains(recent, id)
+	})
+	replacement, err := q.GetQuoteByID(ctx, candidates[rand.IntN(len(candidates))])
+	if err != nil {
+		slog.Warn("get replacement random quote", "error", err, "channel", channel)
+		return quote
+	}
+	return replacement
+}
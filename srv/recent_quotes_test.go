@@ -0,0 +1,27 @@
+package srv
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRecentQuotes(t *testing.T) {
+	rq := newRecentQuotes(3)
+	for id := range int64(5) {
+		rq.add("streamer1", id+1)
+	}
+	rq.add("streamer2", 9)
+
+	if got := rq.ids("streamer1"); !slices.Equal(got, []int64{3, 4, 5}) {
+		t.Errorf("expected the last 3 IDs [3 4 5], got %v", got)
+	}
+	if got := rq.ids("streamer2"); !slices.Equal(got, []int64{9}) {
+		t.Errorf("expected channels tracked separately, got %v", got)
+	}
+
+	disabled := newRecentQuotes(0)
+	disabled.add("streamer1", 1)
+	if got := disabled.ids("streamer1"); len(got) != 0 {
+		t.Errorf("expected a zero window to track nothing, got %v", got)
+	}
+}
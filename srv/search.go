@@ -0,0 +1,166 @@
+package srv
+
+import (
+	"context"
+	"encoding/json"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// searchTerms splits a user's search box input into words, dropping the
+// double quotes FTS5 treats as syntax.
+func searchTerms(query string) []string {
+	return strings.Fields(strings.ReplaceAll(query, `"`, " "))
+}
+
+// ftsMatchQuery turns free text into an FTS5 MATCH expression that finds
+// quotes containing every word. Each word is quoted so operators and
+// punctuation in user input are matched literally instead of causing a
+// syntax error. Returns "" when there is nothing to search for.
+func ftsMatchQuery(query string) string {
+	terms := searchTerms(query)
+	for i, t := range terms {
+		terms[i] = `"` + t + `"`
+	}
+	return strings.Join(terms, " ")
+}
+
+// ftsPrefixQuery is like ftsMatchQuery but matches each word as a prefix,
+// so "knight" also finds "Knights". Words without a letter or digit are
+// dropped because the FTS5 tokenizer ignores them; "" means the query has
+// nothing the index can match.
+func ftsPrefixQuery(query string) string {
+	var terms []string
+	for _, t := range searchTerms(query) {
+		if strings.IndexFunc(t, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+			continue
+		}
+		terms = append(terms, `"`+t+`"*`)
+	}
+	return strings.Join(terms, " ")
+}
+
+// likeEscaper escapes the LIKE wildcards, using backslash as the ESCAPE
+// character the substring search queries declare.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// likeEscape makes user input match literally inside a LIKE pattern, so
+// "100%" doesn't match everything starting with "100".
+func likeEscape(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// highlightTerms HTML-escapes text and wraps case-insensitive occurrences of
+// each search word in <mark>.
+func highlightTerms(text, query string) template.HTML {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return template.HTML(template.HTMLEscapeString(text))
+	}
+	for i, t := range terms {
+		terms[i] = regexp.QuoteMeta(t)
+	}
+	re := regexp.MustCompile(`(?i)` + strings.Join(terms, "|"))
+
+	var b strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringIndex(text, -1) {
+		b.WriteString(template.HTMLEscapeString(text[last:m[0]]))
+		b.WriteString("<mark>")
+		b.WriteString(template.HTMLEscapeString(text[m[0]:m[1]]))
+		b.WriteString("</mark>")
+		last = m[1]
+	}
+	b.WriteString(template.HTMLEscapeString(text[last:]))
+	return template.HTML(b.String())
+}
+
+// searchQuotes runs an API search through the full-text index, best match
+// first. It falls back to the LIKE substring scan when the term has no
+// indexable words, or when the index can't be queried, e.g. because the
+// SQLite build lacks FTS5.
+func (s *Server) searchQuotes(ctx context.Context, q *dbgen.Queries, term string, channel, civ *string) ([]dbgen.Quote, error) {
+	if match := ftsPrefixQuery(term); match != "" {
+		dbCtx, span := StartDBSpan(ctx, "SearchQuotesFTS", attribute.String("term", term))
+		quotes, err := q.SearchQuotesFTS(dbCtx, dbgen.SearchQuotesFTSParams{
+			Query:   match,
+			Channel: channel,
+			Civ:     civ,
+			Limit:   maxSearchResults,
+		})
+		if err == nil {
+			span.End()
+			return quotes, nil
+		}
+		RecordError(span, err)
+		span.End()
+		slog.Warn("full-text search failed, falling back to LIKE", "error", err, "term", term)
+	}
+
+	dbCtx, span := StartDBSpan(ctx, "SearchQuotes", attribute.String("term", term))
+	defer span.End()
+	quotes, err := q.SearchQuotes(dbCtx, dbgen.SearchQuotesParams{
+		Term:    likeEscape(term),
+		Channel: channel,
+		Civ:     civ,
+		Limit:   maxSearchResults,
+	})
+	if err != nil {
+		RecordError(span, err)
+	}
+	return quotes, err
+}
+
+// HandleRebuildSearchIndex repopulates the full-text search index from the
+// quotes table, for when it has drifted or was restored from a backup
+// without it.
+func (s *Server) HandleRebuildSearchIndex(w http.ResponseWriter, r *http.Request) {
+	userEmail := getAuthEmail(r)
+	ctx := r.Context()
+
+	if userEmail == "" {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if !s.isAdmin(userEmail) {
+		RecordSecurityEvent(ctx, "admin_required",
+			attribute.String("user.email", userEmail),
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Admin access required", http.StatusForbidden)
+		return
+	}
+
+	q := dbgen.New(s.WriteDB)
+	dbCtx, span := StartDBSpan(ctx, "RebuildQuotesFTS")
+	err := q.RebuildQuotesFTS(dbCtx)
+	if err != nil {
+		RecordError(span, err)
+	}
+	span.End()
+	if err != nil {
+		slog.Error("rebuild search index", "error", err)
+		http.Error(w, "Failed to rebuild search index", http.StatusInternalServerError)
+		return
+	}
+
+	count, err := q.CountQuotes(ctx)
+	if err != nil {
+		slog.Error("count quotes", "error", err)
+	}
+
+	slog.Info("rebuilt search index", "quotes", count, "by", userEmail)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int64{"indexed": count})
+}
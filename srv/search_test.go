@@ -0,0 +1,143 @@
+package srv
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+)
+
+func TestFTSMatchQuery(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"   ", ""},
+		{"wall", `"wall"`},
+		{"  wall  early ", `"wall" "early"`},
+		{`"wall" AND (`, `"wall" "AND" "("`},
+		{`""`, ""},
+	}
+	for _, tt := range tests {
+		if got := ftsMatchQuery(tt.in); got != tt.want {
+			t.Errorf("ftsMatchQuery(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFTSPrefixQuery(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"knight", `"knight"*`},
+		{"royal  knight", `"royal"* "knight"*`},
+		{`"wall" OR (`, `"wall"* "OR"*`},
+		{"100%", `"100%"*`},
+		{"% _", ""},
+	}
+	for _, tt := range tests {
+		if got := ftsPrefixQuery(tt.in); got != tt.want {
+			t.Errorf("ftsPrefixQuery(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLikeEscape(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"knight", "knight"},
+		{"100%", `100\%`},
+		{"a_b", `a\_b`},
+		{`back\slash`, `back\\slash`},
+	}
+	for _, tt := range tests {
+		if got := likeEscape(tt.in); got != tt.want {
+			t.Errorf("likeEscape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHighlightTerms(t *testing.T) {
+	tests := []struct {
+		text, query string
+		want        string
+	}{
+		{"Wall early", "", "Wall early"},
+		{"Wall early, wall often", "wall", "<mark>Wall</mark> early, <mark>wall</mark> often"},
+		{"a+b <i>c</i>", "a+b c", "<mark>a+b</mark> &lt;i&gt;<mark>c</mark>&lt;/i&gt;"},
+	}
+	for _, tt := range tests {
+		if got := string(highlightTerms(tt.text, tt.query)); got != tt.want {
+			t.Errorf("highlightTerms(%q, %q) = %q, want %q", tt.text, tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestHandleRebuildSearchIndex(t *testing.T) {
+	rebuild := func(server *Server, email string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/admin/search/rebuild", nil)
+		if email != "" {
+			req.Header.Set("X-ExeDev-UserID", "user-1")
+			req.Header.Set("X-ExeDev-Email", email)
+		}
+		w := httptest.NewRecorder()
+		server.HandleRebuildSearchIndex(w, req)
+		return w
+	}
+
+	t.Run("requires admin", func(t *testing.T) {
+		server := testServer(t)
+		if w := rebuild(server, ""); w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
+		}
+		if w := rebuild(server, "user@test.com"); w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("repopulates the index", func(t *testing.T) {
+		server := testServer(t)
+		addTestQuote(t, server, "Trebuchets outrange everything", nil, nil)
+		if _, err := server.WriteDB.Exec("INSERT INTO quotes_fts (quotes_fts) VALUES ('delete-all')"); err != nil {
+			t.Fatalf("clear index: %v", err)
+		}
+		q := dbgen.New(server.WriteDB)
+		search := func() []dbgen.Quote {
+			t.Helper()
+			quotes, err := q.SearchQuotesFTS(context.Background(), dbgen.SearchQuotesFTSParams{
+				Query: ftsPrefixQuery("trebuchet"),
+				Limit: maxSearchResults,
+			})
+			if err != nil {
+				t.Fatalf("search: %v", err)
+			}
+			return quotes
+		}
+		if len(search()) != 0 {
+			t.Fatal("expected the cleared index to find nothing")
+		}
+
+		w := rebuild(server, "admin@test.com")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp map[string]int64
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if resp["indexed"] != 1 {
+			t.Errorf("expected 1 indexed quote, got %v", resp)
+		}
+		if len(search()) != 1 {
+			t.Error("expected the rebuilt index to find the quote")
+		}
+	})
+}
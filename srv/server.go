@@ -20,6 +20,12 @@ package srv
 // @tag.description Get matchup-specific tips for civ vs civ scenarios
 // @tag.name suggestions
 // @tag.description Submit quote suggestions for review
+// @tag.name civilizations
+// @tag.description Civilization lookups for bot commands
+// @tag.name meta
+// @tag.description Server and build information
+// @tag.name admin
+// @tag.description Admin-only management endpoints
 
 import (
 	"context"
@@ -32,11 +38,13 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"path/filepath"
 	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -48,32 +56,53 @@ import (
 )
 
 type Server struct {
-	DB           *sql.DB
+	// WriteDB is the primary database. ReadDB serves public read endpoints
+	// and is the same handle as WriteDB unless a read replica is configured.
+	WriteDB      *sql.DB
+	ReadDB       *sql.DB
 	Hostname     string
 	TemplatesDir string
 	StaticDir    string
 	APILimiter   *RateLimiter
-	AdminEmails  map[string]bool
-	Markers      *MarkerClient
-	Config       Config
-	Encryptor    *crypto.Encryptor // for managed channel tokens
-	templates    map[string]*template.Template
+	// RandomLimiter and ListLimiter replace APILimiter for their route
+	// groups; see apiHandler
+	RandomLimiter *RateLimiter
+	ListLimiter   *RateLimiter
+	VoteLimiter   *RateLimiter // per-IP quote votes, on top of APILimiter
+	AdminEmails   map[string]bool
+	Markers       *MarkerClient
+	Config        Config
+	Encryptor     *crypto.Encryptor // for managed channel tokens
+	templates     map[string]*template.Template
+	// channelSettings caches per-channel settings read by bot endpoints
+	channelSettings *channelSettingsCache
+	// recentQuotes tracks quotes served by HandleRandomQuote to avoid repeats
+	recentQuotes *recentQuotes
 	httpServer   *http.Server
+	Clock        Clock // time source, replaced in tests
+	// audit persists security events recorded during requests
+	audit *auditLog
+
+	// civsCache maps lowercased civ names and shortnames to the full name
+	civsCache   map[string]string
+	civsCacheMu sync.RWMutex
 }
 
 type pageData struct {
-	Hostname    string
-	Now         string
-	UserEmail   string
-	UserID      string
-	LoginURL    string
-	LogoutURL   string
-	Quotes      []QuoteView
-	Error       string
-	Success     string
-	QuoteCount  int64
-	LastUpdated string
-	Civs        []CivWithCount
+	Hostname     string
+	Now          string
+	UserEmail    string
+	UserID       string
+	LoginURL     string
+	LogoutURL    string
+	Quotes       []QuoteView
+	Error        string
+	Success      string
+	QuoteCount   int64
+	CivCount     int64
+	CountUnknown bool // count query failed; templates show "?"
+	LastUpdated  string
+	Civs         []CivWithCount
 	// Pagination
 	Page       int
 	PageSize   int
@@ -89,6 +118,44 @@ type pageData struct {
 	// Filtering
 	Channels        []string
 	SelectedChannel string
+	SelectedAuthor  string
+	SelectedCiv     string
+	MatchupOnly     bool
+	SearchQuery     string
+	IncludeDeleted  bool
+	// Social sharing previews (OpenGraph and Twitter cards)
+	OGTitle       string
+	OGDescription string
+	OGImage       string
+	navBadges
+}
+
+// navBadges holds the counts shown next to nav links. Page data embeds it so
+// nav.html can read the fields on any page.
+type navBadges struct {
+	PendingSuggestionCount int64
+}
+
+// navBadgesFor looks up the nav counts for a signed-in user. Admins see every
+// pending suggestion, owners those for their channels. Failures only hide
+// the badge.
+func (s *Server) navBadgesFor(ctx context.Context, email string) navBadges {
+	if email == "" {
+		return navBadges{}
+	}
+	q := dbgen.New(s.WriteDB)
+	var count int64
+	var err error
+	if s.isAdmin(email) {
+		count, err = q.CountPendingSuggestions(ctx)
+	} else {
+		count, err = q.CountPendingSuggestionsForOwner(ctx, strings.ToLower(email))
+	}
+	if err != nil {
+		slog.Warn("count pending suggestions for nav", "error", err)
+		return navBadges{}
+	}
+	return navBadges{PendingSuggestionCount: count}
 }
 
 type QuoteView struct {
@@ -101,6 +168,7 @@ type QuoteView struct {
 	CreatedBy    string
 	RequestedBy  string
 	CreatedAt    string
+	Deleted      bool
 }
 
 type CivWithCount struct {
@@ -136,14 +204,23 @@ func NewWithConfig(cfg Config) (*Server, error) {
 	}
 
 	srv := &Server{
-		Hostname:     cfg.Hostname,
-		TemplatesDir: filepath.Join(baseDir, "templates"),
-		StaticDir:    filepath.Join(baseDir, "static"),
-		APILimiter:   NewRateLimiter(cfg.APIRateLimit, cfg.APIRateInterval, cfg.APIRateBurst),
-		AdminEmails:  adminSet,
-		Markers:      NewMarkerClient(),
-		Config:       cfg,
-	}
+		Hostname:      cfg.Hostname,
+		TemplatesDir:  filepath.Join(baseDir, "templates"),
+		StaticDir:     filepath.Join(baseDir, "static"),
+		APILimiter:    NewRateLimiter(cfg.APIRateLimit, cfg.APIRateInterval, cfg.APIRateBurst),
+		RandomLimiter: NewRateLimiter(cfg.RandomRateLimit, cfg.RandomRateInterval, cfg.RandomRateBurst),
+		ListLimiter:   NewRateLimiter(cfg.ListRateLimit, cfg.ListRateInterval, cfg.ListRateBurst),
+		VoteLimiter:   NewRateLimiter(cfg.VoteRateLimit, cfg.VoteRateInterval, cfg.VoteRateLimit),
+		AdminEmails:   adminSet,
+		Config:        cfg,
+
+		recentQuotes: newRecentQuotes(cfg.RandomNoRepeatWindow),
+		Clock:        realClock{},
+	}
+	// Helpers read the time through the Server, so tests can swap its Clock
+	now := func() time.Time { return srv.Clock.Now() }
+	srv.Markers = NewMarkerClient(cfg.HoneycombAPIKey, cfg.ServiceName, now)
+	srv.channelSettings = newChannelSettingsCache(now)
 
 	// Initialize encryptor for managed channel tokens (optional)
 	if cfg.NightbotSessionKey != "" {
@@ -157,9 +234,14 @@ func NewWithConfig(cfg Config) (*Server, error) {
 	if err := srv.setUpDatabase(cfg.DBPath); err != nil {
 		return nil, err
 	}
+	srv.audit = newAuditLog(srv.WriteDB, now)
+	registerMetrics()
 	if err := srv.loadTemplates(); err != nil {
 		return nil, err
 	}
+	if err := srv.loadCivsCache(context.Background()); err != nil {
+		return nil, err
+	}
 
 	// Create deploy marker on startup
 	srv.Markers.CreateDeployMarker()
@@ -181,37 +263,308 @@ func getAuthEmail(r *http.Request) string {
 	return strings.TrimSpace(r.Header.Get("X-ExeDev-Email"))
 }
 
+// setAuthVaryHeaders tells caches that the response depends on who is
+// logged in. The exe.dev proxy sends identity as headers; Cookie covers the
+// Twitch session.
+func setAuthVaryHeaders(w http.ResponseWriter) {
+	w.Header().Add("Vary", "X-ExeDev-Email, X-ExeDev-UserID, Cookie")
+}
+
+// HandleHealth is a plain-text readiness check: 200 when the database
+// answers a ping. /readyz reports the same in more detail.
 func (s *Server) HandleHealth(w http.ResponseWriter, r *http.Request) {
 	// Check database connection
-	if err := s.DB.PingContext(r.Context()); err != nil {
+	if err := s.WriteDB.PingContext(r.Context()); err != nil {
 		w.WriteHeader(http.StatusServiceUnavailable)
 		fmt.Fprintln(w, "unhealthy: database unreachable")
 		return
 	}
+	w.Header().Set("Cache-Control", "public, max-age=60")
 	w.WriteHeader(http.StatusOK)
 	fmt.Fprintln(w, "ok")
 }
 
+// VersionResponse is the JSON response for the version endpoint
+type VersionResponse struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuiltAt   string `json:"built_at"`
+	GoVersion string `json:"go_version"`
+}
+
+// HandleVersion godoc
+// @Summary Get server version
+// @Description Returns build information for the running server. Not rate limited.
+// @Tags meta
+// @Produce json
+// @Success 200 {object} VersionResponse "Build information"
+// @Router /version [get]
+func (s *Server) HandleVersion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	json.NewEncoder(w).Encode(VersionResponse{
+		Version:   Version,
+		Commit:    CommitSHA,
+		BuiltAt:   BuiltAt,
+		GoVersion: runtime.Version(),
+	})
+}
+
+// MigrationView is the JSON/template representation of a migration's status.
+type MigrationView struct {
+	Number    int        `json:"number"`
+	Filename  string     `json:"filename"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+func migrationViews(infos []db.MigrationInfo) (views []MigrationView, pending int) {
+	views = make([]MigrationView, 0, len(infos))
+	for _, m := range infos {
+		if !m.Applied {
+			pending++
+		}
+		views = append(views, MigrationView{
+			Number:    m.Number,
+			Filename:  m.Filename,
+			Applied:   m.Applied,
+			AppliedAt: m.AppliedAt,
+		})
+	}
+	return views, pending
+}
+
+// healthPingTimeout bounds how long HandleHealthDetailed waits on the database.
+const healthPingTimeout = 500 * time.Millisecond
+
+// HandleHealthDetailed reports database health, build info, migration
+// status and WAL checkpoint health as JSON. Admin only. The checkpoint runs
+// here rather than in /readyz so that probes stay read-only and don't
+// compete with writes for the single writer connection.
+func (s *Server) HandleHealthDetailed(w http.ResponseWriter, r *http.Request) {
+	userEmail := getAuthEmail(r)
+	if userEmail == "" || !s.isAdmin(userEmail) {
+		http.Error(w, "Admin access required", http.StatusForbidden)
+		return
+	}
+
+	type migrationSummary struct {
+		Total   int             `json:"total"`
+		Pending int             `json:"pending"`
+		Items   []MigrationView `json:"items,omitempty"`
+	}
+	type walSummary struct {
+		Status             string `json:"status"`
+		LogFrames          int    `json:"log_frames"`
+		CheckpointedFrames int    `json:"checkpointed_frames"`
+	}
+	resp := struct {
+		Status     string            `json:"status"`
+		Database   string            `json:"database"`
+		LatencyMS  int64             `json:"latency_ms"`
+		Version    string            `json:"version"`
+		Commit     string            `json:"commit"`
+		Migrations *migrationSummary `json:"migrations,omitempty"`
+		WAL        *walSummary       `json:"wal,omitempty"`
+	}{
+		Status:   "ok",
+		Database: "ok",
+		Version:  Version,
+		Commit:   CommitSHA,
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), healthPingTimeout)
+	defer cancel()
+	start := time.Now()
+	pingErr := s.WriteDB.PingContext(ctx)
+	resp.LatencyMS = time.Since(start).Milliseconds()
+
+	status := http.StatusOK
+	if pingErr != nil {
+		slog.Warn("health check: database ping failed", "error", pingErr)
+		resp.Status = "unhealthy"
+		resp.Database = "error"
+		status = http.StatusServiceUnavailable
+	} else {
+		if infos, err := db.MigrationStatus(s.WriteDB); err != nil {
+			slog.Warn("health check: migration status", "error", err)
+		} else {
+			views, pending := migrationViews(infos)
+			resp.Migrations = &migrationSummary{Total: len(views), Pending: pending, Items: views}
+		}
+
+		if wal, err := db.WALCheckpoint(ctx, s.WriteDB); err != nil {
+			slog.Warn("health check: wal checkpoint", "error", err)
+			resp.WAL = &walSummary{Status: "error"}
+		} else {
+			resp.WAL = &walSummary{
+				Status:             "ok",
+				LogFrames:          wal.LogFrames,
+				CheckpointedFrames: wal.CheckpointedFrames,
+			}
+			if wal.Busy {
+				resp.WAL.Status = "busy"
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleLivez is the liveness probe: 200 whenever the process can serve
+// requests. It never touches the database, so an orchestrator restarts the
+// server only when it is wedged, and depools it via /readyz when the
+// database is the problem.
+func (s *Server) HandleLivez(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	fmt.Fprintln(w, "ok")
+}
+
+// HandleReadyz is the readiness probe. It answers 200 with a JSON report
+// of migration status when the database responds, and 503 otherwise.
+// Startup already fails on migration errors, so pending migrations are
+// reported without failing the probe. It only reads, so it stays cheap
+// however often it is polled; WAL checkpoint health is on /health/detailed.
+func (s *Server) HandleReadyz(w http.ResponseWriter, r *http.Request) {
+	type migrationSummary struct {
+		Total   int `json:"total"`
+		Pending int `json:"pending"`
+	}
+	resp := struct {
+		Status     string            `json:"status"`
+		Database   string            `json:"database"`
+		Migrations *migrationSummary `json:"migrations,omitempty"`
+	}{
+		Status:   "ok",
+		Database: "ok",
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), healthPingTimeout)
+	defer cancel()
+
+	ready := true
+	if err := s.WriteDB.PingContext(ctx); err != nil {
+		slog.Warn("readiness: database ping failed", "error", err)
+		resp.Database = "error"
+		ready = false
+	} else if infos, err := db.MigrationStatus(s.WriteDB); err != nil {
+		slog.Warn("readiness: migration status", "error", err)
+		ready = false
+	} else {
+		views, pending := migrationViews(infos)
+		resp.Migrations = &migrationSummary{Total: len(views), Pending: pending}
+	}
+
+	status := http.StatusOK
+	if !ready {
+		resp.Status = "unavailable"
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleAdminMigrations lists embedded migrations and whether each has been
+// applied. Renders JSON when requested via Accept, HTML otherwise.
+func (s *Server) HandleAdminMigrations(w http.ResponseWriter, r *http.Request) {
+	userEmail := getAuthEmail(r)
+	if userEmail == "" {
+		http.Redirect(w, r, loginURLForRequest(r), http.StatusSeeOther)
+		return
+	}
+	if !s.isAdmin(userEmail) {
+		http.Error(w, "Admin access required", http.StatusForbidden)
+		return
+	}
+
+	infos, err := db.MigrationStatus(s.WriteDB)
+	if err != nil {
+		slog.Error("migration status", "error", err)
+		http.Error(w, "Failed to read migration status", http.StatusInternalServerError)
+		return
+	}
+	views, pending := migrationViews(infos)
+
+	if WantsJSON(r) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(views)
+		return
+	}
+
+	data := struct {
+		Hostname        string
+		UserEmail       string
+		LogoutURL       string
+		IsAdmin         bool
+		IsAuthenticated bool
+		IsPublicPage    bool
+		Migrations      []MigrationView
+		Total           int
+		Pending         int
+		navBadges
+	}{
+		Hostname:        s.Hostname,
+		UserEmail:       userEmail,
+		LogoutURL:       "/__exe.dev/logout",
+		IsAdmin:         true,
+		IsAuthenticated: true,
+		Migrations:      views,
+		Total:           len(views),
+		Pending:         pending,
+		navBadges:       s.navBadgesFor(r.Context(), userEmail),
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.renderTemplate(w, "admin_migrations.html", data); err != nil {
+		slog.Error("render migrations template", "error", err)
+	}
+}
+
 func (s *Server) HandleRoot(w http.ResponseWriter, r *http.Request) {
+	setAuthVaryHeaders(w)
 	userID, userEmail := getAuthUser(r)
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.ReadDB)
 	count, _ := q.CountQuotes(r.Context())
+	civCount, _ := q.CountCivs(r.Context())
 
 	var lastUpdated string
 	if ts, err := q.GetLastUpdated(r.Context()); err == nil {
-		lastUpdated = formatTimeAgo(ts)
+		lastUpdated = s.formatTimeAgo(ts)
+	}
+
+	// Recent activity is a nice-to-have; skip the section on failure
+	recent, err := q.ListQuotesPaginated(r.Context(), dbgen.ListQuotesPaginatedParams{
+		Limit:  5,
+		Offset: 0,
+	})
+	if err != nil {
+		slog.Warn("list recent quotes", "error", err)
 	}
 
 	data := pageData{
 		Hostname:    s.Hostname,
-		Now:         time.Now().Format(time.RFC3339),
+		Now:         s.Clock.Now().Format(time.RFC3339),
 		UserEmail:   userEmail,
 		UserID:      userID,
 		LoginURL:    loginURLForRequest(r),
 		LogoutURL:   "/__exe.dev/logout",
+		Quotes:      quotesToViews(recent, userEmail, s.Clock.Now()),
 		QuoteCount:  count,
+		CivCount:    civCount,
 		LastUpdated: lastUpdated,
+
+		OGTitle:       "AoE4 Quote Database",
+		OGDescription: "AoE4 quotes and matchup tips for your stream chat",
+		OGImage:       "https://" + s.Hostname + "/static/favicon.svg",
+	}
+	if count > 0 {
+		data.OGDescription = fmt.Sprintf("Over %d AoE4 quotes and matchup tips", count)
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -235,7 +588,7 @@ func maskEmail(email string) string {
 	return local[:1] + "***" + local[len(local)-1:] + "@" + domain
 }
 
-func quotesToViews(quotes []dbgen.Quote, currentUserEmail string) []QuoteView {
+func quotesToViews(quotes []dbgen.Quote, currentUserEmail string, now time.Time) []QuoteView {
 	views := make([]QuoteView, len(quotes))
 	for i, q := range quotes {
 		var createdBy string
@@ -257,7 +610,8 @@ func quotesToViews(quotes []dbgen.Quote, currentUserEmail string) []QuoteView {
 			ID:        q.ID,
 			Text:      q.Text,
 			CreatedBy: createdBy,
-			CreatedAt: formatTimeAgo(q.CreatedAt),
+			CreatedAt: formatTimeAgoFrom(q.CreatedAt, now),
+			Deleted:   q.DeletedAt != nil,
 		}
 		if q.Author != nil {
 			views[i].Author = *q.Author
@@ -278,14 +632,28 @@ func quotesToViews(quotes []dbgen.Quote, currentUserEmail string) []QuoteView {
 	return views
 }
 
+// QuoteListResponse is the JSON form of the quote management page
+type QuoteListResponse struct {
+	Quotes        []QuoteResponse `json:"quotes"`
+	IsAdmin       bool            `json:"is_admin"`
+	OwnedChannels []string        `json:"owned_channels"`
+}
+
+// HandleQuotes serves the quote management page, or the same quote list as
+// JSON for scripts that send Accept: application/json.
 func (s *Server) HandleQuotes(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	auth := s.getAuthInfo(r)
+	w.Header().Add("Vary", "Accept")
 
 	if !auth.IsAuthenticated {
 		RecordSecurityEvent(ctx, "auth_required",
 			attribute.String("path", r.URL.Path),
 		)
+		if WantsJSON(r) {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
 		// Redirect to Twitch login for quote management
 		http.Redirect(w, r, "/auth/twitch?redirect="+url.QueryEscape(r.URL.String()), http.StatusSeeOther)
 		return
@@ -309,23 +677,120 @@ func (s *Server) HandleQuotes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	// Optional channel selector; non-admins may only pick channels they manage
+	selectedChannel := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("channel")))
+	if err := ValidateChannel(selectedChannel); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if selectedChannel == "" && !auth.IsAdmin && len(manageableChannels) == 1 {
+		selectedChannel = manageableChannels[0]
+	}
+	if selectedChannel != "" && !auth.IsAdmin && !containsFold(manageableChannels, selectedChannel) {
+		RecordSecurityEvent(ctx, "permission_denied",
+			attribute.String("user.identity", auth.DisplayIdentity()),
+			attribute.String("path", r.URL.Path),
+			attribute.String("channel", selectedChannel),
+			attribute.String("reason", "not_authorized"),
+		)
+		http.Error(w, "You don't have permission to manage quotes for this channel", http.StatusForbidden)
+		return
+	}
+
+	includeDeleted := r.URL.Query().Get("include_deleted") == "1" || r.URL.Query().Get("include_deleted") == "true"
+	if includeDeleted && !auth.IsAdmin {
+		RecordSecurityEvent(ctx, "admin_required",
+			attribute.String("user.identity", auth.DisplayIdentity()),
+			attribute.String("path", r.URL.Path),
+			attribute.String("reason", "include_deleted"),
+		)
+		http.Error(w, "Admin access required", http.StatusForbidden)
+		return
+	}
+
+	q := dbgen.New(s.WriteDB)
 	var quotes []dbgen.Quote
 	var err error
 
-	if auth.IsAdmin {
+	switch {
+	case selectedChannel != "":
+		quotes, err = q.ListQuotesByChannelOnly(ctx, &selectedChannel)
+	case auth.IsAdmin:
 		// Admins see all quotes
 		quotes, err = q.ListAllQuotes(ctx)
-	} else {
-		// Channel owners/moderators see only their channel's quotes
-		// For now, just use the first manageable channel (most users will have one)
-		// TODO: add channel selector if user manages multiple channels
-		quotes, err = q.ListQuotesByChannelOnly(ctx, &manageableChannels[0])
+	default:
+		// Channel owners/moderators see quotes from all their channels combined
+		channelPtrs := make([]*string, len(manageableChannels))
+		for i := range manageableChannels {
+			channelPtrs[i] = &manageableChannels[i]
+		}
+		quotes, err = q.ListQuotesByChannels(ctx, channelPtrs)
 	}
 	if err != nil {
 		slog.Error("list quotes", "error", err)
 	}
 
+	// Trashed quotes follow the live ones, most recently deleted first
+	if err == nil && includeDeleted {
+		var deleted []dbgen.Quote
+		deleted, err = q.ListDeletedQuotes(ctx)
+		if err != nil {
+			slog.Error("list deleted quotes", "error", err)
+		}
+		for _, quote := range deleted {
+			if selectedChannel == "" || (quote.Channel != nil && strings.EqualFold(*quote.Channel, selectedChannel)) {
+				quotes = append(quotes, quote)
+			}
+		}
+	}
+
+	if WantsJSON(r) {
+		if err != nil {
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		resp := QuoteListResponse{
+			Quotes:        make([]QuoteResponse, len(quotes)),
+			IsAdmin:       auth.IsAdmin,
+			OwnedChannels: manageableChannels,
+		}
+		if resp.OwnedChannels == nil {
+			resp.OwnedChannels = []string{}
+		}
+		for i, quote := range quotes {
+			resp.Quotes[i] = QuoteResponse{
+				ID:           quote.ID,
+				Text:         quote.Text,
+				Author:       quote.Author,
+				Civilization: quote.Civilization,
+				OpponentCiv:  quote.OpponentCiv,
+				CreatedAt:    quote.CreatedAt.Format(time.RFC3339),
+				Upvotes:      quote.Upvotes,
+				Downvotes:    quote.Downvotes,
+				Deleted:      quote.DeletedAt != nil,
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	// Channels offered in the selector: every channel with quotes for
+	// admins, otherwise the channels the user manages
+	selectorChannels := manageableChannels
+	if auth.IsAdmin {
+		channelPtrs, err := q.ListChannels(ctx)
+		if err != nil {
+			slog.Warn("list channels", "error", err)
+		}
+		selectorChannels = nil
+		for _, ch := range channelPtrs {
+			if ch != nil {
+				selectorChannels = append(selectorChannels, *ch)
+			}
+		}
+	}
+
 	// Determine logout URL based on auth method
 	logoutURL := "/__exe.dev/logout"
 	if auth.AuthMethod == "twitch" {
@@ -334,17 +799,21 @@ func (s *Server) HandleQuotes(w http.ResponseWriter, r *http.Request) {
 
 	data := pageData{
 		Hostname:        s.Hostname,
-		Now:             time.Now().Format(time.RFC3339),
+		Now:             s.Clock.Now().Format(time.RFC3339),
 		UserEmail:       auth.DisplayIdentity(),
 		UserID:          auth.UserID,
 		LoginURL:        loginURLForRequest(r),
 		LogoutURL:       logoutURL,
-		Quotes:          quotesToViews(quotes, auth.Email),
+		Quotes:          quotesToViews(quotes, auth.Email, s.Clock.Now()),
 		Success:         r.URL.Query().Get("success"),
 		IsAdmin:         auth.IsAdmin,
 		IsOwner:         isOwner,
 		IsAuthenticated: true,
 		OwnedChannels:   manageableChannels,
+		Channels:        selectorChannels,
+		SelectedChannel: selectedChannel,
+		IncludeDeleted:  includeDeleted,
+		navBadges:       s.navBadgesFor(r.Context(), auth.Email),
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -390,7 +859,7 @@ func (s *Server) HandleAddQuote(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate inputs
-	if err := ValidateQuoteText(text); err != nil {
+	if err := ValidateQuoteTextMin(text, s.Config.MinQuoteTextLen); err != nil {
 		http.Redirect(w, r, "/quotes?error="+url.QueryEscape(err.Error()), http.StatusSeeOther)
 		return
 	}
@@ -398,8 +867,12 @@ func (s *Server) HandleAddQuote(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/quotes?error="+url.QueryEscape(err.Error()), http.StatusSeeOther)
 		return
 	}
+	tags, err := ParseTags(r.FormValue("tags"))
+	if err != nil {
+		http.Redirect(w, r, "/quotes?error="+url.QueryEscape(err.Error()), http.StatusSeeOther)
+		return
+	}
 
-	q := dbgen.New(s.DB)
 	var authorPtr, civPtr, opponentPtr, channelPtr *string
 	if author != "" {
 		authorPtr = &author
@@ -414,13 +887,21 @@ func (s *Server) HandleAddQuote(w http.ResponseWriter, r *http.Request) {
 		channelPtr = &channel
 	}
 
+	// Refuse likely duplicates unless the user confirmed with force=1
+	if r.FormValue("force") != "1" {
+		if dupID := s.findDuplicateQuote(ctx, text, channelPtr); dupID != 0 {
+			http.Redirect(w, r, "/quotes?error="+url.QueryEscape(duplicateQuoteMessage(dupID)), http.StatusSeeOther)
+			return
+		}
+	}
+
 	var emailPtr *string
 	creatorIdentity := auth.DisplayIdentity()
 	if creatorIdentity != "" {
 		emailPtr = &creatorIdentity
 	}
 
-	err := q.CreateQuote(r.Context(), dbgen.CreateQuoteParams{
+	err = s.createTaggedQuote(ctx, dbgen.CreateQuoteParams{
 		UserID:         auth.UserID,
 		CreatedByEmail: emailPtr,
 		Text:           text,
@@ -429,8 +910,8 @@ func (s *Server) HandleAddQuote(w http.ResponseWriter, r *http.Request) {
 		OpponentCiv:    opponentPtr,
 		Channel:        channelPtr,
 		RequestedBy:    nil, // No requester for directly added quotes
-		CreatedAt:      time.Now(),
-	})
+		CreatedAt:      s.Clock.Now(),
+	}, tags)
 	if err != nil {
 		slog.Error("create quote", "error", err)
 		http.Redirect(w, r, "/quotes?error=Failed+to+save+quote", http.StatusSeeOther)
@@ -440,6 +921,30 @@ func (s *Server) HandleAddQuote(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/quotes?success=Quote+added!", http.StatusSeeOther)
 }
 
+// findDuplicateQuote returns the ID of the oldest quote in channel whose text
+// matches, ignoring case and surrounding whitespace, or 0 if there is none.
+// Lookup errors are logged and treated as no duplicate, so they never block
+// adding a quote.
+func (s *Server) findDuplicateQuote(ctx context.Context, text string, channel *string) int64 {
+	ids, err := dbgen.New(s.WriteDB).FindSimilarQuotes(ctx, dbgen.FindSimilarQuotesParams{
+		Text:    text,
+		Channel: channel,
+	})
+	if err != nil {
+		slog.Error("find similar quotes", "error", err)
+		return 0
+	}
+	if len(ids) == 0 {
+		return 0
+	}
+	return ids[0]
+}
+
+// duplicateQuoteMessage is the error shown when a new quote matches quote id
+func duplicateQuoteMessage(id int64) string {
+	return fmt.Sprintf("A nearly identical quote already exists (#%d)", id)
+}
+
 func (s *Server) HandleCivs(w http.ResponseWriter, r *http.Request) {
 	userID, userEmail := getAuthUser(r)
 	ctx := r.Context()
@@ -452,7 +957,7 @@ func (s *Server) HandleCivs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	civs, err := q.ListCivsWithQuoteCount(r.Context())
 	if err != nil {
 		slog.Error("list civs", "error", err)
@@ -482,7 +987,7 @@ func (s *Server) HandleCivs(w http.ResponseWriter, r *http.Request) {
 
 	data := pageData{
 		Hostname:        s.Hostname,
-		Now:             time.Now().Format(time.RFC3339),
+		Now:             s.Clock.Now().Format(time.RFC3339),
 		UserEmail:       userEmail,
 		UserID:          userID,
 		LoginURL:        loginURLForRequest(r),
@@ -492,6 +997,7 @@ func (s *Server) HandleCivs(w http.ResponseWriter, r *http.Request) {
 		Error:           r.URL.Query().Get("error"),
 		IsAdmin:         s.isAdmin(userEmail),
 		IsAuthenticated: true,
+		navBadges:       s.navBadgesFor(r.Context(), userEmail),
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -536,7 +1042,7 @@ func (s *Server) HandleAddCiv(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	var shortnamePtr, variantPtr, dlcPtr *string
 	if shortname != "" {
 		shortnamePtr = &shortname
@@ -560,6 +1066,7 @@ func (s *Server) HandleAddCiv(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.reloadCivsCache()
 	http.Redirect(w, r, "/civs?success=Civilization+added!", http.StatusSeeOther)
 }
 
@@ -606,7 +1113,7 @@ func (s *Server) HandleEditCiv(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	var shortnamePtr, variantPtr, dlcPtr *string
 	if shortname != "" {
 		shortnamePtr = &shortname
@@ -631,11 +1138,12 @@ func (s *Server) HandleEditCiv(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.reloadCivsCache()
 	http.Redirect(w, r, "/civs?success=Civilization+updated!", http.StatusSeeOther)
 }
 
 func (s *Server) HandleDeleteCiv(w http.ResponseWriter, r *http.Request) {
-	userID, _ := getAuthUser(r)
+	userID, userEmail := getAuthUser(r)
 	ctx := r.Context()
 
 	if userID == "" {
@@ -653,7 +1161,21 @@ func (s *Server) HandleDeleteCiv(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	// Force-delete clears every quote's reference to the civ, so it is admin only
+	force := r.URL.Query().Get("force") == "true"
+	if force && !s.isAdmin(userEmail) {
+		RecordSecurityEvent(ctx, "permission_denied",
+			attribute.String("user.email", userEmail),
+			attribute.String("path", r.URL.Path),
+			attribute.String("resource", "civilization"),
+			attribute.Int64("civ.id", id),
+			attribute.String("reason", "force_delete_requires_admin"),
+		)
+		http.Error(w, "Only admins can force-delete a civilization", http.StatusForbidden)
+		return
+	}
+
+	q := dbgen.New(s.WriteDB)
 
 	// Check if civ has quotes before deleting
 	civ, err := q.GetCivByID(r.Context(), id)
@@ -667,6 +1189,17 @@ func (s *Server) HandleDeleteCiv(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if force {
+		if err := s.forceDeleteCiv(ctx, civ); err != nil {
+			slog.Error("force delete civ", "civ", civ.Name, "error", err)
+			http.Redirect(w, r, "/civs?error=Failed+to+delete+civilization", http.StatusSeeOther)
+			return
+		}
+		s.reloadCivsCache()
+		http.Redirect(w, r, "/civs?success=Civilization+deleted", http.StatusSeeOther)
+		return
+	}
+
 	count, _ := q.CountQuotesByCiv(r.Context(), &civ.Name)
 	if count > 0 {
 		http.Redirect(w, r, fmt.Sprintf("/civs?error=Cannot+delete:+%d+quotes+reference+this+civilization", count), http.StatusSeeOther)
@@ -680,9 +1213,32 @@ func (s *Server) HandleDeleteCiv(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.reloadCivsCache()
 	http.Redirect(w, r, "/civs?success=Civilization+deleted", http.StatusSeeOther)
 }
 
+// forceDeleteCiv deletes civ after clearing it from every quote's
+// civilization and opponent_civ, in one transaction. The quotes are kept.
+func (s *Server) forceDeleteCiv(ctx context.Context, civ dbgen.Civilization) error {
+	tx, err := s.WriteDB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	q := dbgen.New(tx)
+	if err := q.ClearQuoteCivilization(ctx, &civ.Name); err != nil {
+		return fmt.Errorf("clear quote civilization: %w", err)
+	}
+	if err := q.ClearQuoteOpponentCiv(ctx, &civ.Name); err != nil {
+		return fmt.Errorf("clear quote opponent civ: %w", err)
+	}
+	if err := q.DeleteCiv(ctx, civ.ID); err != nil {
+		return fmt.Errorf("delete civ: %w", err)
+	}
+	return tx.Commit()
+}
+
 func (s *Server) HandleEditQuote(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	auth := s.getAuthInfo(r)
@@ -702,7 +1258,7 @@ func (s *Server) HandleEditQuote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 
 	// Get the quote to check permission
 	quote, err := q.GetQuoteByID(ctx, id)
@@ -745,8 +1301,22 @@ func (s *Server) HandleEditQuote(w http.ResponseWriter, r *http.Request) {
 	opponentCiv := strings.TrimSpace(r.FormValue("opponent_civ"))
 	channel := strings.TrimSpace(r.FormValue("channel"))
 
+	// Moving a quote needs permission on the destination channel too
+	if !strings.EqualFold(channel, existingChannel) && !s.canManageChannelWithTwitch(ctx, auth.Email, auth.TwitchUsername, channel) {
+		RecordSecurityEvent(ctx, "permission_denied",
+			attribute.String("user.identity", auth.DisplayIdentity()),
+			attribute.String("path", r.URL.Path),
+			attribute.String("resource", "quote"),
+			attribute.Int64("quote.id", id),
+			attribute.String("channel", channel),
+			attribute.String("reason", "not_authorized"),
+		)
+		http.Error(w, "You don't have permission to move quotes to this channel", http.StatusForbidden)
+		return
+	}
+
 	// Validate inputs
-	if err := ValidateQuoteText(text); err != nil {
+	if err := ValidateQuoteTextMin(text, s.Config.MinQuoteTextLen); err != nil {
 		http.Redirect(w, r, "/quotes?error="+url.QueryEscape(err.Error()), http.StatusSeeOther)
 		return
 	}
@@ -787,6 +1357,75 @@ func (s *Server) HandleEditQuote(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) HandleDeleteQuote(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("purge") == "1" {
+		if _, ok := s.purgeQuote(w, r); ok {
+			http.Redirect(w, r, "/quotes/trash?success=Quote+permanently+deleted", http.StatusSeeOther)
+		}
+		return
+	}
+
+	id, ok := s.authorizeQuoteDelete(w, r)
+	if !ok {
+		return
+	}
+
+	if err := dbgen.New(s.WriteDB).DeleteQuoteByID(r.Context(), id); err != nil {
+		slog.Error("delete quote", "error", err)
+	}
+
+	http.Redirect(w, r, "/quotes?success=Quote+moved+to+trash", http.StatusSeeOther)
+}
+
+// DeleteQuoteResponse is returned by the quote delete API
+type DeleteQuoteResponse struct {
+	Deleted bool  `json:"deleted"`
+	Purged  bool  `json:"purged"`
+	ID      int64 `json:"id"`
+}
+
+// HandleDeleteQuoteAPI godoc
+// @Summary Delete a quote
+// @Description Moves a quote to the trash, where an admin can restore it. Requires authentication as an admin or as an owner/moderator of the quote's channel.
+// @Description With purge=1, admins delete the quote permanently, whether or not it is in the trash.
+// @Tags quotes
+// @Produce json
+// @Param id path int true "Quote ID"
+// @Param purge query string false "Set to 1 to delete permanently (admins only)"
+// @Success 200 {object} DeleteQuoteResponse "Quote deleted"
+// @Failure 400 {string} string "Invalid quote ID"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Not allowed to delete this quote"
+// @Failure 404 {string} string "Quote not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /quotes/{id} [delete]
+func (s *Server) HandleDeleteQuoteAPI(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("purge") == "1" {
+		if id, ok := s.purgeQuote(w, r); ok {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(DeleteQuoteResponse{Deleted: true, Purged: true, ID: id})
+		}
+		return
+	}
+
+	id, ok := s.authorizeQuoteDelete(w, r)
+	if !ok {
+		return
+	}
+
+	if err := dbgen.New(s.WriteDB).DeleteQuoteByID(r.Context(), id); err != nil {
+		slog.Error("delete quote", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(DeleteQuoteResponse{Deleted: true, ID: id})
+}
+
+// authorizeQuoteDelete resolves the request's {id} path value and checks the
+// caller may manage the quote's channel. On failure it writes the error
+// response and returns false.
+func (s *Server) authorizeQuoteDelete(w http.ResponseWriter, r *http.Request) (int64, bool) {
 	ctx := r.Context()
 	auth := s.getAuthInfo(r)
 
@@ -795,28 +1434,28 @@ func (s *Server) HandleDeleteQuote(w http.ResponseWriter, r *http.Request) {
 			attribute.String("path", r.URL.Path),
 		)
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
+		return 0, false
 	}
 
 	idStr := r.PathValue("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
 		http.Error(w, "Invalid ID", http.StatusBadRequest)
-		return
+		return 0, false
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 
 	// Get the quote to check permission
 	quote, err := q.GetQuoteByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			http.Error(w, "Quote not found", http.StatusNotFound)
-			return
+			return 0, false
 		}
 		slog.Error("get quote", "error", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
+		return 0, false
 	}
 
 	// Check permission: must be admin, owner, or moderator for this channel
@@ -834,28 +1473,29 @@ func (s *Server) HandleDeleteQuote(w http.ResponseWriter, r *http.Request) {
 			attribute.String("reason", "not_authorized"),
 		)
 		http.Error(w, "You don't have permission to delete this quote", http.StatusForbidden)
-		return
+		return 0, false
 	}
 
-	err = q.DeleteQuoteByID(ctx, id)
-	if err != nil {
-		slog.Error("delete quote", "error", err)
-	}
-
-	http.Redirect(w, r, "/quotes?success=Quote+deleted", http.StatusSeeOther)
+	return id, true
 }
 
 type BulkRequest struct {
 	IDs    []int64 `json:"ids"`
 	Action string  `json:"action"`
 	Value  string  `json:"value"`
+	// Channel, when set, scopes the request: the caller must manage it and
+	// every selected quote must belong to it.
+	Channel string `json:"channel,omitempty"`
 }
 
+// HandleBulkQuotes applies a bulk action to the selected quotes. The writes
+// run in a single transaction so a failure part way through leaves every
+// quote untouched.
 func (s *Server) HandleBulkQuotes(w http.ResponseWriter, r *http.Request) {
-	userID, _ := getAuthUser(r)
 	ctx := r.Context()
+	auth := s.getAuthInfo(r)
 
-	if userID == "" {
+	if !auth.IsAuthenticated {
 		RecordSecurityEvent(ctx, "auth_required",
 			attribute.String("path", r.URL.Path),
 		)
@@ -874,8 +1514,89 @@ func (s *Server) HandleBulkQuotes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	q := dbgen.New(s.DB)
-	var err error
+	switch req.Action {
+	case "channel", "civilization", "clear-channel", "set-author", "clear-author", "delete":
+	default:
+		http.Error(w, "Unknown action", http.StatusBadRequest)
+		return
+	}
+
+	if req.Action == "set-author" {
+		req.Value = strings.TrimSpace(req.Value)
+		if req.Value == "" {
+			http.Error(w, "Author is required", http.StatusBadRequest)
+			return
+		}
+		if err := ValidateAuthor(req.Value); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Check permission on every channel the selected quotes belong to.
+	// This happens before the transaction starts: the permission lookups
+	// use their own connections, and the pool may only hold one.
+	channels, err := dbgen.New(s.WriteDB).ListQuoteChannelsByIDs(ctx, req.IDs)
+	if err != nil {
+		slog.Error("bulk action list channels", "error", err)
+		http.Error(w, "Failed to apply action", http.StatusInternalServerError)
+		return
+	}
+	req.Channel = strings.TrimSpace(req.Channel)
+	for _, ch := range channels {
+		channel := ""
+		if ch != nil {
+			channel = *ch
+		}
+		reason := ""
+		if req.Channel != "" && !strings.EqualFold(channel, req.Channel) {
+			reason = "channel_mismatch"
+		} else if !s.canManageChannelWithTwitch(ctx, auth.Email, auth.TwitchUsername, channel) {
+			reason = "not_authorized"
+		}
+		if reason != "" {
+			RecordSecurityEvent(ctx, "permission_denied",
+				attribute.String("user.identity", auth.DisplayIdentity()),
+				attribute.String("path", r.URL.Path),
+				attribute.String("resource", "quote"),
+				attribute.String("channel", channel),
+				attribute.String("reason", reason),
+			)
+			http.Error(w, "You don't have permission to modify these quotes", http.StatusForbidden)
+			return
+		}
+	}
+
+	// Moving quotes needs permission on the destination channel too;
+	// clearing the channel makes them global, which is admin-only.
+	if req.Action == "channel" || req.Action == "clear-channel" {
+		target := ""
+		if req.Action == "channel" {
+			req.Value = strings.TrimSpace(req.Value)
+			target = req.Value
+		}
+		if !s.canManageChannelWithTwitch(ctx, auth.Email, auth.TwitchUsername, target) {
+			RecordSecurityEvent(ctx, "permission_denied",
+				attribute.String("user.identity", auth.DisplayIdentity()),
+				attribute.String("path", r.URL.Path),
+				attribute.String("resource", "quote"),
+				attribute.String("channel", target),
+				attribute.String("reason", "not_authorized"),
+			)
+			http.Error(w, "You don't have permission to move quotes to this channel", http.StatusForbidden)
+			return
+		}
+	}
+
+	tx, err := s.WriteDB.BeginTx(ctx, nil)
+	if err != nil {
+		slog.Error("bulk action begin tx", "error", err)
+		http.Error(w, "Failed to apply action", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	q := dbgen.New(tx)
 
 	switch req.Action {
 	case "channel":
@@ -883,7 +1604,7 @@ func (s *Server) HandleBulkQuotes(w http.ResponseWriter, r *http.Request) {
 		if req.Value != "" {
 			channelPtr = &req.Value
 		}
-		err = q.BulkUpdateChannel(r.Context(), dbgen.BulkUpdateChannelParams{
+		err = q.BulkUpdateChannel(ctx, dbgen.BulkUpdateChannelParams{
 			Channel: channelPtr,
 			Ids:     req.IDs,
 		})
@@ -892,28 +1613,44 @@ func (s *Server) HandleBulkQuotes(w http.ResponseWriter, r *http.Request) {
 		if req.Value != "" {
 			civPtr = &req.Value
 		}
-		err = q.BulkUpdateCivilization(r.Context(), dbgen.BulkUpdateCivilizationParams{
+		err = q.BulkUpdateCivilization(ctx, dbgen.BulkUpdateCivilizationParams{
 			Civilization: civPtr,
 			Ids:          req.IDs,
 		})
 	case "clear-channel":
-		err = q.BulkUpdateChannel(r.Context(), dbgen.BulkUpdateChannelParams{
+		err = q.BulkUpdateChannel(ctx, dbgen.BulkUpdateChannelParams{
 			Channel: nil,
 			Ids:     req.IDs,
 		})
+	case "set-author":
+		err = q.BulkUpdateAuthor(ctx, dbgen.BulkUpdateAuthorParams{
+			Author: &req.Value,
+			Ids:    req.IDs,
+		})
+	case "clear-author":
+		err = q.BulkUpdateAuthor(ctx, dbgen.BulkUpdateAuthorParams{
+			Author: nil,
+			Ids:    req.IDs,
+		})
 	case "delete":
-		err = q.BulkDeleteQuotes(r.Context(), req.IDs)
-	default:
-		http.Error(w, "Unknown action", http.StatusBadRequest)
-		return
+		err = q.BulkDeleteQuotes(ctx, req.IDs)
 	}
 
 	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			slog.Error("bulk action rollback", "error", rbErr)
+		}
 		slog.Error("bulk action failed", "action", req.Action, "error", err)
 		http.Error(w, "Failed to apply action", http.StatusInternalServerError)
 		return
 	}
 
+	if err := tx.Commit(); err != nil {
+		slog.Error("bulk action commit", "action", req.Action, "error", err)
+		http.Error(w, "Failed to apply action", http.StatusInternalServerError)
+		return
+	}
+
 	// Create marker for bulk operation
 	var opDesc string
 	switch req.Action {
@@ -923,12 +1660,16 @@ func (s *Server) HandleBulkQuotes(w http.ResponseWriter, r *http.Request) {
 		opDesc = fmt.Sprintf("Bulk set civilization to '%s'", req.Value)
 	case "clear-channel":
 		opDesc = "Bulk clear channel"
+	case "set-author":
+		opDesc = fmt.Sprintf("Bulk set author to '%s'", req.Value)
+	case "clear-author":
+		opDesc = "Bulk clear author"
 	case "delete":
 		opDesc = "Bulk delete"
 	}
 	s.Markers.CreateBulkOperationMarker(opDesc, len(req.IDs))
 
-	slog.Info("bulk action completed", "action", req.Action, "count", len(req.IDs), "user", userID)
+	slog.Info("bulk action completed", "action", req.Action, "count", len(req.IDs), "user", auth.DisplayIdentity())
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -939,12 +1680,50 @@ type QuoteResponse struct {
 	Civilization *string `json:"civilization,omitempty"`
 	OpponentCiv  *string `json:"opponent_civ,omitempty"`
 	CreatedAt    string  `json:"created_at"`
+	// Adjacent quote IDs, only set when fetching a single quote by ID.
+	// Omitted at either end of the sequence.
+	NextID *int64 `json:"next_id,omitempty"`
+	PrevID *int64 `json:"prev_id,omitempty"`
+	// Reversed marks a matchup tip stored for the opposite pairing, so it is
+	// written from the opponent's point of view.
+	Reversed  bool  `json:"reversed,omitempty"`
+	Upvotes   int64 `json:"upvotes"`
+	Downvotes int64 `json:"downvotes"`
+	// Tags are only set on single-quote responses (/api/quote and
+	// /api/quotes/{id})
+	Tags []string `json:"tags,omitempty"`
+	// Deleted marks a quote in the trash, only listed for admins with
+	// ?include_deleted=true
+	Deleted bool `json:"deleted,omitempty"`
+	// Channel is only sent as the X-Quote-Channel header on plain-text
+	// responses; the JSON body is unchanged.
+	Channel *string `json:"-"`
 }
 
 const defaultPageSize = 20
 
+// buildPaginationLinks returns the URLs of the pages after and before page,
+// keeping every query parameter of r except page. Either is "" when there is
+// no such page.
+func buildPaginationLinks(r *http.Request, page, totalPages int) (next, prev string) {
+	pageURL := func(n int) string {
+		query := r.URL.Query()
+		query.Set("page", strconv.Itoa(n))
+		u := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+		return u.String()
+	}
+	if page < totalPages {
+		next = pageURL(page + 1)
+	}
+	if page > 1 {
+		prev = pageURL(page - 1)
+	}
+	return next, prev
+}
+
 func (s *Server) HandleQuotesPublic(w http.ResponseWriter, r *http.Request) {
-	q := dbgen.New(s.DB)
+	setAuthVaryHeaders(w)
+	q := dbgen.New(s.ReadDB)
 	ctx := r.Context()
 
 	// Parse pagination params
@@ -955,11 +1734,40 @@ func (s *Server) HandleQuotesPublic(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Parse channel filter
+	// Parse channel and author filters
 	selectedChannel := strings.TrimSpace(r.URL.Query().Get("channel"))
+	selectedAuthor := strings.TrimSpace(r.URL.Query().Get("author"))
+	// The author filter is a substring match, so wildcards in it are literal
+	authorPattern := likeEscape(selectedAuthor)
+
+	// Parse civ and matchup filters
+	selectedCiv := strings.TrimSpace(r.URL.Query().Get("civ"))
+	matchupOnly := r.URL.Query().Get("matchup") != ""
+
+	// Full-text search takes precedence over the other filters, and the civ
+	// filters over the author filter
+	searchQuery := strings.TrimSpace(r.URL.Query().Get("q"))
+	matchQuery := ftsMatchQuery(searchQuery)
+	if matchQuery != "" {
+		selectedAuthor = ""
+		selectedCiv = ""
+		matchupOnly = false
+	} else if selectedCiv != "" || matchupOnly {
+		selectedAuthor = ""
+	}
+	var channelPtr *string
+	if selectedChannel != "" {
+		channelPtr = &selectedChannel
+	}
 
-	// Get list of channels for the filter dropdown
-	channelPtrs, _ := q.ListChannels(ctx)
+	// Get list of channels for the filter dropdown.
+	// A failure here is non-fatal: render with an empty dropdown and a warning.
+	var pageError string
+	channelPtrs, err := q.ListChannels(ctx)
+	if err != nil {
+		slog.Error("list channels", "error", err)
+		pageError = "Channel list unavailable"
+	}
 	var channels []string
 	for _, ch := range channelPtrs {
 		if ch != nil {
@@ -967,36 +1775,115 @@ func (s *Server) HandleQuotesPublic(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Get count and quotes based on filter
-	var count int64
-	var quotes []dbgen.Quote
-	var err error
+	// Civ list for the filter dropdown, non-fatal like the channel list
+	dbCivs, err := q.ListCivs(ctx)
+	if err != nil {
+		slog.Error("list civs", "error", err)
+	}
+	civs := make([]CivWithCount, 0, len(dbCivs))
+	for _, c := range dbCivs {
+		civs = append(civs, CivWithCount{ID: c.ID, Name: c.Name})
+	}
 
-	if selectedChannel != "" {
-		count, _ = q.CountQuotesByChannel(ctx, &selectedChannel)
-		totalPages := int((count + defaultPageSize - 1) / defaultPageSize)
-		if totalPages < 1 {
-			totalPages = 1
+	// Accept shortnames like ?civ=hre. An unknown civ is kept as given and
+	// simply matches no quotes.
+	var civPtr *string
+	if selectedCiv != "" {
+		if resolved, err := s.resolveCivName(ctx, selectedCiv); err == nil {
+			selectedCiv = resolved
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			slog.Error("resolve civ", "civ", selectedCiv, "error", err)
 		}
-		if page > totalPages {
-			page = totalPages
-		}
-		offset := (page - 1) * defaultPageSize
+		civPtr = &selectedCiv
+	}
+
+	// Get count; on failure show "?" and paginate without clamping
+	var count int64
+	switch {
+	case matchQuery != "":
+		count, err = q.CountSearchQuotes(ctx, dbgen.CountSearchQuotesParams{
+			Query:   matchQuery,
+			Channel: channelPtr,
+		})
+	case matchupOnly:
+		count, err = q.CountMatchupQuotes(ctx, dbgen.CountMatchupQuotesParams{
+			Civ:     civPtr,
+			Channel: channelPtr,
+		})
+	case selectedCiv != "":
+		count, err = q.CountQuotesByCivAndChannel(ctx, dbgen.CountQuotesByCivAndChannelParams{
+			Civ:     civPtr,
+			Channel: channelPtr,
+		})
+	case selectedChannel != "" && selectedAuthor != "":
+		count, err = q.CountQuotesByChannelAndAuthor(ctx, dbgen.CountQuotesByChannelAndAuthorParams{
+			Channel: &selectedChannel,
+			Author:  &authorPattern,
+		})
+	case selectedAuthor != "":
+		count, err = q.CountQuotesByAuthor(ctx, &authorPattern)
+	case selectedChannel != "":
+		count, err = q.CountQuotesByChannel(ctx, &selectedChannel)
+	default:
+		count, err = q.CountQuotes(ctx)
+	}
+	countUnavailable := err != nil
+	if countUnavailable {
+		slog.Error("count quotes", "error", err, "channel", selectedChannel, "author", selectedAuthor, "civ", selectedCiv, "q", searchQuery)
+	}
+
+	totalPages := int((count + defaultPageSize - 1) / defaultPageSize)
+	if totalPages < 1 {
+		totalPages = 1
+	}
+	if !countUnavailable && page > totalPages {
+		page = totalPages
+	}
+	offset := (page - 1) * defaultPageSize
+
+	var quotes []dbgen.Quote
+	switch {
+	case matchQuery != "":
+		quotes, err = q.SearchQuotesPaginated(ctx, dbgen.SearchQuotesPaginatedParams{
+			Query:   matchQuery,
+			Channel: channelPtr,
+			Limit:   defaultPageSize,
+			Offset:  int64(offset),
+		})
+	case matchupOnly:
+		quotes, err = q.ListMatchupQuotesPaginated(ctx, dbgen.ListMatchupQuotesPaginatedParams{
+			Civ:     civPtr,
+			Channel: channelPtr,
+			Limit:   defaultPageSize,
+			Offset:  int64(offset),
+		})
+	case selectedCiv != "":
+		quotes, err = q.ListQuotesByCivPaginated(ctx, dbgen.ListQuotesByCivPaginatedParams{
+			Civ:     civPtr,
+			Channel: channelPtr,
+			Limit:   defaultPageSize,
+			Offset:  int64(offset),
+		})
+	case selectedChannel != "" && selectedAuthor != "":
+		quotes, err = q.ListQuotesByChannelAndAuthorPaginated(ctx, dbgen.ListQuotesByChannelAndAuthorPaginatedParams{
+			Channel: &selectedChannel,
+			Author:  &authorPattern,
+			Limit:   defaultPageSize,
+			Offset:  int64(offset),
+		})
+	case selectedAuthor != "":
+		quotes, err = q.ListQuotesByAuthorPaginated(ctx, dbgen.ListQuotesByAuthorPaginatedParams{
+			Author: &authorPattern,
+			Limit:  defaultPageSize,
+			Offset: int64(offset),
+		})
+	case selectedChannel != "":
 		quotes, err = q.ListQuotesByChannelPaginated(ctx, dbgen.ListQuotesByChannelPaginatedParams{
 			Channel: &selectedChannel,
 			Limit:   defaultPageSize,
 			Offset:  int64(offset),
 		})
-	} else {
-		count, _ = q.CountQuotes(ctx)
-		totalPages := int((count + defaultPageSize - 1) / defaultPageSize)
-		if totalPages < 1 {
-			totalPages = 1
-		}
-		if page > totalPages {
-			page = totalPages
-		}
-		offset := (page - 1) * defaultPageSize
+	default:
 		quotes, err = q.ListQuotesPaginated(ctx, dbgen.ListQuotesPaginatedParams{
 			Limit:  defaultPageSize,
 			Offset: int64(offset),
@@ -1009,31 +1896,65 @@ func (s *Server) HandleQuotesPublic(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	totalPages := int((count + defaultPageSize - 1) / defaultPageSize)
-	if totalPages < 1 {
-		totalPages = 1
+	hasNext := page < totalPages
+	if countUnavailable {
+		// Without a total, assume more pages while we keep getting full pages
+		totalPages = page
+		hasNext = len(quotes) == defaultPageSize
+	}
+
+	// Pagination headers let scripts page through results without parsing HTML
+	if !countUnavailable {
+		w.Header().Set("X-Total-Count", strconv.FormatInt(count, 10))
+	}
+	linkPages := totalPages
+	if hasNext {
+		linkPages = max(linkPages, page+1)
+	}
+	next, prev := buildPaginationLinks(r, page, linkPages)
+	var links []string
+	if next != "" {
+		links = append(links, "<"+next+`>; rel="next"`)
+	}
+	if prev != "" {
+		links = append(links, "<"+prev+`>; rel="prev"`)
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
 	}
 
 	userID, userEmail := getAuthUser(r)
+	// The logged-out page is the same for everyone, so shared caches may keep it
+	if userID == "" && userEmail == "" {
+		w.Header().Set("Cache-Control", "public, max-age=60")
+	}
 
 	data := pageData{
 		Hostname:        s.Hostname,
-		Now:             time.Now().Format(time.RFC3339),
+		Now:             s.Clock.Now().Format(time.RFC3339),
 		UserEmail:       userEmail,
 		UserID:          userID,
 		LoginURL:        loginURLForRequest(r),
 		LogoutURL:       "/__exe.dev/logout",
-		Quotes:          quotesToViews(quotes, userEmail),
+		Quotes:          quotesToViews(quotes, userEmail, s.Clock.Now()),
 		QuoteCount:      count,
+		CountUnknown:    countUnavailable,
+		Error:           pageError,
 		Page:            page,
 		PageSize:        defaultPageSize,
 		TotalPages:      totalPages,
 		HasPrev:         page > 1,
-		HasNext:         page < totalPages,
+		HasNext:         hasNext,
 		Channels:        channels,
+		Civs:            civs,
 		SelectedChannel: selectedChannel,
+		SelectedAuthor:  selectedAuthor,
+		SelectedCiv:     selectedCiv,
+		MatchupOnly:     matchupOnly,
+		SearchQuery:     searchQuery,
 		IsPublicPage:    true,
 		IsAuthenticated: userEmail != "",
+		navBadges:       s.navBadgesFor(r.Context(), userEmail),
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -1042,21 +1963,107 @@ func (s *Server) HandleQuotesPublic(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Page size bounds for HandleListAllQuotes
+const (
+	defaultQuotesListLimit = 50
+	maxQuotesListLimit     = 200
+)
+
+// QuotePageResponse is a page of quotes with pagination metadata, returned
+// by /api/quotes with ?meta=1
+type QuotePageResponse struct {
+	Quotes []QuoteResponse `json:"quotes"`
+	Total  int64           `json:"total"`
+	Page   int             `json:"page"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
+
 // HandleListAllQuotes godoc
-// @Summary List all quotes
-// @Description Returns all quotes in the database as JSON
+// @Summary List quotes
+// @Description Returns a page of quotes as JSON, newest first. The total is sent in the X-Total-Count header,
+// @Description and page-based requests get GitHub-style Link headers for the next and previous pages.
+// @Description With meta=1 the response is an object: {"quotes": [...], "total": N, "page": P, "limit": L, "offset": O}.
 // @Tags quotes
 // @Produce json
-// @Success 200 {array} QuoteResponse "List of all quotes"
+// @Param limit query int false "Quotes per page (max 200)" default(50)
+// @Param page query int false "Page number" default(1)
+// @Param offset query int false "Quotes to skip; overrides page"
+// @Param meta query bool false "Wrap the quotes in an object with pagination metadata"
+// @Param include_deleted query bool false "Also list quotes in the trash, marked deleted (admins only)"
+// @Success 200 {array} QuoteResponse "Page of quotes"
+// @Header 200 {integer} X-Total-Count "Total number of quotes"
+// @Failure 403 {string} string "Admin access required"
 // @Failure 500 {string} string "Internal server error"
 // @Router /quotes [get]
 func (s *Server) HandleListAllQuotes(w http.ResponseWriter, r *http.Request) {
 	AddNightbotAttributes(r)
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	limit := defaultQuotesListLimit
+	if l := query.Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = min(parsed, maxQuotesListLimit)
+		}
+	}
+	page := 1
+	if p := query.Get("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+	offset := (page - 1) * limit
+	useOffset := false
+	if o := query.Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+			page = offset/limit + 1
+			useOffset = true
+		}
+	}
 
-	q := dbgen.New(s.DB)
-	quotes, err := q.ListAllQuotes(r.Context())
+	includeDeleted := query.Get("include_deleted") == "1" || query.Get("include_deleted") == "true"
+	if includeDeleted {
+		auth := s.getAuthInfo(r)
+		if !auth.IsAdmin {
+			RecordSecurityEvent(ctx, "admin_required",
+				attribute.String("user.identity", auth.DisplayIdentity()),
+				attribute.String("path", r.URL.Path),
+				attribute.String("reason", "include_deleted"),
+			)
+			http.Error(w, "Admin access required", http.StatusForbidden)
+			return
+		}
+	}
+
+	q := dbgen.New(s.ReadDB)
+	var total int64
+	var quotes []dbgen.Quote
+	var err error
+	if includeDeleted {
+		total, err = q.CountQuotesIncludingDeleted(ctx)
+	} else {
+		total, err = q.CountQuotes(ctx)
+	}
 	if err != nil {
-		slog.Error("list all quotes", "error", err)
+		slog.Error("count quotes", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if includeDeleted {
+		quotes, err = q.ListAllQuotesIncludingDeleted(ctx, dbgen.ListAllQuotesIncludingDeletedParams{
+			Limit:  int64(limit),
+			Offset: int64(offset),
+		})
+	} else {
+		quotes, err = q.ListQuotesPaginated(ctx, dbgen.ListQuotesPaginatedParams{
+			Limit:  int64(limit),
+			Offset: int64(offset),
+		})
+	}
+	if err != nil {
+		slog.Error("list quotes", "error", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
@@ -1069,16 +2076,272 @@ func (s *Server) HandleListAllQuotes(w http.ResponseWriter, r *http.Request) {
 			Author:       quote.Author,
 			Civilization: quote.Civilization,
 			CreatedAt:    quote.CreatedAt.Format(time.RFC3339),
+			Upvotes:      quote.Upvotes,
+			Downvotes:    quote.Downvotes,
+			Deleted:      quote.DeletedAt != nil,
 		}
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+	// Links page by number, which an arbitrary offset doesn't line up with
+	if !useOffset {
+		totalPages := max(int((total+int64(limit)-1)/int64(limit)), 1)
+		next, prev := buildPaginationLinks(r, page, totalPages)
+		var links []string
+		if next != "" {
+			links = append(links, "<"+next+`>; rel="next"`)
+		}
+		if prev != "" {
+			links = append(links, "<"+prev+`>; rel="prev"`)
+		}
+		if len(links) > 0 {
+			w.Header().Set("Link", strings.Join(links, ", "))
+		}
+	}
+
+	if query.Get("meta") == "1" || query.Get("meta") == "true" {
+		json.NewEncoder(w).Encode(QuotePageResponse{
+			Quotes: response,
+			Total:  total,
+			Page:   page,
+			Limit:  limit,
+			Offset: offset,
+		})
+		return
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+// maxSearchResults caps how many quotes HandleSearchQuotes returns
+const maxSearchResults = 25
+
+// HandleSearchQuotes godoc
+// @Summary Search quotes by keyword
+// @Description Full-text search of quote text and author, best match first, at most 25 results. Every word
+// @Description must match the start of a word in the quote or its author, case-insensitively.
+// @Description A single match is returned like /quote (plain text by default). Several matches are a JSON array,
+// @Description except for plain-text clients such as chat bots, which get the best match.
+// @Tags quotes
+// @Produce plain
+// @Produce json
+// @Param q query string true "Search words, matched literally (FTS operators, % and _ have no special meaning)"
+// @Param channel query string false "Only quotes for this channel"
+// @Param civ query string false "Only quotes for this civilization (name or shortname)"
+// @Success 200 {array} QuoteResponse "Matching quotes"
+// @Success 200 {string} string "Matching quote text, or a no-results message"
+// @Failure 400 {string} string "Usage: /api/quotes/search?q=term"
+// @Router /quotes/search [get]
+func (s *Server) HandleSearchQuotes(w http.ResponseWriter, r *http.Request) {
+	AddNightbotAttributes(r)
+	ctx := r.Context()
+
+	term := strings.TrimSpace(r.URL.Query().Get("q"))
+	if term == "" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintln(w, "Usage: /api/quotes/search?q=term")
+		return
+	}
+
+	var channelPtr *string
+	if bc := GetBotChannel(r); bc != nil {
+		channelPtr = &bc.Name
+	}
+	var civPtr *string
+	if civ := strings.TrimSpace(r.URL.Query().Get("civ")); civ != "" {
+		if resolved, err := s.resolveCivName(ctx, civ); err == nil {
+			civ = resolved
+		}
+		civPtr = &civ
+	}
+
+	quotes, err := s.searchQuotes(ctx, dbgen.New(s.ReadDB), term, channelPtr, civPtr)
+	if err != nil {
+		slog.Error("search quotes", "error", err, "term", term)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if len(quotes) == 0 {
+		// Return 200 so bots like Nightbot don't treat it as an error
+		WriteNoResultsResponse(w, r, fmt.Sprintf("No quotes match %s.", term))
+		return
+	}
+
+	response := make([]QuoteResponse, len(quotes))
+	for i, quote := range quotes {
+		response[i] = QuoteResponse{
+			ID:           quote.ID,
+			Text:         quote.Text,
+			Author:       quote.Author,
+			Civilization: quote.Civilization,
+			OpponentCiv:  quote.OpponentCiv,
+			CreatedAt:    quote.CreatedAt.Format(time.RFC3339),
+			Channel:      quote.Channel,
+			Upvotes:      quote.Upvotes,
+			Downvotes:    quote.Downvotes,
+		}
+	}
+	// Chat bots can only show one line, so they get the best match
+	if len(response) == 1 || !WantsJSON(r) {
+		WriteQuoteResponse(w, r, response[0])
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// VoteRequest is the body of a quote vote
+type VoteRequest struct {
+	Direction string `json:"direction"` // "up" or "down"
+}
+
+// HandleVoteQuote godoc
+// @Summary Vote on a quote
+// @Description Adds an up or down vote to a quote and returns the quote with its updated counts.
+// @Description Votes are rate limited per IP, separately from the read API limit.
+// @Tags quotes
+// @Accept json
+// @Produce json
+// @Param id path int true "Quote ID"
+// @Param vote body VoteRequest true "Vote direction"
+// @Success 200 {object} QuoteResponse "Quote with updated vote counts"
+// @Failure 400 {string} string "Invalid quote ID or direction"
+// @Failure 404 {string} string "Quote not found"
+// @Failure 429 {string} string "Too many votes"
+// @Router /quotes/{id}/vote [post]
+// @Router /quote/{id}/vote [post]
+func (s *Server) HandleVoteQuote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid quote ID", http.StatusBadRequest)
+		return
+	}
+
+	var req VoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	q := dbgen.New(s.WriteDB)
+	var vote func(context.Context, int64) (int64, error)
+	switch req.Direction {
+	case "up":
+		vote = q.IncrementUpvote
+	case "down":
+		vote = q.IncrementDownvote
+	default:
+		http.Error(w, `Direction must be "up" or "down"`, http.StatusBadRequest)
+		return
+	}
+
+	ip := clientIP(r)
+	if !s.VoteLimiter.Allow("ip:" + ip) {
+		rateLimitedTotal.WithLabelValues("vote").Inc()
+		RecordSecurityEvent(ctx, "vote_rate_limited",
+			attribute.String("client.ip", ip),
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Too many votes. Please try again later.", http.StatusTooManyRequests)
+		return
+	}
+
+	updated, err := vote(ctx, id)
+	if err != nil {
+		slog.Error("vote quote", "error", err, "id", id)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if updated == 0 {
+		http.Error(w, "Quote not found", http.StatusNotFound)
+		return
+	}
+
+	quote, err := q.GetQuoteByID(ctx, id)
+	if err != nil {
+		slog.Error("get voted quote", "error", err, "id", id)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := QuoteResponse{
+		ID:           quote.ID,
+		Text:         quote.Text,
+		Author:       quote.Author,
+		Civilization: quote.Civilization,
+		OpponentCiv:  quote.OpponentCiv,
+		CreatedAt:    quote.CreatedAt.Format(time.RFC3339),
+		Upvotes:      quote.Upvotes,
+		Downvotes:    quote.Downvotes,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// maxTopQuotes caps the limit parameter of HandleTopQuotes
+const maxTopQuotes = 50
+
+// HandleTopQuotes godoc
+// @Summary List the highest voted quotes
+// @Description Returns quotes ordered by net score (upvotes minus downvotes), highest first.
+// @Tags quotes
+// @Produce json
+// @Param limit query int false "Number of quotes (default 10, max 50)"
+// @Param channel query string false "Only quotes for this channel"
+// @Success 200 {array} QuoteResponse "Top quotes"
+// @Failure 400 {string} string "Invalid limit"
+// @Router /quotes/top [get]
+func (s *Server) HandleTopQuotes(w http.ResponseWriter, r *http.Request) {
+	limit := 10
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(n, maxTopQuotes)
+	}
+	var channelPtr *string
+	if bc := GetBotChannel(r); bc != nil {
+		channelPtr = &bc.Name
+	}
+
+	q := dbgen.New(s.ReadDB)
+	quotes, err := q.GetTopQuotes(r.Context(), dbgen.GetTopQuotesParams{
+		Channel: channelPtr,
+		Limit:   int64(limit),
+	})
+	if err != nil {
+		slog.Error("get top quotes", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]QuoteResponse, len(quotes))
+	for i, quote := range quotes {
+		response[i] = QuoteResponse{
+			ID:           quote.ID,
+			Text:         quote.Text,
+			Author:       quote.Author,
+			Civilization: quote.Civilization,
+			OpponentCiv:  quote.OpponentCiv,
+			CreatedAt:    quote.CreatedAt.Format(time.RFC3339),
+			Upvotes:      quote.Upvotes,
+			Downvotes:    quote.Downvotes,
+		}
+	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
 // HandleGetQuote godoc
 // @Summary Get a specific quote by ID
-// @Description Returns a single quote by its database ID
+// @Description Returns a single quote by its database ID, with the IDs of the adjacent quotes for navigation.
+// @Description /quotes/{id} is the canonical path; /quote/{id} is an alias kept for existing bot commands.
 // @Tags quotes
 // @Produce plain
 // @Produce json
@@ -1086,6 +2349,8 @@ func (s *Server) HandleListAllQuotes(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} QuoteResponse "Quote found"
 // @Failure 400 {string} string "Invalid quote ID"
 // @Failure 404 {string} string "Quote not found"
+// @Failure 410 {object} map[string]string "Quote has been deleted"
+// @Router /quotes/{id} [get]
 // @Router /quote/{id} [get]
 func (s *Server) HandleGetQuote(w http.ResponseWriter, r *http.Request) {
 	AddNightbotAttributes(r)
@@ -1098,16 +2363,16 @@ func (s *Server) HandleGetQuote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.ReadDB)
 	dbCtx, span := StartDBSpan(ctx, "GetQuoteByID", attribute.Int64("quote.id", id))
 	quote, err := q.GetQuoteByID(dbCtx, id)
 	span.End()
 
+	if errors.Is(err, sql.ErrNoRows) {
+		writeMissingQuote(w, r, q, id)
+		return
+	}
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Quote not found", http.StatusNotFound)
-			return
-		}
 		RecordError(trace.SpanFromContext(ctx), err)
 		slog.Error("get quote by id", "error", err, "id", id)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -1121,20 +2386,84 @@ func (s *Server) HandleGetQuote(w http.ResponseWriter, r *http.Request) {
 		Civilization: quote.Civilization,
 		OpponentCiv:  quote.OpponentCiv,
 		CreatedAt:    quote.CreatedAt.Format(time.RFC3339),
+		Channel:      quote.Channel,
+		Upvotes:      quote.Upvotes,
+		Downvotes:    quote.Downvotes,
+		Tags:         quoteTags(ctx, q, quote.ID),
 	}
+	response.PrevID, response.NextID = quoteNeighbors(ctx, q, quote.ID)
 
 	WriteQuoteResponse(w, r, response)
 }
 
+// writeMissingQuote answers a request for a quote GetQuoteByID didn't find:
+// 410 Gone if the quote is in the trash, with a Link to a random quote
+// instead, and 404 if it never existed.
+func writeMissingQuote(w http.ResponseWriter, r *http.Request, q *dbgen.Queries, id int64) {
+	quote, err := q.GetQuoteByIDIncludeDeleted(r.Context(), id)
+	if err != nil || quote.DeletedAt == nil {
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			slog.Warn("get quote including deleted", "error", err, "id", id)
+		}
+		http.Error(w, "Quote not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Link", `</api/quote>; rel="alternate"`)
+	if !WantsJSON(r) {
+		http.Error(w, "Quote has been deleted", http.StatusGone)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusGone)
+	json.NewEncoder(w).Encode(map[string]string{"error": "quote has been deleted"})
+}
+
+// quoteNeighbors returns the IDs of the quotes before and after id, or nil
+// at either end of the sequence.
+func quoteNeighbors(ctx context.Context, q *dbgen.Queries, id int64) (prev, next *int64) {
+	if n, err := q.GetNextQuoteID(ctx, id); err == nil {
+		next = &n
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		slog.Warn("get next quote id", "error", err, "id", id)
+	}
+	if p, err := q.GetPrevQuoteID(ctx, id); err == nil {
+		prev = &p
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		slog.Warn("get prev quote id", "error", err, "id", id)
+	}
+	return prev, next
+}
+
+// nightbotQueryArgs returns the words of a Nightbot $(querystring) request
+// such as /api/matchup?hre french, where the raw query is "hre french" or
+// "hre%20french". It returns nil when the query holds any key=value pair, so
+// regular parameters are never misread as arguments.
+func nightbotQueryArgs(r *http.Request) []string {
+	rawQuery := r.URL.RawQuery
+	if rawQuery == "" || strings.ContainsAny(rawQuery, "=&") {
+		return nil
+	}
+	decoded, err := url.QueryUnescape(rawQuery)
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(decoded)
+}
+
 // HandleMatchup godoc
 // @Summary Get a matchup tip
 // @Description Returns a random tip for a specific civilization matchup (your civ vs opponent civ).
 // @Description Supports two query formats: standard (?civ=X&vs=Y) or Nightbot querystring (?X Y).
+// @Description Tips stored for the reverse pairing (Y vs X) also match; they come back with reversed set,
+// @Description or prefixed with the civ they were written for in plain text.
 // @Tags matchups
 // @Produce plain
 // @Produce json
+// @Produce html
 // @Param civ query string false "Your civilization shortname (e.g., hre)"
 // @Param vs query string false "Opponent civilization shortname (e.g., french)"
+// @Param format query string false "Set to obs for a self-refreshing HTML overlay for OBS browser sources"
 // @Success 200 {object} QuoteResponse "Matchup tip found"
 // @Success 200 {string} string "Matchup tip text (plain text default)"
 // @Failure 400 {string} string "Usage: /api/matchup?civ=X&vs=Y"
@@ -1143,11 +2472,11 @@ func (s *Server) HandleMatchup(w http.ResponseWriter, r *http.Request) {
 	AddNightbotAttributes(r)
 	ctx := r.Context()
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.ReadDB)
 	playCiv := r.URL.Query().Get("civ")
 	vsCiv := r.URL.Query().Get("vs")
 
-	// Get channel from bot headers (Nightbot, Moobot) or query param
+	// Get channel from bot headers (Nightbot, Moobot, StreamElements, Discord) or query param
 	var channel string
 	if bc := GetBotChannel(r); bc != nil {
 		channel = bc.Name
@@ -1157,17 +2486,10 @@ func (s *Server) HandleMatchup(w http.ResponseWriter, r *http.Request) {
 	slog.Info("matchup request", "rawQuery", r.URL.RawQuery, "fullURL", r.URL.String())
 
 	// Support Nightbot querystring format: /api/matchup?hre french
-	// The raw query will be "hre french" or "hre%20french"
 	if playCiv == "" && vsCiv == "" {
-		rawQuery := r.URL.RawQuery
-		if rawQuery != "" {
-			// URL decode and split by space
-			decoded, _ := url.QueryUnescape(rawQuery)
-			parts := strings.Fields(decoded)
-			if len(parts) >= 2 {
-				playCiv = parts[0]
-				vsCiv = parts[1]
-			}
+		if parts := nightbotQueryArgs(r); len(parts) >= 2 {
+			playCiv = parts[0]
+			vsCiv = parts[1]
 		}
 	}
 
@@ -1185,49 +2507,37 @@ func (s *Server) HandleMatchup(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Resolve shortnames
-	dbCtx, span := StartDBSpan(ctx, "ResolveCivName", attribute.String("civ.input", playCiv))
-	if resolved, err := q.ResolveCivName(dbCtx, dbgen.ResolveCivNameParams{
-		Shortname: &playCiv,
-		LOWER:     playCiv,
-	}); err == nil {
+	if resolved, err := s.resolveCivName(ctx, playCiv); err == nil {
 		playCiv = resolved
-		span.SetAttributes(attribute.String("civ.resolved", playCiv))
 	}
-	span.End()
-
-	dbCtx, span = StartDBSpan(ctx, "ResolveCivName", attribute.String("civ.input", vsCiv))
-	if resolved, err := q.ResolveCivName(dbCtx, dbgen.ResolveCivNameParams{
-		Shortname: &vsCiv,
-		LOWER:     vsCiv,
-	}); err == nil {
+	if resolved, err := s.resolveCivName(ctx, vsCiv); err == nil {
 		vsCiv = resolved
-		span.SetAttributes(attribute.String("civ.resolved", vsCiv))
 	}
-	span.End()
 
 	var quote dbgen.Quote
 	var err error
+	// Tips match in either direction, preferring ones written for playCiv
 	if channel != "" {
-		dbCtx, span := StartDBSpan(ctx, "GetRandomMatchupQuote",
+		dbCtx, span := StartDBSpan(ctx, "GetRandomMatchupQuoteBidirectional",
 			attribute.String("civ", playCiv),
 			attribute.String("vs", vsCiv),
 			attribute.String("channel", channel))
-		quote, err = q.GetRandomMatchupQuote(dbCtx, dbgen.GetRandomMatchupQuoteParams{
-			Civilization: &playCiv,
-			OpponentCiv:  &vsCiv,
-			Channel:      &channel,
+		quote, err = q.GetRandomMatchupQuoteBidirectional(dbCtx, dbgen.GetRandomMatchupQuoteBidirectionalParams{
+			Civ:     &playCiv,
+			Vs:      &vsCiv,
+			Channel: &channel,
 		})
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
 			RecordError(span, err)
 		}
 		span.End()
 	} else {
-		dbCtx, span := StartDBSpan(ctx, "GetRandomMatchupQuoteGlobal",
+		dbCtx, span := StartDBSpan(ctx, "GetRandomMatchupQuoteBidirectionalGlobal",
 			attribute.String("civ", playCiv),
 			attribute.String("vs", vsCiv))
-		quote, err = q.GetRandomMatchupQuoteGlobal(dbCtx, dbgen.GetRandomMatchupQuoteGlobalParams{
-			Civilization: &playCiv,
-			OpponentCiv:  &vsCiv,
+		quote, err = q.GetRandomMatchupQuoteBidirectionalGlobal(dbCtx, dbgen.GetRandomMatchupQuoteBidirectionalGlobalParams{
+			Civ: &playCiv,
+			Vs:  &vsCiv,
 		})
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
 			RecordError(span, err)
@@ -1253,11 +2563,15 @@ func (s *Server) HandleMatchup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A tip stored as vsCiv vs playCiv is written from the opponent's side
+	reversed := quote.Civilization == nil || !strings.EqualFold(*quote.Civilization, playCiv)
+
 	// Record successful quote retrieval
 	rootSpan := trace.SpanFromContext(ctx)
 	rootSpan.AddEvent("quote_served", trace.WithAttributes(
 		attribute.Int64("quote.id", quote.ID),
 		attribute.String("query_type", "matchup"),
+		attribute.Bool("matchup.reversed", reversed),
 	))
 
 	response := QuoteResponse{
@@ -1267,18 +2581,26 @@ func (s *Server) HandleMatchup(w http.ResponseWriter, r *http.Request) {
 		Civilization: quote.Civilization,
 		OpponentCiv:  quote.OpponentCiv,
 		CreatedAt:    quote.CreatedAt.Format(time.RFC3339),
+		Channel:      quote.Channel,
+		Reversed:     reversed,
+		Upvotes:      quote.Upvotes,
+		Downvotes:    quote.Downvotes,
 	}
 	WriteQuoteResponse(w, r, response)
 }
 
 // HandleRandomQuote godoc
 // @Summary Get a random quote
-// @Description Returns a random quote from the database. Supports filtering by civilization and channel.
+// @Description Returns a random quote from the database. Supports filtering by civilization, channel and tag.
+// @Description The civ can also be given Nightbot querystring style as a single word (?hre).
 // @Tags quotes
 // @Produce plain
 // @Produce json
+// @Produce html
 // @Param civ query string false "Civilization shortname (e.g., hre, french, mongols)"
 // @Param channel query string false "Channel name for channel-specific quotes"
+// @Param tag query string false "Only pick quotes with this tag (e.g., funny, build-order)"
+// @Param format query string false "Set to obs for a self-refreshing HTML overlay for OBS browser sources"
 // @Success 200 {object} QuoteResponse "Quote found (JSON when Accept: application/json)"
 // @Success 200 {string} string "Quote text (plain text default)"
 // @Header 200 {string} Content-Type "text/plain or application/json based on Accept header"
@@ -1287,31 +2609,68 @@ func (s *Server) HandleRandomQuote(w http.ResponseWriter, r *http.Request) {
 	AddNightbotAttributes(r)
 	ctx := r.Context()
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.ReadDB)
 	civ := r.URL.Query().Get("civ")
+	tag := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("tag")))
+
+	// Support Nightbot querystring format: /api/quote?hre
+	if civ == "" {
+		if parts := nightbotQueryArgs(r); len(parts) == 1 {
+			civ = parts[0]
+		}
+	}
 
-	// Get channel from bot headers (Nightbot, Moobot) or query param
+	// Get channel from bot headers (Nightbot, Moobot, StreamElements, Discord) or query param
 	var channel string
+	source := BotSourceNone
 	if bc := GetBotChannel(r); bc != nil {
 		channel = bc.Name
+		source = bc.Source
 	}
 
-	// Resolve shortname to full civ name
+	// Resolve shortname to full civ name. The resolution type tells
+	// "No quotes available for X" reports apart: a full name, a shortname,
+	// or unknown input used as-is.
 	if civ != "" {
-		dbCtx, span := StartDBSpan(ctx, "ResolveCivName", attribute.String("civ.input", civ))
-		if resolved, err := q.ResolveCivName(dbCtx, dbgen.ResolveCivNameParams{
-			Shortname: &civ,
-			LOWER:     civ,
-		}); err == nil {
+		resolveCtx, span := tracer.Start(ctx, "civs.resolve", trace.WithAttributes(attribute.String("civ.input", civ)))
+		resolved, err := s.resolveCivName(resolveCtx, civ)
+		resolutionType := "passthrough"
+		switch {
+		case err == nil && strings.EqualFold(resolved, civ):
+			resolutionType = "direct"
+		case err == nil:
+			resolutionType = "shortname"
+		case !errors.Is(err, sql.ErrNoRows):
+			RecordError(span, err)
+		}
+		if err == nil {
 			civ = resolved
 			span.SetAttributes(attribute.String("civ.resolved", civ))
 		}
+		span.SetAttributes(
+			attribute.String("civ.resolution_type", resolutionType),
+			attribute.Bool("civ.resolution_found", err == nil),
+		)
 		span.End()
 	}
 
 	var quote dbgen.Quote
 	var err error
-	if civ != "" {
+	if tag != "" {
+		dbCtx, span := StartDBSpan(ctx, "GetRandomQuoteByTag",
+			attribute.String("tag", tag),
+			attribute.String("civ", civ),
+			attribute.String("channel", channel))
+		quote, err = q.GetRandomQuoteByTag(dbCtx, dbgen.GetRandomQuoteByTagParams{
+			Tag:          tag,
+			Civilization: toStringPtr(civ),
+			Channel:      toStringPtr(channel),
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			RecordError(span, err)
+		}
+		span.End()
+	} else if civ != "" {
 		if channel != "" {
 			dbCtx, span := StartDBSpan(ctx, "GetRandomQuoteByCiv",
 				attribute.String("civ", civ),
@@ -1352,15 +2711,22 @@ func (s *Server) HandleRandomQuote(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if err == nil {
+		quote = s.avoidRecentQuote(ctx, q, quote, channel, civ, tag)
+	}
+
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			span := trace.SpanFromContext(ctx)
 			span.AddEvent("no_results", trace.WithAttributes(
 				attribute.String("query_type", "quote"),
 				attribute.String("civ", civ),
+				attribute.String("tag", tag),
 			))
 			// Return 200 so bots like Nightbot don't treat it as an error
-			if civ != "" {
+			if tag != "" {
+				WriteNoResultsResponse(w, r, fmt.Sprintf("No quotes tagged %s.", tag))
+			} else if civ != "" {
 				WriteNoResultsResponse(w, r, fmt.Sprintf("No quotes available for %s.", civ))
 			} else {
 				WriteNoResultsResponse(w, r, "No quotes available.")
@@ -1375,6 +2741,7 @@ func (s *Server) HandleRandomQuote(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Record successful quote retrieval
+	s.recentQuotes.add(channel, quote.ID)
 	rootSpan := trace.SpanFromContext(ctx)
 	rootSpan.AddEvent("quote_served", trace.WithAttributes(
 		attribute.Int64("quote.id", quote.ID),
@@ -1387,10 +2754,222 @@ func (s *Server) HandleRandomQuote(w http.ResponseWriter, r *http.Request) {
 		Author:       quote.Author,
 		Civilization: quote.Civilization,
 		CreatedAt:    quote.CreatedAt.Format(time.RFC3339),
+		Channel:      quote.Channel,
+		Upvotes:      quote.Upvotes,
+		Downvotes:    quote.Downvotes,
+		Tags:         quoteTags(ctx, q, quote.ID),
 	}
+	logQuoteServed(ctx, response, channel, civ, string(source))
 	WriteQuoteResponse(w, r, response)
 }
 
+// CivResponse is the JSON representation of a civilization
+type CivResponse struct {
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Shortname *string `json:"shortname"`
+	Dlc       *string `json:"dlc"`
+}
+
+// CivSummaryResponse is a civilization in the /api/civs list
+type CivSummaryResponse struct {
+	Name       string  `json:"name"`
+	Shortname  *string `json:"shortname"`
+	VariantOf  *string `json:"variant_of"`
+	Dlc        *string `json:"dlc"`
+	QuoteCount int64   `json:"quote_count"`
+}
+
+// HandleListCivs godoc
+// @Summary List civilizations
+// @Description Returns every civilization with its number of quotes, sorted by name, e.g. to fill an overlay's dropdown.
+// @Tags civilizations
+// @Produce json
+// @Param withQuotes query bool false "Only list civilizations that have at least one quote"
+// @Success 200 {array} CivSummaryResponse "Civilizations"
+// @Failure 500 {string} string "Internal server error"
+// @Router /civs [get]
+func (s *Server) HandleListCivs(w http.ResponseWriter, r *http.Request) {
+	q := dbgen.New(s.ReadDB)
+	civs, err := q.ListCivsWithQuoteCount(r.Context())
+	if err != nil {
+		slog.Error("list civs", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	withQuotes := r.URL.Query().Get("withQuotes") == "true"
+	response := make([]CivSummaryResponse, 0, len(civs))
+	for _, civ := range civs {
+		if withQuotes && civ.QuoteCount == 0 {
+			continue
+		}
+		response = append(response, CivSummaryResponse{
+			Name:       civ.Name,
+			Shortname:  civ.Shortname,
+			VariantOf:  civ.VariantOf,
+			Dlc:        civ.Dlc,
+			QuoteCount: civ.QuoteCount,
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// HandleGetRandomCiv godoc
+// @Summary Get a random civilization
+// @Description Returns a random civilization, e.g. for a !randomciv bot command.
+// @Tags civilizations
+// @Produce json
+// @Param has_quotes query bool false "Only pick civilizations that have at least one quote"
+// @Param exclude query string false "Comma-separated civilization names or shortnames to skip"
+// @Success 200 {object} CivResponse "Random civilization, or {\"error\": \"no civilizations\"} when none match"
+// @Failure 500 {string} string "Internal server error"
+// @Router /civs/random [get]
+func (s *Server) HandleGetRandomCiv(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := dbgen.New(s.ReadDB)
+
+	// Normalize to a lowercase comma list for the query's LIKE match
+	var excluded []string
+	for _, name := range strings.Split(r.URL.Query().Get("exclude"), ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			excluded = append(excluded, name)
+		}
+	}
+	exclude := strings.Join(excluded, ",")
+
+	var civ dbgen.Civilization
+	var err error
+	if r.URL.Query().Get("has_quotes") == "true" {
+		civ, err = q.GetRandomCivWithQuotes(ctx, &exclude)
+	} else {
+		civ, err = q.GetRandomCiv(ctx, &exclude)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if errors.Is(err, sql.ErrNoRows) {
+		json.NewEncoder(w).Encode(map[string]string{"error": "no civilizations"})
+		return
+	}
+	if err != nil {
+		slog.Error("get random civ", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(CivResponse{
+		ID:        civ.ID,
+		Name:      civ.Name,
+		Shortname: civ.Shortname,
+		Dlc:       civ.Dlc,
+	})
+}
+
+// maxCivQuotesLimit caps the page size for HandleListCivQuotes
+const maxCivQuotesLimit = 100
+
+// HandleListCivQuotes godoc
+// @Summary List quotes for a civilization
+// @Description Returns a page of quotes for a civilization, newest first. The civ may be a full name or a shortname. The total number of matching quotes is sent in the X-Total-Count header.
+// @Tags civilizations
+// @Produce json
+// @Param civ path string true "Civilization name or shortname (e.g. hre)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Quotes per page (max 100)" default(20)
+// @Param channel query string false "Only include quotes from this channel"
+// @Success 200 {array} QuoteResponse "Quotes for the civilization"
+// @Header 200 {integer} X-Total-Count "Total number of matching quotes"
+// @Failure 400 {string} string "Unknown civilization or invalid channel"
+// @Failure 404 {string} string "No quotes for the civilization yet"
+// @Failure 500 {string} string "Internal server error"
+// @Router /civs/{civ}/quotes [get]
+func (s *Server) HandleListCivQuotes(w http.ResponseWriter, r *http.Request) {
+	AddNightbotAttributes(r)
+	ctx := r.Context()
+	q := dbgen.New(s.ReadDB)
+
+	input := strings.TrimSpace(r.PathValue("civ"))
+	channel := strings.TrimSpace(r.URL.Query().Get("channel"))
+	if err := ValidateChannel(channel); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page := 1
+	if p := r.URL.Query().Get("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+	limit := defaultPageSize
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = min(parsed, maxCivQuotesLimit)
+		}
+	}
+
+	civ, err := s.resolveCivName(ctx, input)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, fmt.Sprintf("Unknown civilization: %s", input), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		slog.Error("resolve civ", "civ", input, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var channelPtr *string
+	if channel != "" {
+		channelPtr = &channel
+	}
+
+	count, err := q.CountQuotesByCivAndChannel(ctx, dbgen.CountQuotesByCivAndChannelParams{
+		Civ:     &civ,
+		Channel: channelPtr,
+	})
+	if err != nil {
+		slog.Error("count civ quotes", "civ", civ, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if count == 0 {
+		http.Error(w, fmt.Sprintf("No quotes for %s yet", civ), http.StatusNotFound)
+		return
+	}
+
+	quotes, err := q.ListQuotesByCivPaginated(ctx, dbgen.ListQuotesByCivPaginatedParams{
+		Civ:     &civ,
+		Channel: channelPtr,
+		Limit:   int64(limit),
+		Offset:  int64((page - 1) * limit),
+	})
+	if err != nil {
+		slog.Error("list civ quotes", "civ", civ, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]QuoteResponse, len(quotes))
+	for i, quote := range quotes {
+		response[i] = QuoteResponse{
+			ID:           quote.ID,
+			Text:         quote.Text,
+			Author:       quote.Author,
+			Civilization: quote.Civilization,
+			OpponentCiv:  quote.OpponentCiv,
+			CreatedAt:    quote.CreatedAt.Format(time.RFC3339),
+			Upvotes:      quote.Upvotes,
+			Downvotes:    quote.Downvotes,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Total-Count", strconv.FormatInt(count, 10))
+	json.NewEncoder(w).Encode(response)
+}
+
 func loginURLForRequest(r *http.Request) string {
 	path := r.URL.RequestURI()
 	v := url.Values{}
@@ -1398,8 +2977,15 @@ func loginURLForRequest(r *http.Request) string {
 	return "/__exe.dev/login?" + v.Encode()
 }
 
-func formatTimeAgo(t time.Time) string {
-	duration := time.Since(t)
+// formatTimeAgo describes t relative to the server's clock.
+func (s *Server) formatTimeAgo(t time.Time) string {
+	return formatTimeAgoFrom(t, s.Clock.Now())
+}
+
+// formatTimeAgoFrom describes t relative to now. Times in the future read as
+// "just now". Split out so a page can format many times against one now.
+func formatTimeAgoFrom(t, now time.Time) string {
+	duration := now.Sub(t)
 	switch {
 	case duration < time.Minute:
 		return "just now"
@@ -1427,13 +3013,52 @@ func formatTimeAgo(t time.Time) string {
 }
 
 var templateFuncs = template.FuncMap{
-	"add":      func(a, b int) int { return a + b },
-	"subtract": func(a, b int) int { return a - b },
+	"add":       func(a, b int) int { return a + b },
+	"subtract":  func(a, b int) int { return a - b },
+	"highlight": highlightTerms,
+}
+
+// requiredTemplates lists the page templates handlers render by name.
+// loadTemplates checks these (plus the nav.html partial) exist before parsing.
+var requiredTemplates = []string{
+	"admin_audit.html",
+	"admin_managed_channels.html",
+	"admin_migrations.html",
+	"admin_nightbot.html",
+	"admin_nightbot_compare.html",
+	"admin_nightbot_deleted.html",
+	"admin_nightbot_diff.html",
+	"admin_nightbot_moderators.html",
+	"admin_nightbot_search.html",
+	"admin_nightbot_snapshots.html",
+	"admin_owners.html",
+	"admin_users.html",
+	"changelog.html",
+	"channel_settings.html",
+	"civs.html",
+	"help.html",
+	"index.html",
+	"quotes.html",
+	"quotes_public.html",
+	"quotes_trash.html",
+	"suggest.html",
+	"suggestions.html",
 }
 
 func (s *Server) loadTemplates() error {
 	s.templates = make(map[string]*template.Template)
 
+	// Pre-flight check so operators see every missing file at once
+	var missing []string
+	for _, name := range append([]string{"nav.html"}, requiredTemplates...) {
+		if _, err := os.Stat(filepath.Join(s.TemplatesDir, name)); err != nil {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing templates: %v", missing)
+	}
+
 	// Auto-discover all HTML templates except partials (nav.html)
 	pattern := filepath.Join(s.TemplatesDir, "*.html")
 	files, err := filepath.Glob(pattern)
@@ -1470,11 +3095,16 @@ func (s *Server) renderTemplate(w http.ResponseWriter, name string, data any) er
 }
 
 func (s *Server) setUpDatabase(dbPath string) error {
-	wdb, err := db.Open(dbPath)
+	maxOpen := s.Config.DBMaxOpenConns
+	if maxOpen <= 0 {
+		maxOpen = 1
+	}
+	wdb, rdb, err := db.OpenPrimaryWithPool(dbPath, s.Config.DBReadPath, maxOpen)
 	if err != nil {
 		return fmt.Errorf("failed to open db: %w", err)
 	}
-	s.DB = wdb
+	s.WriteDB = wdb
+	s.ReadDB = rdb
 
 	migrations, err := db.RunMigrations(wdb)
 	if err != nil {
@@ -1483,16 +3113,77 @@ func (s *Server) setUpDatabase(dbPath string) error {
 
 	// Create markers for each migration that was applied
 	for _, m := range migrations {
-		s.Markers.CreateMigrationMarker(m.Filename, m.StartTime, m.EndTime)
+		s.Markers.CreateMigrationMarker(m.Number, m.Filename, m.StartTime, m.EndTime)
 	}
 
 	return nil
 }
 
+// apiHandler returns the rate limited /api/ routes. Each route group has
+// its own limiter, so a burst against one group doesn't use up another's
+// budget:
+//   - RandomLimiter: single-quote lookups used by chat bot commands
+//     (/api/quote, /api/quote/today, /api/quotes/{id}, /api/matchup,
+//     /api/civs/random, /api/quotes/search, /api/suggest,
+//     /api/suggestions/{id}/status)
+//   - ListLimiter: endpoints returning many records (/api/quotes,
+//     /api/quotes/top, /api/civs, /api/civs/{civ}/quotes,
+//     GET /api/suggestions, GET /api/admin/owners)
+//   - APILimiter: everything else, including docs and writes
+//
+// All limiters key by Nightbot channel when present and by IP otherwise.
+// JSON GET responses get ETags through ConditionalGet, and CORS lets
+// browser overlays on Config.AllowedOrigins read them.
+func (s *Server) apiHandler() http.Handler {
+	mux := http.NewServeMux()
+	limited := func(rl *RateLimiter) func(string, http.HandlerFunc) {
+		return func(pattern string, h http.HandlerFunc) {
+			mux.Handle(pattern, rl.Middleware(h))
+		}
+	}
+	random := limited(s.RandomLimiter)
+	list := limited(s.ListLimiter)
+	api := limited(s.APILimiter)
+
+	api("GET /api/{$}", s.HandleAPIDocs)
+	api("GET /api/openapi.json", s.HandleAPISpec)
+	api("GET /api/changelog", s.HandleAPIChangelog)
+	random("GET /api/quote", s.HandleRandomQuote)
+	random("GET /api/quote/today", s.HandleQuoteOfTheDay)
+	random("GET /api/quotes/search", s.HandleSearchQuotes)
+	list("GET /api/quotes/top", s.HandleTopQuotes)
+	api("POST /api/quotes/{id}/vote", s.HandleVoteQuote)
+	api("POST /api/quote/{id}/vote", s.HandleVoteQuote)
+	// Both paths serve the same response. /api/quote/{id} is not redirected
+	// because it is baked into chat bot commands, and bots may not follow
+	// redirects.
+	random("GET /api/quotes/{id}", s.HandleGetQuote)
+	random("GET /api/quote/{id}", s.HandleGetQuote)
+	list("GET /api/quotes", s.HandleListAllQuotes)
+	api("DELETE /api/quotes/{id}", s.HandleDeleteQuoteAPI)
+	random("GET /api/matchup", s.HandleMatchup)
+	list("GET /api/civs", s.HandleListCivs)
+	random("GET /api/civs/random", s.HandleGetRandomCiv)
+	list("GET /api/civs/{civ}/quotes", s.HandleListCivQuotes)
+	api("POST /api/suggestions", s.HandleSubmitSuggestion)
+	list("GET /api/suggestions", s.HandleAPIListSuggestions)
+	api("GET /api/suggestions/{id}", s.HandleGetSuggestion)
+	random("GET /api/suggestions/{id}/status", s.HandleSuggestionStatus)
+	random("GET /api/suggest", s.HandleBotSuggestion)
+	list("GET /api/admin/owners", s.HandleAPIListChannelOwners)
+	api("POST /api/admin/owners", s.HandleAPIAddChannelOwner)
+	api("DELETE /api/admin/owners", s.HandleAPIRemoveChannelOwner)
+	return CORS(s.Config.AllowedOrigins, ConditionalGet(mux))
+}
+
 func (s *Server) Serve(addr string) error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("GET /{$}", s.HandleRoot)
 	mux.HandleFunc("GET /health", s.HandleHealth)
+	mux.HandleFunc("GET /health/detailed", s.HandleHealthDetailed)
+	mux.HandleFunc("GET /livez", s.HandleLivez)
+	mux.HandleFunc("GET /readyz", s.HandleReadyz)
+	mux.Handle("GET /metrics", metricsHandler())
 	// Twitch OAuth
 	mux.HandleFunc("GET /auth/twitch", s.HandleTwitchAuth)
 	mux.HandleFunc("GET /auth/twitch/callback", s.HandleTwitchCallback)
@@ -1500,12 +3191,17 @@ func (s *Server) Serve(addr string) error {
 	mux.HandleFunc("GET /help", s.HandleHelp)
 	mux.HandleFunc("GET /changelog", s.HandleChangelog)
 	mux.HandleFunc("GET /browse", s.HandleQuotesPublic)
+	mux.HandleFunc("GET /feed.xml", s.HandleFeed)
 	mux.HandleFunc("GET /suggest", s.HandleSuggestForm)
 	mux.HandleFunc("GET /quotes", s.HandleQuotes)
 	mux.HandleFunc("POST /quotes", s.HandleAddQuote)
 	mux.HandleFunc("POST /quotes/bulk", s.HandleBulkQuotes)
+	mux.HandleFunc("GET /quotes/export.csv", s.HandleQuotesExportCSV)
+	mux.HandleFunc("POST /quotes/import", s.HandleImportQuotes)
 	mux.HandleFunc("POST /quotes/{id}/edit", s.HandleEditQuote)
 	mux.HandleFunc("POST /quotes/{id}/delete", s.HandleDeleteQuote)
+	mux.HandleFunc("POST /quotes/{id}/restore", s.HandleRestoreQuote)
+	mux.HandleFunc("GET /quotes/trash", s.HandleQuotesTrash)
 	mux.HandleFunc("GET /civs", s.HandleCivs)
 	mux.HandleFunc("POST /civs", s.HandleAddCiv)
 	mux.HandleFunc("POST /civs/{id}/edit", s.HandleEditCiv)
@@ -1513,11 +3209,21 @@ func (s *Server) Serve(addr string) error {
 	mux.HandleFunc("GET /suggestions", s.HandleListSuggestions)
 	mux.HandleFunc("POST /suggestions/{id}/approve", s.HandleApproveSuggestion)
 	mux.HandleFunc("POST /suggestions/{id}/reject", s.HandleRejectSuggestion)
+	mux.HandleFunc("POST /suggestions/{id}/view", s.HandleMarkSuggestionViewed)
+	mux.HandleFunc("POST /suggestions/bulk-reject", s.HandleBatchRejectSuggestions)
+	mux.HandleFunc("GET /channels/{name}/settings", s.HandleChannelSettings)
+	mux.HandleFunc("POST /channels/{name}/settings", s.HandleUpdateChannelSettings)
 	// Admin routes
 	mux.HandleFunc("GET /admin/users", s.HandleAdminUsers)
+	mux.HandleFunc("GET /admin/migrations", s.HandleAdminMigrations)
+	mux.HandleFunc("GET /admin/audit", s.HandleAdminAudit)
+	mux.HandleFunc("POST /admin/suggestions/purge", s.HandlePurgeSuggestions)
+	mux.HandleFunc("POST /admin/search/rebuild", s.HandleRebuildSearchIndex)
 	mux.HandleFunc("GET /admin/owners", s.HandleListChannelOwners)
 	mux.HandleFunc("POST /admin/owners", s.HandleAddChannelOwner)
 	mux.HandleFunc("POST /admin/owners/delete", s.HandleRemoveChannelOwner)
+	mux.HandleFunc("GET /admin/impersonate", s.HandleAdminImpersonate)
+	mux.HandleFunc("POST /admin/impersonate/stop", s.HandleAdminImpersonateStop)
 	// Nightbot backup/restore
 	mux.HandleFunc("GET /admin/nightbot", s.HandleNightbotAdmin)
 	mux.HandleFunc("GET /admin/nightbot/callback", s.HandleNightbotCallback)
@@ -1549,25 +3255,18 @@ func (s *Server) Serve(addr string) error {
 	mux.Handle("/static/", http.StripPrefix("/static/", StaticFileServer(s.StaticDir)))
 
 	// API routes with rate limiting (including docs)
-	apiMux := http.NewServeMux()
-	apiMux.HandleFunc("GET /api/{$}", s.HandleAPIDocs)
-	apiMux.HandleFunc("GET /api/openapi.json", s.HandleAPISpec)
-	apiMux.HandleFunc("GET /api/quote", s.HandleRandomQuote)
-	apiMux.HandleFunc("GET /api/quote/{id}", s.HandleGetQuote)
-	apiMux.HandleFunc("GET /api/quotes", s.HandleListAllQuotes)
-	apiMux.HandleFunc("GET /api/matchup", s.HandleMatchup)
-	apiMux.HandleFunc("POST /api/suggestions", s.HandleSubmitSuggestion)
-	apiMux.HandleFunc("GET /api/suggest", s.HandleBotSuggestion)
-	mux.Handle("/api/", s.APILimiter.Middleware(apiMux))
-
-	s.httpServer = &http.Server{
-		Addr:    addr,
-		Handler: otelhttp.NewHandler(SecurityHeaders(RequestLogger(s.UserTracking(Gzip(LimitRequestBody(mux))))), "quotes"),
-	}
+	mux.Handle("/api/", s.apiHandler())
+	// Version is cheap and polled by monitors, so it bypasses the API limiter
+	mux.HandleFunc("GET /api/version", s.HandleVersion)
+
+	s.httpServer = s.newHTTPServer(addr, otelhttp.NewHandler(SecurityHeaders(s.Config, RequestLogger(s.AuditEvents(s.UserTracking(s.ImpersonateMiddleware(Gzip(LimitRequestBody(NoStore(Metrics(mux))))))))), "quotes"))
 
 	// Start background cleanup of soft-deleted snapshots
 	s.StartSnapshotCleanup(context.Background())
 
+	// Start background cleanup of old audit events
+	s.StartAuditCleanup(context.Background())
+
 	// Start managed channel sync (if configured)
 	s.StartManagedChannelSync(context.Background())
 
@@ -1575,12 +3274,31 @@ func (s *Server) Serve(addr string) error {
 	return s.httpServer.ListenAndServe()
 }
 
-// Shutdown gracefully shuts down the server
+// newHTTPServer builds the http.Server with timeouts from Config.
+func (s *Server) newHTTPServer(addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:         addr,
+		Handler:      handler,
+		ReadTimeout:  s.Config.ReadTimeout,
+		WriteTimeout: s.Config.WriteTimeout,
+		IdleTimeout:  s.Config.IdleTimeout,
+	}
+}
+
+// Shutdown gracefully shuts down the server, then sends any queued markers
 func (s *Server) Shutdown(ctx context.Context) error {
-	if s.httpServer == nil {
-		return nil
+	if s.httpServer != nil {
+		if err := s.httpServer.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+	if err := s.audit.Flush(ctx); err != nil {
+		slog.Warn("flush audit events", "error", err)
+	}
+	if err := s.Markers.Flush(ctx); err != nil {
+		slog.Warn("flush markers", "error", err)
 	}
-	return s.httpServer.Shutdown(ctx)
+	return nil
 }
 
 // SuggestionRequest is the JSON body for submitting a quote suggestion
@@ -1602,13 +3320,56 @@ type SuggestionResponse struct {
 	Channel     string  `json:"channel"`
 	Status      string  `json:"status"`
 	SubmittedAt string  `json:"submitted_at"`
+	ReviewedBy  *string `json:"reviewed_by,omitempty"`
+	ReviewedAt  *string `json:"reviewed_at,omitempty"`
+}
+
+// suggestionToResponse converts a stored suggestion to its API representation
+func suggestionToResponse(sg dbgen.QuoteSuggestion) SuggestionResponse {
+	resp := SuggestionResponse{
+		ID:           sg.ID,
+		Text:         sg.Text,
+		Author:       sg.Author,
+		Civilization: sg.Civilization,
+		OpponentCiv:  sg.OpponentCiv,
+		Channel:      sg.Channel,
+		Status:       sg.Status,
+		SubmittedAt:  sg.SubmittedAt.Format(time.RFC3339),
+		ReviewedBy:   sg.ReviewedBy,
+	}
+	if sg.ReviewedAt != nil {
+		reviewedAt := sg.ReviewedAt.Format(time.RFC3339)
+		resp.ReviewedAt = &reviewedAt
+	}
+	return resp
+}
+
+// suggestionFromForm builds a SuggestionRequest from parsed form values.
+// Empty optional fields are left nil to match an omitted JSON field.
+func suggestionFromForm(r *http.Request) SuggestionRequest {
+	optional := func(key string) *string {
+		if v := r.FormValue(key); v != "" {
+			return &v
+		}
+		return nil
+	}
+	return SuggestionRequest{
+		Text:         r.FormValue("text"),
+		Author:       optional("author"),
+		Civilization: optional("civilization"),
+		OpponentCiv:  optional("opponent_civ"),
+		Channel:      r.FormValue("channel"),
+	}
 }
 
 // HandleSubmitSuggestion godoc
 // @Summary Submit a quote suggestion
 // @Description Submit a new quote for review. Rate limited per IP (default: 5 per hour, configurable via SUGGESTION_RATE_LIMIT and SUGGESTION_RATE_INTERVAL).
+// @Description If channel is omitted it is taken from the Nightbot-Channel or Moobot-channel-name header.
 // @Tags suggestions
 // @Accept json
+// @Accept x-www-form-urlencoded
+// @Accept mpfd
 // @Produce json
 // @Param suggestion body SuggestionRequest true "Quote suggestion"
 // @Success 201 {object} map[string]string "Suggestion submitted successfully"
@@ -1637,8 +3398,8 @@ func (s *Server) HandleSubmitSuggestion(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Rate limit suggestions per IP
-	q := dbgen.New(s.DB)
-	cutoff := time.Now().Add(-s.Config.SuggestionRateInterval)
+	q := dbgen.New(s.WriteDB)
+	cutoff := s.Clock.Now().Add(-s.Config.SuggestionRateInterval)
 	count, err := q.CountRecentSuggestionsByIP(ctx, dbgen.CountRecentSuggestionsByIPParams{
 		SubmittedByIp: ip,
 		SubmittedAt:   cutoff,
@@ -1649,6 +3410,7 @@ func (s *Server) HandleSubmitSuggestion(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	if count >= int64(s.Config.SuggestionRateLimit) {
+		rateLimitedTotal.WithLabelValues("suggestion").Inc()
 		RecordSecurityEvent(ctx, "suggestion_rate_limited",
 			attribute.String("client.ip", ip),
 			attribute.Int64("suggestion_count", count),
@@ -1658,20 +3420,57 @@ func (s *Server) HandleSubmitSuggestion(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Parse request body
+	// Parse request body: HTML forms post directly, everything else is JSON
 	var req SuggestionRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+	var honeypot string
+	contentType := r.Header.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "multipart/form-data"):
+		if err := r.ParseMultipartForm(MaxRequestBodySize); err != nil {
+			http.Error(w, "Invalid form data", http.StatusBadRequest)
+			return
+		}
+		req = suggestionFromForm(r)
+		honeypot = r.FormValue(suggestHoneypotField)
+	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Invalid form data", http.StatusBadRequest)
+			return
+		}
+		req = suggestionFromForm(r)
+		honeypot = r.FormValue(suggestHoneypotField)
+	default:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Only bots fill in the hidden honeypot field. Report success so they
+	// don't retry, but store nothing.
+	if honeypot != "" {
+		RecordSecurityEvent(ctx, "suggestion_honeypot_triggered",
+			attribute.String("client.ip", ip),
+			attribute.String("path", r.URL.Path),
+		)
+		writeSuggestionCreated(w, req.Channel)
 		return
 	}
 
+	// Bots calling via $(urlfetch POST ...) send the channel as a header
+	if strings.TrimSpace(req.Channel) == "" {
+		if bc := GetBotChannel(r); bc != nil {
+			req.Channel = bc.Name
+		}
+	}
+
 	// Validate required fields
 	if strings.TrimSpace(req.Text) == "" {
 		http.Error(w, "Text is required", http.StatusBadRequest)
 		return
 	}
 	if strings.TrimSpace(req.Channel) == "" {
-		http.Error(w, "Channel is required", http.StatusBadRequest)
+		http.Error(w, "Channel is required (set \"channel\" in the body or send a Nightbot-Channel/Moobot-channel-name header)", http.StatusBadRequest)
 		return
 	}
 
@@ -1683,24 +3482,18 @@ func (s *Server) HandleSubmitSuggestion(w http.ResponseWriter, r *http.Request)
 
 	// Resolve civ shortnames if provided
 	if req.Civilization != nil && *req.Civilization != "" {
-		if resolved, err := q.ResolveCivName(ctx, dbgen.ResolveCivNameParams{
-			Shortname: req.Civilization,
-			LOWER:     strings.ToLower(*req.Civilization),
-		}); err == nil {
+		if resolved, err := s.resolveCivName(ctx, *req.Civilization); err == nil {
 			req.Civilization = &resolved
 		}
 	}
 	if req.OpponentCiv != nil && *req.OpponentCiv != "" {
-		if resolved, err := q.ResolveCivName(ctx, dbgen.ResolveCivNameParams{
-			Shortname: req.OpponentCiv,
-			LOWER:     strings.ToLower(*req.OpponentCiv),
-		}); err == nil {
+		if resolved, err := s.resolveCivName(ctx, *req.OpponentCiv); err == nil {
 			req.OpponentCiv = &resolved
 		}
 	}
 
 	// Create the suggestion
-	now := time.Now()
+	now := s.Clock.Now()
 	err = q.CreateSuggestion(ctx, dbgen.CreateSuggestionParams{
 		Text:            req.Text,
 		Author:          req.Author,
@@ -1717,19 +3510,231 @@ func (s *Server) HandleSubmitSuggestion(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	suggestionsSubmittedTotal.Inc()
 	span := trace.SpanFromContext(ctx)
 	span.AddEvent("suggestion_created", trace.WithAttributes(
 		attribute.String("channel", req.Channel),
 	))
 
+	writeSuggestionCreated(w, req.Channel)
+}
+
+// suggestHoneypotField is a form field hidden from people on the suggest
+// page. A submission that fills it in came from a bot.
+const suggestHoneypotField = "website"
+
+// writeSuggestionCreated writes the 201 response for a submitted suggestion
+func writeSuggestionCreated(w http.ResponseWriter, channel string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(map[string]string{
 		"message": "Suggestion submitted for review",
-		"channel": req.Channel,
+		"channel": channel,
 	})
 }
 
+// HandleGetSuggestion godoc
+// @Summary Get a suggestion by ID
+// @Description Returns a single quote suggestion, including its review status. Requires authentication as an admin or as an owner/moderator of the suggestion's channel.
+// @Tags suggestions
+// @Produce json
+// @Param id path int true "Suggestion ID"
+// @Success 200 {object} SuggestionResponse "Suggestion found"
+// @Failure 400 {string} string "Invalid suggestion ID"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Not allowed to review this channel's suggestions"
+// @Failure 404 {string} string "Suggestion not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /suggestions/{id} [get]
+func (s *Server) HandleGetSuggestion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auth := s.getAuthInfo(r)
+
+	if !auth.IsAuthenticated {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid suggestion ID", http.StatusBadRequest)
+		return
+	}
+
+	suggestion, err := dbgen.New(s.WriteDB).GetSuggestionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Suggestion not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("get suggestion", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !s.canManageChannelWithTwitch(ctx, auth.Email, auth.TwitchUsername, suggestion.Channel) {
+		RecordSecurityEvent(ctx, "permission_denied",
+			attribute.String("user.identity", auth.DisplayIdentity()),
+			attribute.String("path", r.URL.Path),
+			attribute.String("resource", "suggestion"),
+			attribute.String("channel", suggestion.Channel),
+			attribute.String("reason", "not_authorized"),
+		)
+		http.Error(w, "You don't have permission to view this suggestion", http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suggestionToResponse(suggestion))
+}
+
+// SuggestionStatusResponse is the public review status of a suggestion
+type SuggestionStatusResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// suggestionStatusMessages are the chat-friendly messages for each status
+var suggestionStatusMessages = map[string]string{
+	"pending":  "Your quote is waiting for review.",
+	"approved": "Your quote was approved!",
+	"rejected": "Your quote was not approved.",
+}
+
+// HandleSuggestionStatus godoc
+// @Summary Get the review status of a suggestion
+// @Description Returns whether a suggestion is pending, approved or rejected, for chat bots to relay to the viewer who submitted it.
+// @Description No login is needed, but the caller must identify the suggestion's channel with bot headers (Nightbot-Channel, Moobot-Channel) or the channel query param.
+// @Description Plain text by default; JSON with Accept: application/json. Plain-text errors use status 200 so bots relay them to chat.
+// @Tags suggestions
+// @Produce plain
+// @Produce json
+// @Param id path int true "Suggestion ID"
+// @Param channel query string false "Channel name (optional if bot headers present)"
+// @Success 200 {object} SuggestionStatusResponse "Suggestion status"
+// @Failure 400 {string} string "Invalid suggestion ID or unknown channel (JSON only)"
+// @Failure 404 {string} string "Suggestion not found in this channel (JSON only)"
+// @Failure 500 {string} string "Internal server error (JSON only)"
+// @Router /suggestions/{id}/status [get]
+func (s *Server) HandleSuggestionStatus(w http.ResponseWriter, r *http.Request) {
+	AddBotAttributes(r)
+	ctx := r.Context()
+
+	// Bots relay whatever text they get, so plain-text errors stay 200
+	fail := func(msg string, status int) {
+		if WantsJSON(r) {
+			http.Error(w, msg, status)
+			return
+		}
+		writeBotError(w, msg)
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		fail("Invalid suggestion ID", http.StatusBadRequest)
+		return
+	}
+
+	var channel string
+	if bc := GetBotChannel(r); bc != nil {
+		channel = bc.Name
+	}
+	if channel == "" {
+		fail("Could not determine channel - make sure your bot sends channel headers", http.StatusBadRequest)
+		return
+	}
+
+	suggestion, err := dbgen.New(s.ReadDB).GetSuggestionByID(ctx, id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.Error("get suggestion", "error", err)
+		fail("Something went wrong - try again later", http.StatusInternalServerError)
+		return
+	}
+	// Suggestions from other channels look the same as missing ones
+	if err != nil || !strings.EqualFold(suggestion.Channel, channel) {
+		fail(fmt.Sprintf("Suggestion #%d not found", id), http.StatusNotFound)
+		return
+	}
+
+	resp := SuggestionStatusResponse{
+		Status:  suggestion.Status,
+		Message: suggestionStatusMessages[suggestion.Status],
+	}
+	if WantsJSON(r) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, resp.Message)
+}
+
+// HandleAPIListSuggestions godoc
+// @Summary List suggestions for a channel
+// @Description Returns suggestions for a channel, newest first. Only pending suggestions can be listed. Requires authentication as an admin or as an owner/moderator of the channel.
+// @Tags suggestions
+// @Produce json
+// @Param channel query string true "Channel name"
+// @Param status query string false "Suggestion status (only 'pending' is supported)" default(pending)
+// @Success 200 {array} SuggestionResponse "Suggestions for the channel"
+// @Failure 400 {string} string "Missing channel or unsupported status"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Not allowed to review this channel's suggestions"
+// @Failure 500 {string} string "Internal server error"
+// @Router /suggestions [get]
+func (s *Server) HandleAPIListSuggestions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auth := s.getAuthInfo(r)
+
+	if !auth.IsAuthenticated {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	channel := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("channel")))
+	if channel == "" {
+		http.Error(w, "Missing 'channel' parameter", http.StatusBadRequest)
+		return
+	}
+	if status := r.URL.Query().Get("status"); status != "" && status != "pending" {
+		http.Error(w, "Unsupported status (only 'pending' is supported)", http.StatusBadRequest)
+		return
+	}
+
+	if !s.canManageChannelWithTwitch(ctx, auth.Email, auth.TwitchUsername, channel) {
+		RecordSecurityEvent(ctx, "permission_denied",
+			attribute.String("user.identity", auth.DisplayIdentity()),
+			attribute.String("path", r.URL.Path),
+			attribute.String("resource", "suggestion"),
+			attribute.String("channel", channel),
+			attribute.String("reason", "not_authorized"),
+		)
+		http.Error(w, "You don't have permission to view this channel's suggestions", http.StatusForbidden)
+		return
+	}
+
+	suggestions, err := dbgen.New(s.WriteDB).ListPendingSuggestionsByChannel(ctx, channel)
+	if err != nil {
+		slog.Error("list suggestions", "channel", channel, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]SuggestionResponse, 0, len(suggestions))
+	for _, sg := range suggestions {
+		resp = append(resp, suggestionToResponse(sg))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
 // HandleBotSuggestion godoc
 // @Summary Submit a quote suggestion via GET (for chat bots)
 // @Description Submit a quote suggestion using GET request. Designed for Nightbot/Moobot $(urlfetch) commands.
@@ -1740,9 +3745,7 @@ func (s *Server) HandleSubmitSuggestion(w http.ResponseWriter, r *http.Request)
 // @Param channel query string false "Channel name (optional if bot headers present)"
 // @Param author query string false "Quote author"
 // @Param civ query string false "Civilization shortname"
-// @Success 200 {string} string "Success message"
-// @Failure 400 {string} string "Missing text or channel"
-// @Failure 429 {string} string "Too many suggestions"
+// @Success 200 {string} string "Success or error message, always 200 so bots relay it to chat"
 // @Router /suggest [get]
 func (s *Server) HandleBotSuggestion(w http.ResponseWriter, r *http.Request) {
 	AddBotAttributes(r)
@@ -1754,7 +3757,25 @@ func (s *Server) HandleBotSuggestion(w http.ResponseWriter, r *http.Request) {
 		channel = bc.Name
 	}
 	if channel == "" {
-		http.Error(w, "Could not determine channel. Make sure your bot sends channel headers.", http.StatusBadRequest)
+		writeBotError(w, "Could not determine channel - make sure your bot sends channel headers")
+		return
+	}
+
+	// Some channels only accept suggestions from their moderators
+	settings, err := s.getChannelSettings(ctx, channel)
+	if err != nil {
+		slog.Error("get channel settings", "channel", channel, "error", err)
+		writeBotError(w, "Something went wrong - try again later")
+		return
+	}
+	if settings.RequireModForSuggestions && !isBotModerator(r) {
+		RecordSecurityEvent(ctx, "permission_denied",
+			attribute.String("path", r.URL.Path),
+			attribute.String("resource", "bot_suggestion"),
+			attribute.String("channel", channel),
+			attribute.String("reason", "moderator_required"),
+		)
+		writeBotError(w, "Only moderators can suggest quotes in this channel")
 		return
 	}
 
@@ -1767,17 +3788,22 @@ func (s *Server) HandleBotSuggestion(w http.ResponseWriter, r *http.Request) {
 	// Get quote text from query param
 	text := strings.TrimSpace(r.URL.Query().Get("text"))
 	if text == "" {
-		http.Error(w, "Usage: !addquote <quote text>", http.StatusBadRequest)
+		writeBotError(w, "Usage: !addquote <quote text>")
 		return
 	}
 
 	// Validate text length
-	if len(text) < 3 {
-		http.Error(w, "Quote too short (min 3 characters)", http.StatusBadRequest)
+	if err := ValidateQuoteTextMin(text, s.Config.MinQuoteTextLen); err != nil {
+		var ve ValidationError
+		if errors.As(err, &ve) {
+			writeBotError(w, ve.Field+" "+ve.Message)
+		} else {
+			writeBotError(w, err.Error())
+		}
 		return
 	}
 	if len(text) > 500 {
-		http.Error(w, "Quote too long (max 500 characters)", http.StatusBadRequest)
+		writeBotError(w, "Quote too long - max 500 characters")
 		return
 	}
 
@@ -1791,24 +3817,25 @@ func (s *Server) HandleBotSuggestion(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Rate limit suggestions per channel
-	q := dbgen.New(s.DB)
-	cutoff := time.Now().Add(-s.Config.SuggestionRateInterval)
+	q := dbgen.New(s.WriteDB)
+	cutoff := s.Clock.Now().Add(-s.Config.SuggestionRateInterval)
 	count, err := q.CountRecentSuggestionsByChannel(ctx, dbgen.CountRecentSuggestionsByChannelParams{
 		Channel:     channel,
 		SubmittedAt: cutoff,
 	})
 	if err != nil {
 		slog.Error("count recent suggestions", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeBotError(w, "Something went wrong - try again later")
 		return
 	}
 	if count >= int64(s.Config.SuggestionRateLimit) {
+		rateLimitedTotal.WithLabelValues("suggestion").Inc()
 		RecordSecurityEvent(ctx, "suggestion_rate_limited",
 			attribute.String("channel", channel),
 			attribute.Int64("suggestion_count", count),
 			attribute.String("path", r.URL.Path),
 		)
-		fmt.Fprint(w, "Too many suggestions for this channel. Try again later.")
+		writeBotError(w, "Too many suggestions for this channel - try again later")
 		return
 	}
 
@@ -1819,7 +3846,7 @@ func (s *Server) HandleBotSuggestion(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create the suggestion
-	now := time.Now()
+	now := s.Clock.Now()
 	err = q.CreateSuggestion(ctx, dbgen.CreateSuggestionParams{
 		Text:            text,
 		Author:          authorPtr,
@@ -1832,10 +3859,11 @@ func (s *Server) HandleBotSuggestion(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		slog.Error("create suggestion", "error", err)
-		http.Error(w, "Failed to submit quote", http.StatusInternalServerError)
+		writeBotError(w, "Could not submit quote - try again later")
 		return
 	}
 
+	suggestionsSubmittedTotal.Inc()
 	span := trace.SpanFromContext(ctx)
 	span.AddEvent("bot_suggestion_created", trace.WithAttributes(
 		attribute.String("channel", channel),
@@ -1876,16 +3904,24 @@ func (s *Server) HandleListSuggestions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	// Viewed suggestions are hidden unless explicitly requested
+	includeViewed := r.URL.Query().Get("include_viewed") == "true"
+
+	q := dbgen.New(s.WriteDB)
 	var suggestions []dbgen.QuoteSuggestion
 	var err error
 
-	if auth.IsAdmin {
+	switch {
+	case auth.IsAdmin && includeViewed:
 		// Admins see all suggestions
 		suggestions, err = q.ListPendingSuggestions(ctx)
-	} else {
+	case auth.IsAdmin:
+		suggestions, err = q.ListUnviewedPendingSuggestions(ctx)
+	case includeViewed:
 		// Channel owners/moderators see only their channel's suggestions
 		suggestions, err = q.ListPendingSuggestionsByChannel(ctx, manageableChannels[0])
+	default:
+		suggestions, err = q.ListUnviewedPendingSuggestionsByChannel(ctx, manageableChannels[0])
 	}
 	if err != nil {
 		slog.Error("list suggestions", "error", err)
@@ -1893,6 +3929,17 @@ func (s *Server) HandleListSuggestions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	unreadCount := 0
+	duplicates := make(map[int64]int64)
+	for _, sg := range suggestions {
+		if sg.ViewedAt == nil {
+			unreadCount++
+		}
+		if dupID := s.findDuplicateQuote(ctx, sg.Text, &sg.Channel); dupID != 0 {
+			duplicates[sg.ID] = dupID
+		}
+	}
+
 	// Determine logout URL based on auth method
 	logoutURL := "/__exe.dev/logout"
 	if auth.AuthMethod == "twitch" {
@@ -1904,27 +3951,166 @@ func (s *Server) HandleListSuggestions(w http.ResponseWriter, r *http.Request) {
 		UserEmail       string
 		LogoutURL       string
 		Suggestions     []dbgen.QuoteSuggestion
+		// Duplicates maps suggestion IDs to an existing matching quote
+		Duplicates      map[int64]int64
+		Error           string
 		IsAdmin         bool
 		IsOwner         bool
 		IsAuthenticated bool
 		IsPublicPage    bool
 		OwnedChannels   []string
+		IncludeViewed   bool
+		UnreadCount     int
+		navBadges
 	}{
 		Hostname:        s.Hostname,
 		UserEmail:       auth.DisplayIdentity(),
 		LogoutURL:       logoutURL,
 		Suggestions:     suggestions,
+		Duplicates:      duplicates,
+		Error:           r.URL.Query().Get("error"),
+		IncludeViewed:   includeViewed,
+		UnreadCount:     unreadCount,
 		IsAdmin:         auth.IsAdmin,
 		IsOwner:         isOwner,
 		IsAuthenticated: true,
 		IsPublicPage:    false,
 		OwnedChannels:   manageableChannels,
+		navBadges:       s.navBadgesFor(r.Context(), auth.Email),
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.renderTemplate(w, "suggestions.html", data); err != nil {
+		slog.Warn("render template", "url", r.URL.Path, "error", err)
+	}
+}
+
+// HandleMarkSuggestionViewed records that a reviewer has seen a suggestion
+// without approving or rejecting it.
+func (s *Server) HandleMarkSuggestionViewed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auth := s.getAuthInfo(r)
+
+	if !auth.IsAuthenticated {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	q := dbgen.New(s.WriteDB)
+
+	suggestion, err := q.GetSuggestionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Suggestion not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("get suggestion", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// Check permission: must be admin, owner, or moderator for this channel
+	if !s.canManageChannelWithTwitch(ctx, auth.Email, auth.TwitchUsername, suggestion.Channel) {
+		RecordSecurityEvent(ctx, "permission_denied",
+			attribute.String("user.identity", auth.DisplayIdentity()),
+			attribute.String("path", r.URL.Path),
+			attribute.String("resource", "suggestion"),
+			attribute.Int64("suggestion.id", id),
+			attribute.String("channel", suggestion.Channel),
+			attribute.String("reason", "not_authorized"),
+		)
+		http.Error(w, "You don't have permission to review suggestions for this channel", http.StatusForbidden)
+		return
+	}
+
+	now := s.Clock.Now()
+	viewer := auth.DisplayIdentity()
+	err = q.MarkSuggestionViewed(ctx, dbgen.MarkSuggestionViewedParams{
+		ViewerEmail: &viewer,
+		ViewedAt:    &now,
+		ID:          id,
+	})
+	if err != nil {
+		slog.Error("mark suggestion viewed", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+const (
+	defaultPurgeOlderThanDays = 90
+	minPurgeOlderThanDays     = 7
+)
+
+// purgeableSuggestionStatuses are the terminal states eligible for purging.
+var purgeableSuggestionStatuses = []string{"rejected", "expired"}
+
+// HandlePurgeSuggestions deletes rejected and expired suggestions reviewed
+// more than ?older_than_days ago (default 90, minimum 7). Admin only.
+func (s *Server) HandlePurgeSuggestions(w http.ResponseWriter, r *http.Request) {
+	userEmail := getAuthEmail(r)
+	ctx := r.Context()
+
+	if userEmail == "" {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if !s.isAdmin(userEmail) {
+		RecordSecurityEvent(ctx, "admin_required",
+			attribute.String("user.email", userEmail),
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Admin access required", http.StatusForbidden)
+		return
+	}
+
+	days := defaultPurgeOlderThanDays
+	if v := r.URL.Query().Get("older_than_days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "older_than_days must be a number", http.StatusBadRequest)
+			return
+		}
+		days = n
+	}
+	if days < minPurgeOlderThanDays {
+		http.Error(w, fmt.Sprintf("older_than_days must be at least %d", minPurgeOlderThanDays), http.StatusBadRequest)
+		return
 	}
 
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := s.renderTemplate(w, "suggestions.html", data); err != nil {
-		slog.Warn("render template", "url", r.URL.Path, "error", err)
+	cutoff := s.Clock.Now().AddDate(0, 0, -days)
+	q := dbgen.New(s.WriteDB)
+	purged, err := q.PurgeSuggestions(ctx, dbgen.PurgeSuggestionsParams{
+		Statuses:   purgeableSuggestionStatuses,
+		ReviewedAt: &cutoff,
+	})
+	if err != nil {
+		slog.Error("purge suggestions", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
 	}
+
+	slog.Info("purged suggestions", "count", purged, "older_than_days", days, "by", userEmail)
+	s.Markers.CreateBulkOperationMarker("Suggestion purge", int(purged))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int64{"purged": purged})
 }
 
 func (s *Server) HandleApproveSuggestion(w http.ResponseWriter, r *http.Request) {
@@ -1946,7 +4132,7 @@ func (s *Server) HandleApproveSuggestion(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 
 	// Get the suggestion
 	suggestion, err := q.GetSuggestionByID(ctx, id)
@@ -1974,10 +4160,18 @@ func (s *Server) HandleApproveSuggestion(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Refuse likely duplicates unless the reviewer chose "Approve anyway"
+	if r.FormValue("force") != "1" {
+		if dupID := s.findDuplicateQuote(ctx, suggestion.Text, &suggestion.Channel); dupID != 0 {
+			http.Redirect(w, r, "/suggestions?error="+url.QueryEscape(duplicateQuoteMessage(dupID)), http.StatusSeeOther)
+			return
+		}
+	}
+
 	// Create the quote from the suggestion
-	now := time.Now()
+	now := s.Clock.Now()
 	reviewerIdentity := auth.DisplayIdentity()
-	err = q.CreateQuote(ctx, dbgen.CreateQuoteParams{
+	_, err = q.CreateQuote(ctx, dbgen.CreateQuoteParams{
 		UserID:         auth.UserID,
 		CreatedByEmail: &reviewerIdentity,
 		Text:           suggestion.Text,
@@ -2028,7 +4222,7 @@ func (s *Server) HandleRejectSuggestion(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 
 	// Get the suggestion to check permission
 	suggestion, err := q.GetSuggestionByID(ctx, id)
@@ -2056,7 +4250,7 @@ func (s *Server) HandleRejectSuggestion(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	now := time.Now()
+	now := s.Clock.Now()
 	reviewerIdentity := auth.DisplayIdentity()
 
 	err = q.RejectSuggestion(ctx, dbgen.RejectSuggestionParams{
@@ -2073,6 +4267,138 @@ func (s *Server) HandleRejectSuggestion(w http.ResponseWriter, r *http.Request)
 	http.Redirect(w, r, "/suggestions", http.StatusSeeOther)
 }
 
+// BulkRejectRequest is the body for rejecting several suggestions at once
+type BulkRejectRequest struct {
+	IDs    []int64 `json:"ids"`
+	Reason string  `json:"reason"`
+}
+
+// BulkRejectError explains why one suggestion in a bulk rejection was skipped
+type BulkRejectError struct {
+	ID    int64  `json:"id"`
+	Error string `json:"error"`
+}
+
+// BulkRejectResponse reports the outcome of a bulk rejection
+type BulkRejectResponse struct {
+	Rejected int               `json:"rejected"`
+	Errors   []BulkRejectError `json:"errors"`
+}
+
+// maxBulkRejectReasonLen caps the shared rejection reason
+const maxBulkRejectReasonLen = 200
+
+// HandleBatchRejectSuggestions rejects several suggestions with one shared
+// reason. Suggestions that don't exist or belong to a channel the caller
+// can't manage are skipped and reported in errors; the rest are rejected
+// in a single transaction.
+func (s *Server) HandleBatchRejectSuggestions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auth := s.getAuthInfo(r)
+
+	if !auth.IsAuthenticated {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req BulkRejectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if len(req.IDs) == 0 {
+		http.Error(w, "No suggestions selected", http.StatusBadRequest)
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if err := ValidateLength("reason", req.Reason, maxBulkRejectReasonLen); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Check every suggestion before starting the transaction; the
+	// permission lookups need their own connections.
+	resp := BulkRejectResponse{Errors: []BulkRejectError{}}
+	var allowed []int64
+	q := dbgen.New(s.WriteDB)
+	for _, id := range req.IDs {
+		suggestion, err := q.GetSuggestionByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				resp.Errors = append(resp.Errors, BulkRejectError{ID: id, Error: "not found"})
+				continue
+			}
+			slog.Error("get suggestion", "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if !s.canManageChannelWithTwitch(ctx, auth.Email, auth.TwitchUsername, suggestion.Channel) {
+			RecordSecurityEvent(ctx, "permission_denied",
+				attribute.String("user.identity", auth.DisplayIdentity()),
+				attribute.String("path", r.URL.Path),
+				attribute.String("resource", "suggestion"),
+				attribute.Int64("suggestion.id", id),
+				attribute.String("channel", suggestion.Channel),
+				attribute.String("reason", "not_authorized"),
+			)
+			resp.Errors = append(resp.Errors, BulkRejectError{ID: id, Error: "permission denied"})
+			continue
+		}
+		allowed = append(allowed, id)
+	}
+
+	if len(allowed) > 0 {
+		now := s.Clock.Now()
+		reviewerIdentity := auth.DisplayIdentity()
+		var reasonPtr *string
+		if req.Reason != "" {
+			reasonPtr = &req.Reason
+		}
+
+		tx, err := s.WriteDB.BeginTx(ctx, nil)
+		if err != nil {
+			slog.Error("bulk reject begin tx", "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		defer tx.Rollback()
+
+		txq := dbgen.New(tx)
+		for _, id := range allowed {
+			err := txq.RejectSuggestionWithReason(ctx, dbgen.RejectSuggestionWithReasonParams{
+				ReviewedBy:   &reviewerIdentity,
+				ReviewedAt:   &now,
+				RejectReason: reasonPtr,
+				ID:           id,
+			})
+			if err != nil {
+				if rbErr := tx.Rollback(); rbErr != nil {
+					slog.Error("bulk reject rollback", "error", rbErr)
+				}
+				slog.Error("bulk reject suggestion", "id", id, "error", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			slog.Error("bulk reject commit", "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		resp.Rejected = len(allowed)
+		s.Markers.CreateBulkOperationMarker("Bulk reject suggestions", resp.Rejected)
+		slog.Info("bulk reject completed", "count", resp.Rejected, "skipped", len(resp.Errors),
+			"reason", req.Reason, "user", reviewerIdentity)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
 // Authorization helpers
 
 func (s *Server) isAdmin(email string) bool {
@@ -2080,7 +4406,7 @@ func (s *Server) isAdmin(email string) bool {
 }
 
 func (s *Server) getOwnedChannels(ctx context.Context, email string) ([]string, error) {
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	return q.GetChannelsByOwner(ctx, strings.ToLower(strings.TrimSpace(email)))
 }
 
@@ -2114,6 +4440,16 @@ func (s *Server) canManageChannelWithTwitch(ctx context.Context, email, twitchUs
 	return s.canViewNightbotChannelWithTwitch(ctx, email, twitchUsername, channel)
 }
 
+// containsFold reports whether channels contains channel, ignoring case.
+func containsFold(channels []string, channel string) bool {
+	for _, ch := range channels {
+		if strings.EqualFold(ch, channel) {
+			return true
+		}
+	}
+	return false
+}
+
 // getManageableChannels returns channels user can manage quotes for (owned + moderated).
 func (s *Server) getManageableChannels(ctx context.Context, email string) ([]string, error) {
 	return s.getManageableChannelsWithTwitch(ctx, email, "")
@@ -2152,7 +4488,7 @@ func (s *Server) canViewNightbotChannelWithTwitch(ctx context.Context, email, tw
 		}
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 
 	// Check if channel moderator by email
 	if email != "" {
@@ -2200,7 +4536,7 @@ func (s *Server) getViewableNightbotChannelsWithTwitch(ctx context.Context, emai
 		}
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 
 	// Add moderated channels (by email)
 	if email != "" {
@@ -2233,6 +4569,9 @@ func (s *Server) getViewableNightbotChannelsWithTwitch(ctx context.Context, emai
 
 // Admin handlers for channel owner management
 
+// ownersPageSize is the number of channel owners shown per admin page.
+const ownersPageSize = 50
+
 func (s *Server) HandleListChannelOwners(w http.ResponseWriter, r *http.Request) {
 	userEmail := getAuthEmail(r)
 	ctx := r.Context()
@@ -2253,9 +4592,52 @@ func (s *Server) HandleListChannelOwners(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "Admin access required", http.StatusForbidden)
 		return
 	}
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
+
+	// Parse pagination and search params
+	page := 1
+	if p := r.URL.Query().Get("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+	searchChannel := strings.TrimSpace(r.URL.Query().Get("channel"))
+	searchEmail := strings.TrimSpace(r.URL.Query().Get("email"))
+
+	// nil disables a filter; sqlc types these as interface{}. Both are
+	// substring matches, so wildcards in them are literal.
+	var channelFilter, emailFilter interface{}
+	if searchChannel != "" {
+		channelFilter = likeEscape(searchChannel)
+	}
+	if searchEmail != "" {
+		emailFilter = likeEscape(searchEmail)
+	}
+
+	total, err := q.CountSearchChannelOwners(ctx, dbgen.CountSearchChannelOwnersParams{
+		Channel: channelFilter,
+		Email:   emailFilter,
+	})
+	if err != nil {
+		slog.Error("count channel owners", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
 
-	owners, err := q.ListAllChannelOwners(ctx)
+	totalPages := int((total + ownersPageSize - 1) / ownersPageSize)
+	if totalPages < 1 {
+		totalPages = 1
+	}
+	if page > totalPages {
+		page = totalPages
+	}
+
+	owners, err := q.SearchChannelOwners(ctx, dbgen.SearchChannelOwnersParams{
+		Channel: channelFilter,
+		Email:   emailFilter,
+		Limit:   ownersPageSize,
+		Offset:  int64((page - 1) * ownersPageSize),
+	})
 	if err != nil {
 		slog.Error("list channel owners", "error", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -2278,17 +4660,34 @@ func (s *Server) HandleListChannelOwners(w http.ResponseWriter, r *http.Request)
 		IsAdmin         bool
 		IsAuthenticated bool
 		IsPublicPage    bool
+		// Search and pagination
+		SearchChannel string
+		SearchEmail   string
+		TotalOwners   int64
+		Page          int
+		TotalPages    int
+		HasPrev       bool
+		HasNext       bool
+		navBadges
 	}{
 		Hostname:        s.Hostname,
 		UserEmail:       userEmail,
 		LogoutURL:       "/__exe.dev/logout",
 		Owners:          owners,
 		Channels:        channels,
+		SearchChannel:   searchChannel,
+		SearchEmail:     searchEmail,
+		TotalOwners:     total,
+		Page:            page,
+		TotalPages:      totalPages,
+		HasPrev:         page > 1,
+		HasNext:         page < totalPages,
 		Success:         r.URL.Query().Get("success"),
 		Error:           r.URL.Query().Get("error"),
 		IsAdmin:         true,
 		IsAuthenticated: true,
 		IsPublicPage:    false,
+		navBadges:       s.navBadgesFor(r.Context(), userEmail),
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -2330,7 +4729,7 @@ func (s *Server) HandleAddChannelOwner(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/admin/owners?error=Channel+and+email+are+required", http.StatusSeeOther)
 		return
 	}
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 
 	err := q.AddChannelOwner(ctx, dbgen.AddChannelOwnerParams{
 		Channel:   channel,
@@ -2382,7 +4781,7 @@ func (s *Server) HandleRemoveChannelOwner(w http.ResponseWriter, r *http.Request
 		http.Redirect(w, r, "/admin/owners?error=Channel+and+email+are+required", http.StatusSeeOther)
 		return
 	}
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 
 	err := q.RemoveChannelOwner(ctx, dbgen.RemoveChannelOwnerParams{
 		Channel:   channel,
@@ -2400,6 +4799,211 @@ func (s *Server) HandleRemoveChannelOwner(w http.ResponseWriter, r *http.Request
 	http.Redirect(w, r, "/admin/owners?success=Owner+removed", http.StatusSeeOther)
 }
 
+// ChannelOwnerResponse is a channel owner as returned by the admin API
+type ChannelOwnerResponse struct {
+	Channel   string    `json:"channel"`
+	UserEmail string    `json:"user_email"`
+	InvitedBy string    `json:"invited_by"`
+	AddedAt   time.Time `json:"added_at"`
+}
+
+// ChannelOwnerRequest is the body for adding or removing a channel owner
+type ChannelOwnerRequest struct {
+	Channel string `json:"channel"`
+	Email   string `json:"email"`
+}
+
+// requireAdminAPI checks the caller is an admin. On failure it writes a 401
+// or 403 and returns false.
+func (s *Server) requireAdminAPI(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userEmail := getAuthEmail(r)
+	ctx := r.Context()
+
+	if userEmail == "" {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return "", false
+	}
+
+	if !s.isAdmin(userEmail) {
+		RecordSecurityEvent(ctx, "admin_required",
+			attribute.String("user.email", userEmail),
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Admin access required", http.StatusForbidden)
+		return "", false
+	}
+
+	return userEmail, true
+}
+
+// decodeChannelOwnerRequest reads and normalizes a ChannelOwnerRequest. On
+// failure it writes a 400 and returns false.
+func decodeChannelOwnerRequest(w http.ResponseWriter, r *http.Request) (ChannelOwnerRequest, bool) {
+	var req ChannelOwnerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return req, false
+	}
+	req.Channel = strings.TrimSpace(strings.ToLower(req.Channel))
+	req.Email = strings.TrimSpace(strings.ToLower(req.Email))
+	if req.Channel == "" || req.Email == "" {
+		http.Error(w, "Channel and email are required", http.StatusBadRequest)
+		return req, false
+	}
+	return req, true
+}
+
+// HandleAPIListChannelOwners godoc
+// @Summary List channel owners
+// @Description Returns channel owners ordered by channel and email. Requires admin authentication.
+// @Tags admin
+// @Produce json
+// @Param channel query string false "Only owners of this channel"
+// @Success 200 {array} ChannelOwnerResponse "Channel owners"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Admin access required"
+// @Failure 500 {string} string "Internal server error"
+// @Router /admin/owners [get]
+func (s *Server) HandleAPIListChannelOwners(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.requireAdminAPI(w, r); !ok {
+		return
+	}
+	ctx := r.Context()
+	q := dbgen.New(s.WriteDB)
+
+	var owners []dbgen.ChannelOwner
+	var err error
+	if channel := strings.TrimSpace(strings.ToLower(r.URL.Query().Get("channel"))); channel != "" {
+		owners, err = q.ListChannelOwnersByChannel(ctx, channel)
+	} else {
+		owners, err = q.ListAllChannelOwners(ctx)
+	}
+	if err != nil {
+		slog.Error("list channel owners", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]ChannelOwnerResponse, 0, len(owners))
+	for _, o := range owners {
+		resp = append(resp, ChannelOwnerResponse{
+			Channel:   o.Channel,
+			UserEmail: o.UserEmail,
+			InvitedBy: o.InvitedBy,
+			AddedAt:   o.InvitedAt,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleAPIAddChannelOwner godoc
+// @Summary Add a channel owner
+// @Description Grants a user ownership of a channel. Requires admin authentication.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param owner body ChannelOwnerRequest true "Channel and owner email"
+// @Success 201 {object} ChannelOwnerResponse "Owner added"
+// @Failure 400 {string} string "Invalid JSON or missing channel/email"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Admin access required"
+// @Failure 409 {string} string "User already owns this channel"
+// @Failure 500 {string} string "Internal server error"
+// @Router /admin/owners [post]
+func (s *Server) HandleAPIAddChannelOwner(w http.ResponseWriter, r *http.Request) {
+	userEmail, ok := s.requireAdminAPI(w, r)
+	if !ok {
+		return
+	}
+	req, ok := decodeChannelOwnerRequest(w, r)
+	if !ok {
+		return
+	}
+
+	err := dbgen.New(s.WriteDB).AddChannelOwner(r.Context(), dbgen.AddChannelOwnerParams{
+		Channel:   req.Channel,
+		UserEmail: req.Email,
+		InvitedBy: userEmail,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint") {
+			http.Error(w, "User already owns this channel", http.StatusConflict)
+			return
+		}
+		slog.Error("add channel owner", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	s.Markers.CreateConfigChangeMarker(fmt.Sprintf("Channel owner added: %s for #%s", req.Email, req.Channel))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(ChannelOwnerResponse{
+		Channel:   req.Channel,
+		UserEmail: req.Email,
+		InvitedBy: userEmail,
+		AddedAt:   s.Clock.Now().UTC().Truncate(time.Second),
+	})
+}
+
+// HandleAPIRemoveChannelOwner godoc
+// @Summary Remove a channel owner
+// @Description Revokes a user's ownership of a channel. Requires admin authentication.
+// @Tags admin
+// @Accept json
+// @Param owner body ChannelOwnerRequest true "Channel and owner email"
+// @Success 204 "Owner removed"
+// @Failure 400 {string} string "Invalid JSON or missing channel/email"
+// @Failure 401 {string} string "Authentication required"
+// @Failure 403 {string} string "Admin access required"
+// @Failure 404 {string} string "User does not own this channel"
+// @Failure 500 {string} string "Internal server error"
+// @Router /admin/owners [delete]
+func (s *Server) HandleAPIRemoveChannelOwner(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.requireAdminAPI(w, r); !ok {
+		return
+	}
+	req, ok := decodeChannelOwnerRequest(w, r)
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+	q := dbgen.New(s.WriteDB)
+
+	isOwner, err := q.IsChannelOwner(ctx, dbgen.IsChannelOwnerParams{
+		Channel:   req.Channel,
+		UserEmail: req.Email,
+	})
+	if err != nil {
+		slog.Error("check channel owner", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if !isOwner {
+		http.Error(w, "User does not own this channel", http.StatusNotFound)
+		return
+	}
+
+	if err := q.RemoveChannelOwner(ctx, dbgen.RemoveChannelOwnerParams{
+		Channel:   req.Channel,
+		UserEmail: req.Email,
+	}); err != nil {
+		slog.Error("remove channel owner", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	s.Markers.CreateConfigChangeMarker(fmt.Sprintf("Channel owner removed: %s from #%s", req.Email, req.Channel))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // HandleHelp serves the help/documentation page
 func (s *Server) HandleHelp(w http.ResponseWriter, r *http.Request) {
 	data := struct {
@@ -2426,8 +5030,15 @@ func (s *Server) HandleHelp(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// HandleChangelog serves the changelog page
+// HandleChangelog serves the changelog page, or the changelog as JSON when
+// the client asks for it via Accept.
 func (s *Server) HandleChangelog(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Vary", "Accept")
+	if WantsJSON(r) {
+		s.HandleAPIChangelog(w, r)
+		return
+	}
+
 	data := struct {
 		Hostname        string
 		Changelog       []ChangelogEntry
@@ -2439,7 +5050,7 @@ func (s *Server) HandleChangelog(w http.ResponseWriter, r *http.Request) {
 		UserEmail       string
 	}{
 		Hostname:        s.Hostname,
-		Changelog:       Changelog,
+		Changelog:       changelogNewestFirst(),
 		IsPublicPage:    true,
 		IsAuthenticated: false,
 		IsAdmin:         false,
@@ -2454,9 +5065,22 @@ func (s *Server) HandleChangelog(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// HandleAPIChangelog godoc
+// @Summary Get the changelog
+// @Description Returns user-facing changes, newest first.
+// @Tags meta
+// @Produce json
+// @Success 200 {array} ChangelogEntry "Changelog entries"
+// @Router /changelog [get]
+func (s *Server) HandleAPIChangelog(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(changelogNewestFirst())
+}
+
 func (s *Server) HandleSuggestForm(w http.ResponseWriter, r *http.Request) {
+	setAuthVaryHeaders(w)
 	ctx := r.Context()
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.ReadDB)
 
 	civs, err := q.ListCivs(ctx)
 	if err != nil {
@@ -2465,24 +5089,124 @@ func (s *Server) HandleSuggestForm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Pre-fill from bot context so links from chat commands land ready to submit
+	var defaultChannel string
+	if botChannel := GetBotChannel(r); botChannel != nil {
+		defaultChannel = botChannel.Name
+	}
+
+	// Optionally show how much of the channel's suggestion budget is used.
+	// Failure only hides the notice.
+	var limitUsed int64
+	showLimit := false
+	if r.URL.Query().Get("check_limit") == "true" && defaultChannel != "" {
+		limitUsed, err = q.CountRecentSuggestionsByChannel(ctx, dbgen.CountRecentSuggestionsByChannelParams{
+			Channel:     defaultChannel,
+			SubmittedAt: s.Clock.Now().Add(-s.Config.SuggestionRateInterval),
+		})
+		if err != nil {
+			slog.Warn("count recent suggestions", "channel", defaultChannel, "error", err)
+		} else {
+			showLimit = true
+		}
+	}
+	limitRemaining := max(s.Config.SuggestionRateLimit-int(limitUsed), 0)
+	limitWindow := "this hour"
+	if s.Config.SuggestionRateInterval != time.Hour {
+		limitWindow = "in the last " + s.Config.SuggestionRateInterval.String()
+	}
+
+	// Signed-in owners pick from their channels instead of typing one. A
+	// link for a channel they don't own keeps the free-text input so the
+	// channel isn't silently swapped.
+	userEmail := getAuthEmail(r)
+	var ownedChannels []string
+	isOwner := false
+	if userEmail != "" {
+		channels, err := s.getOwnedChannels(ctx, userEmail)
+		if err != nil {
+			slog.Warn("get owned channels", "error", err)
+		}
+		isOwner = len(channels) > 0
+		owned := defaultChannel == ""
+		for _, ch := range channels {
+			if strings.EqualFold(ch, defaultChannel) {
+				defaultChannel = ch
+				owned = true
+			}
+		}
+		if owned {
+			ownedChannels = channels
+		}
+	}
+
+	type civOption struct {
+		Name      string
+		Shortname string
+	}
+	options := make([]civOption, 0, len(civs))
+	for _, c := range civs {
+		opt := civOption{Name: c.Name}
+		if c.Shortname != nil {
+			opt.Shortname = *c.Shortname
+		}
+		options = append(options, opt)
+	}
+	// Accept either a shortname or full name; unknown civs are ignored
+	resolveCiv := func(v string) string {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return ""
+		}
+		for _, opt := range options {
+			if strings.EqualFold(opt.Shortname, v) || strings.EqualFold(opt.Name, v) {
+				return opt.Shortname
+			}
+		}
+		return ""
+	}
+
 	data := struct {
-		Hostname        string
-		Civs            []dbgen.Civilization
-		IsPublicPage    bool
-		IsAuthenticated bool
-		IsAdmin         bool
-		LoginURL        string
-		LogoutURL       string
-		UserEmail       string
+		Hostname           string
+		Civs               []civOption
+		DefaultChannel     string
+		OwnedChannels      []string
+		DefaultCiv         string
+		DefaultOpponentCiv string
+		// Suggestion rate limit, only set when ?check_limit=true
+		ShowSuggestionLimit      bool
+		SuggestionLimit          int
+		SuggestionLimitUsed      int64
+		SuggestionLimitRemaining int
+		SuggestionLimitWindow    string
+		IsPublicPage             bool
+		IsAuthenticated          bool
+		IsAdmin                  bool
+		IsOwner                  bool
+		LoginURL                 string
+		LogoutURL                string
+		UserEmail                string
+		navBadges
 	}{
-		Hostname:        s.Hostname,
-		Civs:            civs,
-		IsPublicPage:    true,
-		IsAuthenticated: false,
-		IsAdmin:         false,
-		LoginURL:        loginURLForRequest(r),
-		LogoutURL:       "/__exe.dev/logout",
-		UserEmail:       "",
+		Hostname:                 s.Hostname,
+		Civs:                     options,
+		DefaultChannel:           defaultChannel,
+		OwnedChannels:            ownedChannels,
+		DefaultCiv:               resolveCiv(r.URL.Query().Get("civ")),
+		DefaultOpponentCiv:       resolveCiv(r.URL.Query().Get("vs")),
+		ShowSuggestionLimit:      showLimit,
+		SuggestionLimit:          s.Config.SuggestionRateLimit,
+		SuggestionLimitUsed:      limitUsed,
+		SuggestionLimitRemaining: limitRemaining,
+		SuggestionLimitWindow:    limitWindow,
+		IsPublicPage:             true,
+		IsAuthenticated:          userEmail != "",
+		IsAdmin:                  s.isAdmin(userEmail),
+		IsOwner:                  isOwner,
+		LoginURL:                 loginURLForRequest(r),
+		LogoutURL:                "/__exe.dev/logout",
+		UserEmail:                userEmail,
+		navBadges:                s.navBadgesFor(ctx, userEmail),
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -1,10 +1,15 @@
 package srv
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -98,7 +103,7 @@ func TestQuotesToViews(t *testing.T) {
 		{ID: 2, Text: "No author", Author: nil, Civilization: nil, OpponentCiv: nil},
 	}
 
-	result := quotesToViews(input, "")
+	result := quotesToViews(input, "", time.Now())
 
 	if len(result) != 2 {
 		t.Fatalf("expected 2 views, got %d", len(result))
@@ -120,34 +125,61 @@ func TestQuotesToViews(t *testing.T) {
 }
 
 func TestFormatTimeAgo(t *testing.T) {
-	now := time.Now()
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	// Clocks in New York spring forward at 2am on March 10, 2024
+	dstNow := time.Date(2024, time.March, 10, 12, 0, 0, 0, newYork)
 
 	tests := []struct {
 		name     string
 		time     time.Time
+		now      time.Time
 		expected string
 	}{
-		{"just now", now.Add(-30 * time.Second), "just now"},
-		{"1 minute ago", now.Add(-1 * time.Minute), "1 minute ago"},
-		{"5 minutes ago", now.Add(-5 * time.Minute), "5 minutes ago"},
-		{"59 minutes ago", now.Add(-59 * time.Minute), "59 minutes ago"},
-		{"1 hour ago", now.Add(-1 * time.Hour), "1 hour ago"},
-		{"2 hours ago", now.Add(-2 * time.Hour), "2 hours ago"},
-		{"23 hours ago", now.Add(-23 * time.Hour), "23 hours ago"},
-		{"yesterday", now.Add(-25 * time.Hour), "yesterday"},
-		{"2 days ago", now.Add(-50 * time.Hour), "2 days ago"},
-		{"6 days ago", now.Add(-6 * 24 * time.Hour), "6 days ago"},
-		{"old date", now.Add(-30 * 24 * time.Hour), now.Add(-30 * 24 * time.Hour).Format("Jan 2, 2006")},
+		{"just now", now.Add(-30 * time.Second), now, "just now"},
+		{"future time", now.Add(time.Hour), now, "just now"},
+		{"1 minute ago", now.Add(-1 * time.Minute), now, "1 minute ago"},
+		{"2 minutes ago", now.Add(-2 * time.Minute), now, "2 minutes ago"},
+		{"59 minutes ago", now.Add(-59 * time.Minute), now, "59 minutes ago"},
+		{"1 hour ago", now.Add(-1 * time.Hour), now, "1 hour ago"},
+		{"2 hours ago", now.Add(-2 * time.Hour), now, "2 hours ago"},
+		{"23 hours ago", now.Add(-23 * time.Hour), now, "23 hours ago"},
+		{"yesterday", now.Add(-24 * time.Hour), now, "yesterday"},
+		{"2 days ago", now.Add(-50 * time.Hour), now, "2 days ago"},
+		{"6 days ago", now.Add(-6 * 24 * time.Hour), now, "6 days ago"},
+		{"7 days shows the date", now.Add(-7 * 24 * time.Hour), now, "Feb 23, 2024"},
+		{"distant past", time.Date(2019, time.July, 4, 9, 0, 0, 0, time.UTC), now, "Jul 4, 2019"},
+		// Feb 29 counts as a day, so two calendar days back is two days ago
+		{"across leap day", time.Date(2024, time.February, 28, 12, 0, 0, 0, time.UTC), now, "2 days ago"},
+		{"leap day itself", time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC), now, "yesterday"},
+		// Noon to noon across the spring-forward is only 23 elapsed hours
+		{"across DST change", time.Date(2024, time.March, 9, 12, 0, 0, 0, newYork), dstNow, "23 hours ago"},
+		{"8 days across DST change", time.Date(2024, time.March, 2, 12, 0, 0, 0, newYork), dstNow, "Mar 2, 2024"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatTimeAgo(tt.time)
+			result := formatTimeAgoFrom(tt.time, tt.now)
 			if result != tt.expected {
-				t.Errorf("formatTimeAgo(%v) = %q, want %q", tt.time, result, tt.expected)
+				t.Errorf("formatTimeAgoFrom(%v, %v) = %q, want %q", tt.time, tt.now, result, tt.expected)
 			}
 		})
 	}
+
+	t.Run("uses the server clock", func(t *testing.T) {
+		server := &Server{Clock: &fakeClock{now: now}}
+		for ago, want := range map[time.Duration]string{
+			5 * time.Minute: "5 minutes ago",
+			24 * time.Hour:  "yesterday",
+		} {
+			if got := server.formatTimeAgo(now.Add(-ago)); got != want {
+				t.Errorf("formatTimeAgo(now - %v) = %q, want %q", ago, got, want)
+			}
+		}
+	})
 }
 
 func TestMaskEmail(t *testing.T) {
@@ -172,3 +204,368 @@ func TestMaskEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadTemplates_ReportsAllMissing(t *testing.T) {
+	dir := t.TempDir()
+	srcDir := "templates"
+
+	// Copy every template except two, so both should be reported
+	skip := map[string]bool{"quotes.html": true, "civs.html": true}
+	entries, err := os.ReadDir(srcDir)
+	if err != nil {
+		t.Fatalf("read templates dir: %v", err)
+	}
+	for _, e := range entries {
+		if skip[e.Name()] {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(srcDir, e.Name()))
+		if err != nil {
+			t.Fatalf("read %s: %v", e.Name(), err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, e.Name()), b, 0o644); err != nil {
+			t.Fatalf("write %s: %v", e.Name(), err)
+		}
+	}
+
+	s := &Server{TemplatesDir: dir}
+	err = s.loadTemplates()
+	if err == nil {
+		t.Fatal("expected error for missing templates")
+	}
+	msg := err.Error()
+	if !strings.HasPrefix(msg, "missing templates:") {
+		t.Errorf("unexpected error message: %s", msg)
+	}
+	for name := range skip {
+		if !strings.Contains(msg, name) {
+			t.Errorf("expected %s in error, got: %s", name, msg)
+		}
+	}
+}
+
+func TestLoadTemplates_MissingNav(t *testing.T) {
+	s := &Server{TemplatesDir: t.TempDir()}
+	err := s.loadTemplates()
+	if err == nil {
+		t.Fatal("expected error for empty templates dir")
+	}
+	if !strings.Contains(err.Error(), "nav.html") {
+		t.Errorf("expected nav.html in error, got: %s", err)
+	}
+}
+
+func TestHandleVersion(t *testing.T) {
+	server := &Server{}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/version", nil)
+	w := httptest.NewRecorder()
+	server.HandleVersion(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if cc := w.Header().Get("Cache-Control"); cc != "no-cache" {
+		t.Errorf("expected Cache-Control no-cache, got %q", cc)
+	}
+
+	var resp VersionResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if resp.GoVersion != runtime.Version() {
+		t.Errorf("expected go_version %s, got %q", runtime.Version(), resp.GoVersion)
+	}
+	if resp.Version != Version || resp.Commit != CommitSHA {
+		t.Errorf("unexpected build info: %+v", resp)
+	}
+}
+
+// slowConnector opens connections through the real driver but delays Ping,
+// so health checks see a slow database without any production hooks.
+type slowConnector struct {
+	driver driver.Driver
+	dsn    string
+	delay  time.Duration
+}
+
+func (c slowConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return slowConn{Conn: conn, delay: c.delay}, nil
+}
+
+func (c slowConnector) Driver() driver.Driver { return c.driver }
+
+type slowConn struct {
+	driver.Conn
+	delay time.Duration
+}
+
+// Ping waits for the configured delay, giving up early if ctx is done.
+func (c slowConn) Ping(ctx context.Context) error {
+	select {
+	case <-time.After(c.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// slowDB returns a *sql.DB over the same database file as base whose
+// PingContext takes at least delay to succeed.
+func slowDB(t *testing.T, base *sql.DB, path string, delay time.Duration) *sql.DB {
+	t.Helper()
+	sdb := sql.OpenDB(slowConnector{driver: base.Driver(), dsn: path, delay: delay})
+	t.Cleanup(func() { sdb.Close() })
+	return sdb
+}
+
+func TestHandleHealth(t *testing.T) {
+	t.Run("returns 200 when database is reachable", func(t *testing.T) {
+		server := testServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleHealth(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", w.Code)
+		}
+		if strings.TrimSpace(w.Body.String()) != "ok" {
+			t.Errorf("expected ok, got %q", w.Body.String())
+		}
+	})
+
+	t.Run("returns 503 when database is closed", func(t *testing.T) {
+		server := testServer(t)
+		server.WriteDB.Close()
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		w := httptest.NewRecorder()
+
+		server.HandleHealth(w, req)
+
+		if w.Code != http.StatusServiceUnavailable {
+			t.Errorf("expected 503, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "unhealthy") {
+			t.Errorf("expected unhealthy, got %q", w.Body.String())
+		}
+	})
+}
+
+func TestHandleLivez(t *testing.T) {
+	server := testServer(t)
+	server.WriteDB.Close()
+
+	w := httptest.NewRecorder()
+	server.HandleLivez(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200 with the database closed, got %d", w.Code)
+	}
+	if strings.TrimSpace(w.Body.String()) != "ok" {
+		t.Errorf("expected ok, got %q", w.Body.String())
+	}
+}
+
+func TestHandleReadyz(t *testing.T) {
+	readyz := func(server *Server) (int, map[string]any) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		server.HandleReadyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var body map[string]any
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return w.Code, body
+	}
+
+	t.Run("ready with migration status", func(t *testing.T) {
+		server := testServer(t)
+		code, body := readyz(server)
+
+		if code != http.StatusOK || body["status"] != "ok" {
+			t.Fatalf("expected 200 ok, got %d %v", code, body)
+		}
+		migrations, _ := body["migrations"].(map[string]any)
+		if migrations == nil || migrations["total"] == float64(0) || migrations["pending"] == nil {
+			t.Errorf("expected a migration summary, got %v", body["migrations"])
+		}
+		if _, ok := body["wal"]; ok {
+			t.Errorf("expected no WAL checkpoint in the probe, got %v", body["wal"])
+		}
+	})
+
+	t.Run("unavailable when database is closed", func(t *testing.T) {
+		server := testServer(t)
+		server.WriteDB.Close()
+		code, body := readyz(server)
+
+		if code != http.StatusServiceUnavailable {
+			t.Errorf("expected 503, got %d", code)
+		}
+		if body["status"] != "unavailable" || body["database"] != "error" {
+			t.Errorf("expected unavailable database error, got %v", body)
+		}
+	})
+}
+
+func TestHandleHealthDetailed(t *testing.T) {
+	detailed := func(server *Server, email string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/health/detailed", nil)
+		if email != "" {
+			req.Header.Set("X-ExeDev-Email", email)
+		}
+		w := httptest.NewRecorder()
+		server.HandleHealthDetailed(w, req)
+		return w
+	}
+
+	t.Run("returns 200 JSON for admin", func(t *testing.T) {
+		server := testServer(t)
+		w := detailed(server, "admin@test.com")
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("expected application/json, got %q", ct)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body["status"] != "ok" || body["database"] != "ok" {
+			t.Errorf("expected status and database ok, got %v", body)
+		}
+		if body["migrations"] == nil {
+			t.Error("expected migrations summary")
+		}
+		wal, _ := body["wal"].(map[string]any)
+		if wal == nil || wal["status"] != "ok" {
+			t.Errorf("expected WAL ok, got %v", body["wal"])
+		}
+	})
+
+	t.Run("returns 403 for non-admin", func(t *testing.T) {
+		server := testServer(t)
+		if w := detailed(server, "user@test.com"); w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+		if w := detailed(server, ""); w.Code != http.StatusForbidden {
+			t.Errorf("expected 403 when unauthenticated, got %d", w.Code)
+		}
+	})
+
+	t.Run("returns 503 JSON when database is slow", func(t *testing.T) {
+		tempDB := filepath.Join(t.TempDir(), "slow.sqlite3")
+		server, err := New(tempDB, "test-hostname", []string{"admin@test.com"})
+		if err != nil {
+			t.Fatalf("failed to create server: %v", err)
+		}
+		server.WriteDB = slowDB(t, server.WriteDB, tempDB, healthPingTimeout+100*time.Millisecond)
+
+		w := detailed(server, "admin@test.com")
+
+		if w.Code != http.StatusServiceUnavailable {
+			t.Fatalf("expected 503, got %d", w.Code)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body["database"] != "error" {
+			t.Errorf(`expected "database": "error", got %v`, body["database"])
+		}
+		if body["status"] != "unhealthy" {
+			t.Errorf(`expected "status": "unhealthy", got %v`, body["status"])
+		}
+	})
+}
+
+func TestReadReplica(t *testing.T) {
+	// Build the replica from a separately seeded database
+	seedPath := filepath.Join(t.TempDir(), "seed.sqlite3")
+	seed, err := New(seedPath, "test-hostname", nil)
+	if err != nil {
+		t.Fatalf("create seed server: %v", err)
+	}
+	addTestQuote(t, seed, "Replicated quote", nil, nil)
+	if _, err := seed.WriteDB.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		t.Fatalf("checkpoint: %v", err)
+	}
+	data, err := os.ReadFile(seedPath)
+	if err != nil {
+		t.Fatalf("read seed: %v", err)
+	}
+	seed.WriteDB.Close()
+	replicaPath := filepath.Join(t.TempDir(), "replica.sqlite3")
+	if err := os.WriteFile(replicaPath, data, 0o600); err != nil {
+		t.Fatalf("write replica: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.DBPath = filepath.Join(t.TempDir(), "primary.sqlite3")
+	cfg.DBReadPath = replicaPath
+	server, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("create server: %v", err)
+	}
+	defer server.WriteDB.Close()
+	defer server.ReadDB.Close()
+
+	if server.ReadDB == server.WriteDB {
+		t.Fatal("expected a separate read handle when DBReadPath is set")
+	}
+
+	// A quote only on the primary must not be served from the replica
+	addTestQuote(t, server, "Primary only quote", nil, nil)
+	for i := 0; i < 10; i++ {
+		w := httptest.NewRecorder()
+		server.HandleRandomQuote(w, httptest.NewRequest(http.MethodGet, "/api/quote", nil))
+		if !strings.Contains(w.Body.String(), "Replicated quote") {
+			t.Fatalf("expected quote from replica, got: %s", w.Body.String())
+		}
+	}
+}
+
+func TestAuthVaryHeaders(t *testing.T) {
+	server := testServer(t)
+	addTestQuote(t, server, "A quote for the vary test", nil, nil)
+
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		path     string
+		wantVary bool
+	}{
+		{"root", server.HandleRoot, "/", true},
+		{"browse", server.HandleQuotesPublic, "/browse", true},
+		{"suggest form", server.HandleSuggestForm, "/suggest", true},
+		{"random quote API", server.HandleRandomQuote, "/api/quote", false},
+		{"list quotes API", server.HandleListAllQuotes, "/api/quotes", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.handler(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			vary := strings.Join(w.Header().Values("Vary"), ", ")
+			if !tt.wantVary {
+				if vary != "" {
+					t.Errorf("expected no Vary header, got %q", vary)
+				}
+				return
+			}
+			for _, h := range []string{"X-ExeDev-Email", "X-ExeDev-UserID", "Cookie"} {
+				if !strings.Contains(vary, h) {
+					t.Errorf("expected Vary to include %s, got %q", h, vary)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package srv
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// createTaggedQuote creates a quote and its tags in one transaction
+func (s *Server) createTaggedQuote(ctx context.Context, params dbgen.CreateQuoteParams, tags []string) error {
+	tx, err := s.WriteDB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	q := dbgen.New(tx)
+	id, err := q.CreateQuote(ctx, params)
+	if err != nil {
+		return fmt.Errorf("create quote: %w", err)
+	}
+	for _, tag := range tags {
+		if err := q.CreateTag(ctx, tag); err != nil {
+			return fmt.Errorf("create tag %q: %w", tag, err)
+		}
+		if err := q.AddQuoteTag(ctx, dbgen.AddQuoteTagParams{QuoteID: id, Name: tag}); err != nil {
+			return fmt.Errorf("add tag %q: %w", tag, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// quoteTags returns the tags of quote id for an API response. Errors are
+// logged and leave the tags out rather than failing the request.
+func quoteTags(ctx context.Context, q *dbgen.Queries, id int64) []string {
+	dbCtx, span := StartDBSpan(ctx, "ListQuoteTags", attribute.Int64("quote.id", id))
+	defer span.End()
+	tags, err := q.ListQuoteTags(dbCtx, id)
+	if err != nil {
+		RecordError(span, err)
+		slog.Warn("list quote tags", "quote_id", id, "error", err)
+		return nil
+	}
+	return tags
+}
@@ -0,0 +1,109 @@
+package srv
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+)
+
+func TestQuoteTags(t *testing.T) {
+	// addQuote submits the add quote form as the admin
+	addQuote := func(t *testing.T, server *Server, form url.Values) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/quotes", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-ExeDev-UserID", "admin123")
+		req.Header.Set("X-ExeDev-Email", "admin@test.com")
+		w := httptest.NewRecorder()
+		server.HandleAddQuote(w, req)
+		if w.Code != http.StatusSeeOther {
+			t.Fatalf("expected 303 redirect, got %d", w.Code)
+		}
+		return w.Header().Get("Location")
+	}
+	randomQuote := func(t *testing.T, server *Server, query string) QuoteResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/quote"+query, nil)
+		req.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+		server.HandleRandomQuote(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		var resp QuoteResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v (%s)", err, w.Body.String())
+		}
+		return resp
+	}
+
+	t.Run("adds a quote with several tags", func(t *testing.T) {
+		server := testServer(t)
+		loc := addQuote(t, server, url.Values{
+			"text": {"Wall your wood line early"},
+			"tags": {"Build Order, funny,funny"},
+		})
+		if !strings.Contains(loc, "success") {
+			t.Fatalf("expected success redirect, got %s", loc)
+		}
+
+		quotes, err := dbgen.New(server.WriteDB).ListQuotesByTag(context.Background(), "build-order")
+		if err != nil {
+			t.Fatalf("list quotes by tag: %v", err)
+		}
+		if len(quotes) != 1 {
+			t.Fatalf("expected 1 build-order quote, got %d", len(quotes))
+		}
+
+		resp := randomQuote(t, server, "")
+		if !slices.Equal(resp.Tags, []string{"build-order", "funny"}) {
+			t.Errorf("expected tags [build-order funny], got %v", resp.Tags)
+		}
+	})
+
+	t.Run("rejects invalid tags", func(t *testing.T) {
+		server := testServer(t)
+		loc := addQuote(t, server, url.Values{
+			"text": {"This quote has a bad tag"},
+			"tags": {"fun!"},
+		})
+		if !strings.Contains(loc, "error") {
+			t.Errorf("expected error redirect, got %s", loc)
+		}
+		if quotes, _ := dbgen.New(server.WriteDB).ListAllQuotes(context.Background()); len(quotes) != 0 {
+			t.Errorf("expected no quote to be saved, got %d", len(quotes))
+		}
+	})
+
+	t.Run("random quote filters by tag", func(t *testing.T) {
+		server := testServer(t)
+		addQuote(t, server, url.Values{"text": {"Untagged quote text"}})
+		addQuote(t, server, url.Values{"text": {"Trash talk quote text"}, "tags": {"trash-talk"}})
+		addQuote(t, server, url.Values{"text": {"Funny quote text here"}, "tags": {"funny"}})
+
+		for range 10 {
+			if resp := randomQuote(t, server, "?tag=FUNNY"); resp.Text != "Funny quote text here" {
+				t.Fatalf("expected only the funny quote, got %q", resp.Text)
+			}
+		}
+	})
+
+	t.Run("unknown tag returns no results", func(t *testing.T) {
+		server := testServer(t)
+		addTestQuote(t, server, "Untagged quote text", nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/quote?tag=missing", nil)
+		w := httptest.NewRecorder()
+		server.HandleRandomQuote(w, req)
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "No quotes tagged missing") {
+			t.Errorf("expected no-results message, got %d %q", w.Code, w.Body.String())
+		}
+	})
+}
@@ -7,7 +7,9 @@ import (
 	"log/slog"
 	"net/http"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -17,35 +19,35 @@ import (
 
 var tracer = otel.Tracer("quoteqt")
 
-// StartDBSpan starts a child span for a database operation
+// StartDBSpan starts a child span for a database operation. Ending the span
+// also records the operation's duration in the Prometheus metrics.
 func StartDBSpan(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
 	baseAttrs := []attribute.KeyValue{
 		attribute.String("db.system", "sqlite"),
 		attribute.String("db.operation", operation),
 	}
 	attrs = append(baseAttrs, attrs...)
-	return tracer.Start(ctx, "db."+operation,
+	ctx, span := tracer.Start(ctx, "db."+operation,
 		trace.WithSpanKind(trace.SpanKindClient),
 		trace.WithAttributes(attrs...),
 	)
+	return ctx, dbSpan{Span: span, operation: operation, start: time.Now()}
 }
 
 // RecordSecurityEvent records a security-related event on the current span.
 // Events are prefixed with "security." and also logged via slog for local visibility.
-// Use this for permission denied, auth required, rate limiting, etc.
+// When the request went through AuditEvents, the event is also saved to the
+// audit trail. Use this for permission denied, auth required, rate limiting, etc.
 func RecordSecurityEvent(ctx context.Context, event string, attrs ...attribute.KeyValue) {
 	span := trace.SpanFromContext(ctx)
-	if !span.IsRecording() {
-		// Still log locally even if tracing is disabled
-		logSecurityEvent(event, attrs)
-		return
+	if span.IsRecording() {
+		fullEvent := "security." + event
+		span.AddEvent(fullEvent, trace.WithAttributes(attrs...))
 	}
 
-	fullEvent := "security." + event
-	span.AddEvent(fullEvent, trace.WithAttributes(attrs...))
-
-	// Also log locally for visibility without Honeycomb
+	// Also log locally for visibility without Honeycomb, even if tracing is disabled
 	logSecurityEvent(event, attrs)
+	auditLogFrom(ctx).record(event, attrs)
 }
 
 // logSecurityEvent logs a security event to slog with structured attributes
@@ -85,6 +87,18 @@ func RecordError(span trace.Span, err error) {
 	span.SetStatus(codes.Error, err.Error())
 }
 
+// logQuoteServed records which quote was served to which channel, both in
+// the log for auditing and as an attribute on the request's root span.
+func logQuoteServed(ctx context.Context, q QuoteResponse, channel, civ, source string) {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int64("quote.id", q.ID))
+	slog.InfoContext(ctx, "quote served",
+		"quote_id", q.ID,
+		"channel", channel,
+		"civ", civ,
+		"source", source,
+	)
+}
+
 // WantsJSON checks if the client prefers JSON response based on Accept header.
 // Returns false (plain text) by default for Nightbot compatibility.
 func WantsJSON(r *http.Request) bool {
@@ -94,17 +108,37 @@ func WantsJSON(r *http.Request) bool {
 	return strings.Contains(accept, "application/json")
 }
 
-// WriteQuoteResponse writes a quote as either JSON or plain text based on Accept header.
+// WriteQuoteResponse writes a quote as either JSON or plain text based on
+// Accept header, or as the OBS overlay page for ?format=obs.
 func WriteQuoteResponse(w http.ResponseWriter, r *http.Request, quote QuoteResponse) {
+	quotesServedTotal.Inc()
+	if WantsOBS(r) {
+		writeObsOverlay(w, &quote, "")
+		return
+	}
 	if WantsJSON(r) {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(quote)
 		return
 	}
 
-	// Plain text format for Nightbot compatibility
+	// Plain text format for Nightbot compatibility. Metadata that doesn't
+	// fit in the one-line body goes in headers for clients that can read them.
 	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Quote-ID", strconv.FormatInt(quote.ID, 10))
+	if quote.Civilization != nil && *quote.Civilization != "" {
+		w.Header().Set("X-Quote-Civ", *quote.Civilization)
+	}
+	if quote.Author != nil && *quote.Author != "" {
+		w.Header().Set("X-Quote-Author", *quote.Author)
+	}
+	if quote.Channel != nil && *quote.Channel != "" {
+		w.Header().Set("X-Quote-Channel", *quote.Channel)
+	}
 	var parts []string
+	if quote.Reversed && quote.Civilization != nil {
+		parts = append(parts, fmt.Sprintf("(from the %s side)", *quote.Civilization))
+	}
 	parts = append(parts, quote.Text)
 	if quote.Author != nil && *quote.Author != "" {
 		parts = append(parts, fmt.Sprintf("— %s", *quote.Author))
@@ -115,13 +149,19 @@ func WriteQuoteResponse(w http.ResponseWriter, r *http.Request, quote QuoteRespo
 	fmt.Fprintln(w, strings.Join(parts, " "))
 }
 
-// WriteNoResultsResponse writes a "no results" message as either JSON or plain text.
+// WriteNoResultsResponse writes a "no results" message as either JSON or plain text,
+// or as the OBS overlay page for ?format=obs.
 func WriteNoResultsResponse(w http.ResponseWriter, r *http.Request, message string) {
+	if WantsOBS(r) {
+		writeObsOverlay(w, nil, message)
+		return
+	}
 	if WantsJSON(r) {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]string{"message": message})
 		return
 	}
 	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-No-Results", "true")
 	fmt.Fprintln(w, message)
 }
@@ -6,12 +6,53 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
+	"sync"
 	"testing"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
+var (
+	spanRecorderOnce sync.Once
+	spanRecorder     *tracetest.SpanRecorder
+)
+
+// testSpanRecorder installs a recording tracer provider the first time it is
+// called. The package tracer only picks up the first global provider, so all
+// tests share one recorder and should filter spans by their own attributes.
+func testSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	spanRecorderOnce.Do(func() {
+		spanRecorder = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+	})
+	return spanRecorder
+}
+
+// endedSpanAttrs returns the attributes of the last ended span with the given
+// name whose attributes include match.
+func endedSpanAttrs(rec *tracetest.SpanRecorder, name string, match attribute.KeyValue) map[attribute.Key]attribute.Value {
+	var found map[attribute.Key]attribute.Value
+	for _, span := range rec.Ended() {
+		if span.Name() != name {
+			continue
+		}
+		attrs := make(map[attribute.Key]attribute.Value)
+		for _, kv := range span.Attributes() {
+			attrs[kv.Key] = kv.Value
+		}
+		if v, ok := attrs[match.Key]; ok && v == match.Value {
+			found = attrs
+		}
+	}
+	return found
+}
+
 func TestRecordSecurityEvent_LogsWithoutSpan(t *testing.T) {
 	// Capture log output
 	var buf bytes.Buffer
@@ -211,3 +252,41 @@ func TestSecurityEvents_AdminAllowed(t *testing.T) {
 		t.Errorf("expected no security events for allowed access, got: %s", output)
 	}
 }
+
+func TestHandleRandomQuote_CivResolutionSpan(t *testing.T) {
+	rec := testSpanRecorder(t)
+	server := testServer(t)
+
+	tests := []struct {
+		input    string
+		wantType string
+		found    bool
+		resolved string
+	}{
+		{"Holy Roman Empire", "direct", true, "Holy Roman Empire"},
+		{"hre", "shortname", true, "Holy Roman Empire"},
+		{"notaciv", "passthrough", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/quote?civ="+url.QueryEscape(tt.input), nil)
+			w := httptest.NewRecorder()
+			server.HandleRandomQuote(w, req)
+
+			attrs := endedSpanAttrs(rec, "civs.resolve", attribute.String("civ.input", tt.input))
+			if attrs == nil {
+				t.Fatal("expected a civs.resolve span")
+			}
+			if got := attrs["civ.resolution_type"].AsString(); got != tt.wantType {
+				t.Errorf("civ.resolution_type = %q, want %q", got, tt.wantType)
+			}
+			if got := attrs["civ.resolution_found"].AsBool(); got != tt.found {
+				t.Errorf("civ.resolution_found = %v, want %v", got, tt.found)
+			}
+			if got := attrs["civ.resolved"].AsString(); got != tt.resolved {
+				t.Errorf("civ.resolved = %q, want %q", got, tt.resolved)
+			}
+		})
+	}
+}
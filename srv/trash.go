@@ -0,0 +1,164 @@
+package srv
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TrashedQuoteView is a soft-deleted quote as shown in the trash
+type TrashedQuoteView struct {
+	QuoteView
+	DeletedAt string
+}
+
+// HandleQuotesTrash lists soft-deleted quotes so admins can restore or
+// purge them.
+func (s *Server) HandleQuotesTrash(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auth := s.getAuthInfo(r)
+
+	if !auth.IsAuthenticated {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Redirect(w, r, "/auth/twitch?redirect="+url.QueryEscape(r.URL.String()), http.StatusSeeOther)
+		return
+	}
+	if !auth.IsAdmin {
+		RecordSecurityEvent(ctx, "admin_required",
+			attribute.String("user.identity", auth.DisplayIdentity()),
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Admin access required", http.StatusForbidden)
+		return
+	}
+
+	deleted, err := dbgen.New(s.WriteDB).ListDeletedQuotes(ctx)
+	if err != nil {
+		slog.Error("list deleted quotes", "error", err)
+		http.Error(w, "Failed to load trash", http.StatusInternalServerError)
+		return
+	}
+	views := quotesToViews(deleted, auth.Email, s.Clock.Now())
+	quotes := make([]TrashedQuoteView, len(deleted))
+	for i, quote := range deleted {
+		quotes[i] = TrashedQuoteView{QuoteView: views[i]}
+		if quote.DeletedAt != nil {
+			quotes[i].DeletedAt = s.formatTimeAgo(*quote.DeletedAt)
+		}
+	}
+
+	logoutURL := "/__exe.dev/logout"
+	if auth.AuthMethod == "twitch" {
+		logoutURL = "/auth/logout"
+	}
+
+	data := struct {
+		Quotes          []TrashedQuoteView
+		Success         string
+		Error           string
+		IsAuthenticated bool
+		IsAdmin         bool
+		IsOwner         bool
+		IsPublicPage    bool
+		LogoutURL       string
+		UserEmail       string
+		navBadges
+	}{
+		Quotes:          quotes,
+		Success:         r.URL.Query().Get("success"),
+		Error:           r.URL.Query().Get("error"),
+		IsAuthenticated: true,
+		IsAdmin:         true,
+		LogoutURL:       logoutURL,
+		UserEmail:       auth.DisplayIdentity(),
+		navBadges:       s.navBadgesFor(ctx, auth.Email),
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.renderTemplate(w, "quotes_trash.html", data); err != nil {
+		slog.Warn("render template", "url", r.URL.Path, "error", err)
+	}
+}
+
+// HandleRestoreQuote moves a quote out of the trash. Only admins can
+// restore, since only they can see the trash.
+func (s *Server) HandleRestoreQuote(w http.ResponseWriter, r *http.Request) {
+	id, ok := s.requireAdminQuoteID(w, r)
+	if !ok {
+		return
+	}
+
+	restored, err := dbgen.New(s.WriteDB).RestoreQuote(r.Context(), id)
+	if err != nil {
+		slog.Error("restore quote", "id", id, "error", err)
+		http.Redirect(w, r, "/quotes/trash?error=Failed+to+restore+quote", http.StatusSeeOther)
+		return
+	}
+	if restored == 0 {
+		http.Error(w, "Quote not found in trash", http.StatusNotFound)
+		return
+	}
+
+	slog.Info("quote restored", "id", id, "by", s.getAuthInfo(r).DisplayIdentity())
+	http.Redirect(w, r, "/quotes/trash?success=Quote+restored", http.StatusSeeOther)
+}
+
+// purgeQuote permanently deletes the quote named by the request's {id}
+// path value, whether or not it is in the trash. Only admins may purge. On
+// failure it writes the error response and returns false.
+func (s *Server) purgeQuote(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, ok := s.requireAdminQuoteID(w, r)
+	if !ok {
+		return 0, false
+	}
+
+	purged, err := dbgen.New(s.WriteDB).PurgeQuoteByID(r.Context(), id)
+	if err != nil {
+		slog.Error("purge quote", "id", id, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return 0, false
+	}
+	if purged == 0 {
+		http.Error(w, "Quote not found", http.StatusNotFound)
+		return 0, false
+	}
+
+	slog.Info("quote purged", "id", id, "by", s.getAuthInfo(r).DisplayIdentity())
+	return id, true
+}
+
+// requireAdminQuoteID checks the caller is an admin and parses the {id}
+// path value. On failure it writes the error response and returns false.
+func (s *Server) requireAdminQuoteID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	ctx := r.Context()
+	auth := s.getAuthInfo(r)
+
+	if !auth.IsAuthenticated {
+		RecordSecurityEvent(ctx, "auth_required",
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return 0, false
+	}
+	if !auth.IsAdmin {
+		RecordSecurityEvent(ctx, "admin_required",
+			attribute.String("user.identity", auth.DisplayIdentity()),
+			attribute.String("path", r.URL.Path),
+		)
+		http.Error(w, "Admin access required", http.StatusForbidden)
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
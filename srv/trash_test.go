@@ -0,0 +1,283 @@
+package srv
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/webframp/quoteqt/db/dbgen"
+)
+
+func TestQuoteTrash(t *testing.T) {
+	// setup adds one quote and returns its ID
+	setup := func(t *testing.T) (*Server, int64) {
+		t.Helper()
+		server := testServer(t)
+		addTestQuote(t, server, "Trash me gently", nil, nil)
+		quotes, err := dbgen.New(server.WriteDB).ListAllQuotes(context.Background())
+		if err != nil || len(quotes) != 1 {
+			t.Fatalf("expected 1 seeded quote, got %d (%v)", len(quotes), err)
+		}
+		return server, quotes[0].ID
+	}
+	request := func(method, target, email string, id int64) *http.Request {
+		req := httptest.NewRequest(method, target, nil)
+		req.SetPathValue("id", strconv.FormatInt(id, 10))
+		if email != "" {
+			req.Header.Set("X-ExeDev-UserID", "user-1")
+			req.Header.Set("X-ExeDev-Email", email)
+		}
+		return req
+	}
+	randomQuote := func(server *Server) string {
+		w := httptest.NewRecorder()
+		server.HandleRandomQuote(w, httptest.NewRequest(http.MethodGet, "/api/quote", nil))
+		return w.Body.String()
+	}
+	trashed := func(t *testing.T, server *Server) []dbgen.Quote {
+		t.Helper()
+		quotes, err := dbgen.New(server.WriteDB).ListDeletedQuotes(context.Background())
+		if err != nil {
+			t.Fatalf("list deleted quotes: %v", err)
+		}
+		return quotes
+	}
+
+	t.Run("deleted quotes leave random selection and can be restored", func(t *testing.T) {
+		server, id := setup(t)
+
+		w := httptest.NewRecorder()
+		server.HandleDeleteQuote(w, request(http.MethodPost, "/quotes/1/delete", "admin@test.com", id))
+		if w.Code != http.StatusSeeOther {
+			t.Fatalf("expected 303, got %d", w.Code)
+		}
+		if body := randomQuote(server); !strings.Contains(body, "No quotes available") {
+			t.Errorf("expected deleted quote to be excluded from random selection, got: %s", body)
+		}
+		if got := trashed(t, server); len(got) != 1 || got[0].ID != id || got[0].DeletedAt == nil {
+			t.Fatalf("expected quote in the trash, got %+v", got)
+		}
+		if _, err := dbgen.New(server.WriteDB).GetQuoteByID(context.Background(), id); err == nil {
+			t.Error("expected GetQuoteByID to skip the deleted quote")
+		}
+		if byUser, err := dbgen.New(server.WriteDB).ListQuotesByUser(context.Background(), ""); err != nil || len(byUser) != 0 {
+			t.Errorf("expected ListQuotesByUser to skip the deleted quote, got %d (%v)", len(byUser), err)
+		}
+
+		w = httptest.NewRecorder()
+		server.HandleRestoreQuote(w, request(http.MethodPost, "/quotes/1/restore", "admin@test.com", id))
+		if w.Code != http.StatusSeeOther {
+			t.Fatalf("expected 303, got %d", w.Code)
+		}
+		if body := randomQuote(server); !strings.Contains(body, "Trash me gently") {
+			t.Errorf("expected restored quote to be served again, got: %s", body)
+		}
+		if got := trashed(t, server); len(got) != 0 {
+			t.Errorf("expected empty trash after restore, got %d", len(got))
+		}
+	})
+
+	t.Run("bulk delete moves quotes to the trash", func(t *testing.T) {
+		server, id := setup(t)
+		if err := dbgen.New(server.WriteDB).BulkDeleteQuotes(context.Background(), []int64{id}); err != nil {
+			t.Fatalf("bulk delete: %v", err)
+		}
+		if got := trashed(t, server); len(got) != 1 {
+			t.Errorf("expected 1 trashed quote, got %d", len(got))
+		}
+	})
+
+	t.Run("restoring a quote not in the trash returns 404", func(t *testing.T) {
+		server, id := setup(t)
+		w := httptest.NewRecorder()
+		server.HandleRestoreQuote(w, request(http.MethodPost, "/quotes/1/restore", "admin@test.com", id))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("restore and purge require admin", func(t *testing.T) {
+		server, id := setup(t)
+		addTestOwner(t, server, "somechannel", "owner@test.com")
+
+		w := httptest.NewRecorder()
+		server.HandleRestoreQuote(w, request(http.MethodPost, "/quotes/1/restore", "owner@test.com", id))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("restore: expected 403, got %d", w.Code)
+		}
+
+		w = httptest.NewRecorder()
+		server.HandleDeleteQuote(w, request(http.MethodPost, "/quotes/1/delete?purge=1", "owner@test.com", id))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("purge: expected 403, got %d", w.Code)
+		}
+
+		w = httptest.NewRecorder()
+		server.HandleRestoreQuote(w, request(http.MethodPost, "/quotes/1/restore", "", id))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("restore without auth: expected 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("purge deletes a trashed quote for good", func(t *testing.T) {
+		server, id := setup(t)
+		if err := dbgen.New(server.WriteDB).DeleteQuoteByID(context.Background(), id); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+
+		w := httptest.NewRecorder()
+		server.HandleDeleteQuote(w, request(http.MethodPost, "/quotes/1/delete?purge=1", "admin@test.com", id))
+		if w.Code != http.StatusSeeOther {
+			t.Fatalf("expected 303, got %d", w.Code)
+		}
+		if got := trashed(t, server); len(got) != 0 {
+			t.Errorf("expected purged quote to leave the trash, got %d", len(got))
+		}
+
+		w = httptest.NewRecorder()
+		server.HandleRestoreQuote(w, request(http.MethodPost, "/quotes/1/restore", "admin@test.com", id))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected purged quote to be unrestorable, got %d", w.Code)
+		}
+	})
+
+	t.Run("API purge reports purged", func(t *testing.T) {
+		server, id := setup(t)
+		w := httptest.NewRecorder()
+		server.HandleDeleteQuoteAPI(w, request(http.MethodDelete, "/api/quotes/1?purge=1", "admin@test.com", id))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp DeleteQuoteResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if !resp.Deleted || !resp.Purged || resp.ID != id {
+			t.Errorf("unexpected response %+v", resp)
+		}
+		if got := trashed(t, server); len(got) != 0 {
+			t.Errorf("expected purge to skip the trash, got %d", len(got))
+		}
+	})
+
+	t.Run("trash page lists deleted quotes for admins only", func(t *testing.T) {
+		server, id := setup(t)
+		if err := dbgen.New(server.WriteDB).DeleteQuoteByID(context.Background(), id); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+		addTestOwner(t, server, "somechannel", "owner@test.com")
+
+		w := httptest.NewRecorder()
+		server.HandleQuotesTrash(w, request(http.MethodGet, "/quotes/trash", "owner@test.com", 0))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected 403 for owner, got %d", w.Code)
+		}
+
+		w = httptest.NewRecorder()
+		server.HandleQuotesTrash(w, request(http.MethodGet, "/quotes/trash", "admin@test.com", 0))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		body := w.Body.String()
+		if !strings.Contains(body, "Trash me gently") {
+			t.Error("expected deleted quote on the trash page")
+		}
+		if !strings.Contains(body, "/quotes/"+strconv.FormatInt(id, 10)+"/restore") {
+			t.Error("expected a restore form")
+		}
+	})
+
+	t.Run("API lists deleted quotes only for admins with include_deleted", func(t *testing.T) {
+		server, id := setup(t)
+		addTestQuote(t, server, "Still here", nil, nil)
+		if err := dbgen.New(server.WriteDB).DeleteQuoteByID(context.Background(), id); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+		addTestOwner(t, server, "somechannel", "owner@test.com")
+
+		list := func(t *testing.T, query, email string) (*httptest.ResponseRecorder, []QuoteResponse) {
+			t.Helper()
+			w := httptest.NewRecorder()
+			server.HandleListAllQuotes(w, request(http.MethodGet, "/api/quotes"+query, email, 0))
+			var quotes []QuoteResponse
+			if w.Code == http.StatusOK {
+				if err := json.Unmarshal(w.Body.Bytes(), &quotes); err != nil {
+					t.Fatalf("decode response: %v", err)
+				}
+			}
+			return w, quotes
+		}
+
+		w, quotes := list(t, "", "admin@test.com")
+		if len(quotes) != 1 || quotes[0].Text != "Still here" || quotes[0].Deleted {
+			t.Errorf("expected only the live quote without the flag, got %+v", quotes)
+		}
+
+		for _, email := range []string{"", "owner@test.com"} {
+			if w, _ = list(t, "?include_deleted=true", email); w.Code != http.StatusForbidden {
+				t.Errorf("%q: expected 403, got %d", email, w.Code)
+			}
+		}
+
+		w, quotes = list(t, "?include_deleted=true", "admin@test.com")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200 for admin, got %d", w.Code)
+		}
+		if got := w.Header().Get("X-Total-Count"); got != "2" {
+			t.Errorf("expected X-Total-Count 2, got %q", got)
+		}
+		deleted := map[int64]bool{}
+		for _, q := range quotes {
+			deleted[q.ID] = q.Deleted
+		}
+		if len(quotes) != 2 || !deleted[id] {
+			t.Errorf("expected both quotes with the trashed one marked deleted, got %+v", quotes)
+		}
+		if !strings.Contains(w.Body.String(), `"deleted":true`) {
+			t.Error(`expected "deleted":true in the JSON`)
+		}
+	})
+
+	t.Run("quotes page includes deleted quotes for admins who ask", func(t *testing.T) {
+		server, id := setup(t)
+		if err := dbgen.New(server.WriteDB).DeleteQuoteByID(context.Background(), id); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+		owned := "somechannel"
+		addTestQuote(t, server, "Owned quote", nil, &owned)
+		addTestOwner(t, server, owned, "owner@test.com")
+		restoreForm := "/quotes/" + strconv.FormatInt(id, 10) + "/restore"
+
+		w := httptest.NewRecorder()
+		server.HandleQuotes(w, request(http.MethodGet, "/quotes", "admin@test.com", 0))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if body := w.Body.String(); strings.Contains(body, "Trash me gently") || !strings.Contains(body, "Include deleted") {
+			t.Error("expected the checkbox but no deleted quotes by default")
+		}
+
+		w = httptest.NewRecorder()
+		server.HandleQuotes(w, request(http.MethodGet, "/quotes?include_deleted=1", "admin@test.com", 0))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		body := w.Body.String()
+		if !strings.Contains(body, "Trash me gently") || !strings.Contains(body, restoreForm) {
+			t.Error("expected the deleted quote with a restore form")
+		}
+		if !strings.Contains(body, "Owned quote") {
+			t.Error("expected live quotes to still be listed")
+		}
+
+		w = httptest.NewRecorder()
+		server.HandleQuotes(w, request(http.MethodGet, "/quotes?include_deleted=1", "owner@test.com", 0))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected 403 for owner, got %d", w.Code)
+		}
+	})
+}
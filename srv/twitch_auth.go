@@ -180,7 +180,7 @@ func (s *Server) HandleTwitchLogout(w http.ResponseWriter, r *http.Request) {
 
 	// Get and delete session from database
 	if cookie, err := r.Cookie(sessionCookieName); err == nil {
-		q := dbgen.New(s.DB)
+		q := dbgen.New(s.WriteDB)
 		_ = q.DeleteTwitchSession(ctx, cookie.Value)
 	}
 
@@ -205,7 +205,7 @@ func (s *Server) getTwitchSession(r *http.Request) *TwitchSession {
 		return nil
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	session, err := q.GetTwitchSession(r.Context(), cookie.Value)
 	if err != nil {
 		return nil
@@ -312,7 +312,7 @@ func (s *Server) createTwitchSession(ctx context.Context, user *TwitchUser) (str
 
 	expiresAt := time.Now().Add(sessionDuration)
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	err := q.CreateTwitchSession(ctx, dbgen.CreateTwitchSessionParams{
 		ID:             signedID,
 		TwitchID:       user.ID,
@@ -352,7 +352,7 @@ func stringVal(s *string) string {
 
 // cleanupExpiredSessions removes expired Twitch sessions periodically
 func (s *Server) cleanupExpiredSessions() {
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	if err := q.DeleteExpiredTwitchSessions(context.Background()); err != nil {
 		slog.Warn("cleanup expired sessions", "error", err)
 	}
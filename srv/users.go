@@ -20,15 +20,15 @@ var tracker = &userTracker{
 	lastSeen: make(map[string]time.Time),
 }
 
-// shouldTrack returns true if we should record this user visit
+// shouldTrack returns true if we should record this user visit at now
 // (debounces to once per 5 minutes per user)
-func (t *userTracker) shouldTrack(userID string) bool {
+func (t *userTracker) shouldTrack(userID string, now time.Time) bool {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
 	last, ok := t.lastSeen[userID]
-	if !ok || time.Since(last) > 5*time.Minute {
-		t.lastSeen[userID] = time.Now()
+	if !ok || now.Sub(last) > 5*time.Minute {
+		t.lastSeen[userID] = now
 		return true
 	}
 	return false
@@ -40,9 +40,9 @@ func (s *Server) UserTracking(next http.Handler) http.Handler {
 		userID := strings.TrimSpace(r.Header.Get("X-ExeDev-UserID"))
 		userEmail := strings.TrimSpace(r.Header.Get("X-ExeDev-Email"))
 
-		if userID != "" && userEmail != "" && tracker.shouldTrack(userID) {
+		if userID != "" && userEmail != "" && tracker.shouldTrack(userID, s.Clock.Now()) {
 			go func() {
-				q := dbgen.New(s.DB)
+				q := dbgen.New(s.WriteDB)
 				if err := q.UpsertUser(r.Context(), dbgen.UpsertUserParams{
 					UserID: userID,
 					Email:  strings.ToLower(userEmail),
@@ -71,7 +71,7 @@ func (s *Server) HandleAdminUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	q := dbgen.New(s.DB)
+	q := dbgen.New(s.WriteDB)
 	users, err := q.GetAllUsers(ctx)
 	if err != nil {
 		slog.Error("get users", "error", err)
@@ -95,11 +95,11 @@ func (s *Server) HandleAdminUsers(w http.ResponseWriter, r *http.Request) {
 			ID:          u.ID,
 			UserID:      u.UserID,
 			Email:       u.Email,
-			FirstSeenAt: formatTimeAgo(u.FirstSeenAt),
-			LastSeenAt:  formatTimeAgo(u.LastSeenAt),
+			FirstSeenAt: s.formatTimeAgo(u.FirstSeenAt),
+			LastSeenAt:  s.formatTimeAgo(u.LastSeenAt),
 			VisitCount:  u.VisitCount,
 			IsAdmin:     s.isAdmin(u.Email),
-			IsOnline:    time.Since(u.LastSeenAt) < 15*time.Minute,
+			IsOnline:    s.Clock.Now().Sub(u.LastSeenAt) < 15*time.Minute,
 		})
 	}
 
@@ -114,6 +114,7 @@ func (s *Server) HandleAdminUsers(w http.ResponseWriter, r *http.Request) {
 		Error           string
 		Users           []UserView
 		TotalUsers      int
+		navBadges
 	}{
 		Hostname:        s.Hostname,
 		UserEmail:       userEmail,
@@ -123,6 +124,7 @@ func (s *Server) HandleAdminUsers(w http.ResponseWriter, r *http.Request) {
 		IsPublicPage:    false,
 		Users:           userViews,
 		TotalUsers:      len(userViews),
+		navBadges:       s.navBadgesFor(r.Context(), userEmail),
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -2,18 +2,26 @@ package srv
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 )
 
 // Field length limits
 const (
+	MinQuoteTextLen   = 10
 	MaxQuoteTextLen   = 1000
 	MaxAuthorLen      = 100
 	MaxCivNameLen     = 100
 	MaxShortnameLen   = 50
 	MaxDLCLen         = 100
+	MaxChannelLen     = 100
+	MaxTagLen         = 32
+	MaxTagsPerQuote   = 10
 )
 
 // ValidationError represents a validation failure
@@ -48,12 +56,72 @@ func ValidateRequired(field, value string) error {
 	return nil
 }
 
+// ValidateMinLength checks if a trimmed string is at least minLen runes long
+func ValidateMinLength(field, value string, minLen int) error {
+	if utf8.RuneCountInString(strings.TrimSpace(value)) < minLen {
+		return ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("must be at least %d characters", minLen),
+		}
+	}
+	return nil
+}
+
 // ValidateQuoteText validates quote text field
 func ValidateQuoteText(text string) error {
+	return ValidateQuoteTextMin(text, MinQuoteTextLen)
+}
+
+// ValidateQuoteTextMin validates quote text field with a configurable minimum length
+func ValidateQuoteTextMin(text string, minLen int) error {
 	if err := ValidateRequired("Quote text", text); err != nil {
 		return err
 	}
-	return ValidateLength("Quote text", text, MaxQuoteTextLen)
+	if err := ValidateMinLength("Quote text", text, minLen); err != nil {
+		return err
+	}
+	if err := ValidateLength("Quote text", text, MaxQuoteTextLen); err != nil {
+		return err
+	}
+	if isOnlyURL(text) {
+		return ValidationError{
+			Field:   "Quote text",
+			Message: "must contain more than just a URL",
+		}
+	}
+	if looksLikeURL(text) {
+		slog.Warn("quote text looks like a URL", "text_length", len(text))
+	}
+	return nil
+}
+
+// isOnlyURL reports whether the trimmed text is a single absolute HTTP(S) URL
+func isOnlyURL(text string) bool {
+	text = strings.TrimSpace(text)
+	if strings.ContainsFunc(text, unicode.IsSpace) {
+		return false
+	}
+	u, err := url.ParseRequestURI(text)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// looksLikeURL reports whether more than half of the non-whitespace
+// characters are URL punctuation. Used only to flag suspicious text.
+func looksLikeURL(text string) bool {
+	var total, urlChars int
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		total++
+		if strings.ContainsRune("/:?=&%._-", r) {
+			urlChars++
+		}
+	}
+	return total > 0 && urlChars*2 > total
 }
 
 // ValidateAuthor validates author field (optional)
@@ -88,6 +156,46 @@ func ValidateDLC(dlc string) error {
 	return ValidateLength("DLC", dlc, MaxDLCLen)
 }
 
+// ValidateChannel validates channel name field (optional)
+func ValidateChannel(channel string) error {
+	if channel == "" {
+		return nil
+	}
+	return ValidateLength("Channel", channel, MaxChannelLen)
+}
+
+// ParseTags splits a comma-separated tags field into lowercase tag names,
+// dropping empty entries and duplicates. Spaces inside a tag become hyphens,
+// so "build order" and "build-order" are the same tag.
+func ParseTags(s string) ([]string, error) {
+	var tags []string
+	for _, field := range strings.Split(s, ",") {
+		tag := strings.Join(strings.Fields(strings.ToLower(field)), "-")
+		if tag == "" || slices.Contains(tags, tag) {
+			continue
+		}
+		if err := ValidateLength("Tag", tag, MaxTagLen); err != nil {
+			return nil, err
+		}
+		for _, r := range tag {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' {
+				return nil, ValidationError{
+					Field:   "Tag",
+					Message: "may only contain letters, numbers and hyphens",
+				}
+			}
+		}
+		tags = append(tags, tag)
+	}
+	if len(tags) > MaxTagsPerQuote {
+		return nil, ValidationError{
+			Field:   "Tags",
+			Message: fmt.Sprintf("at most %d per quote", MaxTagsPerQuote),
+		}
+	}
+	return tags, nil
+}
+
 // MaxRequestBodySize is the maximum allowed request body size (5MB)
 // Needs to be large enough for Nightbot command imports
 const MaxRequestBodySize = 5 * 1024 * 1024
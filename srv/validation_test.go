@@ -1,6 +1,7 @@
 package srv
 
 import (
+	"slices"
 	"strings"
 	"testing"
 )
@@ -12,9 +13,13 @@ func TestValidateQuoteText(t *testing.T) {
 		wantErr bool
 	}{
 		{"valid short", "Hello world", false},
+		{"valid min length", strings.Repeat("a", MinQuoteTextLen), false},
 		{"valid max length", strings.Repeat("a", MaxQuoteTextLen), false},
 		{"empty", "", true},
 		{"whitespace only", "   ", true},
+		{"too short", "ok", true},
+		{"too short after trim", "  " + strings.Repeat("a", MinQuoteTextLen-1) + "  ", true},
+		{"unicode min length", strings.Repeat("日", MinQuoteTextLen), false},
 		{"too long", strings.Repeat("a", MaxQuoteTextLen+1), true},
 	}
 
@@ -28,6 +33,67 @@ func TestValidateQuoteText(t *testing.T) {
 	}
 }
 
+func TestValidateQuoteText_URLs(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr string
+	}{
+		{"pure URL", "https://spam.example.com/buy-now", "must contain more than just a URL"},
+		{"pure URL with surrounding whitespace", "  http://spam.example.com/?ref=1  ", "must contain more than just a URL"},
+		{"URL with text", "https://example.com/clip was the best play ever", ""},
+		{"text with URL in middle", "Watch this https://example.com/clip it is amazing", ""},
+		{"non-HTTP scheme", "ftp://files.example.com/quotes.txt", ""},
+		{"empty", "", "is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateQuoteText(tt.text)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateQuoteText() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateQuoteText() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLooksLikeURL(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"a/b/c/d/e/f?g=h&i=j", false},
+		{"//..//..//..??==&&", true},
+		{"Just build more sheep", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := looksLikeURL(tt.text); got != tt.want {
+			t.Errorf("looksLikeURL(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestValidateQuoteTextMin(t *testing.T) {
+	if err := ValidateQuoteTextMin("abc", 3); err != nil {
+		t.Errorf("expected 3 chars to pass with min 3: %v", err)
+	}
+	err := ValidateQuoteTextMin("ab", 3)
+	if err == nil {
+		t.Fatal("expected 2 chars to fail with min 3")
+	}
+	if !strings.Contains(err.Error(), "at least 3 characters") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
 func TestValidateAuthor(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -72,6 +138,56 @@ func TestValidateCivName(t *testing.T) {
 	}
 }
 
+func TestValidateChannel(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel string
+		wantErr bool
+	}{
+		{"empty is valid", "", false},
+		{"valid", "testchannel", false},
+		{"max length", strings.Repeat("a", MaxChannelLen), false},
+		{"too long", strings.Repeat("a", MaxChannelLen+1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateChannel(tt.channel)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateChannel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{"empty", "", nil, false},
+		{"normalizes case and spaces", " Funny, Build Order ,,trash-talk", []string{"funny", "build-order", "trash-talk"}, false},
+		{"drops duplicates", "funny,FUNNY, funny ", []string{"funny"}, false},
+		{"rejects punctuation", "funny!", nil, true},
+		{"rejects long tags", strings.Repeat("a", MaxTagLen+1), nil, true},
+		{"rejects too many tags", "a,b,c,d,e,f,g,h,i,j,k", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTags(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestValidateLength_Unicode(t *testing.T) {
 	// Test that we count runes, not bytes
 	// "日本語" is 3 runes but 9 bytes